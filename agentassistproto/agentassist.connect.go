@@ -41,6 +41,18 @@ const (
 	// SrvAgentAssistSendMcpClientInfoProcedure is the fully-qualified name of the SrvAgentAssist's
 	// SendMcpClientInfo RPC.
 	SrvAgentAssistSendMcpClientInfoProcedure = "/agentassistproto.SrvAgentAssist/SendMcpClientInfo"
+	// SrvAgentAssistSubmitRequestProcedure is the fully-qualified name of the SrvAgentAssist's
+	// SubmitRequest RPC.
+	SrvAgentAssistSubmitRequestProcedure = "/agentassistproto.SrvAgentAssist/SubmitRequest"
+	// SrvAgentAssistAwaitResultProcedure is the fully-qualified name of the SrvAgentAssist's
+	// AwaitResult RPC.
+	SrvAgentAssistAwaitResultProcedure = "/agentassistproto.SrvAgentAssist/AwaitResult"
+	// SrvAgentAssistCancelRequestProcedure is the fully-qualified name of the SrvAgentAssist's
+	// CancelRequest RPC.
+	SrvAgentAssistCancelRequestProcedure = "/agentassistproto.SrvAgentAssist/CancelRequest"
+	// SrvAgentAssistWatchRequestProcedure is the fully-qualified name of the SrvAgentAssist's
+	// WatchRequest RPC.
+	SrvAgentAssistWatchRequestProcedure = "/agentassistproto.SrvAgentAssist/WatchRequest"
 )
 
 // SrvAgentAssistClient is a client for the agentassistproto.SrvAgentAssist service.
@@ -48,6 +60,10 @@ type SrvAgentAssistClient interface {
 	AskQuestion(context.Context, *connect.Request[AskQuestionRequest]) (*connect.Response[AskQuestionResponse], error)
 	WorkReport(context.Context, *connect.Request[WorkReportRequest]) (*connect.Response[WorkReportResponse], error)
 	SendMcpClientInfo(context.Context, *connect.Request[McpClientInfoRequest]) (*connect.Response[McpClientInfoResponse], error)
+	SubmitRequest(context.Context, *connect.Request[SubmitRequestRequest]) (*connect.Response[SubmitRequestResponse], error)
+	AwaitResult(context.Context, *connect.Request[AwaitResultRequest]) (*connect.Response[AwaitResultResponse], error)
+	CancelRequest(context.Context, *connect.Request[CancelRequestRequest]) (*connect.Response[CancelRequestResponse], error)
+	WatchRequest(context.Context, *connect.Request[WatchRequestRequest]) (*connect.ServerStreamForClient[RequestEvent], error)
 }
 
 // NewSrvAgentAssistClient constructs a client for the agentassistproto.SrvAgentAssist service. By
@@ -79,6 +95,30 @@ func NewSrvAgentAssistClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(srvAgentAssistMethods.ByName("SendMcpClientInfo")),
 			connect.WithClientOptions(opts...),
 		),
+		submitRequest: connect.NewClient[SubmitRequestRequest, SubmitRequestResponse](
+			httpClient,
+			baseURL+SrvAgentAssistSubmitRequestProcedure,
+			connect.WithSchema(srvAgentAssistMethods.ByName("SubmitRequest")),
+			connect.WithClientOptions(opts...),
+		),
+		awaitResult: connect.NewClient[AwaitResultRequest, AwaitResultResponse](
+			httpClient,
+			baseURL+SrvAgentAssistAwaitResultProcedure,
+			connect.WithSchema(srvAgentAssistMethods.ByName("AwaitResult")),
+			connect.WithClientOptions(opts...),
+		),
+		cancelRequest: connect.NewClient[CancelRequestRequest, CancelRequestResponse](
+			httpClient,
+			baseURL+SrvAgentAssistCancelRequestProcedure,
+			connect.WithSchema(srvAgentAssistMethods.ByName("CancelRequest")),
+			connect.WithClientOptions(opts...),
+		),
+		watchRequest: connect.NewClient[WatchRequestRequest, RequestEvent](
+			httpClient,
+			baseURL+SrvAgentAssistWatchRequestProcedure,
+			connect.WithSchema(srvAgentAssistMethods.ByName("WatchRequest")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -87,6 +127,10 @@ type srvAgentAssistClient struct {
 	askQuestion       *connect.Client[AskQuestionRequest, AskQuestionResponse]
 	workReport        *connect.Client[WorkReportRequest, WorkReportResponse]
 	sendMcpClientInfo *connect.Client[McpClientInfoRequest, McpClientInfoResponse]
+	submitRequest     *connect.Client[SubmitRequestRequest, SubmitRequestResponse]
+	awaitResult       *connect.Client[AwaitResultRequest, AwaitResultResponse]
+	cancelRequest     *connect.Client[CancelRequestRequest, CancelRequestResponse]
+	watchRequest      *connect.Client[WatchRequestRequest, RequestEvent]
 }
 
 // AskQuestion calls agentassistproto.SrvAgentAssist.AskQuestion.
@@ -104,11 +148,35 @@ func (c *srvAgentAssistClient) SendMcpClientInfo(ctx context.Context, req *conne
 	return c.sendMcpClientInfo.CallUnary(ctx, req)
 }
 
+// SubmitRequest calls agentassistproto.SrvAgentAssist.SubmitRequest.
+func (c *srvAgentAssistClient) SubmitRequest(ctx context.Context, req *connect.Request[SubmitRequestRequest]) (*connect.Response[SubmitRequestResponse], error) {
+	return c.submitRequest.CallUnary(ctx, req)
+}
+
+// AwaitResult calls agentassistproto.SrvAgentAssist.AwaitResult.
+func (c *srvAgentAssistClient) AwaitResult(ctx context.Context, req *connect.Request[AwaitResultRequest]) (*connect.Response[AwaitResultResponse], error) {
+	return c.awaitResult.CallUnary(ctx, req)
+}
+
+// CancelRequest calls agentassistproto.SrvAgentAssist.CancelRequest.
+func (c *srvAgentAssistClient) CancelRequest(ctx context.Context, req *connect.Request[CancelRequestRequest]) (*connect.Response[CancelRequestResponse], error) {
+	return c.cancelRequest.CallUnary(ctx, req)
+}
+
+// WatchRequest calls agentassistproto.SrvAgentAssist.WatchRequest.
+func (c *srvAgentAssistClient) WatchRequest(ctx context.Context, req *connect.Request[WatchRequestRequest]) (*connect.ServerStreamForClient[RequestEvent], error) {
+	return c.watchRequest.CallServerStream(ctx, req)
+}
+
 // SrvAgentAssistHandler is an implementation of the agentassistproto.SrvAgentAssist service.
 type SrvAgentAssistHandler interface {
 	AskQuestion(context.Context, *connect.Request[AskQuestionRequest]) (*connect.Response[AskQuestionResponse], error)
 	WorkReport(context.Context, *connect.Request[WorkReportRequest]) (*connect.Response[WorkReportResponse], error)
 	SendMcpClientInfo(context.Context, *connect.Request[McpClientInfoRequest]) (*connect.Response[McpClientInfoResponse], error)
+	SubmitRequest(context.Context, *connect.Request[SubmitRequestRequest]) (*connect.Response[SubmitRequestResponse], error)
+	AwaitResult(context.Context, *connect.Request[AwaitResultRequest]) (*connect.Response[AwaitResultResponse], error)
+	CancelRequest(context.Context, *connect.Request[CancelRequestRequest]) (*connect.Response[CancelRequestResponse], error)
+	WatchRequest(context.Context, *connect.Request[WatchRequestRequest], *connect.ServerStream[RequestEvent]) error
 }
 
 // NewSrvAgentAssistHandler builds an HTTP handler from the service implementation. It returns the
@@ -136,6 +204,30 @@ func NewSrvAgentAssistHandler(svc SrvAgentAssistHandler, opts ...connect.Handler
 		connect.WithSchema(srvAgentAssistMethods.ByName("SendMcpClientInfo")),
 		connect.WithHandlerOptions(opts...),
 	)
+	srvAgentAssistSubmitRequestHandler := connect.NewUnaryHandler(
+		SrvAgentAssistSubmitRequestProcedure,
+		svc.SubmitRequest,
+		connect.WithSchema(srvAgentAssistMethods.ByName("SubmitRequest")),
+		connect.WithHandlerOptions(opts...),
+	)
+	srvAgentAssistAwaitResultHandler := connect.NewUnaryHandler(
+		SrvAgentAssistAwaitResultProcedure,
+		svc.AwaitResult,
+		connect.WithSchema(srvAgentAssistMethods.ByName("AwaitResult")),
+		connect.WithHandlerOptions(opts...),
+	)
+	srvAgentAssistCancelRequestHandler := connect.NewUnaryHandler(
+		SrvAgentAssistCancelRequestProcedure,
+		svc.CancelRequest,
+		connect.WithSchema(srvAgentAssistMethods.ByName("CancelRequest")),
+		connect.WithHandlerOptions(opts...),
+	)
+	srvAgentAssistWatchRequestHandler := connect.NewServerStreamHandler(
+		SrvAgentAssistWatchRequestProcedure,
+		svc.WatchRequest,
+		connect.WithSchema(srvAgentAssistMethods.ByName("WatchRequest")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/agentassistproto.SrvAgentAssist/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case SrvAgentAssistAskQuestionProcedure:
@@ -144,6 +236,14 @@ func NewSrvAgentAssistHandler(svc SrvAgentAssistHandler, opts ...connect.Handler
 			srvAgentAssistWorkReportHandler.ServeHTTP(w, r)
 		case SrvAgentAssistSendMcpClientInfoProcedure:
 			srvAgentAssistSendMcpClientInfoHandler.ServeHTTP(w, r)
+		case SrvAgentAssistSubmitRequestProcedure:
+			srvAgentAssistSubmitRequestHandler.ServeHTTP(w, r)
+		case SrvAgentAssistAwaitResultProcedure:
+			srvAgentAssistAwaitResultHandler.ServeHTTP(w, r)
+		case SrvAgentAssistCancelRequestProcedure:
+			srvAgentAssistCancelRequestHandler.ServeHTTP(w, r)
+		case SrvAgentAssistWatchRequestProcedure:
+			srvAgentAssistWatchRequestHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -164,3 +264,19 @@ func (UnimplementedSrvAgentAssistHandler) WorkReport(context.Context, *connect.R
 func (UnimplementedSrvAgentAssistHandler) SendMcpClientInfo(context.Context, *connect.Request[McpClientInfoRequest]) (*connect.Response[McpClientInfoResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("agentassistproto.SrvAgentAssist.SendMcpClientInfo is not implemented"))
 }
+
+func (UnimplementedSrvAgentAssistHandler) SubmitRequest(context.Context, *connect.Request[SubmitRequestRequest]) (*connect.Response[SubmitRequestResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("agentassistproto.SrvAgentAssist.SubmitRequest is not implemented"))
+}
+
+func (UnimplementedSrvAgentAssistHandler) AwaitResult(context.Context, *connect.Request[AwaitResultRequest]) (*connect.Response[AwaitResultResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("agentassistproto.SrvAgentAssist.AwaitResult is not implemented"))
+}
+
+func (UnimplementedSrvAgentAssistHandler) CancelRequest(context.Context, *connect.Request[CancelRequestRequest]) (*connect.Response[CancelRequestResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("agentassistproto.SrvAgentAssist.CancelRequest is not implemented"))
+}
+
+func (UnimplementedSrvAgentAssistHandler) WatchRequest(context.Context, *connect.Request[WatchRequestRequest], *connect.ServerStream[RequestEvent]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("agentassistproto.SrvAgentAssist.WatchRequest is not implemented"))
+}
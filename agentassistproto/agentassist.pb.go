@@ -404,6 +404,10 @@ type McpAskQuestionRequest struct {
 	ReasoningModelName string `protobuf:"bytes,5,opt,name=ReasoningModelName,proto3" json:"ReasoningModelName,omitempty"`
 	// MCP client name from initialize.clientInfo.name (e.g., windsurf)
 	McpClientName string `protobuf:"bytes,6,opt,name=McpClientName,proto3" json:"McpClientName,omitempty"`
+	// MCP session the request was sent from, see McpClientInfoRequest
+	McpSessionID string `protobuf:"bytes,7,opt,name=McpSessionID,proto3" json:"McpSessionID,omitempty"`
+	// client info of the MCP session, attached by the server
+	McpClientInfo *McpClientInfoData `protobuf:"bytes,8,opt,name=McpClientInfo,proto3" json:"McpClientInfo,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -480,6 +484,20 @@ func (x *McpAskQuestionRequest) GetMcpClientName() string {
 	return ""
 }
 
+func (x *McpAskQuestionRequest) GetMcpSessionID() string {
+	if x != nil {
+		return x.McpSessionID
+	}
+	return ""
+}
+
+func (x *McpAskQuestionRequest) GetMcpClientInfo() *McpClientInfoData {
+	if x != nil {
+		return x.McpClientInfo
+	}
+	return nil
+}
+
 type AskQuestionRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// request id
@@ -635,6 +653,15 @@ type McpWorkReportRequest struct {
 	ReasoningModelName string `protobuf:"bytes,5,opt,name=ReasoningModelName,proto3" json:"ReasoningModelName,omitempty"`
 	// MCP client name from initialize.clientInfo.name (e.g., windsurf)
 	McpClientName string `protobuf:"bytes,6,opt,name=McpClientName,proto3" json:"McpClientName,omitempty"`
+	// MCP session the request was sent from, see McpClientInfoRequest
+	McpSessionID string `protobuf:"bytes,7,opt,name=McpSessionID,proto3" json:"McpSessionID,omitempty"`
+	// client info of the MCP session, attached by the server
+	McpClientInfo *McpClientInfoData `protobuf:"bytes,8,opt,name=McpClientInfo,proto3" json:"McpClientInfo,omitempty"`
+	// number of web users who must approve the work report before the agent gets the answer,
+	// 0 or 1 lets the first reply decide unless Approvers are named
+	RequiredApprovals int32 `protobuf:"varint,9,opt,name=RequiredApprovals,proto3" json:"RequiredApprovals,omitempty"`
+	// nicknames of the web users who must all approve the work report
+	Approvers     []string `protobuf:"bytes,10,rep,name=Approvers,proto3" json:"Approvers,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -711,6 +738,34 @@ func (x *McpWorkReportRequest) GetMcpClientName() string {
 	return ""
 }
 
+func (x *McpWorkReportRequest) GetMcpSessionID() string {
+	if x != nil {
+		return x.McpSessionID
+	}
+	return ""
+}
+
+func (x *McpWorkReportRequest) GetMcpClientInfo() *McpClientInfoData {
+	if x != nil {
+		return x.McpClientInfo
+	}
+	return nil
+}
+
+func (x *McpWorkReportRequest) GetRequiredApprovals() int32 {
+	if x != nil {
+		return x.RequiredApprovals
+	}
+	return 0
+}
+
+func (x *McpWorkReportRequest) GetApprovers() []string {
+	if x != nil {
+		return x.Approvers
+	}
+	return nil
+}
+
 type WorkReportRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// request id
@@ -786,10 +841,12 @@ func (x *WorkReportRequest) GetTimestamp() int64 {
 type WorkReportResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// request id
-	ID            string              `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	IsError       bool                `protobuf:"varint,2,opt,name=IsError,proto3" json:"IsError,omitempty"`
-	Meta          map[string]string   `protobuf:"bytes,3,rep,name=Meta,proto3" json:"Meta,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Contents      []*McpResultContent `protobuf:"bytes,4,rep,name=contents,proto3" json:"contents,omitempty"`
+	ID       string              `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	IsError  bool                `protobuf:"varint,2,opt,name=IsError,proto3" json:"IsError,omitempty"`
+	Meta     map[string]string   `protobuf:"bytes,3,rep,name=Meta,proto3" json:"Meta,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Contents []*McpResultContent `protobuf:"bytes,4,rep,name=contents,proto3" json:"contents,omitempty"`
+	// decision of the web user, unset for plain confirmations which count as approvals
+	Decision      *WorkReportDecision `protobuf:"bytes,5,opt,name=Decision,proto3" json:"Decision,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -852,6 +909,123 @@ func (x *WorkReportResponse) GetContents() []*McpResultContent {
 	return nil
 }
 
+func (x *WorkReportResponse) GetDecision() *WorkReportDecision {
+	if x != nil {
+		return x.Decision
+	}
+	return nil
+}
+
+// WorkReportComment is a comment of a web user on one item of a work report
+type WorkReportComment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// what the comment is about, e.g. a file, a step or a line of the summary
+	Item string `protobuf:"bytes,1,opt,name=Item,proto3" json:"Item,omitempty"`
+	// the comment
+	Comment       string `protobuf:"bytes,2,opt,name=Comment,proto3" json:"Comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkReportComment) Reset() {
+	*x = WorkReportComment{}
+	mi := &file_agentassist_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkReportComment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkReportComment) ProtoMessage() {}
+
+func (x *WorkReportComment) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkReportComment.ProtoReflect.Descriptor instead.
+func (*WorkReportComment) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WorkReportComment) GetItem() string {
+	if x != nil {
+		return x.Item
+	}
+	return ""
+}
+
+func (x *WorkReportComment) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+// WorkReportDecision is the typed decision of a web user on a work report
+type WorkReportDecision struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// approved, rejected or changes_requested
+	Decision string `protobuf:"bytes,1,opt,name=Decision,proto3" json:"Decision,omitempty"`
+	// optional comments on items of the work report
+	Comments      []*WorkReportComment `protobuf:"bytes,2,rep,name=Comments,proto3" json:"Comments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkReportDecision) Reset() {
+	*x = WorkReportDecision{}
+	mi := &file_agentassist_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkReportDecision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkReportDecision) ProtoMessage() {}
+
+func (x *WorkReportDecision) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkReportDecision.ProtoReflect.Descriptor instead.
+func (*WorkReportDecision) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WorkReportDecision) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *WorkReportDecision) GetComments() []*WorkReportComment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
 type McpClientInfoData struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// MCP protocol version requested by client
@@ -868,7 +1042,7 @@ type McpClientInfoData struct {
 
 func (x *McpClientInfoData) Reset() {
 	*x = McpClientInfoData{}
-	mi := &file_agentassist_proto_msgTypes[12]
+	mi := &file_agentassist_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -880,7 +1054,7 @@ func (x *McpClientInfoData) String() string {
 func (*McpClientInfoData) ProtoMessage() {}
 
 func (x *McpClientInfoData) ProtoReflect() protoreflect.Message {
-	mi := &file_agentassist_proto_msgTypes[12]
+	mi := &file_agentassist_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -893,7 +1067,7 @@ func (x *McpClientInfoData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use McpClientInfoData.ProtoReflect.Descriptor instead.
 func (*McpClientInfoData) Descriptor() ([]byte, []int) {
-	return file_agentassist_proto_rawDescGZIP(), []int{12}
+	return file_agentassist_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *McpClientInfoData) GetProtocolVersion() string {
@@ -933,14 +1107,16 @@ type McpClientInfoRequest struct {
 	// initialize request payload
 	Request *McpClientInfoData `protobuf:"bytes,3,opt,name=Request,proto3" json:"Request,omitempty"`
 	// timestamp (UTC)
-	Timestamp     int64 `protobuf:"varint,4,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
+	Timestamp int64 `protobuf:"varint,4,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
+	// MCP session the client info belongs to, requests of the session refer to it with McpSessionID
+	McpSessionID  string `protobuf:"bytes,5,opt,name=McpSessionID,proto3" json:"McpSessionID,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *McpClientInfoRequest) Reset() {
 	*x = McpClientInfoRequest{}
-	mi := &file_agentassist_proto_msgTypes[13]
+	mi := &file_agentassist_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -952,7 +1128,7 @@ func (x *McpClientInfoRequest) String() string {
 func (*McpClientInfoRequest) ProtoMessage() {}
 
 func (x *McpClientInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_agentassist_proto_msgTypes[13]
+	mi := &file_agentassist_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -965,7 +1141,7 @@ func (x *McpClientInfoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use McpClientInfoRequest.ProtoReflect.Descriptor instead.
 func (*McpClientInfoRequest) Descriptor() ([]byte, []int) {
-	return file_agentassist_proto_rawDescGZIP(), []int{13}
+	return file_agentassist_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *McpClientInfoRequest) GetID() string {
@@ -996,6 +1172,13 @@ func (x *McpClientInfoRequest) GetTimestamp() int64 {
 	return 0
 }
 
+func (x *McpClientInfoRequest) GetMcpSessionID() string {
+	if x != nil {
+		return x.McpSessionID
+	}
+	return ""
+}
+
 type McpClientInfoResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=Success,proto3" json:"Success,omitempty"`
@@ -1005,7 +1188,7 @@ type McpClientInfoResponse struct {
 
 func (x *McpClientInfoResponse) Reset() {
 	*x = McpClientInfoResponse{}
-	mi := &file_agentassist_proto_msgTypes[14]
+	mi := &file_agentassist_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1017,7 +1200,7 @@ func (x *McpClientInfoResponse) String() string {
 func (*McpClientInfoResponse) ProtoMessage() {}
 
 func (x *McpClientInfoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_agentassist_proto_msgTypes[14]
+	mi := &file_agentassist_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1030,7 +1213,7 @@ func (x *McpClientInfoResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use McpClientInfoResponse.ProtoReflect.Descriptor instead.
 func (*McpClientInfoResponse) Descriptor() ([]byte, []int) {
-	return file_agentassist_proto_rawDescGZIP(), []int{14}
+	return file_agentassist_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *McpClientInfoResponse) GetSuccess() bool {
@@ -1040,29 +1223,33 @@ func (x *McpClientInfoResponse) GetSuccess() bool {
 	return false
 }
 
-type CheckMessageValidityRequest struct {
+// SubmitRequestRequest submits an ask_question or work_report request without waiting for its result.
+// Submitting a request ID that the server already knows re-attaches to the existing request.
+type SubmitRequestRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// list of request IDs to check
-	RequestIds    []string `protobuf:"bytes,1,rep,name=request_ids,json=requestIds,proto3" json:"request_ids,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// ask question request (exactly one of the requests must be set)
+	AskQuestionRequest *AskQuestionRequest `protobuf:"bytes,1,opt,name=AskQuestionRequest,proto3" json:"AskQuestionRequest,omitempty"`
+	// work report request
+	WorkReportRequest *WorkReportRequest `protobuf:"bytes,2,opt,name=WorkReportRequest,proto3" json:"WorkReportRequest,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *CheckMessageValidityRequest) Reset() {
-	*x = CheckMessageValidityRequest{}
-	mi := &file_agentassist_proto_msgTypes[15]
+func (x *SubmitRequestRequest) Reset() {
+	*x = SubmitRequestRequest{}
+	mi := &file_agentassist_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckMessageValidityRequest) String() string {
+func (x *SubmitRequestRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckMessageValidityRequest) ProtoMessage() {}
+func (*SubmitRequestRequest) ProtoMessage() {}
 
-func (x *CheckMessageValidityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_agentassist_proto_msgTypes[15]
+func (x *SubmitRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1073,41 +1260,55 @@ func (x *CheckMessageValidityRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckMessageValidityRequest.ProtoReflect.Descriptor instead.
-func (*CheckMessageValidityRequest) Descriptor() ([]byte, []int) {
-	return file_agentassist_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use SubmitRequestRequest.ProtoReflect.Descriptor instead.
+func (*SubmitRequestRequest) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *CheckMessageValidityRequest) GetRequestIds() []string {
+func (x *SubmitRequestRequest) GetAskQuestionRequest() *AskQuestionRequest {
 	if x != nil {
-		return x.RequestIds
+		return x.AskQuestionRequest
 	}
 	return nil
 }
 
-type CheckMessageValidityResponse struct {
+func (x *SubmitRequestRequest) GetWorkReportRequest() *WorkReportRequest {
+	if x != nil {
+		return x.WorkReportRequest
+	}
+	return nil
+}
+
+type SubmitRequestResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// map of request ID to validity status
-	Validity      map[string]bool `protobuf:"bytes,1,rep,name=validity,proto3" json:"validity,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// request id
+	ID string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	// false if the request was rejected, see Meta for details
+	Success bool `protobuf:"varint,2,opt,name=Success,proto3" json:"Success,omitempty"`
+	// true if the request was already known to the server
+	Resumed bool `protobuf:"varint,3,opt,name=Resumed,proto3" json:"Resumed,omitempty"`
+	// time after which the request times out (unix milliseconds)
+	Deadline      int64             `protobuf:"varint,4,opt,name=Deadline,proto3" json:"Deadline,omitempty"`
+	Meta          map[string]string `protobuf:"bytes,5,rep,name=Meta,proto3" json:"Meta,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CheckMessageValidityResponse) Reset() {
-	*x = CheckMessageValidityResponse{}
-	mi := &file_agentassist_proto_msgTypes[16]
+func (x *SubmitRequestResponse) Reset() {
+	*x = SubmitRequestResponse{}
+	mi := &file_agentassist_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckMessageValidityResponse) String() string {
+func (x *SubmitRequestResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckMessageValidityResponse) ProtoMessage() {}
+func (*SubmitRequestResponse) ProtoMessage() {}
 
-func (x *CheckMessageValidityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_agentassist_proto_msgTypes[16]
+func (x *SubmitRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1118,42 +1319,74 @@ func (x *CheckMessageValidityResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckMessageValidityResponse.ProtoReflect.Descriptor instead.
-func (*CheckMessageValidityResponse) Descriptor() ([]byte, []int) {
-	return file_agentassist_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use SubmitRequestResponse.ProtoReflect.Descriptor instead.
+func (*SubmitRequestResponse) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *CheckMessageValidityResponse) GetValidity() map[string]bool {
+func (x *SubmitRequestResponse) GetID() string {
 	if x != nil {
-		return x.Validity
+		return x.ID
+	}
+	return ""
+}
+
+func (x *SubmitRequestResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SubmitRequestResponse) GetResumed() bool {
+	if x != nil {
+		return x.Resumed
+	}
+	return false
+}
+
+func (x *SubmitRequestResponse) GetDeadline() int64 {
+	if x != nil {
+		return x.Deadline
+	}
+	return 0
+}
+
+func (x *SubmitRequestResponse) GetMeta() map[string]string {
+	if x != nil {
+		return x.Meta
 	}
 	return nil
 }
 
-// GetPendingMessagesRequest represents a request to get all pending messages for a user
-type GetPendingMessagesRequest struct {
+// AwaitResultRequest waits for the result of a submitted request
+type AwaitResultRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// user token to filter messages
-	UserToken     string `protobuf:"bytes,1,opt,name=user_token,json=userToken,proto3" json:"user_token,omitempty"`
+	// request id
+	ID string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	// user token
+	UserToken string `protobuf:"bytes,2,opt,name=UserToken,proto3" json:"UserToken,omitempty"`
+	// how long to wait for the result in seconds before returning Done=false, default is 30s
+	WaitSeconds   int32 `protobuf:"varint,3,opt,name=WaitSeconds,proto3" json:"WaitSeconds,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPendingMessagesRequest) Reset() {
-	*x = GetPendingMessagesRequest{}
-	mi := &file_agentassist_proto_msgTypes[17]
+func (x *AwaitResultRequest) Reset() {
+	*x = AwaitResultRequest{}
+	mi := &file_agentassist_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPendingMessagesRequest) String() string {
+func (x *AwaitResultRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPendingMessagesRequest) ProtoMessage() {}
+func (*AwaitResultRequest) ProtoMessage() {}
 
-func (x *GetPendingMessagesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_agentassist_proto_msgTypes[17]
+func (x *AwaitResultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1164,50 +1397,67 @@ func (x *GetPendingMessagesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPendingMessagesRequest.ProtoReflect.Descriptor instead.
-func (*GetPendingMessagesRequest) Descriptor() ([]byte, []int) {
-	return file_agentassist_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use AwaitResultRequest.ProtoReflect.Descriptor instead.
+func (*AwaitResultRequest) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *GetPendingMessagesRequest) GetUserToken() string {
+func (x *AwaitResultRequest) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+func (x *AwaitResultRequest) GetUserToken() string {
 	if x != nil {
 		return x.UserToken
 	}
 	return ""
 }
 
-// PendingMessage represents a single pending message
-type PendingMessage struct {
+func (x *AwaitResultRequest) GetWaitSeconds() int32 {
+	if x != nil {
+		return x.WaitSeconds
+	}
+	return 0
+}
+
+type AwaitResultResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// message type: "AskQuestion" or "WorkReport"
-	MessageType string `protobuf:"bytes,1,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"`
-	// ask question request (if message_type is "AskQuestion")
-	AskQuestionRequest *AskQuestionRequest `protobuf:"bytes,2,opt,name=ask_question_request,json=askQuestionRequest,proto3" json:"ask_question_request,omitempty"`
-	// work report request (if message_type is "WorkReport")
-	WorkReportRequest *WorkReportRequest `protobuf:"bytes,3,opt,name=work_report_request,json=workReportRequest,proto3" json:"work_report_request,omitempty"`
-	// timestamp when the message was created
-	CreatedAt int64 `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	// timeout in seconds
-	Timeout       int32 `protobuf:"varint,5,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// request id
+	ID string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	// true if the request has completed, the result is in AskQuestionResponse or WorkReportResponse
+	Done bool `protobuf:"varint,2,opt,name=Done,proto3" json:"Done,omitempty"`
+	// true if the server does not know the request (e.g. it restarted without a request store),
+	// the caller should submit it again
+	NotFound bool `protobuf:"varint,3,opt,name=NotFound,proto3" json:"NotFound,omitempty"`
+	// result of an ask question request
+	AskQuestionResponse *AskQuestionResponse `protobuf:"bytes,4,opt,name=AskQuestionResponse,proto3" json:"AskQuestionResponse,omitempty"`
+	// result of a work report request
+	WorkReportResponse *WorkReportResponse `protobuf:"bytes,5,opt,name=WorkReportResponse,proto3" json:"WorkReportResponse,omitempty"`
+	// time after which the pending request times out (unix milliseconds), it moves when the
+	// server gives the request more time
+	Deadline      int64 `protobuf:"varint,6,opt,name=Deadline,proto3" json:"Deadline,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PendingMessage) Reset() {
-	*x = PendingMessage{}
-	mi := &file_agentassist_proto_msgTypes[18]
+func (x *AwaitResultResponse) Reset() {
+	*x = AwaitResultResponse{}
+	mi := &file_agentassist_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PendingMessage) String() string {
+func (x *AwaitResultResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PendingMessage) ProtoMessage() {}
+func (*AwaitResultResponse) ProtoMessage() {}
 
-func (x *PendingMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_agentassist_proto_msgTypes[18]
+func (x *AwaitResultResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1218,72 +1468,81 @@ func (x *PendingMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PendingMessage.ProtoReflect.Descriptor instead.
-func (*PendingMessage) Descriptor() ([]byte, []int) {
-	return file_agentassist_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use AwaitResultResponse.ProtoReflect.Descriptor instead.
+func (*AwaitResultResponse) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *PendingMessage) GetMessageType() string {
+func (x *AwaitResultResponse) GetID() string {
 	if x != nil {
-		return x.MessageType
+		return x.ID
 	}
 	return ""
 }
 
-func (x *PendingMessage) GetAskQuestionRequest() *AskQuestionRequest {
+func (x *AwaitResultResponse) GetDone() bool {
 	if x != nil {
-		return x.AskQuestionRequest
+		return x.Done
 	}
-	return nil
+	return false
 }
 
-func (x *PendingMessage) GetWorkReportRequest() *WorkReportRequest {
+func (x *AwaitResultResponse) GetNotFound() bool {
 	if x != nil {
-		return x.WorkReportRequest
+		return x.NotFound
+	}
+	return false
+}
+
+func (x *AwaitResultResponse) GetAskQuestionResponse() *AskQuestionResponse {
+	if x != nil {
+		return x.AskQuestionResponse
 	}
 	return nil
 }
 
-func (x *PendingMessage) GetCreatedAt() int64 {
+func (x *AwaitResultResponse) GetWorkReportResponse() *WorkReportResponse {
 	if x != nil {
-		return x.CreatedAt
+		return x.WorkReportResponse
 	}
-	return 0
+	return nil
 }
 
-func (x *PendingMessage) GetTimeout() int32 {
+func (x *AwaitResultResponse) GetDeadline() int64 {
 	if x != nil {
-		return x.Timeout
+		return x.Deadline
 	}
 	return 0
 }
 
-// GetPendingMessagesResponse represents the response containing all pending messages
-type GetPendingMessagesResponse struct {
+// CancelRequestRequest withdraws a submitted request the agent no longer waits for
+type CancelRequestRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// list of pending messages
-	PendingMessages []*PendingMessage `protobuf:"bytes,1,rep,name=pending_messages,json=pendingMessages,proto3" json:"pending_messages,omitempty"`
-	// total count of pending messages
-	TotalCount    int32 `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// request id
+	ID string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	// user token
+	UserToken string `protobuf:"bytes,2,opt,name=UserToken,proto3" json:"UserToken,omitempty"`
+	// reason shown to the web users, default is "agent cancelled"
+	Reason        string `protobuf:"bytes,3,opt,name=Reason,proto3" json:"Reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPendingMessagesResponse) Reset() {
-	*x = GetPendingMessagesResponse{}
-	mi := &file_agentassist_proto_msgTypes[19]
+func (x *CancelRequestRequest) Reset() {
+	*x = CancelRequestRequest{}
+	mi := &file_agentassist_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPendingMessagesResponse) String() string {
+func (x *CancelRequestRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPendingMessagesResponse) ProtoMessage() {}
+func (*CancelRequestRequest) ProtoMessage() {}
 
-func (x *GetPendingMessagesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_agentassist_proto_msgTypes[19]
+func (x *CancelRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1294,53 +1553,55 @@ func (x *GetPendingMessagesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPendingMessagesResponse.ProtoReflect.Descriptor instead.
-func (*GetPendingMessagesResponse) Descriptor() ([]byte, []int) {
-	return file_agentassist_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use CancelRequestRequest.ProtoReflect.Descriptor instead.
+func (*CancelRequestRequest) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *GetPendingMessagesResponse) GetPendingMessages() []*PendingMessage {
+func (x *CancelRequestRequest) GetID() string {
 	if x != nil {
-		return x.PendingMessages
+		return x.ID
 	}
-	return nil
+	return ""
 }
 
-func (x *GetPendingMessagesResponse) GetTotalCount() int32 {
+func (x *CancelRequestRequest) GetUserToken() string {
 	if x != nil {
-		return x.TotalCount
+		return x.UserToken
 	}
-	return 0
+	return ""
 }
 
-// RequestCancelledNotification represents a notification that a request has been cancelled
-type RequestCancelledNotification struct {
+func (x *CancelRequestRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CancelRequestResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// request id that was cancelled
-	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
-	// reason for cancellation
-	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
-	// message type: "AskQuestion" or "WorkReport"
-	MessageType   string `protobuf:"bytes,3,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"`
+	// false if the server does not know the request or it has already completed
+	Success       bool `protobuf:"varint,1,opt,name=Success,proto3" json:"Success,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RequestCancelledNotification) Reset() {
-	*x = RequestCancelledNotification{}
-	mi := &file_agentassist_proto_msgTypes[20]
+func (x *CancelRequestResponse) Reset() {
+	*x = CancelRequestResponse{}
+	mi := &file_agentassist_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RequestCancelledNotification) String() string {
+func (x *CancelRequestResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RequestCancelledNotification) ProtoMessage() {}
+func (*CancelRequestResponse) ProtoMessage() {}
 
-func (x *RequestCancelledNotification) ProtoReflect() protoreflect.Message {
-	mi := &file_agentassist_proto_msgTypes[20]
+func (x *CancelRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1351,60 +1612,127 @@ func (x *RequestCancelledNotification) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RequestCancelledNotification.ProtoReflect.Descriptor instead.
-func (*RequestCancelledNotification) Descriptor() ([]byte, []int) {
-	return file_agentassist_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use CancelRequestResponse.ProtoReflect.Descriptor instead.
+func (*CancelRequestResponse) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *RequestCancelledNotification) GetRequestId() string {
+func (x *CancelRequestResponse) GetSuccess() bool {
 	if x != nil {
-		return x.RequestId
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *RequestCancelledNotification) GetReason() string {
+// WatchRequestRequest subscribes to the lifecycle events of a submitted request
+type WatchRequestRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// request id
+	ID string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	// user token
+	UserToken     string `protobuf:"bytes,2,opt,name=UserToken,proto3" json:"UserToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRequestRequest) Reset() {
+	*x = WatchRequestRequest{}
+	mi := &file_agentassist_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequestRequest) ProtoMessage() {}
+
+func (x *WatchRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[23]
 	if x != nil {
-		return x.Reason
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequestRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequestRequest) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *WatchRequestRequest) GetID() string {
+	if x != nil {
+		return x.ID
 	}
 	return ""
 }
 
-func (x *RequestCancelledNotification) GetMessageType() string {
+func (x *WatchRequestRequest) GetUserToken() string {
 	if x != nil {
-		return x.MessageType
+		return x.UserToken
 	}
 	return ""
 }
 
-// OnlineUser represents an online user with the same token
-type OnlineUser struct {
+// RequestEvent reports a step in the lifecycle of a request
+type RequestEvent struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// client id
-	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	// user nickname
-	Nickname string `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`
-	// connection timestamp
-	ConnectedAt   int64 `protobuf:"varint,3,opt,name=connected_at,json=connectedAt,proto3" json:"connected_at,omitempty"`
+	// request id
+	ID string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	// event type:
+	// queued: the server accepted the request, it stays queued while no web client is online
+	// broadcast: the request was delivered to ClientCount web clients
+	// viewed: the web user Nickname viewed the request
+	// reply_draft: the web user Nickname is typing a reply
+	// claimed: the web user Nickname claimed the request to answer it
+	// approved: the web user Nickname approved a work report that needs more approvals
+	// escalated: nobody replied in time, a timeout policy of the server gave the request more
+	// time and delivered it to more web users
+	// extended: the web user Nickname gave the request more time, Message tells the new deadline
+	// reminded: nobody answered for a while, a reminder was sent to the web users and the
+	// external notifier of the reminder, if any
+	// interim_reply: the web user Nickname sent an interim reply, e.g. "looking into it", the
+	// request stays pending; Message is "<Nickname>: <text>"
+	// no_clients: no web client was online and the server fails such requests (final)
+	// answered: the web user Nickname replied (final)
+	// cancelled: the request was cancelled, Message is the reason (final)
+	// timed_out: nobody replied before the timeout of the request, Message tells whether a
+	// timeout policy of the server answered it (final)
+	Type string `protobuf:"bytes,2,opt,name=Type,proto3" json:"Type,omitempty"`
+	// number of web clients the request was delivered to
+	ClientCount int32 `protobuf:"varint,3,opt,name=ClientCount,proto3" json:"ClientCount,omitempty"`
+	// nickname of the web user the event is about
+	Nickname string `protobuf:"bytes,4,opt,name=Nickname,proto3" json:"Nickname,omitempty"`
+	// human readable description of the event, e.g. "viewed by alice"
+	Message string `protobuf:"bytes,5,opt,name=Message,proto3" json:"Message,omitempty"`
+	// timestamp of the event (UTC)
+	Timestamp int64 `protobuf:"varint,6,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
+	// true for the event that completed the request, the last one of the stream
+	Final         bool `protobuf:"varint,7,opt,name=Final,proto3" json:"Final,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OnlineUser) Reset() {
-	*x = OnlineUser{}
-	mi := &file_agentassist_proto_msgTypes[21]
+func (x *RequestEvent) Reset() {
+	*x = RequestEvent{}
+	mi := &file_agentassist_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OnlineUser) String() string {
+func (x *RequestEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OnlineUser) ProtoMessage() {}
+func (*RequestEvent) ProtoMessage() {}
 
-func (x *OnlineUser) ProtoReflect() protoreflect.Message {
-	mi := &file_agentassist_proto_msgTypes[21]
+func (x *RequestEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1415,56 +1743,83 @@ func (x *OnlineUser) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OnlineUser.ProtoReflect.Descriptor instead.
-func (*OnlineUser) Descriptor() ([]byte, []int) {
-	return file_agentassist_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use RequestEvent.ProtoReflect.Descriptor instead.
+func (*RequestEvent) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *OnlineUser) GetClientId() string {
+func (x *RequestEvent) GetID() string {
 	if x != nil {
-		return x.ClientId
+		return x.ID
 	}
 	return ""
 }
 
-func (x *OnlineUser) GetNickname() string {
+func (x *RequestEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *RequestEvent) GetClientCount() int32 {
+	if x != nil {
+		return x.ClientCount
+	}
+	return 0
+}
+
+func (x *RequestEvent) GetNickname() string {
 	if x != nil {
 		return x.Nickname
 	}
 	return ""
 }
 
-func (x *OnlineUser) GetConnectedAt() int64 {
+func (x *RequestEvent) GetMessage() string {
 	if x != nil {
-		return x.ConnectedAt
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RequestEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
 	}
 	return 0
 }
 
-// GetOnlineUsersRequest represents a request to get online users with the same token
-type GetOnlineUsersRequest struct {
+func (x *RequestEvent) GetFinal() bool {
+	if x != nil {
+		return x.Final
+	}
+	return false
+}
+
+type CheckMessageValidityRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// user token to filter users
-	UserToken     string `protobuf:"bytes,1,opt,name=user_token,json=userToken,proto3" json:"user_token,omitempty"`
+	// list of request IDs to check
+	RequestIds    []string `protobuf:"bytes,1,rep,name=request_ids,json=requestIds,proto3" json:"request_ids,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOnlineUsersRequest) Reset() {
-	*x = GetOnlineUsersRequest{}
-	mi := &file_agentassist_proto_msgTypes[22]
+func (x *CheckMessageValidityRequest) Reset() {
+	*x = CheckMessageValidityRequest{}
+	mi := &file_agentassist_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOnlineUsersRequest) String() string {
+func (x *CheckMessageValidityRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOnlineUsersRequest) ProtoMessage() {}
+func (*CheckMessageValidityRequest) ProtoMessage() {}
 
-func (x *GetOnlineUsersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_agentassist_proto_msgTypes[22]
+func (x *CheckMessageValidityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1475,44 +1830,41 @@ func (x *GetOnlineUsersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOnlineUsersRequest.ProtoReflect.Descriptor instead.
-func (*GetOnlineUsersRequest) Descriptor() ([]byte, []int) {
-	return file_agentassist_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use CheckMessageValidityRequest.ProtoReflect.Descriptor instead.
+func (*CheckMessageValidityRequest) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *GetOnlineUsersRequest) GetUserToken() string {
+func (x *CheckMessageValidityRequest) GetRequestIds() []string {
 	if x != nil {
-		return x.UserToken
+		return x.RequestIds
 	}
-	return ""
+	return nil
 }
 
-// GetOnlineUsersResponse represents the response containing online users
-type GetOnlineUsersResponse struct {
+type CheckMessageValidityResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// list of online users
-	OnlineUsers []*OnlineUser `protobuf:"bytes,1,rep,name=online_users,json=onlineUsers,proto3" json:"online_users,omitempty"`
-	// total count of online users
-	TotalCount    int32 `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// map of request ID to validity status
+	Validity      map[string]bool `protobuf:"bytes,1,rep,name=validity,proto3" json:"validity,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOnlineUsersResponse) Reset() {
-	*x = GetOnlineUsersResponse{}
-	mi := &file_agentassist_proto_msgTypes[23]
+func (x *CheckMessageValidityResponse) Reset() {
+	*x = CheckMessageValidityResponse{}
+	mi := &file_agentassist_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOnlineUsersResponse) String() string {
+func (x *CheckMessageValidityResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOnlineUsersResponse) ProtoMessage() {}
+func (*CheckMessageValidityResponse) ProtoMessage() {}
 
-func (x *GetOnlineUsersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_agentassist_proto_msgTypes[23]
+func (x *CheckMessageValidityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1523,61 +1875,265 @@ func (x *GetOnlineUsersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOnlineUsersResponse.ProtoReflect.Descriptor instead.
-func (*GetOnlineUsersResponse) Descriptor() ([]byte, []int) {
-	return file_agentassist_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use CheckMessageValidityResponse.ProtoReflect.Descriptor instead.
+func (*CheckMessageValidityResponse) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *GetOnlineUsersResponse) GetOnlineUsers() []*OnlineUser {
+func (x *CheckMessageValidityResponse) GetValidity() map[string]bool {
 	if x != nil {
-		return x.OnlineUsers
+		return x.Validity
 	}
 	return nil
 }
 
-func (x *GetOnlineUsersResponse) GetTotalCount() int32 {
-	if x != nil {
+// GetPendingMessagesRequest represents a request to get all pending messages for a user
+type GetPendingMessagesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// user token to filter messages
+	UserToken     string `protobuf:"bytes,1,opt,name=user_token,json=userToken,proto3" json:"user_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPendingMessagesRequest) Reset() {
+	*x = GetPendingMessagesRequest{}
+	mi := &file_agentassist_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPendingMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPendingMessagesRequest) ProtoMessage() {}
+
+func (x *GetPendingMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPendingMessagesRequest.ProtoReflect.Descriptor instead.
+func (*GetPendingMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetPendingMessagesRequest) GetUserToken() string {
+	if x != nil {
+		return x.UserToken
+	}
+	return ""
+}
+
+// PendingMessage represents a single pending message
+type PendingMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// message type: "AskQuestion" or "WorkReport"
+	MessageType string `protobuf:"bytes,1,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"`
+	// ask question request (if message_type is "AskQuestion")
+	AskQuestionRequest *AskQuestionRequest `protobuf:"bytes,2,opt,name=ask_question_request,json=askQuestionRequest,proto3" json:"ask_question_request,omitempty"`
+	// work report request (if message_type is "WorkReport")
+	WorkReportRequest *WorkReportRequest `protobuf:"bytes,3,opt,name=work_report_request,json=workReportRequest,proto3" json:"work_report_request,omitempty"`
+	// time the server accepted the request, unix milliseconds
+	CreatedAt int64 `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// timeout in seconds
+	Timeout int32 `protobuf:"varint,5,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// time the request times out, unix milliseconds
+	Deadline int64 `protobuf:"varint,6,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// number of web clients the request was delivered to
+	DeliveryCount int32 `protobuf:"varint,7,opt,name=delivery_count,json=deliveryCount,proto3" json:"delivery_count,omitempty"`
+	// time a web user first viewed the request, unix milliseconds, 0 if nobody has
+	FirstViewedAt int64 `protobuf:"varint,8,opt,name=first_viewed_at,json=firstViewedAt,proto3" json:"first_viewed_at,omitempty"`
+	// approvals so far of a work report that needs several of them
+	ApprovalProgress *ApprovalProgress `protobuf:"bytes,9,opt,name=approval_progress,json=approvalProgress,proto3" json:"approval_progress,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PendingMessage) Reset() {
+	*x = PendingMessage{}
+	mi := &file_agentassist_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PendingMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingMessage) ProtoMessage() {}
+
+func (x *PendingMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingMessage.ProtoReflect.Descriptor instead.
+func (*PendingMessage) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PendingMessage) GetMessageType() string {
+	if x != nil {
+		return x.MessageType
+	}
+	return ""
+}
+
+func (x *PendingMessage) GetAskQuestionRequest() *AskQuestionRequest {
+	if x != nil {
+		return x.AskQuestionRequest
+	}
+	return nil
+}
+
+func (x *PendingMessage) GetWorkReportRequest() *WorkReportRequest {
+	if x != nil {
+		return x.WorkReportRequest
+	}
+	return nil
+}
+
+func (x *PendingMessage) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *PendingMessage) GetTimeout() int32 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *PendingMessage) GetDeadline() int64 {
+	if x != nil {
+		return x.Deadline
+	}
+	return 0
+}
+
+func (x *PendingMessage) GetDeliveryCount() int32 {
+	if x != nil {
+		return x.DeliveryCount
+	}
+	return 0
+}
+
+func (x *PendingMessage) GetFirstViewedAt() int64 {
+	if x != nil {
+		return x.FirstViewedAt
+	}
+	return 0
+}
+
+func (x *PendingMessage) GetApprovalProgress() *ApprovalProgress {
+	if x != nil {
+		return x.ApprovalProgress
+	}
+	return nil
+}
+
+// GetPendingMessagesResponse represents the response containing all pending messages
+type GetPendingMessagesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// list of pending messages
+	PendingMessages []*PendingMessage `protobuf:"bytes,1,rep,name=pending_messages,json=pendingMessages,proto3" json:"pending_messages,omitempty"`
+	// total count of pending messages
+	TotalCount    int32 `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPendingMessagesResponse) Reset() {
+	*x = GetPendingMessagesResponse{}
+	mi := &file_agentassist_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPendingMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPendingMessagesResponse) ProtoMessage() {}
+
+func (x *GetPendingMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPendingMessagesResponse.ProtoReflect.Descriptor instead.
+func (*GetPendingMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetPendingMessagesResponse) GetPendingMessages() []*PendingMessage {
+	if x != nil {
+		return x.PendingMessages
+	}
+	return nil
+}
+
+func (x *GetPendingMessagesResponse) GetTotalCount() int32 {
+	if x != nil {
 		return x.TotalCount
 	}
 	return 0
 }
 
-// ChatMessage represents a chat message between users
-type ChatMessage struct {
+// RequestCancelledNotification represents a notification that a request has been cancelled
+type RequestCancelledNotification struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// message id
-	MessageId string `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
-	// sender client id
-	SenderClientId string `protobuf:"bytes,2,opt,name=sender_client_id,json=senderClientId,proto3" json:"sender_client_id,omitempty"`
-	// sender nickname
-	SenderNickname string `protobuf:"bytes,3,opt,name=sender_nickname,json=senderNickname,proto3" json:"sender_nickname,omitempty"`
-	// receiver client id
-	ReceiverClientId string `protobuf:"bytes,4,opt,name=receiver_client_id,json=receiverClientId,proto3" json:"receiver_client_id,omitempty"`
-	// receiver nickname
-	ReceiverNickname string `protobuf:"bytes,5,opt,name=receiver_nickname,json=receiverNickname,proto3" json:"receiver_nickname,omitempty"`
-	// message content
-	Content string `protobuf:"bytes,6,opt,name=content,proto3" json:"content,omitempty"`
-	// timestamp when the message was sent
-	SentAt        int64 `protobuf:"varint,7,opt,name=sent_at,json=sentAt,proto3" json:"sent_at,omitempty"`
+	// request id that was cancelled
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// reason for cancellation
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// message type: "AskQuestion" or "WorkReport"
+	MessageType   string `protobuf:"bytes,3,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChatMessage) Reset() {
-	*x = ChatMessage{}
-	mi := &file_agentassist_proto_msgTypes[24]
+func (x *RequestCancelledNotification) Reset() {
+	*x = RequestCancelledNotification{}
+	mi := &file_agentassist_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChatMessage) String() string {
+func (x *RequestCancelledNotification) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChatMessage) ProtoMessage() {}
+func (*RequestCancelledNotification) ProtoMessage() {}
 
-func (x *ChatMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_agentassist_proto_msgTypes[24]
+func (x *RequestCancelledNotification) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1588,86 +2144,736 @@ func (x *ChatMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChatMessage.ProtoReflect.Descriptor instead.
-func (*ChatMessage) Descriptor() ([]byte, []int) {
-	return file_agentassist_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use RequestCancelledNotification.ProtoReflect.Descriptor instead.
+func (*RequestCancelledNotification) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *ChatMessage) GetMessageId() string {
+func (x *RequestCancelledNotification) GetRequestId() string {
 	if x != nil {
-		return x.MessageId
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *RequestCancelledNotification) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RequestCancelledNotification) GetMessageType() string {
+	if x != nil {
+		return x.MessageType
+	}
+	return ""
+}
+
+// OnlineUser represents an online user with the same token
+type OnlineUser struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// client id
+	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	// user nickname
+	Nickname string `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	// connection timestamp
+	ConnectedAt   int64 `protobuf:"varint,3,opt,name=connected_at,json=connectedAt,proto3" json:"connected_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OnlineUser) Reset() {
+	*x = OnlineUser{}
+	mi := &file_agentassist_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OnlineUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnlineUser) ProtoMessage() {}
+
+func (x *OnlineUser) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnlineUser.ProtoReflect.Descriptor instead.
+func (*OnlineUser) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *OnlineUser) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *OnlineUser) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *OnlineUser) GetConnectedAt() int64 {
+	if x != nil {
+		return x.ConnectedAt
+	}
+	return 0
+}
+
+// GetOnlineUsersRequest represents a request to get online users with the same token
+type GetOnlineUsersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// user token to filter users
+	UserToken     string `protobuf:"bytes,1,opt,name=user_token,json=userToken,proto3" json:"user_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOnlineUsersRequest) Reset() {
+	*x = GetOnlineUsersRequest{}
+	mi := &file_agentassist_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOnlineUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOnlineUsersRequest) ProtoMessage() {}
+
+func (x *GetOnlineUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOnlineUsersRequest.ProtoReflect.Descriptor instead.
+func (*GetOnlineUsersRequest) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetOnlineUsersRequest) GetUserToken() string {
+	if x != nil {
+		return x.UserToken
+	}
+	return ""
+}
+
+// GetOnlineUsersResponse represents the response containing online users
+type GetOnlineUsersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// list of online users
+	OnlineUsers []*OnlineUser `protobuf:"bytes,1,rep,name=online_users,json=onlineUsers,proto3" json:"online_users,omitempty"`
+	// total count of online users
+	TotalCount    int32 `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOnlineUsersResponse) Reset() {
+	*x = GetOnlineUsersResponse{}
+	mi := &file_agentassist_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOnlineUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOnlineUsersResponse) ProtoMessage() {}
+
+func (x *GetOnlineUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOnlineUsersResponse.ProtoReflect.Descriptor instead.
+func (*GetOnlineUsersResponse) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetOnlineUsersResponse) GetOnlineUsers() []*OnlineUser {
+	if x != nil {
+		return x.OnlineUsers
+	}
+	return nil
+}
+
+func (x *GetOnlineUsersResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+// ChatMessage represents a chat message between users
+type ChatMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// message id
+	MessageId string `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	// sender client id
+	SenderClientId string `protobuf:"bytes,2,opt,name=sender_client_id,json=senderClientId,proto3" json:"sender_client_id,omitempty"`
+	// sender nickname
+	SenderNickname string `protobuf:"bytes,3,opt,name=sender_nickname,json=senderNickname,proto3" json:"sender_nickname,omitempty"`
+	// receiver client id
+	ReceiverClientId string `protobuf:"bytes,4,opt,name=receiver_client_id,json=receiverClientId,proto3" json:"receiver_client_id,omitempty"`
+	// receiver nickname
+	ReceiverNickname string `protobuf:"bytes,5,opt,name=receiver_nickname,json=receiverNickname,proto3" json:"receiver_nickname,omitempty"`
+	// message content
+	Content string `protobuf:"bytes,6,opt,name=content,proto3" json:"content,omitempty"`
+	// timestamp when the message was sent
+	SentAt        int64 `protobuf:"varint,7,opt,name=sent_at,json=sentAt,proto3" json:"sent_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatMessage) Reset() {
+	*x = ChatMessage{}
+	mi := &file_agentassist_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatMessage) ProtoMessage() {}
+
+func (x *ChatMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatMessage.ProtoReflect.Descriptor instead.
+func (*ChatMessage) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ChatMessage) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetSenderClientId() string {
+	if x != nil {
+		return x.SenderClientId
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetSenderNickname() string {
+	if x != nil {
+		return x.SenderNickname
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetReceiverClientId() string {
+	if x != nil {
+		return x.ReceiverClientId
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetReceiverNickname() string {
+	if x != nil {
+		return x.ReceiverNickname
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetSentAt() int64 {
+	if x != nil {
+		return x.SentAt
+	}
+	return 0
+}
+
+// SendChatMessageRequest represents a request to send a chat message
+type SendChatMessageRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// receiver client id
+	ReceiverClientId string `protobuf:"bytes,1,opt,name=receiver_client_id,json=receiverClientId,proto3" json:"receiver_client_id,omitempty"`
+	// message content
+	Content       string `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendChatMessageRequest) Reset() {
+	*x = SendChatMessageRequest{}
+	mi := &file_agentassist_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendChatMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendChatMessageRequest) ProtoMessage() {}
+
+func (x *SendChatMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendChatMessageRequest.ProtoReflect.Descriptor instead.
+func (*SendChatMessageRequest) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SendChatMessageRequest) GetReceiverClientId() string {
+	if x != nil {
+		return x.ReceiverClientId
+	}
+	return ""
+}
+
+func (x *SendChatMessageRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+// ChatMessageNotification represents a notification of a new chat message
+type ChatMessageNotification struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the chat message
+	ChatMessage   *ChatMessage `protobuf:"bytes,1,opt,name=chat_message,json=chatMessage,proto3" json:"chat_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatMessageNotification) Reset() {
+	*x = ChatMessageNotification{}
+	mi := &file_agentassist_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatMessageNotification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatMessageNotification) ProtoMessage() {}
+
+func (x *ChatMessageNotification) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatMessageNotification.ProtoReflect.Descriptor instead.
+func (*ChatMessageNotification) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ChatMessageNotification) GetChatMessage() *ChatMessage {
+	if x != nil {
+		return x.ChatMessage
+	}
+	return nil
+}
+
+// UserLoginResponse represents the response to a user login
+type UserLoginResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// client id assigned by server
+	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	// success status
+	Success bool `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	// error message if login failed
+	ErrorMessage  string `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserLoginResponse) Reset() {
+	*x = UserLoginResponse{}
+	mi := &file_agentassist_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserLoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserLoginResponse) ProtoMessage() {}
+
+func (x *UserLoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserLoginResponse.ProtoReflect.Descriptor instead.
+func (*UserLoginResponse) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *UserLoginResponse) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *UserLoginResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UserLoginResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// UserConnectionStatusNotification represents a notification when a user connects or disconnects
+type UserConnectionStatusNotification struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the user who connected/disconnected
+	User *OnlineUser `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	// connection status: "connected" or "disconnected"
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// timestamp of the status change
+	Timestamp     int64 `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserConnectionStatusNotification) Reset() {
+	*x = UserConnectionStatusNotification{}
+	mi := &file_agentassist_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserConnectionStatusNotification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserConnectionStatusNotification) ProtoMessage() {}
+
+func (x *UserConnectionStatusNotification) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserConnectionStatusNotification.ProtoReflect.Descriptor instead.
+func (*UserConnectionStatusNotification) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *UserConnectionStatusNotification) GetUser() *OnlineUser {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *UserConnectionStatusNotification) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UserConnectionStatusNotification) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// RequestClaim is the claim of a web user on a request they are answering. Only the user
+// holding the claim can reply until it is released, on disconnect or after it was idle.
+type RequestClaim struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// request id
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// client id of the user holding the claim, empty if the request is not claimed
+	ClientId string `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	// nickname of the user holding the claim or who answered the request
+	Nickname string `protobuf:"bytes,3,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	// time the claim is released unless the user keeps typing, unix milliseconds
+	ExpiresAt int64 `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// true if the claim was granted (ClaimRequest only)
+	Success bool `protobuf:"varint,5,opt,name=success,proto3" json:"success,omitempty"`
+	// why a claim or reply was refused, e.g. "already answered by alice"
+	ErrorMessage  string `protobuf:"bytes,6,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestClaim) Reset() {
+	*x = RequestClaim{}
+	mi := &file_agentassist_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestClaim) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestClaim) ProtoMessage() {}
+
+func (x *RequestClaim) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestClaim.ProtoReflect.Descriptor instead.
+func (*RequestClaim) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RequestClaim) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *RequestClaim) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *RequestClaim) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *RequestClaim) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *RequestClaim) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RequestClaim) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// ReplyAck tells the user who sent a reply whether it reached the waiting agent
+type ReplyAck struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// request id
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// delivered, recorded (an approval that does not complete the quorum yet), unknown,
+	// expired, already_answered, invalid (e.g. an unknown decision, see message) or failed
+	// (the server was too busy to take the reply, it can be sent again)
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// time the reply was delivered, unix milliseconds; for already_answered the time the
+	// earlier reply was, 0 if it is still being delivered
+	DeliveredAt int64 `protobuf:"varint,3,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	// nickname of the user who answered, for already_answered
+	RepliedBy string `protobuf:"bytes,4,opt,name=replied_by,json=repliedBy,proto3" json:"replied_by,omitempty"`
+	// how an expired request ended, e.g. "Request timed out"
+	Message       string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplyAck) Reset() {
+	*x = ReplyAck{}
+	mi := &file_agentassist_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplyAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplyAck) ProtoMessage() {}
+
+func (x *ReplyAck) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *ChatMessage) GetSenderClientId() string {
-	if x != nil {
-		return x.SenderClientId
-	}
-	return ""
+// Deprecated: Use ReplyAck.ProtoReflect.Descriptor instead.
+func (*ReplyAck) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *ChatMessage) GetSenderNickname() string {
+func (x *ReplyAck) GetRequestId() string {
 	if x != nil {
-		return x.SenderNickname
+		return x.RequestId
 	}
 	return ""
 }
 
-func (x *ChatMessage) GetReceiverClientId() string {
+func (x *ReplyAck) GetStatus() string {
 	if x != nil {
-		return x.ReceiverClientId
+		return x.Status
 	}
 	return ""
 }
 
-func (x *ChatMessage) GetReceiverNickname() string {
+func (x *ReplyAck) GetDeliveredAt() int64 {
 	if x != nil {
-		return x.ReceiverNickname
+		return x.DeliveredAt
 	}
-	return ""
+	return 0
 }
 
-func (x *ChatMessage) GetContent() string {
+func (x *ReplyAck) GetRepliedBy() string {
 	if x != nil {
-		return x.Content
+		return x.RepliedBy
 	}
 	return ""
 }
 
-func (x *ChatMessage) GetSentAt() int64 {
+func (x *ReplyAck) GetMessage() string {
 	if x != nil {
-		return x.SentAt
+		return x.Message
 	}
-	return 0
+	return ""
 }
 
-// SendChatMessageRequest represents a request to send a chat message
-type SendChatMessageRequest struct {
+// ApprovalProgress is the state of a work report that needs several approvals. A reply with
+// a decision other than approved resolves the work report right away.
+type ApprovalProgress struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// receiver client id
-	ReceiverClientId string `protobuf:"bytes,1,opt,name=receiver_client_id,json=receiverClientId,proto3" json:"receiver_client_id,omitempty"`
-	// message content
-	Content       string `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// request id
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// number of approvals required
+	RequiredApprovals int32 `protobuf:"varint,2,opt,name=required_approvals,json=requiredApprovals,proto3" json:"required_approvals,omitempty"`
+	// nicknames of the users who must all approve
+	Approvers []string `protobuf:"bytes,3,rep,name=approvers,proto3" json:"approvers,omitempty"`
+	// nicknames of the users who approved so far, in order
+	ApprovedBy []string `protobuf:"bytes,4,rep,name=approved_by,json=approvedBy,proto3" json:"approved_by,omitempty"`
+	// named approvers who did not approve yet
+	PendingApprovers []string `protobuf:"bytes,5,rep,name=pending_approvers,json=pendingApprovers,proto3" json:"pending_approvers,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *SendChatMessageRequest) Reset() {
-	*x = SendChatMessageRequest{}
-	mi := &file_agentassist_proto_msgTypes[25]
+func (x *ApprovalProgress) Reset() {
+	*x = ApprovalProgress{}
+	mi := &file_agentassist_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SendChatMessageRequest) String() string {
+func (x *ApprovalProgress) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SendChatMessageRequest) ProtoMessage() {}
+func (*ApprovalProgress) ProtoMessage() {}
 
-func (x *SendChatMessageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_agentassist_proto_msgTypes[25]
+func (x *ApprovalProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1678,49 +2884,81 @@ func (x *SendChatMessageRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SendChatMessageRequest.ProtoReflect.Descriptor instead.
-func (*SendChatMessageRequest) Descriptor() ([]byte, []int) {
-	return file_agentassist_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use ApprovalProgress.ProtoReflect.Descriptor instead.
+func (*ApprovalProgress) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *SendChatMessageRequest) GetReceiverClientId() string {
+func (x *ApprovalProgress) GetRequestId() string {
 	if x != nil {
-		return x.ReceiverClientId
+		return x.RequestId
 	}
 	return ""
 }
 
-func (x *SendChatMessageRequest) GetContent() string {
+func (x *ApprovalProgress) GetRequiredApprovals() int32 {
 	if x != nil {
-		return x.Content
+		return x.RequiredApprovals
 	}
-	return ""
+	return 0
 }
 
-// ChatMessageNotification represents a notification of a new chat message
-type ChatMessageNotification struct {
+func (x *ApprovalProgress) GetApprovers() []string {
+	if x != nil {
+		return x.Approvers
+	}
+	return nil
+}
+
+func (x *ApprovalProgress) GetApprovedBy() []string {
+	if x != nil {
+		return x.ApprovedBy
+	}
+	return nil
+}
+
+func (x *ApprovalProgress) GetPendingApprovers() []string {
+	if x != nil {
+		return x.PendingApprovers
+	}
+	return nil
+}
+
+// DeadlineExtension asks for more time to answer a pending request, or tells its new deadline
+type DeadlineExtension struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// the chat message
-	ChatMessage   *ChatMessage `protobuf:"bytes,1,opt,name=chat_message,json=chatMessage,proto3" json:"chat_message,omitempty"`
+	// request id
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// how many seconds to push the deadline out by (ExtendDeadline requests), 0 for the server
+	// default
+	ExtendSeconds int32 `protobuf:"varint,2,opt,name=extend_seconds,json=extendSeconds,proto3" json:"extend_seconds,omitempty"`
+	// deadline of the request, unix milliseconds
+	Deadline int64 `protobuf:"varint,3,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// nickname of the user who extended the deadline, empty if the server did
+	Nickname string `protobuf:"bytes,4,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	// true if the deadline was extended (ExtendDeadline responses)
+	Success bool `protobuf:"varint,5,opt,name=success,proto3" json:"success,omitempty"`
+	// why the deadline could not be extended, e.g. "already answered by alice"
+	ErrorMessage  string `protobuf:"bytes,6,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChatMessageNotification) Reset() {
-	*x = ChatMessageNotification{}
-	mi := &file_agentassist_proto_msgTypes[26]
+func (x *DeadlineExtension) Reset() {
+	*x = DeadlineExtension{}
+	mi := &file_agentassist_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChatMessageNotification) String() string {
+func (x *DeadlineExtension) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChatMessageNotification) ProtoMessage() {}
+func (*DeadlineExtension) ProtoMessage() {}
 
-func (x *ChatMessageNotification) ProtoReflect() protoreflect.Message {
-	mi := &file_agentassist_proto_msgTypes[26]
+func (x *DeadlineExtension) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1731,46 +2969,90 @@ func (x *ChatMessageNotification) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChatMessageNotification.ProtoReflect.Descriptor instead.
-func (*ChatMessageNotification) Descriptor() ([]byte, []int) {
-	return file_agentassist_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use DeadlineExtension.ProtoReflect.Descriptor instead.
+func (*DeadlineExtension) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *ChatMessageNotification) GetChatMessage() *ChatMessage {
+func (x *DeadlineExtension) GetRequestId() string {
 	if x != nil {
-		return x.ChatMessage
+		return x.RequestId
 	}
-	return nil
+	return ""
 }
 
-// UserLoginResponse represents the response to a user login
-type UserLoginResponse struct {
+func (x *DeadlineExtension) GetExtendSeconds() int32 {
+	if x != nil {
+		return x.ExtendSeconds
+	}
+	return 0
+}
+
+func (x *DeadlineExtension) GetDeadline() int64 {
+	if x != nil {
+		return x.Deadline
+	}
+	return 0
+}
+
+func (x *DeadlineExtension) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *DeadlineExtension) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeadlineExtension) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// InterimReply tells the waiting agent something without answering the request, e.g. that
+// the user is looking into it. The request stays pending until the final reply.
+type InterimReply struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// client id assigned by server
-	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	// success status
-	Success bool `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
-	// error message if login failed
-	ErrorMessage  string `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	// request id
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// text forwarded to the agent
+	Text string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	// how many seconds to push the deadline out by, 0 to keep it
+	ExtendSeconds int32 `protobuf:"varint,3,opt,name=extend_seconds,json=extendSeconds,proto3" json:"extend_seconds,omitempty"`
+	// nickname of the user who sent the interim reply
+	Nickname string `protobuf:"bytes,4,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	// deadline of the request after the interim reply, unix milliseconds
+	Deadline int64 `protobuf:"varint,5,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// true if the interim reply was forwarded (InterimReply responses)
+	Success bool `protobuf:"varint,6,opt,name=success,proto3" json:"success,omitempty"`
+	// why the interim reply was refused, e.g. "already answered by alice"
+	ErrorMessage  string `protobuf:"bytes,7,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UserLoginResponse) Reset() {
-	*x = UserLoginResponse{}
-	mi := &file_agentassist_proto_msgTypes[27]
+func (x *InterimReply) Reset() {
+	*x = InterimReply{}
+	mi := &file_agentassist_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserLoginResponse) String() string {
+func (x *InterimReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserLoginResponse) ProtoMessage() {}
+func (*InterimReply) ProtoMessage() {}
 
-func (x *UserLoginResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_agentassist_proto_msgTypes[27]
+func (x *InterimReply) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1781,60 +3063,92 @@ func (x *UserLoginResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserLoginResponse.ProtoReflect.Descriptor instead.
-func (*UserLoginResponse) Descriptor() ([]byte, []int) {
-	return file_agentassist_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use InterimReply.ProtoReflect.Descriptor instead.
+func (*InterimReply) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *UserLoginResponse) GetClientId() string {
+func (x *InterimReply) GetRequestId() string {
 	if x != nil {
-		return x.ClientId
+		return x.RequestId
 	}
 	return ""
 }
 
-func (x *UserLoginResponse) GetSuccess() bool {
+func (x *InterimReply) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *InterimReply) GetExtendSeconds() int32 {
+	if x != nil {
+		return x.ExtendSeconds
+	}
+	return 0
+}
+
+func (x *InterimReply) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *InterimReply) GetDeadline() int64 {
+	if x != nil {
+		return x.Deadline
+	}
+	return 0
+}
+
+func (x *InterimReply) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *UserLoginResponse) GetErrorMessage() string {
+func (x *InterimReply) GetErrorMessage() string {
 	if x != nil {
 		return x.ErrorMessage
 	}
 	return ""
 }
 
-// UserConnectionStatusNotification represents a notification when a user connects or disconnects
-type UserConnectionStatusNotification struct {
+// Reminder reminds the web users of a request that nobody answered for a while
+type Reminder struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// the user who connected/disconnected
-	User *OnlineUser `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
-	// connection status: "connected" or "disconnected"
-	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	// timestamp of the status change
-	Timestamp     int64 `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// request id
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// number of the reminder, 1 for the first
+	Number int32 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	// how long the request has been waiting for an answer, in seconds
+	UnansweredSeconds int64 `protobuf:"varint,3,opt,name=unanswered_seconds,json=unansweredSeconds,proto3" json:"unanswered_seconds,omitempty"`
+	// nicknames the request was escalated to by this reminder
+	EscalatedTo []string `protobuf:"bytes,4,rep,name=escalated_to,json=escalatedTo,proto3" json:"escalated_to,omitempty"`
+	// human readable description, e.g. "not answered for 5m0s"
+	Message       string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UserConnectionStatusNotification) Reset() {
-	*x = UserConnectionStatusNotification{}
-	mi := &file_agentassist_proto_msgTypes[28]
+func (x *Reminder) Reset() {
+	*x = Reminder{}
+	mi := &file_agentassist_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserConnectionStatusNotification) String() string {
+func (x *Reminder) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserConnectionStatusNotification) ProtoMessage() {}
+func (*Reminder) ProtoMessage() {}
 
-func (x *UserConnectionStatusNotification) ProtoReflect() protoreflect.Message {
-	mi := &file_agentassist_proto_msgTypes[28]
+func (x *Reminder) ProtoReflect() protoreflect.Message {
+	mi := &file_agentassist_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1845,32 +3159,46 @@ func (x *UserConnectionStatusNotification) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserConnectionStatusNotification.ProtoReflect.Descriptor instead.
-func (*UserConnectionStatusNotification) Descriptor() ([]byte, []int) {
-	return file_agentassist_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use Reminder.ProtoReflect.Descriptor instead.
+func (*Reminder) Descriptor() ([]byte, []int) {
+	return file_agentassist_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *UserConnectionStatusNotification) GetUser() *OnlineUser {
+func (x *Reminder) GetRequestId() string {
 	if x != nil {
-		return x.User
+		return x.RequestId
 	}
-	return nil
+	return ""
 }
 
-func (x *UserConnectionStatusNotification) GetStatus() string {
+func (x *Reminder) GetNumber() int32 {
 	if x != nil {
-		return x.Status
+		return x.Number
 	}
-	return ""
+	return 0
 }
 
-func (x *UserConnectionStatusNotification) GetTimestamp() int64 {
+func (x *Reminder) GetUnansweredSeconds() int64 {
 	if x != nil {
-		return x.Timestamp
+		return x.UnansweredSeconds
 	}
 	return 0
 }
 
+func (x *Reminder) GetEscalatedTo() []string {
+	if x != nil {
+		return x.EscalatedTo
+	}
+	return nil
+}
+
+func (x *Reminder) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 type WebsocketMessage struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// WebsocketMessage cmd
@@ -1887,6 +3215,24 @@ type WebsocketMessage struct {
 	// GetOnlineUsers: get online users with the same token
 	// SendChatMessage: send a chat message to another user
 	// ChatMessageNotification: notification of a new chat message
+	// RequestViewed: user viewed a request, str param is the request id
+	// ReplyDraft: user is typing a reply to a request, str param is the request id
+	// ClaimRequest: user claims a request to answer it, str param is the request id;
+	//   the server answers with the outcome in RequestClaim
+	// ReleaseClaim: user gives up the claim on a request, str param is the request id
+	// RequestClaimed: notification that a request was claimed or released, in RequestClaim
+	// ReplyRejected: the reply of the user was refused because another user claimed the
+	//   request, RequestClaim tells who
+	// ReplyAck: outcome of an AskQuestionReply or WorkReportReply, in ReplyAck
+	// ApprovalProgress: an approval of a work report needing several approvals was recorded,
+	//   in ApprovalProgress
+	// ExtendDeadline: user asks for more time to answer a request, in DeadlineExtension; the
+	//   server answers with the outcome in DeadlineExtension
+	// DeadlineExtended: notification of the new deadline of a request, in DeadlineExtension
+	// InterimReply: user tells the agent something without answering a request, in
+	//   InterimReply; the server answers with the outcome, and notifies the other users of
+	//   the request with the same cmd
+	// Reminder: a request nobody answered for a while, in Reminder
 	Cmd string `protobuf:"bytes,1,opt,name=Cmd,proto3" json:"Cmd,omitempty"`
 	// ask question
 	AskQuestionRequest *AskQuestionRequest `protobuf:"bytes,2,opt,name=AskQuestionRequest,proto3" json:"AskQuestionRequest,omitempty"`
@@ -1918,6 +3264,18 @@ type WebsocketMessage struct {
 	UserLoginResponse *UserLoginResponse `protobuf:"bytes,23,opt,name=UserLoginResponse,proto3" json:"UserLoginResponse,omitempty"`
 	// user connection status notification
 	UserConnectionStatusNotification *UserConnectionStatusNotification `protobuf:"bytes,24,opt,name=UserConnectionStatusNotification,proto3" json:"UserConnectionStatusNotification,omitempty"`
+	// claim of a request, for ClaimRequest, RequestClaimed and ReplyRejected
+	RequestClaim *RequestClaim `protobuf:"bytes,25,opt,name=RequestClaim,proto3" json:"RequestClaim,omitempty"`
+	// reply acknowledgement
+	ReplyAck *ReplyAck `protobuf:"bytes,26,opt,name=ReplyAck,proto3" json:"ReplyAck,omitempty"`
+	// approval progress of a work report
+	ApprovalProgress *ApprovalProgress `protobuf:"bytes,27,opt,name=ApprovalProgress,proto3" json:"ApprovalProgress,omitempty"`
+	// deadline extension, for ExtendDeadline and DeadlineExtended
+	DeadlineExtension *DeadlineExtension `protobuf:"bytes,28,opt,name=DeadlineExtension,proto3" json:"DeadlineExtension,omitempty"`
+	// interim reply, for InterimReply
+	InterimReply *InterimReply `protobuf:"bytes,29,opt,name=InterimReply,proto3" json:"InterimReply,omitempty"`
+	// reminder of an unanswered request
+	Reminder *Reminder `protobuf:"bytes,30,opt,name=Reminder,proto3" json:"Reminder,omitempty"`
 	// str param
 	StrParam string `protobuf:"bytes,12,opt,name=StrParam,proto3" json:"StrParam,omitempty"`
 	// user nickname (for UserLogin and notifications)
@@ -1928,7 +3286,7 @@ type WebsocketMessage struct {
 
 func (x *WebsocketMessage) Reset() {
 	*x = WebsocketMessage{}
-	mi := &file_agentassist_proto_msgTypes[29]
+	mi := &file_agentassist_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1940,7 +3298,7 @@ func (x *WebsocketMessage) String() string {
 func (*WebsocketMessage) ProtoMessage() {}
 
 func (x *WebsocketMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_agentassist_proto_msgTypes[29]
+	mi := &file_agentassist_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1953,7 +3311,7 @@ func (x *WebsocketMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WebsocketMessage.ProtoReflect.Descriptor instead.
 func (*WebsocketMessage) Descriptor() ([]byte, []int) {
-	return file_agentassist_proto_rawDescGZIP(), []int{29}
+	return file_agentassist_proto_rawDescGZIP(), []int{45}
 }
 
 func (x *WebsocketMessage) GetCmd() string {
@@ -2068,6 +3426,48 @@ func (x *WebsocketMessage) GetUserConnectionStatusNotification() *UserConnection
 	return nil
 }
 
+func (x *WebsocketMessage) GetRequestClaim() *RequestClaim {
+	if x != nil {
+		return x.RequestClaim
+	}
+	return nil
+}
+
+func (x *WebsocketMessage) GetReplyAck() *ReplyAck {
+	if x != nil {
+		return x.ReplyAck
+	}
+	return nil
+}
+
+func (x *WebsocketMessage) GetApprovalProgress() *ApprovalProgress {
+	if x != nil {
+		return x.ApprovalProgress
+	}
+	return nil
+}
+
+func (x *WebsocketMessage) GetDeadlineExtension() *DeadlineExtension {
+	if x != nil {
+		return x.DeadlineExtension
+	}
+	return nil
+}
+
+func (x *WebsocketMessage) GetInterimReply() *InterimReply {
+	if x != nil {
+		return x.InterimReply
+	}
+	return nil
+}
+
+func (x *WebsocketMessage) GetReminder() *Reminder {
+	if x != nil {
+		return x.Reminder
+	}
+	return nil
+}
+
 func (x *WebsocketMessage) GetStrParam() string {
 	if x != nil {
 		return x.StrParam
@@ -2110,14 +3510,16 @@ const file_agentassist_proto_rawDesc = "" +
 	"\x05audio\x18\x04 \x01(\v2\x1e.agentassistproto.AudioContentR\x05audio\x12O\n" +
 	"\x11embedded_resource\x18\x05 \x01(\v2\".agentassistproto.EmbeddedResourceR\x10embeddedResource\"\n" +
 	"\n" +
-	"\bMsgEmpty\"\xed\x01\n" +
+	"\bMsgEmpty\"\xdc\x02\n" +
 	"\x15McpAskQuestionRequest\x12*\n" +
 	"\x10ProjectDirectory\x18\x01 \x01(\tR\x10ProjectDirectory\x12\x1a\n" +
 	"\bQuestion\x18\x02 \x01(\tR\bQuestion\x12\x18\n" +
 	"\aTimeout\x18\x03 \x01(\x05R\aTimeout\x12\x1c\n" +
 	"\tAgentName\x18\x04 \x01(\tR\tAgentName\x12.\n" +
 	"\x12ReasoningModelName\x18\x05 \x01(\tR\x12ReasoningModelName\x12$\n" +
-	"\rMcpClientName\x18\x06 \x01(\tR\rMcpClientName\"\xa3\x01\n" +
+	"\rMcpClientName\x18\x06 \x01(\tR\rMcpClientName\x12\"\n" +
+	"\fMcpSessionID\x18\a \x01(\tR\fMcpSessionID\x12I\n" +
+	"\rMcpClientInfo\x18\b \x01(\v2#.agentassistproto.McpClientInfoDataR\rMcpClientInfo\"\xa3\x01\n" +
 	"\x12AskQuestionRequest\x12\x0e\n" +
 	"\x02ID\x18\x01 \x01(\tR\x02ID\x12\x1c\n" +
 	"\tUserToken\x18\x02 \x01(\tR\tUserToken\x12A\n" +
@@ -2130,41 +3532,94 @@ const file_agentassist_proto_rawDesc = "" +
 	"\bcontents\x18\x04 \x03(\v2\".agentassistproto.McpResultContentR\bcontents\x1a7\n" +
 	"\tMetaEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xea\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa5\x03\n" +
 	"\x14McpWorkReportRequest\x12*\n" +
 	"\x10ProjectDirectory\x18\x01 \x01(\tR\x10ProjectDirectory\x12\x18\n" +
 	"\aSummary\x18\x02 \x01(\tR\aSummary\x12\x18\n" +
 	"\aTimeout\x18\x03 \x01(\x05R\aTimeout\x12\x1c\n" +
 	"\tAgentName\x18\x04 \x01(\tR\tAgentName\x12.\n" +
 	"\x12ReasoningModelName\x18\x05 \x01(\tR\x12ReasoningModelName\x12$\n" +
-	"\rMcpClientName\x18\x06 \x01(\tR\rMcpClientName\"\xa1\x01\n" +
+	"\rMcpClientName\x18\x06 \x01(\tR\rMcpClientName\x12\"\n" +
+	"\fMcpSessionID\x18\a \x01(\tR\fMcpSessionID\x12I\n" +
+	"\rMcpClientInfo\x18\b \x01(\v2#.agentassistproto.McpClientInfoDataR\rMcpClientInfo\x12,\n" +
+	"\x11RequiredApprovals\x18\t \x01(\x05R\x11RequiredApprovals\x12\x1c\n" +
+	"\tApprovers\x18\n" +
+	" \x03(\tR\tApprovers\"\xa1\x01\n" +
 	"\x11WorkReportRequest\x12\x0e\n" +
 	"\x02ID\x18\x01 \x01(\tR\x02ID\x12\x1c\n" +
 	"\tUserToken\x18\x02 \x01(\tR\tUserToken\x12@\n" +
 	"\aRequest\x18\x03 \x01(\v2&.agentassistproto.McpWorkReportRequestR\aRequest\x12\x1c\n" +
-	"\tTimestamp\x18\x04 \x01(\x03R\tTimestamp\"\xfb\x01\n" +
+	"\tTimestamp\x18\x04 \x01(\x03R\tTimestamp\"\xbd\x02\n" +
 	"\x12WorkReportResponse\x12\x0e\n" +
 	"\x02ID\x18\x01 \x01(\tR\x02ID\x12\x18\n" +
 	"\aIsError\x18\x02 \x01(\bR\aIsError\x12B\n" +
 	"\x04Meta\x18\x03 \x03(\v2..agentassistproto.WorkReportResponse.MetaEntryR\x04Meta\x12>\n" +
-	"\bcontents\x18\x04 \x03(\v2\".agentassistproto.McpResultContentR\bcontents\x1a7\n" +
+	"\bcontents\x18\x04 \x03(\v2\".agentassistproto.McpResultContentR\bcontents\x12@\n" +
+	"\bDecision\x18\x05 \x01(\v2$.agentassistproto.WorkReportDecisionR\bDecision\x1a7\n" +
 	"\tMetaEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xaf\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"A\n" +
+	"\x11WorkReportComment\x12\x12\n" +
+	"\x04Item\x18\x01 \x01(\tR\x04Item\x12\x18\n" +
+	"\aComment\x18\x02 \x01(\tR\aComment\"q\n" +
+	"\x12WorkReportDecision\x12\x1a\n" +
+	"\bDecision\x18\x01 \x01(\tR\bDecision\x12?\n" +
+	"\bComments\x18\x02 \x03(\v2#.agentassistproto.WorkReportCommentR\bComments\"\xaf\x01\n" +
 	"\x11McpClientInfoData\x12(\n" +
 	"\x0fProtocolVersion\x18\x01 \x01(\tR\x0fProtocolVersion\x12*\n" +
 	"\x10CapabilitiesJson\x18\x02 \x01(\tR\x10CapabilitiesJson\x12\x1e\n" +
 	"\n" +
 	"ClientName\x18\x03 \x01(\tR\n" +
 	"ClientName\x12$\n" +
-	"\rClientVersion\x18\x04 \x01(\tR\rClientVersion\"\xa1\x01\n" +
+	"\rClientVersion\x18\x04 \x01(\tR\rClientVersion\"\xc5\x01\n" +
 	"\x14McpClientInfoRequest\x12\x0e\n" +
 	"\x02ID\x18\x01 \x01(\tR\x02ID\x12\x1c\n" +
 	"\tUserToken\x18\x02 \x01(\tR\tUserToken\x12=\n" +
 	"\aRequest\x18\x03 \x01(\v2#.agentassistproto.McpClientInfoDataR\aRequest\x12\x1c\n" +
-	"\tTimestamp\x18\x04 \x01(\x03R\tTimestamp\"1\n" +
+	"\tTimestamp\x18\x04 \x01(\x03R\tTimestamp\x12\"\n" +
+	"\fMcpSessionID\x18\x05 \x01(\tR\fMcpSessionID\"1\n" +
 	"\x15McpClientInfoResponse\x12\x18\n" +
-	"\aSuccess\x18\x01 \x01(\bR\aSuccess\">\n" +
+	"\aSuccess\x18\x01 \x01(\bR\aSuccess\"\xbf\x01\n" +
+	"\x14SubmitRequestRequest\x12T\n" +
+	"\x12AskQuestionRequest\x18\x01 \x01(\v2$.agentassistproto.AskQuestionRequestR\x12AskQuestionRequest\x12Q\n" +
+	"\x11WorkReportRequest\x18\x02 \x01(\v2#.agentassistproto.WorkReportRequestR\x11WorkReportRequest\"\xf7\x01\n" +
+	"\x15SubmitRequestResponse\x12\x0e\n" +
+	"\x02ID\x18\x01 \x01(\tR\x02ID\x12\x18\n" +
+	"\aSuccess\x18\x02 \x01(\bR\aSuccess\x12\x18\n" +
+	"\aResumed\x18\x03 \x01(\bR\aResumed\x12\x1a\n" +
+	"\bDeadline\x18\x04 \x01(\x03R\bDeadline\x12E\n" +
+	"\x04Meta\x18\x05 \x03(\v21.agentassistproto.SubmitRequestResponse.MetaEntryR\x04Meta\x1a7\n" +
+	"\tMetaEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"d\n" +
+	"\x12AwaitResultRequest\x12\x0e\n" +
+	"\x02ID\x18\x01 \x01(\tR\x02ID\x12\x1c\n" +
+	"\tUserToken\x18\x02 \x01(\tR\tUserToken\x12 \n" +
+	"\vWaitSeconds\x18\x03 \x01(\x05R\vWaitSeconds\"\xa0\x02\n" +
+	"\x13AwaitResultResponse\x12\x0e\n" +
+	"\x02ID\x18\x01 \x01(\tR\x02ID\x12\x12\n" +
+	"\x04Done\x18\x02 \x01(\bR\x04Done\x12\x1a\n" +
+	"\bNotFound\x18\x03 \x01(\bR\bNotFound\x12W\n" +
+	"\x13AskQuestionResponse\x18\x04 \x01(\v2%.agentassistproto.AskQuestionResponseR\x13AskQuestionResponse\x12T\n" +
+	"\x12WorkReportResponse\x18\x05 \x01(\v2$.agentassistproto.WorkReportResponseR\x12WorkReportResponse\x12\x1a\n" +
+	"\bDeadline\x18\x06 \x01(\x03R\bDeadline\"\\\n" +
+	"\x14CancelRequestRequest\x12\x0e\n" +
+	"\x02ID\x18\x01 \x01(\tR\x02ID\x12\x1c\n" +
+	"\tUserToken\x18\x02 \x01(\tR\tUserToken\x12\x16\n" +
+	"\x06Reason\x18\x03 \x01(\tR\x06Reason\"1\n" +
+	"\x15CancelRequestResponse\x12\x18\n" +
+	"\aSuccess\x18\x01 \x01(\bR\aSuccess\"C\n" +
+	"\x13WatchRequestRequest\x12\x0e\n" +
+	"\x02ID\x18\x01 \x01(\tR\x02ID\x12\x1c\n" +
+	"\tUserToken\x18\x02 \x01(\tR\tUserToken\"\xbe\x01\n" +
+	"\fRequestEvent\x12\x0e\n" +
+	"\x02ID\x18\x01 \x01(\tR\x02ID\x12\x12\n" +
+	"\x04Type\x18\x02 \x01(\tR\x04Type\x12 \n" +
+	"\vClientCount\x18\x03 \x01(\x05R\vClientCount\x12\x1a\n" +
+	"\bNickname\x18\x04 \x01(\tR\bNickname\x12\x18\n" +
+	"\aMessage\x18\x05 \x01(\tR\aMessage\x12\x1c\n" +
+	"\tTimestamp\x18\x06 \x01(\x03R\tTimestamp\x12\x14\n" +
+	"\x05Final\x18\a \x01(\bR\x05Final\">\n" +
 	"\x1bCheckMessageValidityRequest\x12\x1f\n" +
 	"\vrequest_ids\x18\x01 \x03(\tR\n" +
 	"requestIds\"\xb5\x01\n" +
@@ -2175,14 +3630,18 @@ const file_agentassist_proto_rawDesc = "" +
 	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01\":\n" +
 	"\x19GetPendingMessagesRequest\x12\x1d\n" +
 	"\n" +
-	"user_token\x18\x01 \x01(\tR\tuserToken\"\x99\x02\n" +
+	"user_token\x18\x01 \x01(\tR\tuserToken\"\xd5\x03\n" +
 	"\x0ePendingMessage\x12!\n" +
 	"\fmessage_type\x18\x01 \x01(\tR\vmessageType\x12V\n" +
 	"\x14ask_question_request\x18\x02 \x01(\v2$.agentassistproto.AskQuestionRequestR\x12askQuestionRequest\x12S\n" +
 	"\x13work_report_request\x18\x03 \x01(\v2#.agentassistproto.WorkReportRequestR\x11workReportRequest\x12\x1d\n" +
 	"\n" +
 	"created_at\x18\x04 \x01(\x03R\tcreatedAt\x12\x18\n" +
-	"\atimeout\x18\x05 \x01(\x05R\atimeout\"\x8a\x01\n" +
+	"\atimeout\x18\x05 \x01(\x05R\atimeout\x12\x1a\n" +
+	"\bdeadline\x18\x06 \x01(\x03R\bdeadline\x12%\n" +
+	"\x0edelivery_count\x18\a \x01(\x05R\rdeliveryCount\x12&\n" +
+	"\x0ffirst_viewed_at\x18\b \x01(\x03R\rfirstViewedAt\x12O\n" +
+	"\x11approval_progress\x18\t \x01(\v2\".agentassistproto.ApprovalProgressR\x10approvalProgress\"\x8a\x01\n" +
 	"\x1aGetPendingMessagesResponse\x12K\n" +
 	"\x10pending_messages\x18\x01 \x03(\v2 .agentassistproto.PendingMessageR\x0fpendingMessages\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
@@ -2225,7 +3684,56 @@ const file_agentassist_proto_rawDesc = "" +
 	" UserConnectionStatusNotification\x120\n" +
 	"\x04user\x18\x01 \x01(\v2\x1c.agentassistproto.OnlineUserR\x04user\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1c\n" +
-	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\"\xc1\f\n" +
+	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\"\xc4\x01\n" +
+	"\fRequestClaim\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12\x1b\n" +
+	"\tclient_id\x18\x02 \x01(\tR\bclientId\x12\x1a\n" +
+	"\bnickname\x18\x03 \x01(\tR\bnickname\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\x03R\texpiresAt\x12\x18\n" +
+	"\asuccess\x18\x05 \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\x06 \x01(\tR\ferrorMessage\"\x9d\x01\n" +
+	"\bReplyAck\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12!\n" +
+	"\fdelivered_at\x18\x03 \x01(\x03R\vdeliveredAt\x12\x1d\n" +
+	"\n" +
+	"replied_by\x18\x04 \x01(\tR\trepliedBy\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\"\xcc\x01\n" +
+	"\x10ApprovalProgress\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12-\n" +
+	"\x12required_approvals\x18\x02 \x01(\x05R\x11requiredApprovals\x12\x1c\n" +
+	"\tapprovers\x18\x03 \x03(\tR\tapprovers\x12\x1f\n" +
+	"\vapproved_by\x18\x04 \x03(\tR\n" +
+	"approvedBy\x12+\n" +
+	"\x11pending_approvers\x18\x05 \x03(\tR\x10pendingApprovers\"\xd0\x01\n" +
+	"\x11DeadlineExtension\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12%\n" +
+	"\x0eextend_seconds\x18\x02 \x01(\x05R\rextendSeconds\x12\x1a\n" +
+	"\bdeadline\x18\x03 \x01(\x03R\bdeadline\x12\x1a\n" +
+	"\bnickname\x18\x04 \x01(\tR\bnickname\x12\x18\n" +
+	"\asuccess\x18\x05 \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\x06 \x01(\tR\ferrorMessage\"\xdf\x01\n" +
+	"\fInterimReply\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12%\n" +
+	"\x0eextend_seconds\x18\x03 \x01(\x05R\rextendSeconds\x12\x1a\n" +
+	"\bnickname\x18\x04 \x01(\tR\bnickname\x12\x1a\n" +
+	"\bdeadline\x18\x05 \x01(\x03R\bdeadline\x12\x18\n" +
+	"\asuccess\x18\x06 \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\a \x01(\tR\ferrorMessage\"\xad\x01\n" +
+	"\bReminder\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\x05R\x06number\x12-\n" +
+	"\x12unanswered_seconds\x18\x03 \x01(\x03R\x11unansweredSeconds\x12!\n" +
+	"\fescalated_to\x18\x04 \x03(\tR\vescalatedTo\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\"\xdc\x0f\n" +
 	"\x10WebsocketMessage\x12\x10\n" +
 	"\x03Cmd\x18\x01 \x01(\tR\x03Cmd\x12T\n" +
 	"\x12AskQuestionRequest\x18\x02 \x01(\v2$.agentassistproto.AskQuestionRequestR\x12AskQuestionRequest\x12Q\n" +
@@ -2242,14 +3750,24 @@ const file_agentassist_proto_rawDesc = "" +
 	"\x16SendChatMessageRequest\x18\x15 \x01(\v2(.agentassistproto.SendChatMessageRequestR\x16SendChatMessageRequest\x12c\n" +
 	"\x17ChatMessageNotification\x18\x16 \x01(\v2).agentassistproto.ChatMessageNotificationR\x17ChatMessageNotification\x12Q\n" +
 	"\x11UserLoginResponse\x18\x17 \x01(\v2#.agentassistproto.UserLoginResponseR\x11UserLoginResponse\x12~\n" +
-	" UserConnectionStatusNotification\x18\x18 \x01(\v22.agentassistproto.UserConnectionStatusNotificationR UserConnectionStatusNotification\x12\x1a\n" +
+	" UserConnectionStatusNotification\x18\x18 \x01(\v22.agentassistproto.UserConnectionStatusNotificationR UserConnectionStatusNotification\x12B\n" +
+	"\fRequestClaim\x18\x19 \x01(\v2\x1e.agentassistproto.RequestClaimR\fRequestClaim\x126\n" +
+	"\bReplyAck\x18\x1a \x01(\v2\x1a.agentassistproto.ReplyAckR\bReplyAck\x12N\n" +
+	"\x10ApprovalProgress\x18\x1b \x01(\v2\".agentassistproto.ApprovalProgressR\x10ApprovalProgress\x12Q\n" +
+	"\x11DeadlineExtension\x18\x1c \x01(\v2#.agentassistproto.DeadlineExtensionR\x11DeadlineExtension\x12B\n" +
+	"\fInterimReply\x18\x1d \x01(\v2\x1e.agentassistproto.InterimReplyR\fInterimReply\x126\n" +
+	"\bReminder\x18\x1e \x01(\v2\x1a.agentassistproto.ReminderR\bReminder\x12\x1a\n" +
 	"\bStrParam\x18\f \x01(\tR\bStrParam\x12\x1a\n" +
-	"\bNickname\x18\x12 \x01(\tR\bNickname2\xab\x02\n" +
+	"\bNickname\x18\x12 \x01(\tR\bNickname2\xa4\x05\n" +
 	"\x0eSrvAgentAssist\x12Z\n" +
 	"\vAskQuestion\x12$.agentassistproto.AskQuestionRequest\x1a%.agentassistproto.AskQuestionResponse\x12W\n" +
 	"\n" +
 	"WorkReport\x12#.agentassistproto.WorkReportRequest\x1a$.agentassistproto.WorkReportResponse\x12d\n" +
-	"\x11SendMcpClientInfo\x12&.agentassistproto.McpClientInfoRequest\x1a'.agentassistproto.McpClientInfoResponseB8Z6github.com/yangjuncode/agentassistant/agentassistprotob\x06proto3"
+	"\x11SendMcpClientInfo\x12&.agentassistproto.McpClientInfoRequest\x1a'.agentassistproto.McpClientInfoResponse\x12`\n" +
+	"\rSubmitRequest\x12&.agentassistproto.SubmitRequestRequest\x1a'.agentassistproto.SubmitRequestResponse\x12Z\n" +
+	"\vAwaitResult\x12$.agentassistproto.AwaitResultRequest\x1a%.agentassistproto.AwaitResultResponse\x12`\n" +
+	"\rCancelRequest\x12&.agentassistproto.CancelRequestRequest\x1a'.agentassistproto.CancelRequestResponse\x12W\n" +
+	"\fWatchRequest\x12%.agentassistproto.WatchRequestRequest\x1a\x1e.agentassistproto.RequestEvent0\x01B8Z6github.com/yangjuncode/agentassistant/agentassistprotob\x06proto3"
 
 var (
 	file_agentassist_proto_rawDescOnce sync.Once
@@ -2263,7 +3781,7 @@ func file_agentassist_proto_rawDescGZIP() []byte {
 	return file_agentassist_proto_rawDescData
 }
 
-var file_agentassist_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
+var file_agentassist_proto_msgTypes = make([]protoimpl.MessageInfo, 50)
 var file_agentassist_proto_goTypes = []any{
 	(*TextContent)(nil),                      // 0: agentassistproto.TextContent
 	(*ImageContent)(nil),                     // 1: agentassistproto.ImageContent
@@ -2277,73 +3795,114 @@ var file_agentassist_proto_goTypes = []any{
 	(*McpWorkReportRequest)(nil),             // 9: agentassistproto.McpWorkReportRequest
 	(*WorkReportRequest)(nil),                // 10: agentassistproto.WorkReportRequest
 	(*WorkReportResponse)(nil),               // 11: agentassistproto.WorkReportResponse
-	(*McpClientInfoData)(nil),                // 12: agentassistproto.McpClientInfoData
-	(*McpClientInfoRequest)(nil),             // 13: agentassistproto.McpClientInfoRequest
-	(*McpClientInfoResponse)(nil),            // 14: agentassistproto.McpClientInfoResponse
-	(*CheckMessageValidityRequest)(nil),      // 15: agentassistproto.CheckMessageValidityRequest
-	(*CheckMessageValidityResponse)(nil),     // 16: agentassistproto.CheckMessageValidityResponse
-	(*GetPendingMessagesRequest)(nil),        // 17: agentassistproto.GetPendingMessagesRequest
-	(*PendingMessage)(nil),                   // 18: agentassistproto.PendingMessage
-	(*GetPendingMessagesResponse)(nil),       // 19: agentassistproto.GetPendingMessagesResponse
-	(*RequestCancelledNotification)(nil),     // 20: agentassistproto.RequestCancelledNotification
-	(*OnlineUser)(nil),                       // 21: agentassistproto.OnlineUser
-	(*GetOnlineUsersRequest)(nil),            // 22: agentassistproto.GetOnlineUsersRequest
-	(*GetOnlineUsersResponse)(nil),           // 23: agentassistproto.GetOnlineUsersResponse
-	(*ChatMessage)(nil),                      // 24: agentassistproto.ChatMessage
-	(*SendChatMessageRequest)(nil),           // 25: agentassistproto.SendChatMessageRequest
-	(*ChatMessageNotification)(nil),          // 26: agentassistproto.ChatMessageNotification
-	(*UserLoginResponse)(nil),                // 27: agentassistproto.UserLoginResponse
-	(*UserConnectionStatusNotification)(nil), // 28: agentassistproto.UserConnectionStatusNotification
-	(*WebsocketMessage)(nil),                 // 29: agentassistproto.WebsocketMessage
-	nil,                                      // 30: agentassistproto.AskQuestionResponse.MetaEntry
-	nil,                                      // 31: agentassistproto.WorkReportResponse.MetaEntry
-	nil,                                      // 32: agentassistproto.CheckMessageValidityResponse.ValidityEntry
+	(*WorkReportComment)(nil),                // 12: agentassistproto.WorkReportComment
+	(*WorkReportDecision)(nil),               // 13: agentassistproto.WorkReportDecision
+	(*McpClientInfoData)(nil),                // 14: agentassistproto.McpClientInfoData
+	(*McpClientInfoRequest)(nil),             // 15: agentassistproto.McpClientInfoRequest
+	(*McpClientInfoResponse)(nil),            // 16: agentassistproto.McpClientInfoResponse
+	(*SubmitRequestRequest)(nil),             // 17: agentassistproto.SubmitRequestRequest
+	(*SubmitRequestResponse)(nil),            // 18: agentassistproto.SubmitRequestResponse
+	(*AwaitResultRequest)(nil),               // 19: agentassistproto.AwaitResultRequest
+	(*AwaitResultResponse)(nil),              // 20: agentassistproto.AwaitResultResponse
+	(*CancelRequestRequest)(nil),             // 21: agentassistproto.CancelRequestRequest
+	(*CancelRequestResponse)(nil),            // 22: agentassistproto.CancelRequestResponse
+	(*WatchRequestRequest)(nil),              // 23: agentassistproto.WatchRequestRequest
+	(*RequestEvent)(nil),                     // 24: agentassistproto.RequestEvent
+	(*CheckMessageValidityRequest)(nil),      // 25: agentassistproto.CheckMessageValidityRequest
+	(*CheckMessageValidityResponse)(nil),     // 26: agentassistproto.CheckMessageValidityResponse
+	(*GetPendingMessagesRequest)(nil),        // 27: agentassistproto.GetPendingMessagesRequest
+	(*PendingMessage)(nil),                   // 28: agentassistproto.PendingMessage
+	(*GetPendingMessagesResponse)(nil),       // 29: agentassistproto.GetPendingMessagesResponse
+	(*RequestCancelledNotification)(nil),     // 30: agentassistproto.RequestCancelledNotification
+	(*OnlineUser)(nil),                       // 31: agentassistproto.OnlineUser
+	(*GetOnlineUsersRequest)(nil),            // 32: agentassistproto.GetOnlineUsersRequest
+	(*GetOnlineUsersResponse)(nil),           // 33: agentassistproto.GetOnlineUsersResponse
+	(*ChatMessage)(nil),                      // 34: agentassistproto.ChatMessage
+	(*SendChatMessageRequest)(nil),           // 35: agentassistproto.SendChatMessageRequest
+	(*ChatMessageNotification)(nil),          // 36: agentassistproto.ChatMessageNotification
+	(*UserLoginResponse)(nil),                // 37: agentassistproto.UserLoginResponse
+	(*UserConnectionStatusNotification)(nil), // 38: agentassistproto.UserConnectionStatusNotification
+	(*RequestClaim)(nil),                     // 39: agentassistproto.RequestClaim
+	(*ReplyAck)(nil),                         // 40: agentassistproto.ReplyAck
+	(*ApprovalProgress)(nil),                 // 41: agentassistproto.ApprovalProgress
+	(*DeadlineExtension)(nil),                // 42: agentassistproto.DeadlineExtension
+	(*InterimReply)(nil),                     // 43: agentassistproto.InterimReply
+	(*Reminder)(nil),                         // 44: agentassistproto.Reminder
+	(*WebsocketMessage)(nil),                 // 45: agentassistproto.WebsocketMessage
+	nil,                                      // 46: agentassistproto.AskQuestionResponse.MetaEntry
+	nil,                                      // 47: agentassistproto.WorkReportResponse.MetaEntry
+	nil,                                      // 48: agentassistproto.SubmitRequestResponse.MetaEntry
+	nil,                                      // 49: agentassistproto.CheckMessageValidityResponse.ValidityEntry
 }
 var file_agentassist_proto_depIdxs = []int32{
 	0,  // 0: agentassistproto.McpResultContent.text:type_name -> agentassistproto.TextContent
 	1,  // 1: agentassistproto.McpResultContent.image:type_name -> agentassistproto.ImageContent
 	2,  // 2: agentassistproto.McpResultContent.audio:type_name -> agentassistproto.AudioContent
 	3,  // 3: agentassistproto.McpResultContent.embedded_resource:type_name -> agentassistproto.EmbeddedResource
-	6,  // 4: agentassistproto.AskQuestionRequest.Request:type_name -> agentassistproto.McpAskQuestionRequest
-	30, // 5: agentassistproto.AskQuestionResponse.Meta:type_name -> agentassistproto.AskQuestionResponse.MetaEntry
-	4,  // 6: agentassistproto.AskQuestionResponse.contents:type_name -> agentassistproto.McpResultContent
-	9,  // 7: agentassistproto.WorkReportRequest.Request:type_name -> agentassistproto.McpWorkReportRequest
-	31, // 8: agentassistproto.WorkReportResponse.Meta:type_name -> agentassistproto.WorkReportResponse.MetaEntry
-	4,  // 9: agentassistproto.WorkReportResponse.contents:type_name -> agentassistproto.McpResultContent
-	12, // 10: agentassistproto.McpClientInfoRequest.Request:type_name -> agentassistproto.McpClientInfoData
-	32, // 11: agentassistproto.CheckMessageValidityResponse.validity:type_name -> agentassistproto.CheckMessageValidityResponse.ValidityEntry
-	7,  // 12: agentassistproto.PendingMessage.ask_question_request:type_name -> agentassistproto.AskQuestionRequest
-	10, // 13: agentassistproto.PendingMessage.work_report_request:type_name -> agentassistproto.WorkReportRequest
-	18, // 14: agentassistproto.GetPendingMessagesResponse.pending_messages:type_name -> agentassistproto.PendingMessage
-	21, // 15: agentassistproto.GetOnlineUsersResponse.online_users:type_name -> agentassistproto.OnlineUser
-	24, // 16: agentassistproto.ChatMessageNotification.chat_message:type_name -> agentassistproto.ChatMessage
-	21, // 17: agentassistproto.UserConnectionStatusNotification.user:type_name -> agentassistproto.OnlineUser
-	7,  // 18: agentassistproto.WebsocketMessage.AskQuestionRequest:type_name -> agentassistproto.AskQuestionRequest
-	10, // 19: agentassistproto.WebsocketMessage.WorkReportRequest:type_name -> agentassistproto.WorkReportRequest
-	8,  // 20: agentassistproto.WebsocketMessage.AskQuestionResponse:type_name -> agentassistproto.AskQuestionResponse
-	11, // 21: agentassistproto.WebsocketMessage.WorkReportResponse:type_name -> agentassistproto.WorkReportResponse
-	15, // 22: agentassistproto.WebsocketMessage.CheckMessageValidityRequest:type_name -> agentassistproto.CheckMessageValidityRequest
-	16, // 23: agentassistproto.WebsocketMessage.CheckMessageValidityResponse:type_name -> agentassistproto.CheckMessageValidityResponse
-	17, // 24: agentassistproto.WebsocketMessage.GetPendingMessagesRequest:type_name -> agentassistproto.GetPendingMessagesRequest
-	19, // 25: agentassistproto.WebsocketMessage.GetPendingMessagesResponse:type_name -> agentassistproto.GetPendingMessagesResponse
-	20, // 26: agentassistproto.WebsocketMessage.RequestCancelledNotification:type_name -> agentassistproto.RequestCancelledNotification
-	22, // 27: agentassistproto.WebsocketMessage.GetOnlineUsersRequest:type_name -> agentassistproto.GetOnlineUsersRequest
-	23, // 28: agentassistproto.WebsocketMessage.GetOnlineUsersResponse:type_name -> agentassistproto.GetOnlineUsersResponse
-	25, // 29: agentassistproto.WebsocketMessage.SendChatMessageRequest:type_name -> agentassistproto.SendChatMessageRequest
-	26, // 30: agentassistproto.WebsocketMessage.ChatMessageNotification:type_name -> agentassistproto.ChatMessageNotification
-	27, // 31: agentassistproto.WebsocketMessage.UserLoginResponse:type_name -> agentassistproto.UserLoginResponse
-	28, // 32: agentassistproto.WebsocketMessage.UserConnectionStatusNotification:type_name -> agentassistproto.UserConnectionStatusNotification
-	7,  // 33: agentassistproto.SrvAgentAssist.AskQuestion:input_type -> agentassistproto.AskQuestionRequest
-	10, // 34: agentassistproto.SrvAgentAssist.WorkReport:input_type -> agentassistproto.WorkReportRequest
-	13, // 35: agentassistproto.SrvAgentAssist.SendMcpClientInfo:input_type -> agentassistproto.McpClientInfoRequest
-	8,  // 36: agentassistproto.SrvAgentAssist.AskQuestion:output_type -> agentassistproto.AskQuestionResponse
-	11, // 37: agentassistproto.SrvAgentAssist.WorkReport:output_type -> agentassistproto.WorkReportResponse
-	14, // 38: agentassistproto.SrvAgentAssist.SendMcpClientInfo:output_type -> agentassistproto.McpClientInfoResponse
-	36, // [36:39] is the sub-list for method output_type
-	33, // [33:36] is the sub-list for method input_type
-	33, // [33:33] is the sub-list for extension type_name
-	33, // [33:33] is the sub-list for extension extendee
-	0,  // [0:33] is the sub-list for field type_name
+	14, // 4: agentassistproto.McpAskQuestionRequest.McpClientInfo:type_name -> agentassistproto.McpClientInfoData
+	6,  // 5: agentassistproto.AskQuestionRequest.Request:type_name -> agentassistproto.McpAskQuestionRequest
+	46, // 6: agentassistproto.AskQuestionResponse.Meta:type_name -> agentassistproto.AskQuestionResponse.MetaEntry
+	4,  // 7: agentassistproto.AskQuestionResponse.contents:type_name -> agentassistproto.McpResultContent
+	14, // 8: agentassistproto.McpWorkReportRequest.McpClientInfo:type_name -> agentassistproto.McpClientInfoData
+	9,  // 9: agentassistproto.WorkReportRequest.Request:type_name -> agentassistproto.McpWorkReportRequest
+	47, // 10: agentassistproto.WorkReportResponse.Meta:type_name -> agentassistproto.WorkReportResponse.MetaEntry
+	4,  // 11: agentassistproto.WorkReportResponse.contents:type_name -> agentassistproto.McpResultContent
+	13, // 12: agentassistproto.WorkReportResponse.Decision:type_name -> agentassistproto.WorkReportDecision
+	12, // 13: agentassistproto.WorkReportDecision.Comments:type_name -> agentassistproto.WorkReportComment
+	14, // 14: agentassistproto.McpClientInfoRequest.Request:type_name -> agentassistproto.McpClientInfoData
+	7,  // 15: agentassistproto.SubmitRequestRequest.AskQuestionRequest:type_name -> agentassistproto.AskQuestionRequest
+	10, // 16: agentassistproto.SubmitRequestRequest.WorkReportRequest:type_name -> agentassistproto.WorkReportRequest
+	48, // 17: agentassistproto.SubmitRequestResponse.Meta:type_name -> agentassistproto.SubmitRequestResponse.MetaEntry
+	8,  // 18: agentassistproto.AwaitResultResponse.AskQuestionResponse:type_name -> agentassistproto.AskQuestionResponse
+	11, // 19: agentassistproto.AwaitResultResponse.WorkReportResponse:type_name -> agentassistproto.WorkReportResponse
+	49, // 20: agentassistproto.CheckMessageValidityResponse.validity:type_name -> agentassistproto.CheckMessageValidityResponse.ValidityEntry
+	7,  // 21: agentassistproto.PendingMessage.ask_question_request:type_name -> agentassistproto.AskQuestionRequest
+	10, // 22: agentassistproto.PendingMessage.work_report_request:type_name -> agentassistproto.WorkReportRequest
+	41, // 23: agentassistproto.PendingMessage.approval_progress:type_name -> agentassistproto.ApprovalProgress
+	28, // 24: agentassistproto.GetPendingMessagesResponse.pending_messages:type_name -> agentassistproto.PendingMessage
+	31, // 25: agentassistproto.GetOnlineUsersResponse.online_users:type_name -> agentassistproto.OnlineUser
+	34, // 26: agentassistproto.ChatMessageNotification.chat_message:type_name -> agentassistproto.ChatMessage
+	31, // 27: agentassistproto.UserConnectionStatusNotification.user:type_name -> agentassistproto.OnlineUser
+	7,  // 28: agentassistproto.WebsocketMessage.AskQuestionRequest:type_name -> agentassistproto.AskQuestionRequest
+	10, // 29: agentassistproto.WebsocketMessage.WorkReportRequest:type_name -> agentassistproto.WorkReportRequest
+	8,  // 30: agentassistproto.WebsocketMessage.AskQuestionResponse:type_name -> agentassistproto.AskQuestionResponse
+	11, // 31: agentassistproto.WebsocketMessage.WorkReportResponse:type_name -> agentassistproto.WorkReportResponse
+	25, // 32: agentassistproto.WebsocketMessage.CheckMessageValidityRequest:type_name -> agentassistproto.CheckMessageValidityRequest
+	26, // 33: agentassistproto.WebsocketMessage.CheckMessageValidityResponse:type_name -> agentassistproto.CheckMessageValidityResponse
+	27, // 34: agentassistproto.WebsocketMessage.GetPendingMessagesRequest:type_name -> agentassistproto.GetPendingMessagesRequest
+	29, // 35: agentassistproto.WebsocketMessage.GetPendingMessagesResponse:type_name -> agentassistproto.GetPendingMessagesResponse
+	30, // 36: agentassistproto.WebsocketMessage.RequestCancelledNotification:type_name -> agentassistproto.RequestCancelledNotification
+	32, // 37: agentassistproto.WebsocketMessage.GetOnlineUsersRequest:type_name -> agentassistproto.GetOnlineUsersRequest
+	33, // 38: agentassistproto.WebsocketMessage.GetOnlineUsersResponse:type_name -> agentassistproto.GetOnlineUsersResponse
+	35, // 39: agentassistproto.WebsocketMessage.SendChatMessageRequest:type_name -> agentassistproto.SendChatMessageRequest
+	36, // 40: agentassistproto.WebsocketMessage.ChatMessageNotification:type_name -> agentassistproto.ChatMessageNotification
+	37, // 41: agentassistproto.WebsocketMessage.UserLoginResponse:type_name -> agentassistproto.UserLoginResponse
+	38, // 42: agentassistproto.WebsocketMessage.UserConnectionStatusNotification:type_name -> agentassistproto.UserConnectionStatusNotification
+	39, // 43: agentassistproto.WebsocketMessage.RequestClaim:type_name -> agentassistproto.RequestClaim
+	40, // 44: agentassistproto.WebsocketMessage.ReplyAck:type_name -> agentassistproto.ReplyAck
+	41, // 45: agentassistproto.WebsocketMessage.ApprovalProgress:type_name -> agentassistproto.ApprovalProgress
+	42, // 46: agentassistproto.WebsocketMessage.DeadlineExtension:type_name -> agentassistproto.DeadlineExtension
+	43, // 47: agentassistproto.WebsocketMessage.InterimReply:type_name -> agentassistproto.InterimReply
+	44, // 48: agentassistproto.WebsocketMessage.Reminder:type_name -> agentassistproto.Reminder
+	7,  // 49: agentassistproto.SrvAgentAssist.AskQuestion:input_type -> agentassistproto.AskQuestionRequest
+	10, // 50: agentassistproto.SrvAgentAssist.WorkReport:input_type -> agentassistproto.WorkReportRequest
+	15, // 51: agentassistproto.SrvAgentAssist.SendMcpClientInfo:input_type -> agentassistproto.McpClientInfoRequest
+	17, // 52: agentassistproto.SrvAgentAssist.SubmitRequest:input_type -> agentassistproto.SubmitRequestRequest
+	19, // 53: agentassistproto.SrvAgentAssist.AwaitResult:input_type -> agentassistproto.AwaitResultRequest
+	21, // 54: agentassistproto.SrvAgentAssist.CancelRequest:input_type -> agentassistproto.CancelRequestRequest
+	23, // 55: agentassistproto.SrvAgentAssist.WatchRequest:input_type -> agentassistproto.WatchRequestRequest
+	8,  // 56: agentassistproto.SrvAgentAssist.AskQuestion:output_type -> agentassistproto.AskQuestionResponse
+	11, // 57: agentassistproto.SrvAgentAssist.WorkReport:output_type -> agentassistproto.WorkReportResponse
+	16, // 58: agentassistproto.SrvAgentAssist.SendMcpClientInfo:output_type -> agentassistproto.McpClientInfoResponse
+	18, // 59: agentassistproto.SrvAgentAssist.SubmitRequest:output_type -> agentassistproto.SubmitRequestResponse
+	20, // 60: agentassistproto.SrvAgentAssist.AwaitResult:output_type -> agentassistproto.AwaitResultResponse
+	22, // 61: agentassistproto.SrvAgentAssist.CancelRequest:output_type -> agentassistproto.CancelRequestResponse
+	24, // 62: agentassistproto.SrvAgentAssist.WatchRequest:output_type -> agentassistproto.RequestEvent
+	56, // [56:63] is the sub-list for method output_type
+	49, // [49:56] is the sub-list for method input_type
+	49, // [49:49] is the sub-list for extension type_name
+	49, // [49:49] is the sub-list for extension extendee
+	0,  // [0:49] is the sub-list for field type_name
 }
 
 func init() { file_agentassist_proto_init() }
@@ -2357,7 +3916,7 @@ func file_agentassist_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_agentassist_proto_rawDesc), len(file_agentassist_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   33,
+			NumMessages:   50,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
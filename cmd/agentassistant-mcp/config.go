@@ -0,0 +1,309 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/BurntSushi/toml"
+	"github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+const configFileName = "agentassistant-mcp.toml"
+
+// ServerProfile holds the settings to connect to one agentassistant-srv
+type ServerProfile struct {
+	AgentAssistantServerHost  string `toml:"agentassistant_server_host"`
+	AgentAssistantServerPort  int    `toml:"agentassistant_server_port"`
+	AgentAssistantServerToken string `toml:"agentassistant_server_token"`
+	// "http" (default) or "https"
+	AgentAssistantServerScheme string `toml:"agentassistant_server_scheme"`
+	// CA bundle to verify the server certificate with, instead of the system roots
+	AgentAssistantServerCAFile string `toml:"agentassistant_server_ca_file"`
+	// Skip verifying the server certificate, only for testing with self-signed certificates
+	AgentAssistantServerInsecureSkipVerify bool `toml:"agentassistant_server_insecure_skip_verify"`
+	// Client certificate and key for servers that require mutual TLS
+	AgentAssistantServerClientCertFile string `toml:"agentassistant_server_client_cert_file"`
+	AgentAssistantServerClientKeyFile  string `toml:"agentassistant_server_client_key_file"`
+	// Ordered list of server URLs to fail over between, e.g. "https://srv1.example.com:8080".
+	// Replaces host, port and scheme if set.
+	AgentAssistantServerURLs []string `toml:"agentassistant_server_urls"`
+	// Projects in these directories (or below them) use this profile. Ignored for the
+	// default profile, which is used for all other projects.
+	ProjectDirectories []string `toml:"project_directories"`
+}
+
+// Config represents the configuration structure. The top level server settings are the
+// default profile.
+type Config struct {
+	ServerProfile
+	// Named server profiles, selected per project by their project_directories
+	Profiles map[string]ServerProfile `toml:"agentassistant_profiles"`
+	// MCP transport: "stdio" (default), "http" (streamable HTTP) or "sse"
+	AgentAssistantMcpTransport string `toml:"agentassistant_mcp_transport"`
+	// Listen address of the http and sse transports, default "127.0.0.1:8090"
+	AgentAssistantMcpListen string `toml:"agentassistant_mcp_listen"`
+	// Bearer token MCP clients of the http and sse transports must send, required by them
+	AgentAssistantMcpListenToken string `toml:"agentassistant_mcp_listen_token"`
+}
+
+// serverConnection is a server profile with an RPC client per server URL
+type serverConnection struct {
+	name      string
+	profile   ServerProfile
+	endpoints []*serverEndpoint
+}
+
+// Server connections, the default profile first
+var connections []*serverConnection
+
+// forcedProfile, if set, is used for every project regardless of its directory
+var forcedProfile *serverConnection
+
+// configSearchPaths returns the locations searched for the config file, in order
+func configSearchPaths() []string {
+	if path := os.Getenv("AGENTASSISTANT_CONFIG"); path != "" {
+		return []string{path}
+	}
+
+	// The current working directory comes first for compatibility with older setups
+	paths := []string{configFileName}
+
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	home, _ := os.UserHomeDir()
+	if configHome == "" && home != "" {
+		configHome = filepath.Join(home, ".config")
+	}
+	if configHome != "" {
+		paths = append(paths, filepath.Join(configHome, "agentassistant", configFileName))
+	}
+	if home != "" {
+		paths = append(paths, filepath.Join(home, "."+configFileName))
+	}
+
+	if execPath, err := os.Executable(); err == nil {
+		paths = append(paths, filepath.Join(filepath.Dir(execPath), configFileName))
+	}
+
+	return paths
+}
+
+// loadConfig loads configuration from the first config file found and applies the
+// AGENTASSISTANT_* environment overrides
+func loadConfig() {
+	for _, path := range configSearchPaths() {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		if _, err := toml.DecodeFile(path, &config); err != nil {
+			log.Printf("Warning: Failed to load config file %s: %v", path, err)
+		} else {
+			log.Printf("Loaded config file %s", path)
+		}
+		break
+	}
+
+	applyEnvOverrides(&config.ServerProfile)
+	if value := os.Getenv("AGENTASSISTANT_MCP_LISTEN_TOKEN"); value != "" {
+		config.AgentAssistantMcpListenToken = value
+	}
+}
+
+// applyEnvOverrides overrides the default profile with AGENTASSISTANT_* environment variables
+func applyEnvOverrides(profile *ServerProfile) {
+	stringFields := map[string]*string{
+		"AGENTASSISTANT_SERVER_HOST":             &profile.AgentAssistantServerHost,
+		"AGENTASSISTANT_SERVER_TOKEN":            &profile.AgentAssistantServerToken,
+		"AGENTASSISTANT_SERVER_SCHEME":           &profile.AgentAssistantServerScheme,
+		"AGENTASSISTANT_SERVER_CA_FILE":          &profile.AgentAssistantServerCAFile,
+		"AGENTASSISTANT_SERVER_CLIENT_CERT_FILE": &profile.AgentAssistantServerClientCertFile,
+		"AGENTASSISTANT_SERVER_CLIENT_KEY_FILE":  &profile.AgentAssistantServerClientKeyFile,
+	}
+	for name, field := range stringFields {
+		if value := os.Getenv(name); value != "" {
+			*field = value
+		}
+	}
+
+	if value := os.Getenv("AGENTASSISTANT_SERVER_URLS"); value != "" {
+		profile.AgentAssistantServerURLs = strings.Split(value, ",")
+	}
+
+	if value := os.Getenv("AGENTASSISTANT_SERVER_PORT"); value != "" {
+		port, err := strconv.Atoi(value)
+		if err != nil {
+			log.Printf("Warning: Ignoring invalid AGENTASSISTANT_SERVER_PORT %q", value)
+		} else {
+			profile.AgentAssistantServerPort = port
+		}
+	}
+
+	if value := os.Getenv("AGENTASSISTANT_SERVER_INSECURE_SKIP_VERIFY"); value != "" {
+		insecure, err := strconv.ParseBool(value)
+		if err != nil {
+			log.Printf("Warning: Ignoring invalid AGENTASSISTANT_SERVER_INSECURE_SKIP_VERIFY %q", value)
+		} else {
+			profile.AgentAssistantServerInsecureSkipVerify = insecure
+		}
+	}
+}
+
+// setDefaults fills in the settings a profile does not configure
+func (p *ServerProfile) setDefaults() {
+	if p.AgentAssistantServerHost == "" {
+		p.AgentAssistantServerHost = "127.0.0.1"
+	}
+	if p.AgentAssistantServerPort == 0 {
+		p.AgentAssistantServerPort = 8080
+	}
+	if p.AgentAssistantServerToken == "" {
+		p.AgentAssistantServerToken = "test-token"
+	}
+	if p.AgentAssistantServerScheme == "" {
+		p.AgentAssistantServerScheme = "http"
+	}
+}
+
+// baseURL returns the server URL of the profile
+func (p *ServerProfile) baseURL() string {
+	return fmt.Sprintf("%s://%s:%d", p.AgentAssistantServerScheme, p.AgentAssistantServerHost, p.AgentAssistantServerPort)
+}
+
+// serverURLs returns the server URLs of the profile in failover order
+func (p *ServerProfile) serverURLs() ([]string, error) {
+	if len(p.AgentAssistantServerURLs) == 0 {
+		return []string{p.baseURL()}, nil
+	}
+
+	var urls []string
+	for _, rawURL := range p.AgentAssistantServerURLs {
+		rawURL = strings.TrimSuffix(strings.TrimSpace(rawURL), "/")
+		u, err := url.Parse(rawURL)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			return nil, fmt.Errorf("invalid server URL %q, expected http(s)://host:port", rawURL)
+		}
+		urls = append(urls, rawURL)
+	}
+	return urls, nil
+}
+
+// connectServers creates an RPC client for the default profile and every named profile
+func connectServers(profileName string) error {
+	names := []string{""}
+	for name := range config.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names[1:])
+
+	for _, name := range names {
+		profile := config.ServerProfile
+		if name != "" {
+			profile = config.Profiles[name]
+		}
+		profile.setDefaults()
+		if profile.AgentAssistantServerInsecureSkipVerify {
+			log.Printf("Warning: server certificate verification is disabled for profile %s", displayProfileName(name))
+		}
+
+		urls, err := profile.serverURLs()
+		if err != nil {
+			return fmt.Errorf("profile %s: %w", displayProfileName(name), err)
+		}
+
+		conn := &serverConnection{name: name, profile: profile}
+		for _, serverURL := range urls {
+			httpClient, err := newHTTPClient(&profile, strings.SplitN(serverURL, ":", 2)[0])
+			if err != nil {
+				return fmt.Errorf("profile %s: %w", displayProfileName(name), err)
+			}
+
+			conn.endpoints = append(conn.endpoints, &serverEndpoint{
+				url:        serverURL,
+				httpClient: httpClient,
+				client: agentassistproto.NewSrvAgentAssistClient(
+					httpClient,
+					serverURL,
+					connect.WithReadMaxBytes(50*1024*1024),
+					connect.WithInterceptors(newBearerTokenInterceptor(profile.AgentAssistantServerToken)),
+				),
+			})
+		}
+		connections = append(connections, conn)
+	}
+
+	if profileName != "" {
+		for _, conn := range connections {
+			if displayProfileName(conn.name) == profileName {
+				forcedProfile = conn
+			}
+		}
+		if forcedProfile == nil {
+			return fmt.Errorf("unknown profile %q", profileName)
+		}
+	}
+
+	return nil
+}
+
+// connectionFor returns the server connection of the profile whose project directories
+// contain projectDirectory most specifically, or the default profile
+func connectionFor(projectDirectory string) *serverConnection {
+	if forcedProfile != nil {
+		return forcedProfile
+	}
+
+	best, bestLength := connections[0], -1
+	project := normalizeDirectory(projectDirectory)
+	for _, conn := range connections[1:] {
+		for _, dir := range conn.profile.ProjectDirectories {
+			dir = normalizeDirectory(dir)
+			if isSubdirectory(project, dir) && len(dir) > bestLength {
+				best, bestLength = conn, len(dir)
+			}
+		}
+	}
+
+	if best.name != "" {
+		log.Printf("Using profile %s for project %s", best.name, projectDirectory)
+	}
+	return best
+}
+
+// normalizeDirectory expands a leading ~ and cleans the path
+func normalizeDirectory(dir string) string {
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			dir = home + dir[1:]
+		}
+	}
+	dir = filepath.Clean(filepath.FromSlash(dir))
+	if runtime.GOOS == "windows" {
+		dir = strings.ToLower(dir)
+	}
+	return dir
+}
+
+// isSubdirectory reports whether dir is parent or one of its subdirectories
+func isSubdirectory(dir, parent string) bool {
+	if dir == parent {
+		return true
+	}
+	if !strings.HasSuffix(parent, string(filepath.Separator)) {
+		parent += string(filepath.Separator)
+	}
+	return strings.HasPrefix(dir, parent)
+}
+
+func displayProfileName(name string) string {
+	if name == "" {
+		return "default"
+	}
+	return name
+}
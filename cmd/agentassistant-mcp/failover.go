@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/yangjuncode/agentassistant/agentassistproto"
+	"github.com/yangjuncode/agentassistant/internal/mcptools"
+)
+
+const (
+	// healthCheckTimeout bounds a single /health request
+	healthCheckTimeout = 3 * time.Second
+	// healthCacheDuration is how long a health check result is reused
+	healthCacheDuration = 10 * time.Second
+)
+
+// serverEndpoint is one server URL of a profile
+type serverEndpoint struct {
+	url        string
+	httpClient *http.Client
+	client     agentassistproto.SrvAgentAssistClient
+
+	mu        sync.Mutex
+	healthy   bool
+	checkedAt time.Time
+
+	// MCP sessions whose client info the server has received
+	clientInfoMu   sync.Mutex
+	clientInfoSent map[string]bool
+}
+
+// isHealthy reports whether the /health endpoint of the server answers, using a cached
+// result if it is recent enough
+func (e *serverEndpoint) isHealthy(ctx context.Context) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.checkedAt.IsZero() && time.Since(e.checkedAt) < healthCacheDuration {
+		return e.healthy
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	e.healthy = false
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.url+"/health", nil)
+	if err == nil {
+		resp, err := e.httpClient.Do(req)
+		if err == nil {
+			resp.Body.Close()
+			e.healthy = resp.StatusCode == http.StatusOK
+		}
+	}
+	e.checkedAt = time.Now()
+	return e.healthy
+}
+
+// markUnhealthy records a failed call so the endpoint is skipped until its next health check
+func (e *serverEndpoint) markUnhealthy() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.healthy = false
+	e.checkedAt = time.Now()
+}
+
+// sendClientInfo reports the client info of the MCP session to the server, once per session
+// and server. A failure is logged and retried with the next request of the session.
+func (e *serverEndpoint) sendClientInfo(ctx context.Context, info *mcptools.ClientInfo, userToken string) {
+	if info == nil {
+		return
+	}
+
+	e.clientInfoMu.Lock()
+	sent := e.clientInfoSent[info.SessionID]
+	e.clientInfoMu.Unlock()
+	if sent {
+		return
+	}
+
+	resp, err := e.client.SendMcpClientInfo(ctx, connect.NewRequest(info.Request(userToken)))
+	if err != nil || !resp.Msg.Success {
+		log.Printf("Failed to send MCP client info to %s: %v", e.url, err)
+		return
+	}
+
+	e.clientInfoMu.Lock()
+	if e.clientInfoSent == nil {
+		e.clientInfoSent = make(map[string]bool)
+	}
+	e.clientInfoSent[info.SessionID] = true
+	e.clientInfoMu.Unlock()
+}
+
+// pickEndpoint returns the first healthy server in configured order. If no server is healthy
+// it returns the one after current (or the first one), so that retries rotate through the list.
+// The second result reports whether the returned server passed its health check.
+func (c *serverConnection) pickEndpoint(ctx context.Context, current *serverEndpoint) (*serverEndpoint, bool) {
+	if len(c.endpoints) == 1 {
+		return c.endpoints[0], true
+	}
+
+	for _, endpoint := range c.endpoints {
+		if endpoint.isHealthy(ctx) {
+			return endpoint, true
+		}
+	}
+
+	for i, endpoint := range c.endpoints {
+		if endpoint == current {
+			return c.endpoints[(i+1)%len(c.endpoints)], false
+		}
+	}
+	return c.endpoints[0], false
+}
+
+// isConnectionError reports whether err means the server could not be reached
+func isConnectionError(err error) bool {
+	code := connect.CodeOf(err)
+	return code == connect.CodeUnavailable || code == connect.CodeDeadlineExceeded
+}
@@ -2,9 +2,8 @@ package main
 
 import (
 	"context"
+	"crypto/subtle"
 	_ "embed"
-	"encoding/base64"
-	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
@@ -13,104 +12,36 @@ import (
 	"os/exec"
 	"runtime"
 	"strings"
-	"sync/atomic"
 
-	"connectrpc.com/connect"
-	"github.com/BurntSushi/toml"
-	"github.com/google/uuid"
-	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
-	"github.com/yangjuncode/agentassistant/agentassistproto"
+	"github.com/yangjuncode/agentassistant/internal/mcptools"
 )
 
 //go:embed version.txt
 var version string
 
-// Config represents the configuration structure
-type Config struct {
-	AgentAssistantServerHost  string `toml:"agentassistant_server_host"`
-	AgentAssistantServerPort  int    `toml:"agentassistant_server_port"`
-	AgentAssistantServerToken string `toml:"agentassistant_server_token"`
-}
-
-type cachedMcpClientInfo struct {
-	ProtocolVersion  string
-	CapabilitiesJson string
-	ClientName       string
-	ClientVersion    string
-}
-
-func cacheMcpClientInfo(params mcp.InitializeParams) {
-	capabilitiesBytes, err := json.Marshal(params.Capabilities)
-	if err != nil {
-		log.Printf("Failed to marshal MCP capabilities: %v", err)
-		capabilitiesBytes = []byte("{}")
-	}
-
-	info := &cachedMcpClientInfo{
-		ProtocolVersion:  params.ProtocolVersion,
-		CapabilitiesJson: string(capabilitiesBytes),
-		ClientName:       params.ClientInfo.Name,
-		ClientVersion:    params.ClientInfo.Version,
-	}
-
-	mcpClientInfo.Store(info)
-}
-
-//func ensureMcpClientInfoSent() {
-//	if mcpClientInfoSent.Load() {
-//		return
-//	}
-//
-//	value := mcpClientInfo.Load()
-//	info, ok := value.(*cachedMcpClientInfo)
-//	if !ok || info == nil {
-//		return
-//	}
-//
-//	req := &agentassistproto.McpClientInfoRequest{
-//		ID:        generateRequestID(),
-//		UserToken: config.AgentAssistantServerToken,
-//		Request: &agentassistproto.McpClientInfoData{
-//			ProtocolVersion:  info.ProtocolVersion,
-//			CapabilitiesJson: info.CapabilitiesJson,
-//			ClientName:       info.ClientName,
-//			ClientVersion:    info.ClientVersion,
-//		},
-//		Timestamp: time.Now().UnixMilli(),
-//	}
-//
-//	if _, err := client.SendMcpClientInfo(context.Background(), connect.NewRequest(req)); err != nil {
-//		log.Printf("Failed to send MCP client info: %v", err)
-//		return
-//	}
-//
-//	log.Printf("Sent MCP client info for %s (%s)", info.ClientName, info.ClientVersion)
-//	mcpClientInfoSent.Store(true)
-//}
-
 // Global configuration
 var config Config
-var client agentassistproto.SrvAgentAssistClient
-
-var mcpClientName atomic.Value
-var mcpClientInfo atomic.Value
-var mcpClientInfoSent atomic.Bool
 
 func main() {
 	// Parse command line arguments
 	var (
-		host  = flag.String("host", "", "Agent Assistant server host")
-		port  = flag.Int("port", 0, "Agent Assistant server port")
-		token = flag.String("token", "", "Agent Assistant server token")
-		web   = flag.Bool("web", false, "Open web interface in browser")
+		host        = flag.String("host", "", "Agent Assistant server host")
+		port        = flag.Int("port", 0, "Agent Assistant server port")
+		token       = flag.String("token", "", "Agent Assistant server token")
+		scheme      = flag.String("scheme", "", "Agent Assistant server scheme, http or https")
+		profile     = flag.String("profile", "", "Use this server profile for all projects")
+		transport   = flag.String("transport", "", "MCP transport: stdio (default), http or sse")
+		listen      = flag.String("listen", "", "Listen address of the http and sse transports (default 127.0.0.1:8090)")
+		listenToken = flag.String("listen-token", "", "Bearer token MCP clients of the http and sse transports must send")
+		web         = flag.Bool("web", false, "Open web interface in browser")
 	)
 	flag.Parse()
 
-	// Load configuration from file
+	// Load configuration from file and environment
 	loadConfig()
 
-	// Override config with command line arguments if provided
+	// Override the default profile with command line arguments if provided
 	if *host != "" {
 		config.AgentAssistantServerHost = *host
 	}
@@ -120,154 +51,90 @@ func main() {
 	if *token != "" {
 		config.AgentAssistantServerToken = *token
 	}
-
-	// Set defaults if not configured
-	if config.AgentAssistantServerHost == "" {
-		config.AgentAssistantServerHost = "127.0.0.1"
+	if *scheme != "" {
+		config.AgentAssistantServerScheme = *scheme
+	}
+	if *profile == "" {
+		*profile = os.Getenv("AGENTASSISTANT_PROFILE")
+	}
+	if *transport != "" {
+		config.AgentAssistantMcpTransport = *transport
 	}
-	if config.AgentAssistantServerPort == 0 {
-		config.AgentAssistantServerPort = 8080
+	if *listen != "" {
+		config.AgentAssistantMcpListen = *listen
 	}
-	if config.AgentAssistantServerToken == "" {
-		config.AgentAssistantServerToken = "test-token"
+	if *listenToken != "" {
+		config.AgentAssistantMcpListenToken = *listenToken
 	}
 
-	// Initialize RPC client
-	httpClient := &http.Client{}
-	serverURL := fmt.Sprintf("http://%s:%d", config.AgentAssistantServerHost, config.AgentAssistantServerPort)
-	client = agentassistproto.NewSrvAgentAssistClient(
-		httpClient,
-		serverURL,
-		connect.WithReadMaxBytes(50*1024*1024),
-	)
+	// Initialize RPC clients
+	if err := connectServers(*profile); err != nil {
+		log.Fatalf("Failed to create RPC clients: %v", err)
+	}
 
 	// Open web interface if requested
 	if *web {
-		webURL := fmt.Sprintf("http://%s:%d?token=%s", config.AgentAssistantServerHost, config.AgentAssistantServerPort, config.AgentAssistantServerToken)
+		conn := connections[0]
+		if forcedProfile != nil {
+			conn = forcedProfile
+		}
+		endpoint, _ := conn.pickEndpoint(context.Background(), nil)
+		webURL := fmt.Sprintf("%s?token=%s", endpoint.url, conn.profile.AgentAssistantServerToken)
 		openBrowser(webURL)
 		return
 	}
 
 	// Create a new MCP server
-	hooks := &server.Hooks{}
-	hooks.AddAfterInitialize(func(ctx context.Context, id any, message *mcp.InitializeRequest, result *mcp.InitializeResult) {
-		mcpClientName.Store(message.Params.ClientInfo.Name)
-		cacheMcpClientInfo(message.Params)
-		//go ensureMcpClientInfoSent()
-	})
-
-	s := server.NewMCPServer(
-		"Agent-Assistant ",
-		version,
-		server.WithToolCapabilities(false),
-		server.WithHooks(hooks),
-	)
-
-	// ask_question tool
-	tool := mcp.NewTool("ask_question",
-		mcp.WithDescription(`
-Ask a question to the Agent-Assistant
-
-This tool allows you to ask a question to the Agent-Assistant. The Agent-Assistant/User will send the answer/feedback to you.
-
-Args:
-- project_directory: The current project directory
-- question: The question to ask
-- timeout: The timeout in seconds, default is 3600s (1 hour)
-- agent_name: The name of the AI agent/client calling this tool (e.g., Antigravity, Cascade)
-- reasoning_model_name: The name of the actual LLM/inference model currently being used for this task (e.g., GPT-4, Gemini 3 Pro)
+	s := mcptools.NewServer("Agent-Assistant ", version, serverBackend{})
 
-Returns:
-- List of TextContent, ImageContent, AudioContent, or EmbeddedResource from Agent-Assistant
-`),
-		//ProjectDirectory
-		mcp.WithString("project_directory",
-			mcp.Required(),
-			mcp.Description("Current project directory"),
-		),
-		//question
-		mcp.WithString("question",
-			mcp.Required(),
-			mcp.Description("The question to ask"),
-		),
-		//timeout
-		mcp.WithNumber("timeout",
-			mcp.DefaultNumber(3600),
-			mcp.Description("Timeout in seconds, default is 3600s (1 hour)"),
-		),
-		//agent_name
-		mcp.WithString("agent_name",
-			mcp.Required(),
-			mcp.Description("The name of the AI agent/client calling this tool (e.g., Antigravity, Cascade)"),
-		),
-		//reasoning_model_name
-		mcp.WithString("reasoning_model_name",
-			mcp.Required(),
-			mcp.Description("The specific identifier of the LLM/inference model being used (e.g., 'gpt-4o', 'claude-3-5-sonnet', 'gemini-1.5-pro'). Do NOT use generic agent names like 'cascade' or 'windsurf'."),
-		),
-	)
-
-	workReportTool := mcp.NewTool("work_report",
-		mcp.WithDescription(`
-before finish task/work, send a work report to Agent-Assistant/User asking for confirmation/approval.
-
-This tool allows you to ask for confirmation/approval from Agent-Assistant/User by sending a work report.
-
-Args:
-- project_directory: The current project directory
-- summary: The summary of the task/work report
-- timeout: The timeout in seconds, default is 3600s (1 hour)
-- agent_name: The name of the AI agent/client calling this tool (e.g., Antigravity, Cascade)
-- reasoning_model_name: The name of the actual LLM/inference model currently being used for this task (e.g., GPT-4, Gemini 3 Pro)
-
-Returns:
-- List of TextContent, ImageContent, AudioContent, or EmbeddedResource from Agent-Assistant
-`),
-		//ProjectDirectory
-		mcp.WithString("project_directory",
-			mcp.Required(),
-			mcp.Description("Current project directory"),
-		),
-		//summary
-		mcp.WithString("summary",
-			mcp.Required(),
-			mcp.Description("Summary of the task/work report"),
-		),
-		//timeout
-		mcp.WithNumber("timeout",
-			mcp.DefaultNumber(3600),
-			mcp.Description("Timeout in seconds, default is 3600s (1 hour)"),
-		),
-		//agent_name
-		mcp.WithString("agent_name",
-			mcp.Required(),
-			mcp.Description("The name of the AI agent/client calling this tool (e.g., Antigravity, Cascade)"),
-		),
-		//reasoning_model_name
-		mcp.WithString("reasoning_model_name",
-			mcp.Required(),
-			mcp.Description("The specific identifier of the LLM/inference model being used (e.g., 'gpt-4o', 'claude-3-5-sonnet', 'gemini-1.5-pro'). Do NOT use generic agent names like 'cascade' or 'windsurf'."),
-		),
-	)
-
-	// Add tool handler
-	s.AddTool(tool, askQuestionHandler)
-	s.AddTool(workReportTool, workReportHandler)
+	if err := serveMCP(s); err != nil {
+		log.Fatalf("Server error: %v", err)
+	}
+}
 
-	// Start the stdio server
-	if err := server.ServeStdio(s); err != nil {
-		fmt.Printf("Server error: %v\n", err)
+// serveMCP serves the MCP server over the configured transport
+func serveMCP(s *server.MCPServer) error {
+	listen := config.AgentAssistantMcpListen
+	if listen == "" {
+		listen = "127.0.0.1:8090"
+	}
+
+	// Remote MCP clients get their requests relayed with the server token of the profile, so
+	// they must authenticate themselves
+	token := config.AgentAssistantMcpListenToken
+	transport := config.AgentAssistantMcpTransport
+	if (transport == "http" || transport == "sse") && token == "" {
+		return fmt.Errorf("the %s transport requires a listen token (-listen-token or agentassistant_mcp_listen_token)", transport)
+	}
+
+	switch transport {
+	case "", "stdio":
+		return mcptools.ServeStdio(s)
+	case "http":
+		log.Printf("Serving MCP over streamable HTTP on %s/mcp", listen)
+		mux := http.NewServeMux()
+		mux.Handle("/mcp", bearerAuthHandler(token, mcptools.CancellationHandler(mcptools.NewStreamableHTTPServer(s))))
+		return http.ListenAndServe(listen, mux)
+	case "sse":
+		log.Printf("Serving MCP over SSE on %s/sse", listen)
+		return http.ListenAndServe(listen, bearerAuthHandler(token, mcptools.CancellationHandler(server.NewSSEServer(s))))
+	default:
+		return fmt.Errorf("unknown transport %q, must be stdio, http or sse", config.AgentAssistantMcpTransport)
 	}
 }
 
-// loadConfig loads configuration from agentassistant-mcp.toml file
-func loadConfig() {
-	configFile := "agentassistant-mcp.toml"
-	if _, err := os.Stat(configFile); err == nil {
-		if _, err := toml.DecodeFile(configFile, &config); err != nil {
-			log.Printf("Warning: Failed to load config file %s: %v", configFile, err)
+// bearerAuthHandler rejects requests without the bearer token in the Authorization header
+func bearerAuthHandler(token string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(sent), []byte(token)) != 1 {
+			log.Printf("Rejected MCP request from %s: invalid token", r.RemoteAddr)
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
 		}
-	}
+		h.ServeHTTP(w, r)
+	})
 }
 
 // openBrowser opens the specified URL in the default browser
@@ -287,216 +154,3 @@ func openBrowser(url string) {
 		log.Printf("Failed to open browser: %v", err)
 	}
 }
-
-// askQuestionHandler handles the ask_question tool
-func askQuestionHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	//ensureMcpClientInfoSent()
-	projectDirectory, err := request.RequireString("project_directory")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	question, err := request.RequireString("question")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	timeout, err := request.RequireInt("timeout")
-	if err != nil {
-		timeout = 3600 // Default timeout (1 hour)
-	}
-
-	// Get optional agent_name and reasoning_model_name
-	agentName, _ := request.RequireString("agent_name")
-	reasoningModelName, _ := request.RequireString("reasoning_model_name")
-
-	currentMcpClientName := ""
-	if v := mcpClientName.Load(); v != nil {
-		if s, ok := v.(string); ok {
-			currentMcpClientName = s
-		}
-	}
-
-	// Create RPC request
-	req := &agentassistproto.AskQuestionRequest{
-		ID:        generateRequestID(),
-		UserToken: config.AgentAssistantServerToken,
-		Request: &agentassistproto.McpAskQuestionRequest{
-			ProjectDirectory:   projectDirectory,
-			Question:           question,
-			Timeout:            int32(timeout),
-			AgentName:          agentName,
-			ReasoningModelName: reasoningModelName,
-			McpClientName:      currentMcpClientName,
-		},
-	}
-
-	// Call the AskQuestion RPC
-	resp, err := client.AskQuestion(context.Background(), connect.NewRequest(req))
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("RPC call failed: %v", err)), nil
-	}
-
-	// Convert response to MCP result
-	return convertToMCPResult(resp.Msg), nil
-}
-
-// workReportHandler handles the work_report tool
-func workReportHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	//ensureMcpClientInfoSent()
-	projectDirectory, err := request.RequireString("project_directory")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	summary, err := request.RequireString("summary")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	timeout, err := request.RequireInt("timeout")
-	if err != nil {
-		timeout = 3600 // Default timeout (1 hour)
-	}
-
-	// Get optional agent_name and reasoning_model_name
-	agentName, _ := request.RequireString("agent_name")
-	reasoningModelName, _ := request.RequireString("reasoning_model_name")
-
-	currentMcpClientName := ""
-	if v := mcpClientName.Load(); v != nil {
-		if s, ok := v.(string); ok {
-			currentMcpClientName = s
-		}
-	}
-
-	// Create RPC request
-	req := &agentassistproto.WorkReportRequest{
-		ID:        generateRequestID(),
-		UserToken: config.AgentAssistantServerToken,
-		Request: &agentassistproto.McpWorkReportRequest{
-			ProjectDirectory:   projectDirectory,
-			Summary:            summary,
-			Timeout:            int32(timeout),
-			AgentName:          agentName,
-			ReasoningModelName: reasoningModelName,
-			McpClientName:      currentMcpClientName,
-		},
-	}
-
-	// Call the WorkReport RPC
-	resp, err := client.WorkReport(context.Background(), connect.NewRequest(req))
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("RPC call failed: %v", err)), nil
-	}
-
-	// Convert response to MCP result
-	return convertToMCPResult(resp.Msg), nil
-}
-
-// generateRequestID generates a unique request ID using UUID V7
-func generateRequestID() string {
-	return uuid.Must(uuid.NewV7()).String()
-}
-
-// convertToMCPResult converts an RPC response to MCP result
-func convertToMCPResult(resp interface{}) *mcp.CallToolResult {
-	var isError bool
-	var contents []*agentassistproto.McpResultContent
-
-	switch r := resp.(type) {
-	case *agentassistproto.AskQuestionResponse:
-		isError = r.IsError
-		contents = r.Contents
-	case *agentassistproto.WorkReportResponse:
-		isError = r.IsError
-		contents = r.Contents
-	default:
-		return mcp.NewToolResultError("Unknown response type")
-	}
-
-	// Convert contents to MCP format
-	var mcpContents []mcp.Content
-	for i, content := range contents {
-		switch content.Type {
-		case 1: // Text content
-			if content.Text != nil {
-				mcpContents = append(mcpContents, mcp.NewTextContent(content.Text.Text))
-			}
-		case 2: // Image content
-			if content.Image != nil {
-				mcpContents = append(mcpContents, mcp.NewImageContent(content.Image.Data, content.Image.MimeType))
-				mcpContents = append(mcpContents, mcp.NewEmbeddedResource(mcp.BlobResourceContents{
-					URI:      fmt.Sprintf("attachment://image/%d", i),
-					MIMEType: content.Image.MimeType,
-					Blob:     content.Image.Data,
-				}))
-			}
-		case 3: // Audio content
-			if content.Audio != nil {
-				mcpContents = append(mcpContents, mcp.NewAudioContent(content.Audio.Data, content.Audio.MimeType))
-				mcpContents = append(mcpContents, mcp.NewEmbeddedResource(mcp.BlobResourceContents{
-					URI:      fmt.Sprintf("attachment://audio/%d", i),
-					MIMEType: content.Audio.MimeType,
-					Blob:     content.Audio.Data,
-				}))
-			}
-		case 4: // Embedded resource
-			if content.EmbeddedResource != nil {
-				// Prefer returning the embedded bytes to the MCP client.
-				// MCP-Go expects embedded resources as EmbeddedResource{type:"resource", resource: BlobResourceContents/TextResourceContents}.
-				if len(content.EmbeddedResource.Data) > 0 {
-					// if strings.HasPrefix(strings.ToLower(content.EmbeddedResource.MimeType), "image/") {
-					// 	mcpContents = append(
-					// 		mcpContents,
-					// 		mcp.NewImageContent(
-					// 			base64.StdEncoding.EncodeToString(content.EmbeddedResource.Data),
-					// 			content.EmbeddedResource.MimeType,
-					// 		),
-					// 	)
-
-					// }
-
-					mcpContents = append(mcpContents, mcp.NewEmbeddedResource(mcp.BlobResourceContents{
-						URI:      content.EmbeddedResource.Uri,
-						MIMEType: content.EmbeddedResource.MimeType,
-						Blob:     base64.StdEncoding.EncodeToString(content.EmbeddedResource.Data),
-					}))
-				} else {
-					// If there is no inline data, fall back to a text description.
-					mcpContents = append(mcpContents, mcp.NewTextContent(fmt.Sprintf("Resource: %s", content.EmbeddedResource.Uri)))
-				}
-			}
-		}
-	}
-
-	if isError {
-		return &mcp.CallToolResult{
-			Content: mcpContents,
-			IsError: true,
-		}
-	}
-
-	// if len(mcpContents) == 0 {
-	// 	mcpContents = append(mcpContents, mcp.NewTextContent("Request completed successfully"))
-	// }
-
-	// Create a tool result with the contents
-	result := &mcp.CallToolResult{
-		Content: mcpContents,
-	}
-	return result
-}
-
-func isTextMimeType(mimeType string) bool {
-	m := strings.ToLower(strings.TrimSpace(mimeType))
-	if strings.HasPrefix(m, "text/") {
-		return true
-	}
-	switch m {
-	case "application/json", "application/xml", "application/javascript", "application/x-yaml", "application/yaml":
-		return true
-	default:
-		return false
-	}
-}
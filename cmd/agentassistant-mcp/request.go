@@ -0,0 +1,337 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/yangjuncode/agentassistant/agentassistproto"
+	"github.com/yangjuncode/agentassistant/internal/mcptools"
+)
+
+const (
+	// awaitWaitSeconds is how long a single AwaitResult call waits on the server
+	awaitWaitSeconds = 30
+	// awaitCallSlack is added to the client side timeout of an AwaitResult call, so that a
+	// connection that silently died (e.g. after the laptop went to sleep) is detected
+	awaitCallSlack = 15 * time.Second
+	// requestGracePeriod is how long to keep retrying after the request timeout, so that the
+	// timeout result of the server is preferred over a local one
+	requestGracePeriod = 30 * time.Second
+
+	// cancelRequestTimeout bounds the CancelRequest call made after the agent cancelled
+	cancelRequestTimeout = 5 * time.Second
+
+	// interimReplyEvent is the type of the RequestEvent of an interim reply of the human
+	interimReplyEvent = "interim_reply"
+
+	initialRetryBackoff = 1 * time.Second
+	maxRetryBackoff     = 30 * time.Second
+)
+
+// serverBackend submits the requests of the MCP tools to the agentassistant-srv of the
+// project's profile
+type serverBackend struct{}
+
+func (serverBackend) Submit(ctx context.Context, submit *agentassistproto.SubmitRequestRequest, timeout int) (*mcptools.Result, error) {
+	// Send the request with the token of the server of the project
+	var conn *serverConnection
+	if r := submit.AskQuestionRequest; r != nil {
+		conn = connectionFor(r.Request.GetProjectDirectory())
+		r.UserToken = conn.profile.AgentAssistantServerToken
+	} else {
+		r := submit.WorkReportRequest
+		conn = connectionFor(r.Request.GetProjectDirectory())
+		r.UserToken = conn.profile.AgentAssistantServerToken
+	}
+
+	// Submit the request and wait for its result, surviving connection failures
+	resp, serverURL, err := submitAndAwait(ctx, conn, submit, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", serverURL, err)
+	}
+
+	// Record which server answered, and mention it if it was not the primary server
+	result := &mcptools.Result{
+		Response: resp,
+		Meta:     map[string]any{"agentassistant_server": serverURL},
+	}
+	if serverURL != conn.endpoints[0].url {
+		result.Note = fmt.Sprintf("(answered via fallback server %s)", serverURL)
+	}
+	return result, nil
+}
+
+// submitAndAwait submits a request to the server and waits for its result. Connection failures
+// are retried with backoff by re-attaching to the same request ID (and re-submitting it if the
+// server lost it) until the request timeout elapses. If the profile lists several servers,
+// connection failures fail over to the next healthy one. The URL of the server that answered
+// is returned with the result.
+func submitAndAwait(ctx context.Context, conn *serverConnection, submit *agentassistproto.SubmitRequestRequest, timeout int) (*agentassistproto.AwaitResultResponse, string, error) {
+	requestID, userToken, setTimeout := submittedRequest(submit)
+	clientInfo := mcptools.ClientInfoFromContext(ctx)
+	deadline := time.Now().Add(time.Duration(timeout)*time.Second + requestGracePeriod)
+
+	endpoint, _ := conn.pickEndpoint(ctx, nil)
+
+	// Progress is watched on the server the request is submitted to
+	progress := &progressWatch{}
+	defer progress.stop()
+
+	submitted := false
+	backoff := initialRetryBackoff
+	var lastErr error
+	for {
+		if !submitted {
+			endpoint.sendClientInfo(ctx, clientInfo, userToken)
+			resp, err := endpoint.client.SubmitRequest(ctx, connect.NewRequest(submit))
+			if connect.CodeOf(err) == connect.CodeUnimplemented {
+				// Older servers only have the blocking RPCs
+				result, err := callBlocking(ctx, endpoint.client, submit)
+				return result, endpoint.url, err
+			}
+			if isAuthError(err) {
+				return nil, endpoint.url, err
+			}
+			if err == nil && !resp.Msg.Success {
+				return nil, endpoint.url, fmt.Errorf("request rejected: %s", resp.Msg.Meta["message"])
+			}
+			if err == nil {
+				submitted = true
+				backoff = initialRetryBackoff
+				progress.start(ctx, endpoint, requestID, userToken)
+				if resp.Msg.Resumed {
+					log.Printf("Re-attached to request %s", requestID)
+				}
+			} else {
+				lastErr = err
+			}
+		}
+
+		if submitted {
+			callCtx, cancel := context.WithTimeout(ctx, awaitWaitSeconds*time.Second+awaitCallSlack)
+			resp, err := endpoint.client.AwaitResult(callCtx, connect.NewRequest(&agentassistproto.AwaitResultRequest{
+				ID:          requestID,
+				UserToken:   userToken,
+				WaitSeconds: awaitWaitSeconds,
+			}))
+			cancel()
+
+			switch {
+			case isAuthError(err):
+				return nil, endpoint.url, err
+			case err != nil:
+				lastErr = err
+			case resp.Msg.Done:
+				return resp.Msg, endpoint.url, nil
+			case resp.Msg.NotFound:
+				// The server lost the request, submit it again with the remaining time
+				log.Printf("Server does not know request %s anymore, submitting it again", requestID)
+				remaining := time.Until(deadline) - requestGracePeriod
+				if remaining <= 0 {
+					return nil, endpoint.url, fmt.Errorf("request timed out after %d seconds", timeout)
+				}
+				setTimeout(int32(math.Ceil(remaining.Seconds())))
+				submitted = false
+				progress.stop()
+				continue
+			default:
+				// Still pending, poll again right away. The server may have given the request
+				// more time, e.g. when a timeout policy escalated it.
+				if serverDeadline := time.UnixMilli(resp.Msg.Deadline).Add(requestGracePeriod); resp.Msg.Deadline > 0 && serverDeadline.After(deadline) {
+					deadline = serverDeadline
+				}
+				if !time.Now().Before(deadline) {
+					return nil, endpoint.url, fmt.Errorf("request timed out after %d seconds", timeout)
+				}
+				backoff = initialRetryBackoff
+				continue
+			}
+		}
+
+		if ctx.Err() != nil {
+			cancelOnServer(ctx, endpoint, requestID, userToken)
+			return nil, endpoint.url, ctx.Err()
+		}
+		if !time.Now().Before(deadline) {
+			return nil, endpoint.url, fmt.Errorf("request timed out after %d seconds, last error: %w", timeout, lastErr)
+		}
+
+		if isConnectionError(lastErr) && len(conn.endpoints) > 1 {
+			endpoint.markUnhealthy()
+			next, healthy := conn.pickEndpoint(ctx, endpoint)
+			if next != endpoint {
+				// The new server does not know the request, it is submitted there from scratch
+				log.Printf("Request %s: server %s failed (%v), failing over to %s", requestID, endpoint.url, lastErr, next.url)
+				// The request may still be pending there, withdraw it so that its web users do
+				// not answer a request nobody waits for. The server is likely unreachable, so
+				// this must not hold up the failover.
+				go cancelOnServer(ctx, endpoint, requestID, userToken)
+				endpoint = next
+				submitted = false
+				if healthy {
+					continue
+				}
+			}
+		}
+
+		log.Printf("Request %s: %v, retrying in %s", requestID, lastErr, backoff)
+		if err := sleepContext(ctx, min(backoff, time.Until(deadline))); err != nil {
+			cancelOnServer(ctx, endpoint, requestID, userToken)
+			return nil, endpoint.url, err
+		}
+		backoff = min(backoff*2, maxRetryBackoff)
+	}
+}
+
+// progressWatch runs watchProgress for the server a request is currently submitted to
+type progressWatch struct {
+	endpoint *serverEndpoint
+	cancel   context.CancelFunc
+}
+
+// start watches the request on endpoint, unless it is already watched there
+func (w *progressWatch) start(ctx context.Context, endpoint *serverEndpoint, requestID, userToken string) {
+	if w.endpoint == endpoint {
+		return
+	}
+	w.stop()
+
+	ctx, w.cancel = context.WithCancel(ctx)
+	w.endpoint = endpoint
+	go watchProgress(ctx, endpoint, requestID, userToken)
+}
+
+func (w *progressWatch) stop() {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	w.endpoint, w.cancel = nil, nil
+}
+
+// watchProgress forwards the lifecycle events of a request, e.g. "viewed by alice", to the MCP
+// client as progress notifications until the request completes or ctx is done. Interim
+// replies of the human are also sent as log messages, to clients that did not ask for
+// progress too.
+func watchProgress(ctx context.Context, endpoint *serverEndpoint, requestID, userToken string) {
+	stream, err := endpoint.client.WatchRequest(ctx, connect.NewRequest(&agentassistproto.WatchRequestRequest{
+		ID:        requestID,
+		UserToken: userToken,
+	}))
+	if err != nil {
+		log.Printf("Failed to watch request %s on %s: %v", requestID, endpoint.url, err)
+		return
+	}
+	defer stream.Close()
+
+	for stream.Receive() {
+		switch event := stream.Msg(); {
+		case event.Final:
+			// The final event is followed by the result itself
+		case event.Type == interimReplyEvent:
+			mcptools.ReportInterimReply(ctx, event.Message)
+		default:
+			mcptools.ReportProgress(ctx, event.Message)
+		}
+	}
+	// Older servers cannot report progress, the request is still answered
+	if err := stream.Err(); err != nil && ctx.Err() == nil && connect.CodeOf(err) != connect.CodeUnimplemented {
+		log.Printf("Watching request %s on %s failed: %v", requestID, endpoint.url, err)
+	}
+}
+
+// cancelOnServer withdraws a request the agent no longer waits for, so that the web users
+// see it cancelled right away instead of at its timeout
+func cancelOnServer(ctx context.Context, endpoint *serverEndpoint, requestID, userToken string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cancelRequestTimeout)
+	defer cancel()
+
+	resp, err := endpoint.client.CancelRequest(ctx, connect.NewRequest(&agentassistproto.CancelRequestRequest{
+		ID:        requestID,
+		UserToken: userToken,
+	}))
+	if err != nil {
+		log.Printf("Failed to cancel request %s on %s: %v", requestID, endpoint.url, err)
+		return
+	}
+	if resp.Msg.Success {
+		log.Printf("Cancelled request %s on %s", requestID, endpoint.url)
+	}
+}
+
+// callBlocking sends the request in submit with the blocking AskQuestion/WorkReport RPCs
+func callBlocking(ctx context.Context, client agentassistproto.SrvAgentAssistClient, submit *agentassistproto.SubmitRequestRequest) (*agentassistproto.AwaitResultResponse, error) {
+	if submit.AskQuestionRequest != nil {
+		resp, err := client.AskQuestion(ctx, connect.NewRequest(submit.AskQuestionRequest))
+		if err != nil {
+			return nil, err
+		}
+		return &agentassistproto.AwaitResultResponse{ID: resp.Msg.ID, Done: true, AskQuestionResponse: resp.Msg}, nil
+	}
+
+	resp, err := client.WorkReport(ctx, connect.NewRequest(submit.WorkReportRequest))
+	if err != nil {
+		return nil, err
+	}
+	return &agentassistproto.AwaitResultResponse{ID: resp.Msg.ID, Done: true, WorkReportResponse: resp.Msg}, nil
+}
+
+// submittedRequest returns the ID and user token of the request in submit, and a function
+// that updates its timeout
+func submittedRequest(submit *agentassistproto.SubmitRequestRequest) (string, string, func(int32)) {
+	if r := submit.AskQuestionRequest; r != nil {
+		return r.ID, r.UserToken, func(timeout int32) { r.Request.Timeout = timeout }
+	}
+	r := submit.WorkReportRequest
+	return r.ID, r.UserToken, func(timeout int32) { r.Request.Timeout = timeout }
+}
+
+// isAuthError reports whether err is a rejected token, which retrying cannot fix
+func isAuthError(err error) bool {
+	code := connect.CodeOf(err)
+	return code == connect.CodeUnauthenticated || code == connect.CodePermissionDenied
+}
+
+// newBearerTokenInterceptor returns a Connect interceptor that sends token in the Authorization header
+func newBearerTokenInterceptor(token string) connect.Interceptor {
+	return &bearerTokenInterceptor{token: token}
+}
+
+type bearerTokenInterceptor struct {
+	token string
+}
+
+func (i *bearerTokenInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		req.Header().Set("Authorization", "Bearer "+i.token)
+		return next(ctx, req)
+	}
+}
+
+func (i *bearerTokenInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		conn := next(ctx, spec)
+		conn.RequestHeader().Set("Authorization", "Bearer "+i.token)
+		return conn
+	}
+}
+
+func (i *bearerTokenInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+// sleepContext sleeps for d or until ctx is cancelled
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// newHTTPClient creates the HTTP client used to call a server of a profile, configured
+// for TLS if the server scheme is https
+func newHTTPClient(profile *ServerProfile, scheme string) (*http.Client, error) {
+	switch scheme {
+	case "http":
+		return &http.Client{}, nil
+	case "https":
+	default:
+		return nil, fmt.Errorf("unsupported server scheme %q, must be http or https", scheme)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: profile.AgentAssistantServerInsecureSkipVerify,
+	}
+
+	if profile.AgentAssistantServerCAFile != "" {
+		pem, err := os.ReadFile(profile.AgentAssistantServerCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", profile.AgentAssistantServerCAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	// Client certificate for servers that require mutual TLS
+	if profile.AgentAssistantServerClientCertFile != "" || profile.AgentAssistantServerClientKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(profile.AgentAssistantServerClientCertFile, profile.AgentAssistantServerClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	transport.ForceAttemptHTTP2 = true
+	return &http.Client{Transport: transport}, nil
+}
@@ -2,9 +2,12 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"github.com/BurntSushi/toml"
 	"github.com/yangjuncode/agentassistant/www"
+	"io"
 	"io/fs"
 	"log"
 	"net/http"
@@ -15,6 +18,7 @@ import (
 	"syscall"
 	"time"
 
+	"connectrpc.com/connect"
 	"github.com/yangjuncode/agentassistant/agentassistproto"
 	"github.com/yangjuncode/agentassistant/internal/service"
 	"golang.org/x/net/http2"
@@ -24,6 +28,48 @@ import (
 // Config represents the TOML configuration structure
 type Config struct {
 	AgentAssistantServerPort int `toml:"agentassistant_server_port"`
+	// Pending requests are persisted to this file so they survive a restart.
+	// Empty keeps them in memory only.
+	AgentAssistantServerStoreFile string `toml:"agentassistant_server_store_file"`
+	// Tokens accepted by the server. Without tokens any token is accepted and
+	// requests are routed by comparing tokens.
+	AgentAssistantServerTokens []service.TokenConfig `toml:"agentassistant_server_tokens"`
+	// Certificate and key of the HTTPS (and wss://) listener
+	AgentAssistantServerTLSCertFile string `toml:"agentassistant_server_tls_cert_file"`
+	AgentAssistantServerTLSKeyFile  string `toml:"agentassistant_server_tls_key_file"`
+	// CA bundle that client certificates must be signed by. Empty disables mutual TLS.
+	AgentAssistantServerTLSClientCAFile string `toml:"agentassistant_server_tls_client_ca_file"`
+	// Port of the HTTPS listener. If set, plain HTTP keeps being served on
+	// agentassistant_server_port; if zero, HTTPS replaces it.
+	AgentAssistantServerTLSPort int `toml:"agentassistant_server_tls_port"`
+	// Browser origins besides the server itself that may use the WebSocket and
+	// RPC endpoints, e.g. "https://chat.example.com"
+	AgentAssistantServerAllowedOrigins []string `toml:"agentassistant_server_allowed_origins"`
+	// Allow any origin. Only for development, any web page can then answer requests.
+	AgentAssistantServerAllowAllOrigins bool `toml:"agentassistant_server_allow_all_origins"`
+	// Serve the ask_question and work_report MCP tools over streamable HTTP at /mcp
+	AgentAssistantServerMcpEnabled bool `toml:"agentassistant_server_mcp_enabled"`
+	// What happens to requests while no web client is online: "queue" (default) holds
+	// them until a client logs in or they time out, "fail" answers them with no_clients
+	AgentAssistantServerNoClientsPolicy string `toml:"agentassistant_server_no_clients_policy"`
+	// Rules that deliver matching requests only to some web users, the first matching
+	// rule wins. Requests no rule matches go to all clients of their token.
+	AgentAssistantServerRoutes []service.RouteConfig `toml:"agentassistant_server_routes"`
+	// Client groups the routing rules refer to as "@name", mapped to the nicknames of their members
+	AgentAssistantServerClientGroups map[string][]string `toml:"agentassistant_server_client_groups"`
+	// Policies that answer or escalate the requests nobody answered in time instead of
+	// timing them out with an error, the first matching policy applies
+	AgentAssistantServerTimeoutPolicies []service.TimeoutPolicyConfig `toml:"agentassistant_server_timeout_policies"`
+	// How long after its creation web users may extend the deadline of a request to,
+	// e.g. "8h". Empty defaults to 4 hours.
+	AgentAssistantServerMaxRequestLifetime string `toml:"agentassistant_server_max_request_lifetime"`
+	// Reminders of the requests nobody answered for a while, which can escalate them to
+	// more web users and run an external notifier
+	AgentAssistantServerReminders []service.ReminderConfig `toml:"agentassistant_server_reminders"`
+	// Webhooks notified of new requests and how they ended, e.g. chat or paging tools
+	AgentAssistantServerWebhooks []service.WebhookConfig `toml:"agentassistant_server_webhooks"`
+	// File every webhook delivery attempt is appended to as a line of JSON, empty for none
+	AgentAssistantServerWebhookDeliveryLog string `toml:"agentassistant_server_webhook_delivery_log"`
 }
 
 // loadConfig loads configuration from the TOML file
@@ -56,26 +102,155 @@ func loadConfig() (*Config, error) {
 }
 
 func main() {
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run runs the server until it is interrupted. It returns errors instead of exiting so that
+// deferred cleanup, such as closing the request store, always runs.
+func run() error {
+	hashToken := flag.String("hash-token", "", "Print the secret_sha256 value of a token secret and exit")
+	flag.Parse()
+
+	if *hashToken != "" {
+		fmt.Println(service.HashToken(*hashToken))
+		return nil
+	}
+
 	// Load configuration
 	config, err := loadConfig()
 	if err != nil {
-		log.Fatalf("Failed to load configuration: %v", err)
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Build the token registry if tokens are configured
+	var tokens *service.TokenRegistry
+	if len(config.AgentAssistantServerTokens) > 0 {
+		tokens, err = service.NewTokenRegistry(config.AgentAssistantServerTokens)
+		if err != nil {
+			return fmt.Errorf("invalid token configuration: %w", err)
+		}
+		log.Printf("Token authentication enabled with %d tokens", len(config.AgentAssistantServerTokens))
+	} else {
+		log.Printf("Warning: no tokens configured, any token is accepted")
+	}
+
+	// Build the origin allow-list shared by the WebSocket and CORS checks
+	origins, err := service.NewOriginPolicy(config.AgentAssistantServerAllowedOrigins, config.AgentAssistantServerAllowAllOrigins)
+	if err != nil {
+		return fmt.Errorf("invalid allowed origins: %w", err)
+	}
+	if config.AgentAssistantServerAllowAllOrigins {
+		log.Printf("Warning: all origins are allowed, any web page can connect to the server")
+	}
+
+	if err := service.ValidateNoClientsPolicy(config.AgentAssistantServerNoClientsPolicy); err != nil {
+		return fmt.Errorf("invalid agentassistant_server_no_clients_policy: %w", err)
+	}
+
+	// Build the request router if routing rules are configured
+	var router *service.Router
+	if len(config.AgentAssistantServerRoutes) > 0 {
+		router, err = service.NewRouter(config.AgentAssistantServerRoutes, config.AgentAssistantServerClientGroups)
+		if err != nil {
+			return fmt.Errorf("invalid routing configuration: %w", err)
+		}
+		log.Printf("Request routing enabled with %d rules", len(config.AgentAssistantServerRoutes))
+	}
+
+	// Build the timeout policies if configured
+	var timeoutPolicies *service.TimeoutPolicies
+	if len(config.AgentAssistantServerTimeoutPolicies) > 0 {
+		timeoutPolicies, err = service.NewTimeoutPolicies(config.AgentAssistantServerTimeoutPolicies, config.AgentAssistantServerClientGroups)
+		if err != nil {
+			return fmt.Errorf("invalid timeout policy configuration: %w", err)
+		}
+		log.Printf("Timeout policies enabled with %d policies", len(config.AgentAssistantServerTimeoutPolicies))
+	}
+
+	var reminders *service.Reminders
+	if len(config.AgentAssistantServerReminders) > 0 {
+		reminders, err = service.NewReminders(config.AgentAssistantServerReminders, config.AgentAssistantServerClientGroups)
+		if err != nil {
+			return fmt.Errorf("invalid reminder configuration: %w", err)
+		}
+		log.Printf("Reminders enabled with %d reminders", len(config.AgentAssistantServerReminders))
+	}
+
+	var webhooks *service.Webhooks
+	if len(config.AgentAssistantServerWebhooks) > 0 {
+		var deliveryLog io.Writer
+		if config.AgentAssistantServerWebhookDeliveryLog != "" {
+			logFile, err := os.OpenFile(config.AgentAssistantServerWebhookDeliveryLog, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+			if err != nil {
+				return fmt.Errorf("failed to open webhook delivery log: %w", err)
+			}
+			defer logFile.Close()
+			deliveryLog = logFile
+		}
+		webhooks, err = service.NewWebhooks(config.AgentAssistantServerWebhooks, deliveryLog)
+		if err != nil {
+			return fmt.Errorf("invalid webhook configuration: %w", err)
+		}
+		log.Printf("Webhooks enabled with %d webhooks", len(config.AgentAssistantServerWebhooks))
+	}
+
+	var maxRequestLifetime time.Duration
+	if config.AgentAssistantServerMaxRequestLifetime != "" {
+		maxRequestLifetime, err = time.ParseDuration(config.AgentAssistantServerMaxRequestLifetime)
+		if err != nil || maxRequestLifetime <= 0 {
+			return fmt.Errorf("invalid agentassistant_server_max_request_lifetime: must be a positive duration such as \"8h\"")
+		}
+	}
+
+	// Open the pending request store if configured
+	var store service.RequestStore
+	if config.AgentAssistantServerStoreFile != "" {
+		fileStore, err := service.OpenFileRequestStore(config.AgentAssistantServerStoreFile)
+		if err != nil {
+			return fmt.Errorf("failed to open request store: %w", err)
+		}
+		defer fileStore.Close()
+		store = fileStore
+		log.Printf("Persisting pending requests to %s", config.AgentAssistantServerStoreFile)
 	}
 
 	// Create the service instance
-	svc := service.NewAgentAssistService()
+	svc := service.NewAgentAssistServiceWithOptions(service.BroadcasterOptions{
+		Store:              store,
+		NoClientsPolicy:    config.AgentAssistantServerNoClientsPolicy,
+		Router:             router,
+		TimeoutPolicies:    timeoutPolicies,
+		MaxRequestLifetime: maxRequestLifetime,
+		Reminders:          reminders,
+		Webhooks:           webhooks,
+	})
 
 	// Create HTTP mux
 	mux := http.NewServeMux()
 
 	// Register the Connect-Go handlers
-	path, handler := agentassistproto.NewSrvAgentAssistHandler(svc)
+	var handlerOptions []connect.HandlerOption
+	if tokens != nil {
+		handlerOptions = append(handlerOptions, connect.WithInterceptors(service.NewAuthInterceptor(tokens)))
+	}
+	path, handler := agentassistproto.NewSrvAgentAssistHandler(svc, handlerOptions...)
 	mux.Handle(path, handler)
 
 	// Register WebSocket handler for web interface
-	wsHandler := service.NewWebSocketHandler(svc.GetBroadcaster())
+	wsHandler := service.NewWebSocketHandlerWithOptions(svc.GetBroadcaster(), service.WebSocketHandlerOptions{
+		Tokens:  tokens,
+		Origins: origins,
+	})
 	mux.HandleFunc("/ws", wsHandler.HandleWebSocket)
 
+	// Register the embedded MCP server if enabled
+	if config.AgentAssistantServerMcpEnabled {
+		mux.Handle("/mcp", newMCPHandler(svc, tokens))
+		log.Printf("Serving MCP over streamable HTTP at /mcp")
+	}
+
 	// Add health check endpoint
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -91,60 +266,93 @@ func main() {
 		//fallback to package www.Dist embedded directory
 		wwwfs, err := fs.Sub(www.Dist, "dist")
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		fileServer := http.FileServer(http.FS(wwwfs))
 		mux.Handle("/", fileServer)
 	}
 
 	// Add CORS middleware for web interface
-	corsHandler := addCORS(mux)
+	corsHandler := origins.Handler(mux)
+
+	// Create HTTP servers with HTTP/2 support
+	var servers []*http.Server
+	serverErrors := make(chan error, 2)
+	plainPort := config.AgentAssistantServerPort
+	if config.tlsEnabled() {
+		tlsConfig, err := loadTLSConfig(config)
+		if err != nil {
+			return fmt.Errorf("invalid TLS configuration: %w", err)
+		}
 
-	// Create HTTP server with HTTP/2 support
-	server := &http.Server{
-		Addr:    ":" + strconv.Itoa(config.AgentAssistantServerPort),
-		Handler: h2c.NewHandler(corsHandler, &http2.Server{}),
+		tlsPort := config.AgentAssistantServerTLSPort
+		if tlsPort == 0 {
+			tlsPort = config.AgentAssistantServerPort
+			plainPort = 0
+		}
+
+		server := &http.Server{
+			Addr:      ":" + strconv.Itoa(tlsPort),
+			Handler:   corsHandler,
+			TLSConfig: tlsConfig,
+		}
+		servers = append(servers, server)
+
+		// Start server in a goroutine
+		go func() {
+			log.Printf("Starting Agent Assistant server on :%d (HTTPS)", tlsPort)
+			if err := server.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
+				serverErrors <- fmt.Errorf("server failed to start: %w", err)
+			}
+		}()
 	}
 
-	// Start server in a goroutine
-	go func() {
-		log.Printf("Starting Agent Assistant server on :%d", config.AgentAssistantServerPort)
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Server failed to start: %v", err)
+	if plainPort != 0 {
+		server := &http.Server{
+			Addr:    ":" + strconv.Itoa(plainPort),
+			Handler: h2c.NewHandler(corsHandler, &http2.Server{}),
 		}
-	}()
+		servers = append(servers, server)
+
+		// Start server in a goroutine
+		go func() {
+			log.Printf("Starting Agent Assistant server on :%d", plainPort)
+			if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				serverErrors <- fmt.Errorf("server failed to start: %w", err)
+			}
+		}()
+	}
 
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	var serveErr error
+	select {
+	case <-quit:
+	case serveErr = <-serverErrors:
+	}
 	log.Println("Shutting down server...")
 
 	// Create a deadline to wait for
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	// Attempt graceful shutdown
-	if err := server.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+	// Attempt graceful shutdown of every server before closing the store they write to
+	errs := []error{serveErr}
+	for _, server := range servers {
+		if err := server.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("server on %s forced to shutdown: %w", server.Addr, err))
+		}
 	}
-
-	log.Println("Server exited")
-}
-
-// addCORS adds CORS headers to allow web interface access
-func addCORS(h http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Connect-Protocol-Version, Connect-Timeout-Ms")
-		w.Header().Set("Access-Control-Expose-Headers", "Connect-Protocol-Version")
-
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
+	if store != nil {
+		if err := store.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close request store: %w", err))
 		}
+	}
 
-		h.ServeHTTP(w, r)
-	})
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	log.Println("Server exited")
+	return nil
 }
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"log"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/yangjuncode/agentassistant/agentassistproto"
+	"github.com/yangjuncode/agentassistant/internal/mcptools"
+	"github.com/yangjuncode/agentassistant/internal/service"
+)
+
+//go:embed version.txt
+var version string
+
+type mcpTokenKey struct{}
+
+// newMCPHandler returns the handler of the embedded MCP server. It requires the same agent
+// tokens as the Connect endpoints, sent in the Authorization header.
+func newMCPHandler(svc *service.AgentAssistService, tokens *service.TokenRegistry) http.Handler {
+	s := mcptools.NewServer("Agent-Assistant ", version, &serviceBackend{svc: svc})
+	return mcpAuthHandler(tokens, mcptools.CancellationHandler(mcptools.NewStreamableHTTPServer(s)))
+}
+
+// mcpAuthHandler authenticates the token of MCP requests, which is only accepted in the
+// Authorization header to keep it out of URLs and logs. Without a token registry the token is
+// only used for routing.
+func mcpAuthHandler(tokens *service.TokenRegistry, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := service.BearerToken(r.Header)
+
+		ctx := context.WithValue(r.Context(), mcpTokenKey{}, token)
+		if tokens != nil {
+			identity, err := tokens.Authenticate(token, service.ScopeAgent)
+			if err != nil {
+				log.Printf("Rejected MCP request from %s: %v", r.RemoteAddr, err)
+				status := http.StatusUnauthorized
+				if errors.Is(err, service.ErrScopeDenied) {
+					status = http.StatusForbidden
+				}
+				http.Error(w, err.Error(), status)
+				return
+			}
+			ctx = service.ContextWithIdentity(ctx, identity)
+		}
+
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// serviceBackend handles the requests of the embedded MCP tools in-process
+type serviceBackend struct {
+	svc *service.AgentAssistService
+}
+
+func (b *serviceBackend) Submit(ctx context.Context, submit *agentassistproto.SubmitRequestRequest, timeout int) (*mcptools.Result, error) {
+	token, _ := ctx.Value(mcpTokenKey{}).(string)
+
+	// Storing the client info is cheap in-process, so it is refreshed with every request
+	if info := mcptools.ClientInfoFromContext(ctx); info != nil {
+		if _, err := b.svc.SendMcpClientInfo(ctx, connect.NewRequest(info.Request(token))); err != nil {
+			log.Printf("Failed to store MCP client info: %v", err)
+		}
+	}
+
+	if r := submit.AskQuestionRequest; r != nil {
+		r.UserToken = token
+		resp, err := b.svc.AskQuestion(ctx, connect.NewRequest(r))
+		if err != nil {
+			return nil, err
+		}
+		return &mcptools.Result{
+			Response: &agentassistproto.AwaitResultResponse{ID: r.ID, Done: true, AskQuestionResponse: resp.Msg},
+		}, nil
+	}
+
+	r := submit.WorkReportRequest
+	r.UserToken = token
+	resp, err := b.svc.WorkReport(ctx, connect.NewRequest(r))
+	if err != nil {
+		return nil, err
+	}
+	return &mcptools.Result{
+		Response: &agentassistproto.AwaitResultResponse{ID: r.ID, Done: true, WorkReportResponse: resp.Msg},
+	}, nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// tlsEnabled reports whether a certificate is configured
+func (c *Config) tlsEnabled() bool {
+	return c.AgentAssistantServerTLSCertFile != "" || c.AgentAssistantServerTLSKeyFile != ""
+}
+
+// loadTLSConfig builds the TLS configuration of the HTTPS listener. With a client CA
+// configured, clients must present a certificate signed by it (mutual TLS).
+func loadTLSConfig(config *Config) (*tls.Config, error) {
+	if config.AgentAssistantServerTLSCertFile == "" || config.AgentAssistantServerTLSKeyFile == "" {
+		return nil, fmt.Errorf("both agentassistant_server_tls_cert_file and agentassistant_server_tls_key_file are required")
+	}
+
+	cert, err := tls.LoadX509KeyPair(config.AgentAssistantServerTLSCertFile, config.AgentAssistantServerTLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load certificate: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if config.AgentAssistantServerTLSClientCAFile != "" {
+		pem, err := os.ReadFile(config.AgentAssistantServerTLSClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in client CA file %s", config.AgentAssistantServerTLSClientCAFile)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
@@ -17,6 +17,20 @@ class WebSocketCommands {
   static const String chatMessageNotification = 'ChatMessageNotification';
   static const String userConnectionStatusNotification =
       'UserConnectionStatusNotification';
+  static const String replyRejected = 'ReplyRejected';
+  static const String replyAck = 'ReplyAck';
+  static const String approvalProgress = 'ApprovalProgress';
+  static const String extendDeadline = 'ExtendDeadline';
+  static const String deadlineExtended = 'DeadlineExtended';
+  static const String interimReply = 'InterimReply';
+  static const String reminder = 'Reminder';
+}
+
+/// Decisions on a work report, see WorkReportDecision in agentassist.proto
+class WorkReportDecisions {
+  static const String approved = 'approved';
+  static const String rejected = 'rejected';
+  static const String changesRequested = 'changes_requested';
 }
 
 /// Content type constants for McpResultContent
@@ -498,6 +498,8 @@ class McpAskQuestionRequest extends $pb.GeneratedMessage {
     $core.String? agentName,
     $core.String? reasoningModelName,
     $core.String? mcpClientName,
+    $core.String? mcpSessionID,
+    McpClientInfoData? mcpClientInfo,
   }) {
     final $result = create();
     if (projectDirectory != null) {
@@ -518,6 +520,12 @@ class McpAskQuestionRequest extends $pb.GeneratedMessage {
     if (mcpClientName != null) {
       $result.mcpClientName = mcpClientName;
     }
+    if (mcpSessionID != null) {
+      $result.mcpSessionID = mcpSessionID;
+    }
+    if (mcpClientInfo != null) {
+      $result.mcpClientInfo = mcpClientInfo;
+    }
     return $result;
   }
   McpAskQuestionRequest._() : super();
@@ -531,6 +539,8 @@ class McpAskQuestionRequest extends $pb.GeneratedMessage {
     ..aOS(4, _omitFieldNames ? '' : 'AgentName', protoName: 'AgentName')
     ..aOS(5, _omitFieldNames ? '' : 'ReasoningModelName', protoName: 'ReasoningModelName')
     ..aOS(6, _omitFieldNames ? '' : 'McpClientName', protoName: 'McpClientName')
+    ..aOS(7, _omitFieldNames ? '' : 'McpSessionID', protoName: 'McpSessionID')
+    ..aOM<McpClientInfoData>(8, _omitFieldNames ? '' : 'McpClientInfo', protoName: 'McpClientInfo', subBuilder: McpClientInfoData.create)
     ..hasRequiredFields = false
   ;
 
@@ -614,6 +624,28 @@ class McpAskQuestionRequest extends $pb.GeneratedMessage {
   $core.bool hasMcpClientName() => $_has(5);
   @$pb.TagNumber(6)
   void clearMcpClientName() => clearField(6);
+
+  /// MCP session the request was sent from, see McpClientInfoRequest
+  @$pb.TagNumber(7)
+  $core.String get mcpSessionID => $_getSZ(6);
+  @$pb.TagNumber(7)
+  set mcpSessionID($core.String v) { $_setString(6, v); }
+  @$pb.TagNumber(7)
+  $core.bool hasMcpSessionID() => $_has(6);
+  @$pb.TagNumber(7)
+  void clearMcpSessionID() => clearField(7);
+
+  /// client info of the MCP session, attached by the server
+  @$pb.TagNumber(8)
+  McpClientInfoData get mcpClientInfo => $_getN(7);
+  @$pb.TagNumber(8)
+  set mcpClientInfo(McpClientInfoData v) { setField(8, v); }
+  @$pb.TagNumber(8)
+  $core.bool hasMcpClientInfo() => $_has(7);
+  @$pb.TagNumber(8)
+  void clearMcpClientInfo() => clearField(8);
+  @$pb.TagNumber(8)
+  McpClientInfoData ensureMcpClientInfo() => $_ensure(7);
 }
 
 class AskQuestionRequest extends $pb.GeneratedMessage {
@@ -803,6 +835,10 @@ class McpWorkReportRequest extends $pb.GeneratedMessage {
     $core.String? agentName,
     $core.String? reasoningModelName,
     $core.String? mcpClientName,
+    $core.String? mcpSessionID,
+    McpClientInfoData? mcpClientInfo,
+    $core.int? requiredApprovals,
+    $core.Iterable<$core.String>? approvers,
   }) {
     final $result = create();
     if (projectDirectory != null) {
@@ -823,6 +859,18 @@ class McpWorkReportRequest extends $pb.GeneratedMessage {
     if (mcpClientName != null) {
       $result.mcpClientName = mcpClientName;
     }
+    if (mcpSessionID != null) {
+      $result.mcpSessionID = mcpSessionID;
+    }
+    if (mcpClientInfo != null) {
+      $result.mcpClientInfo = mcpClientInfo;
+    }
+    if (requiredApprovals != null) {
+      $result.requiredApprovals = requiredApprovals;
+    }
+    if (approvers != null) {
+      $result.approvers.addAll(approvers);
+    }
     return $result;
   }
   McpWorkReportRequest._() : super();
@@ -836,6 +884,10 @@ class McpWorkReportRequest extends $pb.GeneratedMessage {
     ..aOS(4, _omitFieldNames ? '' : 'AgentName', protoName: 'AgentName')
     ..aOS(5, _omitFieldNames ? '' : 'ReasoningModelName', protoName: 'ReasoningModelName')
     ..aOS(6, _omitFieldNames ? '' : 'McpClientName', protoName: 'McpClientName')
+    ..aOS(7, _omitFieldNames ? '' : 'McpSessionID', protoName: 'McpSessionID')
+    ..aOM<McpClientInfoData>(8, _omitFieldNames ? '' : 'McpClientInfo', protoName: 'McpClientInfo', subBuilder: McpClientInfoData.create)
+    ..a<$core.int>(9, _omitFieldNames ? '' : 'RequiredApprovals', $pb.PbFieldType.O3, protoName: 'RequiredApprovals')
+    ..pPS(10, _omitFieldNames ? '' : 'Approvers', protoName: 'Approvers')
     ..hasRequiredFields = false
   ;
 
@@ -919,6 +971,43 @@ class McpWorkReportRequest extends $pb.GeneratedMessage {
   $core.bool hasMcpClientName() => $_has(5);
   @$pb.TagNumber(6)
   void clearMcpClientName() => clearField(6);
+
+  /// MCP session the request was sent from, see McpClientInfoRequest
+  @$pb.TagNumber(7)
+  $core.String get mcpSessionID => $_getSZ(6);
+  @$pb.TagNumber(7)
+  set mcpSessionID($core.String v) { $_setString(6, v); }
+  @$pb.TagNumber(7)
+  $core.bool hasMcpSessionID() => $_has(6);
+  @$pb.TagNumber(7)
+  void clearMcpSessionID() => clearField(7);
+
+  /// client info of the MCP session, attached by the server
+  @$pb.TagNumber(8)
+  McpClientInfoData get mcpClientInfo => $_getN(7);
+  @$pb.TagNumber(8)
+  set mcpClientInfo(McpClientInfoData v) { setField(8, v); }
+  @$pb.TagNumber(8)
+  $core.bool hasMcpClientInfo() => $_has(7);
+  @$pb.TagNumber(8)
+  void clearMcpClientInfo() => clearField(8);
+  @$pb.TagNumber(8)
+  McpClientInfoData ensureMcpClientInfo() => $_ensure(7);
+
+  /// number of web users who must approve the work report before the agent gets the answer,
+  /// 0 or 1 lets the first reply decide unless Approvers are named
+  @$pb.TagNumber(9)
+  $core.int get requiredApprovals => $_getIZ(8);
+  @$pb.TagNumber(9)
+  set requiredApprovals($core.int v) { $_setSignedInt32(8, v); }
+  @$pb.TagNumber(9)
+  $core.bool hasRequiredApprovals() => $_has(8);
+  @$pb.TagNumber(9)
+  void clearRequiredApprovals() => clearField(9);
+
+  /// nicknames of the web users who must all approve the work report
+  @$pb.TagNumber(10)
+  $core.List<$core.String> get approvers => $_getList(9);
 }
 
 class WorkReportRequest extends $pb.GeneratedMessage {
@@ -1025,6 +1114,7 @@ class WorkReportResponse extends $pb.GeneratedMessage {
     $core.bool? isError,
     $core.Map<$core.String, $core.String>? meta,
     $core.Iterable<McpResultContent>? contents,
+    WorkReportDecision? decision,
   }) {
     final $result = create();
     if (iD != null) {
@@ -1039,6 +1129,9 @@ class WorkReportResponse extends $pb.GeneratedMessage {
     if (contents != null) {
       $result.contents.addAll(contents);
     }
+    if (decision != null) {
+      $result.decision = decision;
+    }
     return $result;
   }
   WorkReportResponse._() : super();
@@ -1050,6 +1143,7 @@ class WorkReportResponse extends $pb.GeneratedMessage {
     ..aOB(2, _omitFieldNames ? '' : 'IsError', protoName: 'IsError')
     ..m<$core.String, $core.String>(3, _omitFieldNames ? '' : 'Meta', protoName: 'Meta', entryClassName: 'WorkReportResponse.MetaEntry', keyFieldType: $pb.PbFieldType.OS, valueFieldType: $pb.PbFieldType.OS, packageName: const $pb.PackageName('agentassistproto'))
     ..pc<McpResultContent>(4, _omitFieldNames ? '' : 'contents', $pb.PbFieldType.PM, subBuilder: McpResultContent.create)
+    ..aOM<WorkReportDecision>(5, _omitFieldNames ? '' : 'Decision', protoName: 'Decision', subBuilder: WorkReportDecision.create)
     ..hasRequiredFields = false
   ;
 
@@ -1098,6 +1192,146 @@ class WorkReportResponse extends $pb.GeneratedMessage {
 
   @$pb.TagNumber(4)
   $core.List<McpResultContent> get contents => $_getList(3);
+
+  /// decision of the web user, unset for plain confirmations which count as approvals
+  @$pb.TagNumber(5)
+  WorkReportDecision get decision => $_getN(4);
+  @$pb.TagNumber(5)
+  set decision(WorkReportDecision v) { setField(5, v); }
+  @$pb.TagNumber(5)
+  $core.bool hasDecision() => $_has(4);
+  @$pb.TagNumber(5)
+  void clearDecision() => clearField(5);
+  @$pb.TagNumber(5)
+  WorkReportDecision ensureDecision() => $_ensure(4);
+}
+
+/// WorkReportComment is a comment of a web user on one item of a work report
+class WorkReportComment extends $pb.GeneratedMessage {
+  factory WorkReportComment({
+    $core.String? item,
+    $core.String? comment,
+  }) {
+    final $result = create();
+    if (item != null) {
+      $result.item = item;
+    }
+    if (comment != null) {
+      $result.comment = comment;
+    }
+    return $result;
+  }
+  WorkReportComment._() : super();
+  factory WorkReportComment.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory WorkReportComment.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'WorkReportComment', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..aOS(1, _omitFieldNames ? '' : 'Item', protoName: 'Item')
+    ..aOS(2, _omitFieldNames ? '' : 'Comment', protoName: 'Comment')
+    ..hasRequiredFields = false
+  ;
+
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
+  'Will be removed in next major version')
+  WorkReportComment clone() => WorkReportComment()..mergeFromMessage(this);
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
+  'Will be removed in next major version')
+  WorkReportComment copyWith(void Function(WorkReportComment) updates) => super.copyWith((message) => updates(message as WorkReportComment)) as WorkReportComment;
+
+  $pb.BuilderInfo get info_ => _i;
+
+  @$core.pragma('dart2js:noInline')
+  static WorkReportComment create() => WorkReportComment._();
+  WorkReportComment createEmptyInstance() => create();
+  static $pb.PbList<WorkReportComment> createRepeated() => $pb.PbList<WorkReportComment>();
+  @$core.pragma('dart2js:noInline')
+  static WorkReportComment getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<WorkReportComment>(create);
+  static WorkReportComment? _defaultInstance;
+
+  /// what the comment is about, e.g. a file, a step or a line of the summary
+  @$pb.TagNumber(1)
+  $core.String get item => $_getSZ(0);
+  @$pb.TagNumber(1)
+  set item($core.String v) { $_setString(0, v); }
+  @$pb.TagNumber(1)
+  $core.bool hasItem() => $_has(0);
+  @$pb.TagNumber(1)
+  void clearItem() => clearField(1);
+
+  /// the comment
+  @$pb.TagNumber(2)
+  $core.String get comment => $_getSZ(1);
+  @$pb.TagNumber(2)
+  set comment($core.String v) { $_setString(1, v); }
+  @$pb.TagNumber(2)
+  $core.bool hasComment() => $_has(1);
+  @$pb.TagNumber(2)
+  void clearComment() => clearField(2);
+}
+
+/// WorkReportDecision is the typed decision of a web user on a work report
+class WorkReportDecision extends $pb.GeneratedMessage {
+  factory WorkReportDecision({
+    $core.String? decision,
+    $core.Iterable<WorkReportComment>? comments,
+  }) {
+    final $result = create();
+    if (decision != null) {
+      $result.decision = decision;
+    }
+    if (comments != null) {
+      $result.comments.addAll(comments);
+    }
+    return $result;
+  }
+  WorkReportDecision._() : super();
+  factory WorkReportDecision.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory WorkReportDecision.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'WorkReportDecision', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..aOS(1, _omitFieldNames ? '' : 'Decision', protoName: 'Decision')
+    ..pc<WorkReportComment>(2, _omitFieldNames ? '' : 'Comments', $pb.PbFieldType.PM, protoName: 'Comments', subBuilder: WorkReportComment.create)
+    ..hasRequiredFields = false
+  ;
+
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
+  'Will be removed in next major version')
+  WorkReportDecision clone() => WorkReportDecision()..mergeFromMessage(this);
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
+  'Will be removed in next major version')
+  WorkReportDecision copyWith(void Function(WorkReportDecision) updates) => super.copyWith((message) => updates(message as WorkReportDecision)) as WorkReportDecision;
+
+  $pb.BuilderInfo get info_ => _i;
+
+  @$core.pragma('dart2js:noInline')
+  static WorkReportDecision create() => WorkReportDecision._();
+  WorkReportDecision createEmptyInstance() => create();
+  static $pb.PbList<WorkReportDecision> createRepeated() => $pb.PbList<WorkReportDecision>();
+  @$core.pragma('dart2js:noInline')
+  static WorkReportDecision getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<WorkReportDecision>(create);
+  static WorkReportDecision? _defaultInstance;
+
+  /// approved, rejected or changes_requested
+  @$pb.TagNumber(1)
+  $core.String get decision => $_getSZ(0);
+  @$pb.TagNumber(1)
+  set decision($core.String v) { $_setString(0, v); }
+  @$pb.TagNumber(1)
+  $core.bool hasDecision() => $_has(0);
+  @$pb.TagNumber(1)
+  void clearDecision() => clearField(1);
+
+  /// optional comments on items of the work report
+  @$pb.TagNumber(2)
+  $core.List<WorkReportComment> get comments => $_getList(1);
 }
 
 class McpClientInfoData extends $pb.GeneratedMessage {
@@ -1202,6 +1436,7 @@ class McpClientInfoRequest extends $pb.GeneratedMessage {
     $core.String? userToken,
     McpClientInfoData? request,
     $fixnum.Int64? timestamp,
+    $core.String? mcpSessionID,
   }) {
     final $result = create();
     if (iD != null) {
@@ -1216,6 +1451,9 @@ class McpClientInfoRequest extends $pb.GeneratedMessage {
     if (timestamp != null) {
       $result.timestamp = timestamp;
     }
+    if (mcpSessionID != null) {
+      $result.mcpSessionID = mcpSessionID;
+    }
     return $result;
   }
   McpClientInfoRequest._() : super();
@@ -1227,6 +1465,7 @@ class McpClientInfoRequest extends $pb.GeneratedMessage {
     ..aOS(2, _omitFieldNames ? '' : 'UserToken', protoName: 'UserToken')
     ..aOM<McpClientInfoData>(3, _omitFieldNames ? '' : 'Request', protoName: 'Request', subBuilder: McpClientInfoData.create)
     ..aInt64(4, _omitFieldNames ? '' : 'Timestamp', protoName: 'Timestamp')
+    ..aOS(5, _omitFieldNames ? '' : 'McpSessionID', protoName: 'McpSessionID')
     ..hasRequiredFields = false
   ;
 
@@ -1292,6 +1531,16 @@ class McpClientInfoRequest extends $pb.GeneratedMessage {
   $core.bool hasTimestamp() => $_has(3);
   @$pb.TagNumber(4)
   void clearTimestamp() => clearField(4);
+
+  /// MCP session the client info belongs to, requests of the session refer to it with McpSessionID
+  @$pb.TagNumber(5)
+  $core.String get mcpSessionID => $_getSZ(4);
+  @$pb.TagNumber(5)
+  set mcpSessionID($core.String v) { $_setString(4, v); }
+  @$pb.TagNumber(5)
+  $core.bool hasMcpSessionID() => $_has(4);
+  @$pb.TagNumber(5)
+  void clearMcpSessionID() => clearField(5);
 }
 
 class McpClientInfoResponse extends $pb.GeneratedMessage {
@@ -1344,22 +1593,29 @@ class McpClientInfoResponse extends $pb.GeneratedMessage {
   void clearSuccess() => clearField(1);
 }
 
-class CheckMessageValidityRequest extends $pb.GeneratedMessage {
-  factory CheckMessageValidityRequest({
-    $core.Iterable<$core.String>? requestIds,
+/// SubmitRequestRequest submits an ask_question or work_report request without waiting for its result.
+/// Submitting a request ID that the server already knows re-attaches to the existing request.
+class SubmitRequestRequest extends $pb.GeneratedMessage {
+  factory SubmitRequestRequest({
+    AskQuestionRequest? askQuestionRequest,
+    WorkReportRequest? workReportRequest,
   }) {
     final $result = create();
-    if (requestIds != null) {
-      $result.requestIds.addAll(requestIds);
+    if (askQuestionRequest != null) {
+      $result.askQuestionRequest = askQuestionRequest;
+    }
+    if (workReportRequest != null) {
+      $result.workReportRequest = workReportRequest;
     }
     return $result;
   }
-  CheckMessageValidityRequest._() : super();
-  factory CheckMessageValidityRequest.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
-  factory CheckMessageValidityRequest.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+  SubmitRequestRequest._() : super();
+  factory SubmitRequestRequest.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory SubmitRequestRequest.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
 
-  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'CheckMessageValidityRequest', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
-    ..pPS(1, _omitFieldNames ? '' : 'requestIds')
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'SubmitRequestRequest', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..aOM<AskQuestionRequest>(1, _omitFieldNames ? '' : 'AskQuestionRequest', protoName: 'AskQuestionRequest', subBuilder: AskQuestionRequest.create)
+    ..aOM<WorkReportRequest>(2, _omitFieldNames ? '' : 'WorkReportRequest', protoName: 'WorkReportRequest', subBuilder: WorkReportRequest.create)
     ..hasRequiredFields = false
   ;
 
@@ -1367,44 +1623,84 @@ class CheckMessageValidityRequest extends $pb.GeneratedMessage {
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
   'Will be removed in next major version')
-  CheckMessageValidityRequest clone() => CheckMessageValidityRequest()..mergeFromMessage(this);
+  SubmitRequestRequest clone() => SubmitRequestRequest()..mergeFromMessage(this);
   @$core.Deprecated(
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
   'Will be removed in next major version')
-  CheckMessageValidityRequest copyWith(void Function(CheckMessageValidityRequest) updates) => super.copyWith((message) => updates(message as CheckMessageValidityRequest)) as CheckMessageValidityRequest;
+  SubmitRequestRequest copyWith(void Function(SubmitRequestRequest) updates) => super.copyWith((message) => updates(message as SubmitRequestRequest)) as SubmitRequestRequest;
 
   $pb.BuilderInfo get info_ => _i;
 
   @$core.pragma('dart2js:noInline')
-  static CheckMessageValidityRequest create() => CheckMessageValidityRequest._();
-  CheckMessageValidityRequest createEmptyInstance() => create();
-  static $pb.PbList<CheckMessageValidityRequest> createRepeated() => $pb.PbList<CheckMessageValidityRequest>();
+  static SubmitRequestRequest create() => SubmitRequestRequest._();
+  SubmitRequestRequest createEmptyInstance() => create();
+  static $pb.PbList<SubmitRequestRequest> createRepeated() => $pb.PbList<SubmitRequestRequest>();
   @$core.pragma('dart2js:noInline')
-  static CheckMessageValidityRequest getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<CheckMessageValidityRequest>(create);
-  static CheckMessageValidityRequest? _defaultInstance;
+  static SubmitRequestRequest getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<SubmitRequestRequest>(create);
+  static SubmitRequestRequest? _defaultInstance;
 
-  /// list of request IDs to check
+  /// ask question request (exactly one of the requests must be set)
   @$pb.TagNumber(1)
-  $core.List<$core.String> get requestIds => $_getList(0);
+  AskQuestionRequest get askQuestionRequest => $_getN(0);
+  @$pb.TagNumber(1)
+  set askQuestionRequest(AskQuestionRequest v) { setField(1, v); }
+  @$pb.TagNumber(1)
+  $core.bool hasAskQuestionRequest() => $_has(0);
+  @$pb.TagNumber(1)
+  void clearAskQuestionRequest() => clearField(1);
+  @$pb.TagNumber(1)
+  AskQuestionRequest ensureAskQuestionRequest() => $_ensure(0);
+
+  /// work report request
+  @$pb.TagNumber(2)
+  WorkReportRequest get workReportRequest => $_getN(1);
+  @$pb.TagNumber(2)
+  set workReportRequest(WorkReportRequest v) { setField(2, v); }
+  @$pb.TagNumber(2)
+  $core.bool hasWorkReportRequest() => $_has(1);
+  @$pb.TagNumber(2)
+  void clearWorkReportRequest() => clearField(2);
+  @$pb.TagNumber(2)
+  WorkReportRequest ensureWorkReportRequest() => $_ensure(1);
 }
 
-class CheckMessageValidityResponse extends $pb.GeneratedMessage {
-  factory CheckMessageValidityResponse({
-    $core.Map<$core.String, $core.bool>? validity,
+class SubmitRequestResponse extends $pb.GeneratedMessage {
+  factory SubmitRequestResponse({
+    $core.String? iD,
+    $core.bool? success,
+    $core.bool? resumed,
+    $fixnum.Int64? deadline,
+    $core.Map<$core.String, $core.String>? meta,
   }) {
     final $result = create();
-    if (validity != null) {
-      $result.validity.addAll(validity);
+    if (iD != null) {
+      $result.iD = iD;
+    }
+    if (success != null) {
+      $result.success = success;
+    }
+    if (resumed != null) {
+      $result.resumed = resumed;
+    }
+    if (deadline != null) {
+      $result.deadline = deadline;
+    }
+    if (meta != null) {
+      $result.meta.addAll(meta);
     }
     return $result;
   }
-  CheckMessageValidityResponse._() : super();
-  factory CheckMessageValidityResponse.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
-  factory CheckMessageValidityResponse.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+  SubmitRequestResponse._() : super();
+  factory SubmitRequestResponse.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory SubmitRequestResponse.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
 
-  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'CheckMessageValidityResponse', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
-    ..m<$core.String, $core.bool>(1, _omitFieldNames ? '' : 'validity', entryClassName: 'CheckMessageValidityResponse.ValidityEntry', keyFieldType: $pb.PbFieldType.OS, valueFieldType: $pb.PbFieldType.OB, packageName: const $pb.PackageName('agentassistproto'))
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'SubmitRequestResponse', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..aOS(1, _omitFieldNames ? '' : 'ID', protoName: 'ID')
+    ..aOB(2, _omitFieldNames ? '' : 'Success', protoName: 'Success')
+    ..aOB(3, _omitFieldNames ? '' : 'Resumed', protoName: 'Resumed')
+    ..aInt64(4, _omitFieldNames ? '' : 'Deadline', protoName: 'Deadline')
+    ..m<$core.String, $core.String>(5, _omitFieldNames ? '' : 'Meta', protoName: 'Meta', entryClassName: 'SubmitRequestResponse.MetaEntry', keyFieldType: $pb.PbFieldType.OS, valueFieldType: $pb.PbFieldType.OS, packageName: const $pb.PackageName('agentassistproto'))
     ..hasRequiredFields = false
   ;
 
@@ -1412,45 +1708,94 @@ class CheckMessageValidityResponse extends $pb.GeneratedMessage {
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
   'Will be removed in next major version')
-  CheckMessageValidityResponse clone() => CheckMessageValidityResponse()..mergeFromMessage(this);
+  SubmitRequestResponse clone() => SubmitRequestResponse()..mergeFromMessage(this);
   @$core.Deprecated(
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
   'Will be removed in next major version')
-  CheckMessageValidityResponse copyWith(void Function(CheckMessageValidityResponse) updates) => super.copyWith((message) => updates(message as CheckMessageValidityResponse)) as CheckMessageValidityResponse;
+  SubmitRequestResponse copyWith(void Function(SubmitRequestResponse) updates) => super.copyWith((message) => updates(message as SubmitRequestResponse)) as SubmitRequestResponse;
 
   $pb.BuilderInfo get info_ => _i;
 
   @$core.pragma('dart2js:noInline')
-  static CheckMessageValidityResponse create() => CheckMessageValidityResponse._();
-  CheckMessageValidityResponse createEmptyInstance() => create();
-  static $pb.PbList<CheckMessageValidityResponse> createRepeated() => $pb.PbList<CheckMessageValidityResponse>();
+  static SubmitRequestResponse create() => SubmitRequestResponse._();
+  SubmitRequestResponse createEmptyInstance() => create();
+  static $pb.PbList<SubmitRequestResponse> createRepeated() => $pb.PbList<SubmitRequestResponse>();
   @$core.pragma('dart2js:noInline')
-  static CheckMessageValidityResponse getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<CheckMessageValidityResponse>(create);
-  static CheckMessageValidityResponse? _defaultInstance;
+  static SubmitRequestResponse getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<SubmitRequestResponse>(create);
+  static SubmitRequestResponse? _defaultInstance;
 
-  /// map of request ID to validity status
+  /// request id
   @$pb.TagNumber(1)
-  $core.Map<$core.String, $core.bool> get validity => $_getMap(0);
+  $core.String get iD => $_getSZ(0);
+  @$pb.TagNumber(1)
+  set iD($core.String v) { $_setString(0, v); }
+  @$pb.TagNumber(1)
+  $core.bool hasID() => $_has(0);
+  @$pb.TagNumber(1)
+  void clearID() => clearField(1);
+
+  /// false if the request was rejected, see Meta for details
+  @$pb.TagNumber(2)
+  $core.bool get success => $_getBF(1);
+  @$pb.TagNumber(2)
+  set success($core.bool v) { $_setBool(1, v); }
+  @$pb.TagNumber(2)
+  $core.bool hasSuccess() => $_has(1);
+  @$pb.TagNumber(2)
+  void clearSuccess() => clearField(2);
+
+  /// true if the request was already known to the server
+  @$pb.TagNumber(3)
+  $core.bool get resumed => $_getBF(2);
+  @$pb.TagNumber(3)
+  set resumed($core.bool v) { $_setBool(2, v); }
+  @$pb.TagNumber(3)
+  $core.bool hasResumed() => $_has(2);
+  @$pb.TagNumber(3)
+  void clearResumed() => clearField(3);
+
+  /// time after which the request times out (unix milliseconds)
+  @$pb.TagNumber(4)
+  $fixnum.Int64 get deadline => $_getI64(3);
+  @$pb.TagNumber(4)
+  set deadline($fixnum.Int64 v) { $_setInt64(3, v); }
+  @$pb.TagNumber(4)
+  $core.bool hasDeadline() => $_has(3);
+  @$pb.TagNumber(4)
+  void clearDeadline() => clearField(4);
+
+  @$pb.TagNumber(5)
+  $core.Map<$core.String, $core.String> get meta => $_getMap(4);
 }
 
-/// GetPendingMessagesRequest represents a request to get all pending messages for a user
-class GetPendingMessagesRequest extends $pb.GeneratedMessage {
-  factory GetPendingMessagesRequest({
+/// AwaitResultRequest waits for the result of a submitted request
+class AwaitResultRequest extends $pb.GeneratedMessage {
+  factory AwaitResultRequest({
+    $core.String? iD,
     $core.String? userToken,
+    $core.int? waitSeconds,
   }) {
     final $result = create();
+    if (iD != null) {
+      $result.iD = iD;
+    }
     if (userToken != null) {
       $result.userToken = userToken;
     }
+    if (waitSeconds != null) {
+      $result.waitSeconds = waitSeconds;
+    }
     return $result;
   }
-  GetPendingMessagesRequest._() : super();
-  factory GetPendingMessagesRequest.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
-  factory GetPendingMessagesRequest.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+  AwaitResultRequest._() : super();
+  factory AwaitResultRequest.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory AwaitResultRequest.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
 
-  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'GetPendingMessagesRequest', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
-    ..aOS(1, _omitFieldNames ? '' : 'userToken')
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'AwaitResultRequest', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..aOS(1, _omitFieldNames ? '' : 'ID', protoName: 'ID')
+    ..aOS(2, _omitFieldNames ? '' : 'UserToken', protoName: 'UserToken')
+    ..a<$core.int>(3, _omitFieldNames ? '' : 'WaitSeconds', $pb.PbFieldType.O3, protoName: 'WaitSeconds')
     ..hasRequiredFields = false
   ;
 
@@ -1458,71 +1803,95 @@ class GetPendingMessagesRequest extends $pb.GeneratedMessage {
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
   'Will be removed in next major version')
-  GetPendingMessagesRequest clone() => GetPendingMessagesRequest()..mergeFromMessage(this);
+  AwaitResultRequest clone() => AwaitResultRequest()..mergeFromMessage(this);
   @$core.Deprecated(
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
   'Will be removed in next major version')
-  GetPendingMessagesRequest copyWith(void Function(GetPendingMessagesRequest) updates) => super.copyWith((message) => updates(message as GetPendingMessagesRequest)) as GetPendingMessagesRequest;
+  AwaitResultRequest copyWith(void Function(AwaitResultRequest) updates) => super.copyWith((message) => updates(message as AwaitResultRequest)) as AwaitResultRequest;
 
   $pb.BuilderInfo get info_ => _i;
 
   @$core.pragma('dart2js:noInline')
-  static GetPendingMessagesRequest create() => GetPendingMessagesRequest._();
-  GetPendingMessagesRequest createEmptyInstance() => create();
-  static $pb.PbList<GetPendingMessagesRequest> createRepeated() => $pb.PbList<GetPendingMessagesRequest>();
+  static AwaitResultRequest create() => AwaitResultRequest._();
+  AwaitResultRequest createEmptyInstance() => create();
+  static $pb.PbList<AwaitResultRequest> createRepeated() => $pb.PbList<AwaitResultRequest>();
   @$core.pragma('dart2js:noInline')
-  static GetPendingMessagesRequest getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<GetPendingMessagesRequest>(create);
-  static GetPendingMessagesRequest? _defaultInstance;
+  static AwaitResultRequest getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<AwaitResultRequest>(create);
+  static AwaitResultRequest? _defaultInstance;
 
-  /// user token to filter messages
+  /// request id
   @$pb.TagNumber(1)
-  $core.String get userToken => $_getSZ(0);
+  $core.String get iD => $_getSZ(0);
   @$pb.TagNumber(1)
-  set userToken($core.String v) { $_setString(0, v); }
+  set iD($core.String v) { $_setString(0, v); }
   @$pb.TagNumber(1)
-  $core.bool hasUserToken() => $_has(0);
+  $core.bool hasID() => $_has(0);
   @$pb.TagNumber(1)
-  void clearUserToken() => clearField(1);
+  void clearID() => clearField(1);
+
+  /// user token
+  @$pb.TagNumber(2)
+  $core.String get userToken => $_getSZ(1);
+  @$pb.TagNumber(2)
+  set userToken($core.String v) { $_setString(1, v); }
+  @$pb.TagNumber(2)
+  $core.bool hasUserToken() => $_has(1);
+  @$pb.TagNumber(2)
+  void clearUserToken() => clearField(2);
+
+  /// how long to wait for the result in seconds before returning Done=false, default is 30s
+  @$pb.TagNumber(3)
+  $core.int get waitSeconds => $_getIZ(2);
+  @$pb.TagNumber(3)
+  set waitSeconds($core.int v) { $_setSignedInt32(2, v); }
+  @$pb.TagNumber(3)
+  $core.bool hasWaitSeconds() => $_has(2);
+  @$pb.TagNumber(3)
+  void clearWaitSeconds() => clearField(3);
 }
 
-/// PendingMessage represents a single pending message
-class PendingMessage extends $pb.GeneratedMessage {
-  factory PendingMessage({
-    $core.String? messageType,
-    AskQuestionRequest? askQuestionRequest,
-    WorkReportRequest? workReportRequest,
-    $fixnum.Int64? createdAt,
-    $core.int? timeout,
+class AwaitResultResponse extends $pb.GeneratedMessage {
+  factory AwaitResultResponse({
+    $core.String? iD,
+    $core.bool? done,
+    $core.bool? notFound,
+    AskQuestionResponse? askQuestionResponse,
+    WorkReportResponse? workReportResponse,
+    $fixnum.Int64? deadline,
   }) {
     final $result = create();
-    if (messageType != null) {
-      $result.messageType = messageType;
+    if (iD != null) {
+      $result.iD = iD;
     }
-    if (askQuestionRequest != null) {
-      $result.askQuestionRequest = askQuestionRequest;
+    if (done != null) {
+      $result.done = done;
     }
-    if (workReportRequest != null) {
-      $result.workReportRequest = workReportRequest;
+    if (notFound != null) {
+      $result.notFound = notFound;
     }
-    if (createdAt != null) {
-      $result.createdAt = createdAt;
+    if (askQuestionResponse != null) {
+      $result.askQuestionResponse = askQuestionResponse;
     }
-    if (timeout != null) {
-      $result.timeout = timeout;
+    if (workReportResponse != null) {
+      $result.workReportResponse = workReportResponse;
+    }
+    if (deadline != null) {
+      $result.deadline = deadline;
     }
     return $result;
   }
-  PendingMessage._() : super();
-  factory PendingMessage.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
-  factory PendingMessage.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+  AwaitResultResponse._() : super();
+  factory AwaitResultResponse.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory AwaitResultResponse.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
 
-  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'PendingMessage', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
-    ..aOS(1, _omitFieldNames ? '' : 'messageType')
-    ..aOM<AskQuestionRequest>(2, _omitFieldNames ? '' : 'askQuestionRequest', subBuilder: AskQuestionRequest.create)
-    ..aOM<WorkReportRequest>(3, _omitFieldNames ? '' : 'workReportRequest', subBuilder: WorkReportRequest.create)
-    ..aInt64(4, _omitFieldNames ? '' : 'createdAt')
-    ..a<$core.int>(5, _omitFieldNames ? '' : 'timeout', $pb.PbFieldType.O3)
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'AwaitResultResponse', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..aOS(1, _omitFieldNames ? '' : 'ID', protoName: 'ID')
+    ..aOB(2, _omitFieldNames ? '' : 'Done', protoName: 'Done')
+    ..aOB(3, _omitFieldNames ? '' : 'NotFound', protoName: 'NotFound')
+    ..aOM<AskQuestionResponse>(4, _omitFieldNames ? '' : 'AskQuestionResponse', protoName: 'AskQuestionResponse', subBuilder: AskQuestionResponse.create)
+    ..aOM<WorkReportResponse>(5, _omitFieldNames ? '' : 'WorkReportResponse', protoName: 'WorkReportResponse', subBuilder: WorkReportResponse.create)
+    ..aInt64(6, _omitFieldNames ? '' : 'Deadline', protoName: 'Deadline')
     ..hasRequiredFields = false
   ;
 
@@ -1530,100 +1899,117 @@ class PendingMessage extends $pb.GeneratedMessage {
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
   'Will be removed in next major version')
-  PendingMessage clone() => PendingMessage()..mergeFromMessage(this);
+  AwaitResultResponse clone() => AwaitResultResponse()..mergeFromMessage(this);
   @$core.Deprecated(
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
   'Will be removed in next major version')
-  PendingMessage copyWith(void Function(PendingMessage) updates) => super.copyWith((message) => updates(message as PendingMessage)) as PendingMessage;
+  AwaitResultResponse copyWith(void Function(AwaitResultResponse) updates) => super.copyWith((message) => updates(message as AwaitResultResponse)) as AwaitResultResponse;
 
   $pb.BuilderInfo get info_ => _i;
 
   @$core.pragma('dart2js:noInline')
-  static PendingMessage create() => PendingMessage._();
-  PendingMessage createEmptyInstance() => create();
-  static $pb.PbList<PendingMessage> createRepeated() => $pb.PbList<PendingMessage>();
+  static AwaitResultResponse create() => AwaitResultResponse._();
+  AwaitResultResponse createEmptyInstance() => create();
+  static $pb.PbList<AwaitResultResponse> createRepeated() => $pb.PbList<AwaitResultResponse>();
   @$core.pragma('dart2js:noInline')
-  static PendingMessage getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<PendingMessage>(create);
-  static PendingMessage? _defaultInstance;
+  static AwaitResultResponse getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<AwaitResultResponse>(create);
+  static AwaitResultResponse? _defaultInstance;
 
-  /// message type: "AskQuestion" or "WorkReport"
+  /// request id
   @$pb.TagNumber(1)
-  $core.String get messageType => $_getSZ(0);
+  $core.String get iD => $_getSZ(0);
   @$pb.TagNumber(1)
-  set messageType($core.String v) { $_setString(0, v); }
+  set iD($core.String v) { $_setString(0, v); }
   @$pb.TagNumber(1)
-  $core.bool hasMessageType() => $_has(0);
+  $core.bool hasID() => $_has(0);
   @$pb.TagNumber(1)
-  void clearMessageType() => clearField(1);
+  void clearID() => clearField(1);
 
-  /// ask question request (if message_type is "AskQuestion")
-  @$pb.TagNumber(2)
-  AskQuestionRequest get askQuestionRequest => $_getN(1);
+  /// true if the request has completed, the result is in AskQuestionResponse or WorkReportResponse
   @$pb.TagNumber(2)
-  set askQuestionRequest(AskQuestionRequest v) { setField(2, v); }
+  $core.bool get done => $_getBF(1);
   @$pb.TagNumber(2)
-  $core.bool hasAskQuestionRequest() => $_has(1);
+  set done($core.bool v) { $_setBool(1, v); }
   @$pb.TagNumber(2)
-  void clearAskQuestionRequest() => clearField(2);
+  $core.bool hasDone() => $_has(1);
   @$pb.TagNumber(2)
-  AskQuestionRequest ensureAskQuestionRequest() => $_ensure(1);
+  void clearDone() => clearField(2);
 
-  /// work report request (if message_type is "WorkReport")
-  @$pb.TagNumber(3)
-  WorkReportRequest get workReportRequest => $_getN(2);
+  /// true if the server does not know the request (e.g. it restarted without a request store),
+  /// the caller should submit it again
   @$pb.TagNumber(3)
-  set workReportRequest(WorkReportRequest v) { setField(3, v); }
+  $core.bool get notFound => $_getBF(2);
   @$pb.TagNumber(3)
-  $core.bool hasWorkReportRequest() => $_has(2);
+  set notFound($core.bool v) { $_setBool(2, v); }
   @$pb.TagNumber(3)
-  void clearWorkReportRequest() => clearField(3);
+  $core.bool hasNotFound() => $_has(2);
   @$pb.TagNumber(3)
-  WorkReportRequest ensureWorkReportRequest() => $_ensure(2);
+  void clearNotFound() => clearField(3);
 
-  /// timestamp when the message was created
+  /// result of an ask question request
   @$pb.TagNumber(4)
-  $fixnum.Int64 get createdAt => $_getI64(3);
+  AskQuestionResponse get askQuestionResponse => $_getN(3);
   @$pb.TagNumber(4)
-  set createdAt($fixnum.Int64 v) { $_setInt64(3, v); }
+  set askQuestionResponse(AskQuestionResponse v) { setField(4, v); }
   @$pb.TagNumber(4)
-  $core.bool hasCreatedAt() => $_has(3);
+  $core.bool hasAskQuestionResponse() => $_has(3);
   @$pb.TagNumber(4)
-  void clearCreatedAt() => clearField(4);
+  void clearAskQuestionResponse() => clearField(4);
+  @$pb.TagNumber(4)
+  AskQuestionResponse ensureAskQuestionResponse() => $_ensure(3);
 
-  /// timeout in seconds
+  /// result of a work report request
   @$pb.TagNumber(5)
-  $core.int get timeout => $_getIZ(4);
+  WorkReportResponse get workReportResponse => $_getN(4);
   @$pb.TagNumber(5)
-  set timeout($core.int v) { $_setSignedInt32(4, v); }
+  set workReportResponse(WorkReportResponse v) { setField(5, v); }
   @$pb.TagNumber(5)
-  $core.bool hasTimeout() => $_has(4);
+  $core.bool hasWorkReportResponse() => $_has(4);
   @$pb.TagNumber(5)
-  void clearTimeout() => clearField(5);
+  void clearWorkReportResponse() => clearField(5);
+  @$pb.TagNumber(5)
+  WorkReportResponse ensureWorkReportResponse() => $_ensure(4);
+
+  /// time after which the pending request times out (unix milliseconds), it moves when the
+  /// server gives the request more time
+  @$pb.TagNumber(6)
+  $fixnum.Int64 get deadline => $_getI64(5);
+  @$pb.TagNumber(6)
+  set deadline($fixnum.Int64 v) { $_setInt64(5, v); }
+  @$pb.TagNumber(6)
+  $core.bool hasDeadline() => $_has(5);
+  @$pb.TagNumber(6)
+  void clearDeadline() => clearField(6);
 }
 
-/// GetPendingMessagesResponse represents the response containing all pending messages
-class GetPendingMessagesResponse extends $pb.GeneratedMessage {
-  factory GetPendingMessagesResponse({
-    $core.Iterable<PendingMessage>? pendingMessages,
-    $core.int? totalCount,
+/// CancelRequestRequest withdraws a submitted request the agent no longer waits for
+class CancelRequestRequest extends $pb.GeneratedMessage {
+  factory CancelRequestRequest({
+    $core.String? iD,
+    $core.String? userToken,
+    $core.String? reason,
   }) {
     final $result = create();
-    if (pendingMessages != null) {
-      $result.pendingMessages.addAll(pendingMessages);
+    if (iD != null) {
+      $result.iD = iD;
     }
-    if (totalCount != null) {
-      $result.totalCount = totalCount;
+    if (userToken != null) {
+      $result.userToken = userToken;
+    }
+    if (reason != null) {
+      $result.reason = reason;
     }
     return $result;
   }
-  GetPendingMessagesResponse._() : super();
-  factory GetPendingMessagesResponse.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
-  factory GetPendingMessagesResponse.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+  CancelRequestRequest._() : super();
+  factory CancelRequestRequest.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory CancelRequestRequest.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
 
-  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'GetPendingMessagesResponse', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
-    ..pc<PendingMessage>(1, _omitFieldNames ? '' : 'pendingMessages', $pb.PbFieldType.PM, subBuilder: PendingMessage.create)
-    ..a<$core.int>(2, _omitFieldNames ? '' : 'totalCount', $pb.PbFieldType.O3)
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'CancelRequestRequest', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..aOS(1, _omitFieldNames ? '' : 'ID', protoName: 'ID')
+    ..aOS(2, _omitFieldNames ? '' : 'UserToken', protoName: 'UserToken')
+    ..aOS(3, _omitFieldNames ? '' : 'Reason', protoName: 'Reason')
     ..hasRequiredFields = false
   ;
 
@@ -1631,65 +2017,70 @@ class GetPendingMessagesResponse extends $pb.GeneratedMessage {
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
   'Will be removed in next major version')
-  GetPendingMessagesResponse clone() => GetPendingMessagesResponse()..mergeFromMessage(this);
+  CancelRequestRequest clone() => CancelRequestRequest()..mergeFromMessage(this);
   @$core.Deprecated(
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
   'Will be removed in next major version')
-  GetPendingMessagesResponse copyWith(void Function(GetPendingMessagesResponse) updates) => super.copyWith((message) => updates(message as GetPendingMessagesResponse)) as GetPendingMessagesResponse;
+  CancelRequestRequest copyWith(void Function(CancelRequestRequest) updates) => super.copyWith((message) => updates(message as CancelRequestRequest)) as CancelRequestRequest;
 
   $pb.BuilderInfo get info_ => _i;
 
   @$core.pragma('dart2js:noInline')
-  static GetPendingMessagesResponse create() => GetPendingMessagesResponse._();
-  GetPendingMessagesResponse createEmptyInstance() => create();
-  static $pb.PbList<GetPendingMessagesResponse> createRepeated() => $pb.PbList<GetPendingMessagesResponse>();
+  static CancelRequestRequest create() => CancelRequestRequest._();
+  CancelRequestRequest createEmptyInstance() => create();
+  static $pb.PbList<CancelRequestRequest> createRepeated() => $pb.PbList<CancelRequestRequest>();
   @$core.pragma('dart2js:noInline')
-  static GetPendingMessagesResponse getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<GetPendingMessagesResponse>(create);
-  static GetPendingMessagesResponse? _defaultInstance;
+  static CancelRequestRequest getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<CancelRequestRequest>(create);
+  static CancelRequestRequest? _defaultInstance;
 
-  /// list of pending messages
+  /// request id
   @$pb.TagNumber(1)
-  $core.List<PendingMessage> get pendingMessages => $_getList(0);
+  $core.String get iD => $_getSZ(0);
+  @$pb.TagNumber(1)
+  set iD($core.String v) { $_setString(0, v); }
+  @$pb.TagNumber(1)
+  $core.bool hasID() => $_has(0);
+  @$pb.TagNumber(1)
+  void clearID() => clearField(1);
 
-  /// total count of pending messages
+  /// user token
   @$pb.TagNumber(2)
-  $core.int get totalCount => $_getIZ(1);
+  $core.String get userToken => $_getSZ(1);
   @$pb.TagNumber(2)
-  set totalCount($core.int v) { $_setSignedInt32(1, v); }
+  set userToken($core.String v) { $_setString(1, v); }
   @$pb.TagNumber(2)
-  $core.bool hasTotalCount() => $_has(1);
+  $core.bool hasUserToken() => $_has(1);
   @$pb.TagNumber(2)
-  void clearTotalCount() => clearField(2);
+  void clearUserToken() => clearField(2);
+
+  /// reason shown to the web users, default is "agent cancelled"
+  @$pb.TagNumber(3)
+  $core.String get reason => $_getSZ(2);
+  @$pb.TagNumber(3)
+  set reason($core.String v) { $_setString(2, v); }
+  @$pb.TagNumber(3)
+  $core.bool hasReason() => $_has(2);
+  @$pb.TagNumber(3)
+  void clearReason() => clearField(3);
 }
 
-/// RequestCancelledNotification represents a notification that a request has been cancelled
-class RequestCancelledNotification extends $pb.GeneratedMessage {
-  factory RequestCancelledNotification({
-    $core.String? requestId,
-    $core.String? reason,
-    $core.String? messageType,
+class CancelRequestResponse extends $pb.GeneratedMessage {
+  factory CancelRequestResponse({
+    $core.bool? success,
   }) {
     final $result = create();
-    if (requestId != null) {
-      $result.requestId = requestId;
-    }
-    if (reason != null) {
-      $result.reason = reason;
-    }
-    if (messageType != null) {
-      $result.messageType = messageType;
+    if (success != null) {
+      $result.success = success;
     }
     return $result;
   }
-  RequestCancelledNotification._() : super();
-  factory RequestCancelledNotification.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
-  factory RequestCancelledNotification.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+  CancelRequestResponse._() : super();
+  factory CancelRequestResponse.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory CancelRequestResponse.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
 
-  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'RequestCancelledNotification', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
-    ..aOS(1, _omitFieldNames ? '' : 'requestId')
-    ..aOS(2, _omitFieldNames ? '' : 'reason')
-    ..aOS(3, _omitFieldNames ? '' : 'messageType')
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'CancelRequestResponse', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..aOB(1, _omitFieldNames ? '' : 'Success', protoName: 'Success')
     ..hasRequiredFields = false
   ;
 
@@ -1697,81 +2088,56 @@ class RequestCancelledNotification extends $pb.GeneratedMessage {
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
   'Will be removed in next major version')
-  RequestCancelledNotification clone() => RequestCancelledNotification()..mergeFromMessage(this);
+  CancelRequestResponse clone() => CancelRequestResponse()..mergeFromMessage(this);
   @$core.Deprecated(
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
   'Will be removed in next major version')
-  RequestCancelledNotification copyWith(void Function(RequestCancelledNotification) updates) => super.copyWith((message) => updates(message as RequestCancelledNotification)) as RequestCancelledNotification;
+  CancelRequestResponse copyWith(void Function(CancelRequestResponse) updates) => super.copyWith((message) => updates(message as CancelRequestResponse)) as CancelRequestResponse;
 
   $pb.BuilderInfo get info_ => _i;
 
   @$core.pragma('dart2js:noInline')
-  static RequestCancelledNotification create() => RequestCancelledNotification._();
-  RequestCancelledNotification createEmptyInstance() => create();
-  static $pb.PbList<RequestCancelledNotification> createRepeated() => $pb.PbList<RequestCancelledNotification>();
+  static CancelRequestResponse create() => CancelRequestResponse._();
+  CancelRequestResponse createEmptyInstance() => create();
+  static $pb.PbList<CancelRequestResponse> createRepeated() => $pb.PbList<CancelRequestResponse>();
   @$core.pragma('dart2js:noInline')
-  static RequestCancelledNotification getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<RequestCancelledNotification>(create);
-  static RequestCancelledNotification? _defaultInstance;
+  static CancelRequestResponse getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<CancelRequestResponse>(create);
+  static CancelRequestResponse? _defaultInstance;
 
-  /// request id that was cancelled
+  /// false if the server does not know the request or it has already completed
   @$pb.TagNumber(1)
-  $core.String get requestId => $_getSZ(0);
+  $core.bool get success => $_getBF(0);
   @$pb.TagNumber(1)
-  set requestId($core.String v) { $_setString(0, v); }
+  set success($core.bool v) { $_setBool(0, v); }
   @$pb.TagNumber(1)
-  $core.bool hasRequestId() => $_has(0);
+  $core.bool hasSuccess() => $_has(0);
   @$pb.TagNumber(1)
-  void clearRequestId() => clearField(1);
-
-  /// reason for cancellation
-  @$pb.TagNumber(2)
-  $core.String get reason => $_getSZ(1);
-  @$pb.TagNumber(2)
-  set reason($core.String v) { $_setString(1, v); }
-  @$pb.TagNumber(2)
-  $core.bool hasReason() => $_has(1);
-  @$pb.TagNumber(2)
-  void clearReason() => clearField(2);
-
-  /// message type: "AskQuestion" or "WorkReport"
-  @$pb.TagNumber(3)
-  $core.String get messageType => $_getSZ(2);
-  @$pb.TagNumber(3)
-  set messageType($core.String v) { $_setString(2, v); }
-  @$pb.TagNumber(3)
-  $core.bool hasMessageType() => $_has(2);
-  @$pb.TagNumber(3)
-  void clearMessageType() => clearField(3);
+  void clearSuccess() => clearField(1);
 }
 
-/// OnlineUser represents an online user with the same token
-class OnlineUser extends $pb.GeneratedMessage {
-  factory OnlineUser({
-    $core.String? clientId,
-    $core.String? nickname,
-    $fixnum.Int64? connectedAt,
+/// WatchRequestRequest subscribes to the lifecycle events of a submitted request
+class WatchRequestRequest extends $pb.GeneratedMessage {
+  factory WatchRequestRequest({
+    $core.String? iD,
+    $core.String? userToken,
   }) {
     final $result = create();
-    if (clientId != null) {
-      $result.clientId = clientId;
-    }
-    if (nickname != null) {
-      $result.nickname = nickname;
+    if (iD != null) {
+      $result.iD = iD;
     }
-    if (connectedAt != null) {
-      $result.connectedAt = connectedAt;
+    if (userToken != null) {
+      $result.userToken = userToken;
     }
     return $result;
   }
-  OnlineUser._() : super();
-  factory OnlineUser.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
-  factory OnlineUser.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+  WatchRequestRequest._() : super();
+  factory WatchRequestRequest.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory WatchRequestRequest.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
 
-  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'OnlineUser', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
-    ..aOS(1, _omitFieldNames ? '' : 'clientId')
-    ..aOS(2, _omitFieldNames ? '' : 'nickname')
-    ..aInt64(3, _omitFieldNames ? '' : 'connectedAt')
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'WatchRequestRequest', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..aOS(1, _omitFieldNames ? '' : 'ID', protoName: 'ID')
+    ..aOS(2, _omitFieldNames ? '' : 'UserToken', protoName: 'UserToken')
     ..hasRequiredFields = false
   ;
 
@@ -1779,57 +2145,297 @@ class OnlineUser extends $pb.GeneratedMessage {
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
   'Will be removed in next major version')
-  OnlineUser clone() => OnlineUser()..mergeFromMessage(this);
+  WatchRequestRequest clone() => WatchRequestRequest()..mergeFromMessage(this);
   @$core.Deprecated(
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
   'Will be removed in next major version')
-  OnlineUser copyWith(void Function(OnlineUser) updates) => super.copyWith((message) => updates(message as OnlineUser)) as OnlineUser;
+  WatchRequestRequest copyWith(void Function(WatchRequestRequest) updates) => super.copyWith((message) => updates(message as WatchRequestRequest)) as WatchRequestRequest;
 
   $pb.BuilderInfo get info_ => _i;
 
   @$core.pragma('dart2js:noInline')
-  static OnlineUser create() => OnlineUser._();
-  OnlineUser createEmptyInstance() => create();
-  static $pb.PbList<OnlineUser> createRepeated() => $pb.PbList<OnlineUser>();
+  static WatchRequestRequest create() => WatchRequestRequest._();
+  WatchRequestRequest createEmptyInstance() => create();
+  static $pb.PbList<WatchRequestRequest> createRepeated() => $pb.PbList<WatchRequestRequest>();
   @$core.pragma('dart2js:noInline')
-  static OnlineUser getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<OnlineUser>(create);
-  static OnlineUser? _defaultInstance;
+  static WatchRequestRequest getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<WatchRequestRequest>(create);
+  static WatchRequestRequest? _defaultInstance;
 
-  /// client id
+  /// request id
   @$pb.TagNumber(1)
-  $core.String get clientId => $_getSZ(0);
+  $core.String get iD => $_getSZ(0);
   @$pb.TagNumber(1)
-  set clientId($core.String v) { $_setString(0, v); }
+  set iD($core.String v) { $_setString(0, v); }
   @$pb.TagNumber(1)
-  $core.bool hasClientId() => $_has(0);
+  $core.bool hasID() => $_has(0);
   @$pb.TagNumber(1)
-  void clearClientId() => clearField(1);
+  void clearID() => clearField(1);
 
-  /// user nickname
+  /// user token
   @$pb.TagNumber(2)
-  $core.String get nickname => $_getSZ(1);
+  $core.String get userToken => $_getSZ(1);
   @$pb.TagNumber(2)
-  set nickname($core.String v) { $_setString(1, v); }
+  set userToken($core.String v) { $_setString(1, v); }
   @$pb.TagNumber(2)
-  $core.bool hasNickname() => $_has(1);
+  $core.bool hasUserToken() => $_has(1);
   @$pb.TagNumber(2)
-  void clearNickname() => clearField(2);
+  void clearUserToken() => clearField(2);
+}
 
-  /// connection timestamp
+/// RequestEvent reports a step in the lifecycle of a request
+class RequestEvent extends $pb.GeneratedMessage {
+  factory RequestEvent({
+    $core.String? iD,
+    $core.String? type,
+    $core.int? clientCount,
+    $core.String? nickname,
+    $core.String? message,
+    $fixnum.Int64? timestamp,
+    $core.bool? final,
+  }) {
+    final $result = create();
+    if (iD != null) {
+      $result.iD = iD;
+    }
+    if (type != null) {
+      $result.type = type;
+    }
+    if (clientCount != null) {
+      $result.clientCount = clientCount;
+    }
+    if (nickname != null) {
+      $result.nickname = nickname;
+    }
+    if (message != null) {
+      $result.message = message;
+    }
+    if (timestamp != null) {
+      $result.timestamp = timestamp;
+    }
+    if (final != null) {
+      $result.final = final;
+    }
+    return $result;
+  }
+  RequestEvent._() : super();
+  factory RequestEvent.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory RequestEvent.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'RequestEvent', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..aOS(1, _omitFieldNames ? '' : 'ID', protoName: 'ID')
+    ..aOS(2, _omitFieldNames ? '' : 'Type', protoName: 'Type')
+    ..a<$core.int>(3, _omitFieldNames ? '' : 'ClientCount', $pb.PbFieldType.O3, protoName: 'ClientCount')
+    ..aOS(4, _omitFieldNames ? '' : 'Nickname', protoName: 'Nickname')
+    ..aOS(5, _omitFieldNames ? '' : 'Message', protoName: 'Message')
+    ..aInt64(6, _omitFieldNames ? '' : 'Timestamp', protoName: 'Timestamp')
+    ..aOB(7, _omitFieldNames ? '' : 'Final', protoName: 'Final')
+    ..hasRequiredFields = false
+  ;
+
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
+  'Will be removed in next major version')
+  RequestEvent clone() => RequestEvent()..mergeFromMessage(this);
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
+  'Will be removed in next major version')
+  RequestEvent copyWith(void Function(RequestEvent) updates) => super.copyWith((message) => updates(message as RequestEvent)) as RequestEvent;
+
+  $pb.BuilderInfo get info_ => _i;
+
+  @$core.pragma('dart2js:noInline')
+  static RequestEvent create() => RequestEvent._();
+  RequestEvent createEmptyInstance() => create();
+  static $pb.PbList<RequestEvent> createRepeated() => $pb.PbList<RequestEvent>();
+  @$core.pragma('dart2js:noInline')
+  static RequestEvent getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<RequestEvent>(create);
+  static RequestEvent? _defaultInstance;
+
+  /// request id
+  @$pb.TagNumber(1)
+  $core.String get iD => $_getSZ(0);
+  @$pb.TagNumber(1)
+  set iD($core.String v) { $_setString(0, v); }
+  @$pb.TagNumber(1)
+  $core.bool hasID() => $_has(0);
+  @$pb.TagNumber(1)
+  void clearID() => clearField(1);
+
+  /// event type:
+  /// queued: the server accepted the request, it stays queued while no web client is online
+  /// broadcast: the request was delivered to ClientCount web clients
+  /// viewed: the web user Nickname viewed the request
+  /// reply_draft: the web user Nickname is typing a reply
+  /// claimed: the web user Nickname claimed the request to answer it
+  /// approved: the web user Nickname approved a work report that needs more approvals
+  /// escalated: nobody replied in time, a timeout policy of the server gave the request more
+  /// time and delivered it to more web users
+  /// extended: the web user Nickname gave the request more time, Message tells the new deadline
+  /// reminded: nobody answered for a while, a reminder was sent to the web users and the
+  /// external notifier of the reminder, if any
+  /// interim_reply: the web user Nickname sent an interim reply, e.g. "looking into it", the
+  /// request stays pending; Message is "<Nickname>: <text>"
+  /// no_clients: no web client was online and the server fails such requests (final)
+  /// answered: the web user Nickname replied (final)
+  /// cancelled: the request was cancelled, Message is the reason (final)
+  /// timed_out: nobody replied before the timeout of the request, Message tells whether a
+  /// timeout policy of the server answered it (final)
+  @$pb.TagNumber(2)
+  $core.String get type => $_getSZ(1);
+  @$pb.TagNumber(2)
+  set type($core.String v) { $_setString(1, v); }
+  @$pb.TagNumber(2)
+  $core.bool hasType() => $_has(1);
+  @$pb.TagNumber(2)
+  void clearType() => clearField(2);
+
+  /// number of web clients the request was delivered to
   @$pb.TagNumber(3)
-  $fixnum.Int64 get connectedAt => $_getI64(2);
+  $core.int get clientCount => $_getIZ(2);
   @$pb.TagNumber(3)
-  set connectedAt($fixnum.Int64 v) { $_setInt64(2, v); }
+  set clientCount($core.int v) { $_setSignedInt32(2, v); }
   @$pb.TagNumber(3)
-  $core.bool hasConnectedAt() => $_has(2);
+  $core.bool hasClientCount() => $_has(2);
   @$pb.TagNumber(3)
-  void clearConnectedAt() => clearField(3);
+  void clearClientCount() => clearField(3);
+
+  /// nickname of the web user the event is about
+  @$pb.TagNumber(4)
+  $core.String get nickname => $_getSZ(3);
+  @$pb.TagNumber(4)
+  set nickname($core.String v) { $_setString(3, v); }
+  @$pb.TagNumber(4)
+  $core.bool hasNickname() => $_has(3);
+  @$pb.TagNumber(4)
+  void clearNickname() => clearField(4);
+
+  /// human readable description of the event, e.g. "viewed by alice"
+  @$pb.TagNumber(5)
+  $core.String get message => $_getSZ(4);
+  @$pb.TagNumber(5)
+  set message($core.String v) { $_setString(4, v); }
+  @$pb.TagNumber(5)
+  $core.bool hasMessage() => $_has(4);
+  @$pb.TagNumber(5)
+  void clearMessage() => clearField(5);
+
+  /// timestamp of the event (UTC)
+  @$pb.TagNumber(6)
+  $fixnum.Int64 get timestamp => $_getI64(5);
+  @$pb.TagNumber(6)
+  set timestamp($fixnum.Int64 v) { $_setInt64(5, v); }
+  @$pb.TagNumber(6)
+  $core.bool hasTimestamp() => $_has(5);
+  @$pb.TagNumber(6)
+  void clearTimestamp() => clearField(6);
+
+  /// true for the event that completed the request, the last one of the stream
+  @$pb.TagNumber(7)
+  $core.bool get final => $_getBF(6);
+  @$pb.TagNumber(7)
+  set final($core.bool v) { $_setBool(6, v); }
+  @$pb.TagNumber(7)
+  $core.bool hasFinal() => $_has(6);
+  @$pb.TagNumber(7)
+  void clearFinal() => clearField(7);
 }
 
-/// GetOnlineUsersRequest represents a request to get online users with the same token
-class GetOnlineUsersRequest extends $pb.GeneratedMessage {
-  factory GetOnlineUsersRequest({
+class CheckMessageValidityRequest extends $pb.GeneratedMessage {
+  factory CheckMessageValidityRequest({
+    $core.Iterable<$core.String>? requestIds,
+  }) {
+    final $result = create();
+    if (requestIds != null) {
+      $result.requestIds.addAll(requestIds);
+    }
+    return $result;
+  }
+  CheckMessageValidityRequest._() : super();
+  factory CheckMessageValidityRequest.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory CheckMessageValidityRequest.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'CheckMessageValidityRequest', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..pPS(1, _omitFieldNames ? '' : 'requestIds')
+    ..hasRequiredFields = false
+  ;
+
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
+  'Will be removed in next major version')
+  CheckMessageValidityRequest clone() => CheckMessageValidityRequest()..mergeFromMessage(this);
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
+  'Will be removed in next major version')
+  CheckMessageValidityRequest copyWith(void Function(CheckMessageValidityRequest) updates) => super.copyWith((message) => updates(message as CheckMessageValidityRequest)) as CheckMessageValidityRequest;
+
+  $pb.BuilderInfo get info_ => _i;
+
+  @$core.pragma('dart2js:noInline')
+  static CheckMessageValidityRequest create() => CheckMessageValidityRequest._();
+  CheckMessageValidityRequest createEmptyInstance() => create();
+  static $pb.PbList<CheckMessageValidityRequest> createRepeated() => $pb.PbList<CheckMessageValidityRequest>();
+  @$core.pragma('dart2js:noInline')
+  static CheckMessageValidityRequest getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<CheckMessageValidityRequest>(create);
+  static CheckMessageValidityRequest? _defaultInstance;
+
+  /// list of request IDs to check
+  @$pb.TagNumber(1)
+  $core.List<$core.String> get requestIds => $_getList(0);
+}
+
+class CheckMessageValidityResponse extends $pb.GeneratedMessage {
+  factory CheckMessageValidityResponse({
+    $core.Map<$core.String, $core.bool>? validity,
+  }) {
+    final $result = create();
+    if (validity != null) {
+      $result.validity.addAll(validity);
+    }
+    return $result;
+  }
+  CheckMessageValidityResponse._() : super();
+  factory CheckMessageValidityResponse.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory CheckMessageValidityResponse.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'CheckMessageValidityResponse', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..m<$core.String, $core.bool>(1, _omitFieldNames ? '' : 'validity', entryClassName: 'CheckMessageValidityResponse.ValidityEntry', keyFieldType: $pb.PbFieldType.OS, valueFieldType: $pb.PbFieldType.OB, packageName: const $pb.PackageName('agentassistproto'))
+    ..hasRequiredFields = false
+  ;
+
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
+  'Will be removed in next major version')
+  CheckMessageValidityResponse clone() => CheckMessageValidityResponse()..mergeFromMessage(this);
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
+  'Will be removed in next major version')
+  CheckMessageValidityResponse copyWith(void Function(CheckMessageValidityResponse) updates) => super.copyWith((message) => updates(message as CheckMessageValidityResponse)) as CheckMessageValidityResponse;
+
+  $pb.BuilderInfo get info_ => _i;
+
+  @$core.pragma('dart2js:noInline')
+  static CheckMessageValidityResponse create() => CheckMessageValidityResponse._();
+  CheckMessageValidityResponse createEmptyInstance() => create();
+  static $pb.PbList<CheckMessageValidityResponse> createRepeated() => $pb.PbList<CheckMessageValidityResponse>();
+  @$core.pragma('dart2js:noInline')
+  static CheckMessageValidityResponse getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<CheckMessageValidityResponse>(create);
+  static CheckMessageValidityResponse? _defaultInstance;
+
+  /// map of request ID to validity status
+  @$pb.TagNumber(1)
+  $core.Map<$core.String, $core.bool> get validity => $_getMap(0);
+}
+
+/// GetPendingMessagesRequest represents a request to get all pending messages for a user
+class GetPendingMessagesRequest extends $pb.GeneratedMessage {
+  factory GetPendingMessagesRequest({
     $core.String? userToken,
   }) {
     final $result = create();
@@ -1838,12 +2444,935 @@ class GetOnlineUsersRequest extends $pb.GeneratedMessage {
     }
     return $result;
   }
-  GetOnlineUsersRequest._() : super();
-  factory GetOnlineUsersRequest.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
-  factory GetOnlineUsersRequest.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+  GetPendingMessagesRequest._() : super();
+  factory GetPendingMessagesRequest.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory GetPendingMessagesRequest.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'GetPendingMessagesRequest', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..aOS(1, _omitFieldNames ? '' : 'userToken')
+    ..hasRequiredFields = false
+  ;
+
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
+  'Will be removed in next major version')
+  GetPendingMessagesRequest clone() => GetPendingMessagesRequest()..mergeFromMessage(this);
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
+  'Will be removed in next major version')
+  GetPendingMessagesRequest copyWith(void Function(GetPendingMessagesRequest) updates) => super.copyWith((message) => updates(message as GetPendingMessagesRequest)) as GetPendingMessagesRequest;
+
+  $pb.BuilderInfo get info_ => _i;
+
+  @$core.pragma('dart2js:noInline')
+  static GetPendingMessagesRequest create() => GetPendingMessagesRequest._();
+  GetPendingMessagesRequest createEmptyInstance() => create();
+  static $pb.PbList<GetPendingMessagesRequest> createRepeated() => $pb.PbList<GetPendingMessagesRequest>();
+  @$core.pragma('dart2js:noInline')
+  static GetPendingMessagesRequest getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<GetPendingMessagesRequest>(create);
+  static GetPendingMessagesRequest? _defaultInstance;
+
+  /// user token to filter messages
+  @$pb.TagNumber(1)
+  $core.String get userToken => $_getSZ(0);
+  @$pb.TagNumber(1)
+  set userToken($core.String v) { $_setString(0, v); }
+  @$pb.TagNumber(1)
+  $core.bool hasUserToken() => $_has(0);
+  @$pb.TagNumber(1)
+  void clearUserToken() => clearField(1);
+}
+
+/// PendingMessage represents a single pending message
+class PendingMessage extends $pb.GeneratedMessage {
+  factory PendingMessage({
+    $core.String? messageType,
+    AskQuestionRequest? askQuestionRequest,
+    WorkReportRequest? workReportRequest,
+    $fixnum.Int64? createdAt,
+    $core.int? timeout,
+    $fixnum.Int64? deadline,
+    $core.int? deliveryCount,
+    $fixnum.Int64? firstViewedAt,
+    ApprovalProgress? approvalProgress,
+  }) {
+    final $result = create();
+    if (messageType != null) {
+      $result.messageType = messageType;
+    }
+    if (askQuestionRequest != null) {
+      $result.askQuestionRequest = askQuestionRequest;
+    }
+    if (workReportRequest != null) {
+      $result.workReportRequest = workReportRequest;
+    }
+    if (createdAt != null) {
+      $result.createdAt = createdAt;
+    }
+    if (timeout != null) {
+      $result.timeout = timeout;
+    }
+    if (deadline != null) {
+      $result.deadline = deadline;
+    }
+    if (deliveryCount != null) {
+      $result.deliveryCount = deliveryCount;
+    }
+    if (firstViewedAt != null) {
+      $result.firstViewedAt = firstViewedAt;
+    }
+    if (approvalProgress != null) {
+      $result.approvalProgress = approvalProgress;
+    }
+    return $result;
+  }
+  PendingMessage._() : super();
+  factory PendingMessage.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory PendingMessage.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'PendingMessage', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..aOS(1, _omitFieldNames ? '' : 'messageType')
+    ..aOM<AskQuestionRequest>(2, _omitFieldNames ? '' : 'askQuestionRequest', subBuilder: AskQuestionRequest.create)
+    ..aOM<WorkReportRequest>(3, _omitFieldNames ? '' : 'workReportRequest', subBuilder: WorkReportRequest.create)
+    ..aInt64(4, _omitFieldNames ? '' : 'createdAt')
+    ..a<$core.int>(5, _omitFieldNames ? '' : 'timeout', $pb.PbFieldType.O3)
+    ..aInt64(6, _omitFieldNames ? '' : 'deadline')
+    ..a<$core.int>(7, _omitFieldNames ? '' : 'deliveryCount', $pb.PbFieldType.O3)
+    ..aInt64(8, _omitFieldNames ? '' : 'firstViewedAt')
+    ..aOM<ApprovalProgress>(9, _omitFieldNames ? '' : 'approvalProgress', subBuilder: ApprovalProgress.create)
+    ..hasRequiredFields = false
+  ;
+
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
+  'Will be removed in next major version')
+  PendingMessage clone() => PendingMessage()..mergeFromMessage(this);
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
+  'Will be removed in next major version')
+  PendingMessage copyWith(void Function(PendingMessage) updates) => super.copyWith((message) => updates(message as PendingMessage)) as PendingMessage;
+
+  $pb.BuilderInfo get info_ => _i;
+
+  @$core.pragma('dart2js:noInline')
+  static PendingMessage create() => PendingMessage._();
+  PendingMessage createEmptyInstance() => create();
+  static $pb.PbList<PendingMessage> createRepeated() => $pb.PbList<PendingMessage>();
+  @$core.pragma('dart2js:noInline')
+  static PendingMessage getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<PendingMessage>(create);
+  static PendingMessage? _defaultInstance;
+
+  /// message type: "AskQuestion" or "WorkReport"
+  @$pb.TagNumber(1)
+  $core.String get messageType => $_getSZ(0);
+  @$pb.TagNumber(1)
+  set messageType($core.String v) { $_setString(0, v); }
+  @$pb.TagNumber(1)
+  $core.bool hasMessageType() => $_has(0);
+  @$pb.TagNumber(1)
+  void clearMessageType() => clearField(1);
+
+  /// ask question request (if message_type is "AskQuestion")
+  @$pb.TagNumber(2)
+  AskQuestionRequest get askQuestionRequest => $_getN(1);
+  @$pb.TagNumber(2)
+  set askQuestionRequest(AskQuestionRequest v) { setField(2, v); }
+  @$pb.TagNumber(2)
+  $core.bool hasAskQuestionRequest() => $_has(1);
+  @$pb.TagNumber(2)
+  void clearAskQuestionRequest() => clearField(2);
+  @$pb.TagNumber(2)
+  AskQuestionRequest ensureAskQuestionRequest() => $_ensure(1);
+
+  /// work report request (if message_type is "WorkReport")
+  @$pb.TagNumber(3)
+  WorkReportRequest get workReportRequest => $_getN(2);
+  @$pb.TagNumber(3)
+  set workReportRequest(WorkReportRequest v) { setField(3, v); }
+  @$pb.TagNumber(3)
+  $core.bool hasWorkReportRequest() => $_has(2);
+  @$pb.TagNumber(3)
+  void clearWorkReportRequest() => clearField(3);
+  @$pb.TagNumber(3)
+  WorkReportRequest ensureWorkReportRequest() => $_ensure(2);
+
+  /// time the server accepted the request, unix milliseconds
+  @$pb.TagNumber(4)
+  $fixnum.Int64 get createdAt => $_getI64(3);
+  @$pb.TagNumber(4)
+  set createdAt($fixnum.Int64 v) { $_setInt64(3, v); }
+  @$pb.TagNumber(4)
+  $core.bool hasCreatedAt() => $_has(3);
+  @$pb.TagNumber(4)
+  void clearCreatedAt() => clearField(4);
+
+  /// timeout in seconds
+  @$pb.TagNumber(5)
+  $core.int get timeout => $_getIZ(4);
+  @$pb.TagNumber(5)
+  set timeout($core.int v) { $_setSignedInt32(4, v); }
+  @$pb.TagNumber(5)
+  $core.bool hasTimeout() => $_has(4);
+  @$pb.TagNumber(5)
+  void clearTimeout() => clearField(5);
+
+  /// time the request times out, unix milliseconds
+  @$pb.TagNumber(6)
+  $fixnum.Int64 get deadline => $_getI64(5);
+  @$pb.TagNumber(6)
+  set deadline($fixnum.Int64 v) { $_setInt64(5, v); }
+  @$pb.TagNumber(6)
+  $core.bool hasDeadline() => $_has(5);
+  @$pb.TagNumber(6)
+  void clearDeadline() => clearField(6);
+
+  /// number of web clients the request was delivered to
+  @$pb.TagNumber(7)
+  $core.int get deliveryCount => $_getIZ(6);
+  @$pb.TagNumber(7)
+  set deliveryCount($core.int v) { $_setSignedInt32(6, v); }
+  @$pb.TagNumber(7)
+  $core.bool hasDeliveryCount() => $_has(6);
+  @$pb.TagNumber(7)
+  void clearDeliveryCount() => clearField(7);
+
+  /// time a web user first viewed the request, unix milliseconds, 0 if nobody has
+  @$pb.TagNumber(8)
+  $fixnum.Int64 get firstViewedAt => $_getI64(7);
+  @$pb.TagNumber(8)
+  set firstViewedAt($fixnum.Int64 v) { $_setInt64(7, v); }
+  @$pb.TagNumber(8)
+  $core.bool hasFirstViewedAt() => $_has(7);
+  @$pb.TagNumber(8)
+  void clearFirstViewedAt() => clearField(8);
+
+  /// approvals so far of a work report that needs several of them
+  @$pb.TagNumber(9)
+  ApprovalProgress get approvalProgress => $_getN(8);
+  @$pb.TagNumber(9)
+  set approvalProgress(ApprovalProgress v) { setField(9, v); }
+  @$pb.TagNumber(9)
+  $core.bool hasApprovalProgress() => $_has(8);
+  @$pb.TagNumber(9)
+  void clearApprovalProgress() => clearField(9);
+  @$pb.TagNumber(9)
+  ApprovalProgress ensureApprovalProgress() => $_ensure(8);
+}
+
+/// GetPendingMessagesResponse represents the response containing all pending messages
+class GetPendingMessagesResponse extends $pb.GeneratedMessage {
+  factory GetPendingMessagesResponse({
+    $core.Iterable<PendingMessage>? pendingMessages,
+    $core.int? totalCount,
+  }) {
+    final $result = create();
+    if (pendingMessages != null) {
+      $result.pendingMessages.addAll(pendingMessages);
+    }
+    if (totalCount != null) {
+      $result.totalCount = totalCount;
+    }
+    return $result;
+  }
+  GetPendingMessagesResponse._() : super();
+  factory GetPendingMessagesResponse.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory GetPendingMessagesResponse.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'GetPendingMessagesResponse', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..pc<PendingMessage>(1, _omitFieldNames ? '' : 'pendingMessages', $pb.PbFieldType.PM, subBuilder: PendingMessage.create)
+    ..a<$core.int>(2, _omitFieldNames ? '' : 'totalCount', $pb.PbFieldType.O3)
+    ..hasRequiredFields = false
+  ;
+
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
+  'Will be removed in next major version')
+  GetPendingMessagesResponse clone() => GetPendingMessagesResponse()..mergeFromMessage(this);
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
+  'Will be removed in next major version')
+  GetPendingMessagesResponse copyWith(void Function(GetPendingMessagesResponse) updates) => super.copyWith((message) => updates(message as GetPendingMessagesResponse)) as GetPendingMessagesResponse;
+
+  $pb.BuilderInfo get info_ => _i;
+
+  @$core.pragma('dart2js:noInline')
+  static GetPendingMessagesResponse create() => GetPendingMessagesResponse._();
+  GetPendingMessagesResponse createEmptyInstance() => create();
+  static $pb.PbList<GetPendingMessagesResponse> createRepeated() => $pb.PbList<GetPendingMessagesResponse>();
+  @$core.pragma('dart2js:noInline')
+  static GetPendingMessagesResponse getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<GetPendingMessagesResponse>(create);
+  static GetPendingMessagesResponse? _defaultInstance;
+
+  /// list of pending messages
+  @$pb.TagNumber(1)
+  $core.List<PendingMessage> get pendingMessages => $_getList(0);
+
+  /// total count of pending messages
+  @$pb.TagNumber(2)
+  $core.int get totalCount => $_getIZ(1);
+  @$pb.TagNumber(2)
+  set totalCount($core.int v) { $_setSignedInt32(1, v); }
+  @$pb.TagNumber(2)
+  $core.bool hasTotalCount() => $_has(1);
+  @$pb.TagNumber(2)
+  void clearTotalCount() => clearField(2);
+}
+
+/// RequestCancelledNotification represents a notification that a request has been cancelled
+class RequestCancelledNotification extends $pb.GeneratedMessage {
+  factory RequestCancelledNotification({
+    $core.String? requestId,
+    $core.String? reason,
+    $core.String? messageType,
+  }) {
+    final $result = create();
+    if (requestId != null) {
+      $result.requestId = requestId;
+    }
+    if (reason != null) {
+      $result.reason = reason;
+    }
+    if (messageType != null) {
+      $result.messageType = messageType;
+    }
+    return $result;
+  }
+  RequestCancelledNotification._() : super();
+  factory RequestCancelledNotification.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory RequestCancelledNotification.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'RequestCancelledNotification', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..aOS(1, _omitFieldNames ? '' : 'requestId')
+    ..aOS(2, _omitFieldNames ? '' : 'reason')
+    ..aOS(3, _omitFieldNames ? '' : 'messageType')
+    ..hasRequiredFields = false
+  ;
+
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
+  'Will be removed in next major version')
+  RequestCancelledNotification clone() => RequestCancelledNotification()..mergeFromMessage(this);
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
+  'Will be removed in next major version')
+  RequestCancelledNotification copyWith(void Function(RequestCancelledNotification) updates) => super.copyWith((message) => updates(message as RequestCancelledNotification)) as RequestCancelledNotification;
+
+  $pb.BuilderInfo get info_ => _i;
+
+  @$core.pragma('dart2js:noInline')
+  static RequestCancelledNotification create() => RequestCancelledNotification._();
+  RequestCancelledNotification createEmptyInstance() => create();
+  static $pb.PbList<RequestCancelledNotification> createRepeated() => $pb.PbList<RequestCancelledNotification>();
+  @$core.pragma('dart2js:noInline')
+  static RequestCancelledNotification getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<RequestCancelledNotification>(create);
+  static RequestCancelledNotification? _defaultInstance;
+
+  /// request id that was cancelled
+  @$pb.TagNumber(1)
+  $core.String get requestId => $_getSZ(0);
+  @$pb.TagNumber(1)
+  set requestId($core.String v) { $_setString(0, v); }
+  @$pb.TagNumber(1)
+  $core.bool hasRequestId() => $_has(0);
+  @$pb.TagNumber(1)
+  void clearRequestId() => clearField(1);
+
+  /// reason for cancellation
+  @$pb.TagNumber(2)
+  $core.String get reason => $_getSZ(1);
+  @$pb.TagNumber(2)
+  set reason($core.String v) { $_setString(1, v); }
+  @$pb.TagNumber(2)
+  $core.bool hasReason() => $_has(1);
+  @$pb.TagNumber(2)
+  void clearReason() => clearField(2);
+
+  /// message type: "AskQuestion" or "WorkReport"
+  @$pb.TagNumber(3)
+  $core.String get messageType => $_getSZ(2);
+  @$pb.TagNumber(3)
+  set messageType($core.String v) { $_setString(2, v); }
+  @$pb.TagNumber(3)
+  $core.bool hasMessageType() => $_has(2);
+  @$pb.TagNumber(3)
+  void clearMessageType() => clearField(3);
+}
+
+/// OnlineUser represents an online user with the same token
+class OnlineUser extends $pb.GeneratedMessage {
+  factory OnlineUser({
+    $core.String? clientId,
+    $core.String? nickname,
+    $fixnum.Int64? connectedAt,
+  }) {
+    final $result = create();
+    if (clientId != null) {
+      $result.clientId = clientId;
+    }
+    if (nickname != null) {
+      $result.nickname = nickname;
+    }
+    if (connectedAt != null) {
+      $result.connectedAt = connectedAt;
+    }
+    return $result;
+  }
+  OnlineUser._() : super();
+  factory OnlineUser.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory OnlineUser.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'OnlineUser', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..aOS(1, _omitFieldNames ? '' : 'clientId')
+    ..aOS(2, _omitFieldNames ? '' : 'nickname')
+    ..aInt64(3, _omitFieldNames ? '' : 'connectedAt')
+    ..hasRequiredFields = false
+  ;
+
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
+  'Will be removed in next major version')
+  OnlineUser clone() => OnlineUser()..mergeFromMessage(this);
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
+  'Will be removed in next major version')
+  OnlineUser copyWith(void Function(OnlineUser) updates) => super.copyWith((message) => updates(message as OnlineUser)) as OnlineUser;
+
+  $pb.BuilderInfo get info_ => _i;
+
+  @$core.pragma('dart2js:noInline')
+  static OnlineUser create() => OnlineUser._();
+  OnlineUser createEmptyInstance() => create();
+  static $pb.PbList<OnlineUser> createRepeated() => $pb.PbList<OnlineUser>();
+  @$core.pragma('dart2js:noInline')
+  static OnlineUser getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<OnlineUser>(create);
+  static OnlineUser? _defaultInstance;
+
+  /// client id
+  @$pb.TagNumber(1)
+  $core.String get clientId => $_getSZ(0);
+  @$pb.TagNumber(1)
+  set clientId($core.String v) { $_setString(0, v); }
+  @$pb.TagNumber(1)
+  $core.bool hasClientId() => $_has(0);
+  @$pb.TagNumber(1)
+  void clearClientId() => clearField(1);
+
+  /// user nickname
+  @$pb.TagNumber(2)
+  $core.String get nickname => $_getSZ(1);
+  @$pb.TagNumber(2)
+  set nickname($core.String v) { $_setString(1, v); }
+  @$pb.TagNumber(2)
+  $core.bool hasNickname() => $_has(1);
+  @$pb.TagNumber(2)
+  void clearNickname() => clearField(2);
+
+  /// connection timestamp
+  @$pb.TagNumber(3)
+  $fixnum.Int64 get connectedAt => $_getI64(2);
+  @$pb.TagNumber(3)
+  set connectedAt($fixnum.Int64 v) { $_setInt64(2, v); }
+  @$pb.TagNumber(3)
+  $core.bool hasConnectedAt() => $_has(2);
+  @$pb.TagNumber(3)
+  void clearConnectedAt() => clearField(3);
+}
+
+/// GetOnlineUsersRequest represents a request to get online users with the same token
+class GetOnlineUsersRequest extends $pb.GeneratedMessage {
+  factory GetOnlineUsersRequest({
+    $core.String? userToken,
+  }) {
+    final $result = create();
+    if (userToken != null) {
+      $result.userToken = userToken;
+    }
+    return $result;
+  }
+  GetOnlineUsersRequest._() : super();
+  factory GetOnlineUsersRequest.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory GetOnlineUsersRequest.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'GetOnlineUsersRequest', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..aOS(1, _omitFieldNames ? '' : 'userToken')
+    ..hasRequiredFields = false
+  ;
+
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
+  'Will be removed in next major version')
+  GetOnlineUsersRequest clone() => GetOnlineUsersRequest()..mergeFromMessage(this);
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
+  'Will be removed in next major version')
+  GetOnlineUsersRequest copyWith(void Function(GetOnlineUsersRequest) updates) => super.copyWith((message) => updates(message as GetOnlineUsersRequest)) as GetOnlineUsersRequest;
+
+  $pb.BuilderInfo get info_ => _i;
+
+  @$core.pragma('dart2js:noInline')
+  static GetOnlineUsersRequest create() => GetOnlineUsersRequest._();
+  GetOnlineUsersRequest createEmptyInstance() => create();
+  static $pb.PbList<GetOnlineUsersRequest> createRepeated() => $pb.PbList<GetOnlineUsersRequest>();
+  @$core.pragma('dart2js:noInline')
+  static GetOnlineUsersRequest getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<GetOnlineUsersRequest>(create);
+  static GetOnlineUsersRequest? _defaultInstance;
+
+  /// user token to filter users
+  @$pb.TagNumber(1)
+  $core.String get userToken => $_getSZ(0);
+  @$pb.TagNumber(1)
+  set userToken($core.String v) { $_setString(0, v); }
+  @$pb.TagNumber(1)
+  $core.bool hasUserToken() => $_has(0);
+  @$pb.TagNumber(1)
+  void clearUserToken() => clearField(1);
+}
+
+/// GetOnlineUsersResponse represents the response containing online users
+class GetOnlineUsersResponse extends $pb.GeneratedMessage {
+  factory GetOnlineUsersResponse({
+    $core.Iterable<OnlineUser>? onlineUsers,
+    $core.int? totalCount,
+  }) {
+    final $result = create();
+    if (onlineUsers != null) {
+      $result.onlineUsers.addAll(onlineUsers);
+    }
+    if (totalCount != null) {
+      $result.totalCount = totalCount;
+    }
+    return $result;
+  }
+  GetOnlineUsersResponse._() : super();
+  factory GetOnlineUsersResponse.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory GetOnlineUsersResponse.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'GetOnlineUsersResponse', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..pc<OnlineUser>(1, _omitFieldNames ? '' : 'onlineUsers', $pb.PbFieldType.PM, subBuilder: OnlineUser.create)
+    ..a<$core.int>(2, _omitFieldNames ? '' : 'totalCount', $pb.PbFieldType.O3)
+    ..hasRequiredFields = false
+  ;
+
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
+  'Will be removed in next major version')
+  GetOnlineUsersResponse clone() => GetOnlineUsersResponse()..mergeFromMessage(this);
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
+  'Will be removed in next major version')
+  GetOnlineUsersResponse copyWith(void Function(GetOnlineUsersResponse) updates) => super.copyWith((message) => updates(message as GetOnlineUsersResponse)) as GetOnlineUsersResponse;
+
+  $pb.BuilderInfo get info_ => _i;
+
+  @$core.pragma('dart2js:noInline')
+  static GetOnlineUsersResponse create() => GetOnlineUsersResponse._();
+  GetOnlineUsersResponse createEmptyInstance() => create();
+  static $pb.PbList<GetOnlineUsersResponse> createRepeated() => $pb.PbList<GetOnlineUsersResponse>();
+  @$core.pragma('dart2js:noInline')
+  static GetOnlineUsersResponse getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<GetOnlineUsersResponse>(create);
+  static GetOnlineUsersResponse? _defaultInstance;
+
+  /// list of online users
+  @$pb.TagNumber(1)
+  $core.List<OnlineUser> get onlineUsers => $_getList(0);
+
+  /// total count of online users
+  @$pb.TagNumber(2)
+  $core.int get totalCount => $_getIZ(1);
+  @$pb.TagNumber(2)
+  set totalCount($core.int v) { $_setSignedInt32(1, v); }
+  @$pb.TagNumber(2)
+  $core.bool hasTotalCount() => $_has(1);
+  @$pb.TagNumber(2)
+  void clearTotalCount() => clearField(2);
+}
+
+/// ChatMessage represents a chat message between users
+class ChatMessage extends $pb.GeneratedMessage {
+  factory ChatMessage({
+    $core.String? messageId,
+    $core.String? senderClientId,
+    $core.String? senderNickname,
+    $core.String? receiverClientId,
+    $core.String? receiverNickname,
+    $core.String? content,
+    $fixnum.Int64? sentAt,
+  }) {
+    final $result = create();
+    if (messageId != null) {
+      $result.messageId = messageId;
+    }
+    if (senderClientId != null) {
+      $result.senderClientId = senderClientId;
+    }
+    if (senderNickname != null) {
+      $result.senderNickname = senderNickname;
+    }
+    if (receiverClientId != null) {
+      $result.receiverClientId = receiverClientId;
+    }
+    if (receiverNickname != null) {
+      $result.receiverNickname = receiverNickname;
+    }
+    if (content != null) {
+      $result.content = content;
+    }
+    if (sentAt != null) {
+      $result.sentAt = sentAt;
+    }
+    return $result;
+  }
+  ChatMessage._() : super();
+  factory ChatMessage.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory ChatMessage.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'ChatMessage', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..aOS(1, _omitFieldNames ? '' : 'messageId')
+    ..aOS(2, _omitFieldNames ? '' : 'senderClientId')
+    ..aOS(3, _omitFieldNames ? '' : 'senderNickname')
+    ..aOS(4, _omitFieldNames ? '' : 'receiverClientId')
+    ..aOS(5, _omitFieldNames ? '' : 'receiverNickname')
+    ..aOS(6, _omitFieldNames ? '' : 'content')
+    ..aInt64(7, _omitFieldNames ? '' : 'sentAt')
+    ..hasRequiredFields = false
+  ;
+
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
+  'Will be removed in next major version')
+  ChatMessage clone() => ChatMessage()..mergeFromMessage(this);
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
+  'Will be removed in next major version')
+  ChatMessage copyWith(void Function(ChatMessage) updates) => super.copyWith((message) => updates(message as ChatMessage)) as ChatMessage;
+
+  $pb.BuilderInfo get info_ => _i;
+
+  @$core.pragma('dart2js:noInline')
+  static ChatMessage create() => ChatMessage._();
+  ChatMessage createEmptyInstance() => create();
+  static $pb.PbList<ChatMessage> createRepeated() => $pb.PbList<ChatMessage>();
+  @$core.pragma('dart2js:noInline')
+  static ChatMessage getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<ChatMessage>(create);
+  static ChatMessage? _defaultInstance;
+
+  /// message id
+  @$pb.TagNumber(1)
+  $core.String get messageId => $_getSZ(0);
+  @$pb.TagNumber(1)
+  set messageId($core.String v) { $_setString(0, v); }
+  @$pb.TagNumber(1)
+  $core.bool hasMessageId() => $_has(0);
+  @$pb.TagNumber(1)
+  void clearMessageId() => clearField(1);
+
+  /// sender client id
+  @$pb.TagNumber(2)
+  $core.String get senderClientId => $_getSZ(1);
+  @$pb.TagNumber(2)
+  set senderClientId($core.String v) { $_setString(1, v); }
+  @$pb.TagNumber(2)
+  $core.bool hasSenderClientId() => $_has(1);
+  @$pb.TagNumber(2)
+  void clearSenderClientId() => clearField(2);
+
+  /// sender nickname
+  @$pb.TagNumber(3)
+  $core.String get senderNickname => $_getSZ(2);
+  @$pb.TagNumber(3)
+  set senderNickname($core.String v) { $_setString(2, v); }
+  @$pb.TagNumber(3)
+  $core.bool hasSenderNickname() => $_has(2);
+  @$pb.TagNumber(3)
+  void clearSenderNickname() => clearField(3);
+
+  /// receiver client id
+  @$pb.TagNumber(4)
+  $core.String get receiverClientId => $_getSZ(3);
+  @$pb.TagNumber(4)
+  set receiverClientId($core.String v) { $_setString(3, v); }
+  @$pb.TagNumber(4)
+  $core.bool hasReceiverClientId() => $_has(3);
+  @$pb.TagNumber(4)
+  void clearReceiverClientId() => clearField(4);
+
+  /// receiver nickname
+  @$pb.TagNumber(5)
+  $core.String get receiverNickname => $_getSZ(4);
+  @$pb.TagNumber(5)
+  set receiverNickname($core.String v) { $_setString(4, v); }
+  @$pb.TagNumber(5)
+  $core.bool hasReceiverNickname() => $_has(4);
+  @$pb.TagNumber(5)
+  void clearReceiverNickname() => clearField(5);
+
+  /// message content
+  @$pb.TagNumber(6)
+  $core.String get content => $_getSZ(5);
+  @$pb.TagNumber(6)
+  set content($core.String v) { $_setString(5, v); }
+  @$pb.TagNumber(6)
+  $core.bool hasContent() => $_has(5);
+  @$pb.TagNumber(6)
+  void clearContent() => clearField(6);
+
+  /// timestamp when the message was sent
+  @$pb.TagNumber(7)
+  $fixnum.Int64 get sentAt => $_getI64(6);
+  @$pb.TagNumber(7)
+  set sentAt($fixnum.Int64 v) { $_setInt64(6, v); }
+  @$pb.TagNumber(7)
+  $core.bool hasSentAt() => $_has(6);
+  @$pb.TagNumber(7)
+  void clearSentAt() => clearField(7);
+}
+
+/// SendChatMessageRequest represents a request to send a chat message
+class SendChatMessageRequest extends $pb.GeneratedMessage {
+  factory SendChatMessageRequest({
+    $core.String? receiverClientId,
+    $core.String? content,
+  }) {
+    final $result = create();
+    if (receiverClientId != null) {
+      $result.receiverClientId = receiverClientId;
+    }
+    if (content != null) {
+      $result.content = content;
+    }
+    return $result;
+  }
+  SendChatMessageRequest._() : super();
+  factory SendChatMessageRequest.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory SendChatMessageRequest.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'SendChatMessageRequest', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..aOS(1, _omitFieldNames ? '' : 'receiverClientId')
+    ..aOS(2, _omitFieldNames ? '' : 'content')
+    ..hasRequiredFields = false
+  ;
+
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
+  'Will be removed in next major version')
+  SendChatMessageRequest clone() => SendChatMessageRequest()..mergeFromMessage(this);
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
+  'Will be removed in next major version')
+  SendChatMessageRequest copyWith(void Function(SendChatMessageRequest) updates) => super.copyWith((message) => updates(message as SendChatMessageRequest)) as SendChatMessageRequest;
+
+  $pb.BuilderInfo get info_ => _i;
+
+  @$core.pragma('dart2js:noInline')
+  static SendChatMessageRequest create() => SendChatMessageRequest._();
+  SendChatMessageRequest createEmptyInstance() => create();
+  static $pb.PbList<SendChatMessageRequest> createRepeated() => $pb.PbList<SendChatMessageRequest>();
+  @$core.pragma('dart2js:noInline')
+  static SendChatMessageRequest getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<SendChatMessageRequest>(create);
+  static SendChatMessageRequest? _defaultInstance;
+
+  /// receiver client id
+  @$pb.TagNumber(1)
+  $core.String get receiverClientId => $_getSZ(0);
+  @$pb.TagNumber(1)
+  set receiverClientId($core.String v) { $_setString(0, v); }
+  @$pb.TagNumber(1)
+  $core.bool hasReceiverClientId() => $_has(0);
+  @$pb.TagNumber(1)
+  void clearReceiverClientId() => clearField(1);
+
+  /// message content
+  @$pb.TagNumber(2)
+  $core.String get content => $_getSZ(1);
+  @$pb.TagNumber(2)
+  set content($core.String v) { $_setString(1, v); }
+  @$pb.TagNumber(2)
+  $core.bool hasContent() => $_has(1);
+  @$pb.TagNumber(2)
+  void clearContent() => clearField(2);
+}
+
+/// ChatMessageNotification represents a notification of a new chat message
+class ChatMessageNotification extends $pb.GeneratedMessage {
+  factory ChatMessageNotification({
+    ChatMessage? chatMessage,
+  }) {
+    final $result = create();
+    if (chatMessage != null) {
+      $result.chatMessage = chatMessage;
+    }
+    return $result;
+  }
+  ChatMessageNotification._() : super();
+  factory ChatMessageNotification.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory ChatMessageNotification.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'ChatMessageNotification', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..aOM<ChatMessage>(1, _omitFieldNames ? '' : 'chatMessage', subBuilder: ChatMessage.create)
+    ..hasRequiredFields = false
+  ;
+
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
+  'Will be removed in next major version')
+  ChatMessageNotification clone() => ChatMessageNotification()..mergeFromMessage(this);
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
+  'Will be removed in next major version')
+  ChatMessageNotification copyWith(void Function(ChatMessageNotification) updates) => super.copyWith((message) => updates(message as ChatMessageNotification)) as ChatMessageNotification;
+
+  $pb.BuilderInfo get info_ => _i;
+
+  @$core.pragma('dart2js:noInline')
+  static ChatMessageNotification create() => ChatMessageNotification._();
+  ChatMessageNotification createEmptyInstance() => create();
+  static $pb.PbList<ChatMessageNotification> createRepeated() => $pb.PbList<ChatMessageNotification>();
+  @$core.pragma('dart2js:noInline')
+  static ChatMessageNotification getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<ChatMessageNotification>(create);
+  static ChatMessageNotification? _defaultInstance;
+
+  /// the chat message
+  @$pb.TagNumber(1)
+  ChatMessage get chatMessage => $_getN(0);
+  @$pb.TagNumber(1)
+  set chatMessage(ChatMessage v) { setField(1, v); }
+  @$pb.TagNumber(1)
+  $core.bool hasChatMessage() => $_has(0);
+  @$pb.TagNumber(1)
+  void clearChatMessage() => clearField(1);
+  @$pb.TagNumber(1)
+  ChatMessage ensureChatMessage() => $_ensure(0);
+}
+
+/// UserLoginResponse represents the response to a user login
+class UserLoginResponse extends $pb.GeneratedMessage {
+  factory UserLoginResponse({
+    $core.String? clientId,
+    $core.bool? success,
+    $core.String? errorMessage,
+  }) {
+    final $result = create();
+    if (clientId != null) {
+      $result.clientId = clientId;
+    }
+    if (success != null) {
+      $result.success = success;
+    }
+    if (errorMessage != null) {
+      $result.errorMessage = errorMessage;
+    }
+    return $result;
+  }
+  UserLoginResponse._() : super();
+  factory UserLoginResponse.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory UserLoginResponse.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'UserLoginResponse', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..aOS(1, _omitFieldNames ? '' : 'clientId')
+    ..aOB(2, _omitFieldNames ? '' : 'success')
+    ..aOS(3, _omitFieldNames ? '' : 'errorMessage')
+    ..hasRequiredFields = false
+  ;
+
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
+  'Will be removed in next major version')
+  UserLoginResponse clone() => UserLoginResponse()..mergeFromMessage(this);
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
+  'Will be removed in next major version')
+  UserLoginResponse copyWith(void Function(UserLoginResponse) updates) => super.copyWith((message) => updates(message as UserLoginResponse)) as UserLoginResponse;
+
+  $pb.BuilderInfo get info_ => _i;
+
+  @$core.pragma('dart2js:noInline')
+  static UserLoginResponse create() => UserLoginResponse._();
+  UserLoginResponse createEmptyInstance() => create();
+  static $pb.PbList<UserLoginResponse> createRepeated() => $pb.PbList<UserLoginResponse>();
+  @$core.pragma('dart2js:noInline')
+  static UserLoginResponse getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<UserLoginResponse>(create);
+  static UserLoginResponse? _defaultInstance;
+
+  /// client id assigned by server
+  @$pb.TagNumber(1)
+  $core.String get clientId => $_getSZ(0);
+  @$pb.TagNumber(1)
+  set clientId($core.String v) { $_setString(0, v); }
+  @$pb.TagNumber(1)
+  $core.bool hasClientId() => $_has(0);
+  @$pb.TagNumber(1)
+  void clearClientId() => clearField(1);
+
+  /// success status
+  @$pb.TagNumber(2)
+  $core.bool get success => $_getBF(1);
+  @$pb.TagNumber(2)
+  set success($core.bool v) { $_setBool(1, v); }
+  @$pb.TagNumber(2)
+  $core.bool hasSuccess() => $_has(1);
+  @$pb.TagNumber(2)
+  void clearSuccess() => clearField(2);
+
+  /// error message if login failed
+  @$pb.TagNumber(3)
+  $core.String get errorMessage => $_getSZ(2);
+  @$pb.TagNumber(3)
+  set errorMessage($core.String v) { $_setString(2, v); }
+  @$pb.TagNumber(3)
+  $core.bool hasErrorMessage() => $_has(2);
+  @$pb.TagNumber(3)
+  void clearErrorMessage() => clearField(3);
+}
+
+/// UserConnectionStatusNotification represents a notification when a user connects or disconnects
+class UserConnectionStatusNotification extends $pb.GeneratedMessage {
+  factory UserConnectionStatusNotification({
+    OnlineUser? user,
+    $core.String? status,
+    $fixnum.Int64? timestamp,
+  }) {
+    final $result = create();
+    if (user != null) {
+      $result.user = user;
+    }
+    if (status != null) {
+      $result.status = status;
+    }
+    if (timestamp != null) {
+      $result.timestamp = timestamp;
+    }
+    return $result;
+  }
+  UserConnectionStatusNotification._() : super();
+  factory UserConnectionStatusNotification.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory UserConnectionStatusNotification.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
 
-  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'GetOnlineUsersRequest', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
-    ..aOS(1, _omitFieldNames ? '' : 'userToken')
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'UserConnectionStatusNotification', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..aOM<OnlineUser>(1, _omitFieldNames ? '' : 'user', subBuilder: OnlineUser.create)
+    ..aOS(2, _omitFieldNames ? '' : 'status')
+    ..aInt64(3, _omitFieldNames ? '' : 'timestamp')
     ..hasRequiredFields = false
   ;
 
@@ -1851,56 +3380,99 @@ class GetOnlineUsersRequest extends $pb.GeneratedMessage {
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
   'Will be removed in next major version')
-  GetOnlineUsersRequest clone() => GetOnlineUsersRequest()..mergeFromMessage(this);
+  UserConnectionStatusNotification clone() => UserConnectionStatusNotification()..mergeFromMessage(this);
   @$core.Deprecated(
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
   'Will be removed in next major version')
-  GetOnlineUsersRequest copyWith(void Function(GetOnlineUsersRequest) updates) => super.copyWith((message) => updates(message as GetOnlineUsersRequest)) as GetOnlineUsersRequest;
+  UserConnectionStatusNotification copyWith(void Function(UserConnectionStatusNotification) updates) => super.copyWith((message) => updates(message as UserConnectionStatusNotification)) as UserConnectionStatusNotification;
 
   $pb.BuilderInfo get info_ => _i;
 
   @$core.pragma('dart2js:noInline')
-  static GetOnlineUsersRequest create() => GetOnlineUsersRequest._();
-  GetOnlineUsersRequest createEmptyInstance() => create();
-  static $pb.PbList<GetOnlineUsersRequest> createRepeated() => $pb.PbList<GetOnlineUsersRequest>();
+  static UserConnectionStatusNotification create() => UserConnectionStatusNotification._();
+  UserConnectionStatusNotification createEmptyInstance() => create();
+  static $pb.PbList<UserConnectionStatusNotification> createRepeated() => $pb.PbList<UserConnectionStatusNotification>();
   @$core.pragma('dart2js:noInline')
-  static GetOnlineUsersRequest getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<GetOnlineUsersRequest>(create);
-  static GetOnlineUsersRequest? _defaultInstance;
+  static UserConnectionStatusNotification getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<UserConnectionStatusNotification>(create);
+  static UserConnectionStatusNotification? _defaultInstance;
 
-  /// user token to filter users
+  /// the user who connected/disconnected
   @$pb.TagNumber(1)
-  $core.String get userToken => $_getSZ(0);
+  OnlineUser get user => $_getN(0);
   @$pb.TagNumber(1)
-  set userToken($core.String v) { $_setString(0, v); }
+  set user(OnlineUser v) { setField(1, v); }
   @$pb.TagNumber(1)
-  $core.bool hasUserToken() => $_has(0);
+  $core.bool hasUser() => $_has(0);
   @$pb.TagNumber(1)
-  void clearUserToken() => clearField(1);
+  void clearUser() => clearField(1);
+  @$pb.TagNumber(1)
+  OnlineUser ensureUser() => $_ensure(0);
+
+  /// connection status: "connected" or "disconnected"
+  @$pb.TagNumber(2)
+  $core.String get status => $_getSZ(1);
+  @$pb.TagNumber(2)
+  set status($core.String v) { $_setString(1, v); }
+  @$pb.TagNumber(2)
+  $core.bool hasStatus() => $_has(1);
+  @$pb.TagNumber(2)
+  void clearStatus() => clearField(2);
+
+  /// timestamp of the status change
+  @$pb.TagNumber(3)
+  $fixnum.Int64 get timestamp => $_getI64(2);
+  @$pb.TagNumber(3)
+  set timestamp($fixnum.Int64 v) { $_setInt64(2, v); }
+  @$pb.TagNumber(3)
+  $core.bool hasTimestamp() => $_has(2);
+  @$pb.TagNumber(3)
+  void clearTimestamp() => clearField(3);
 }
 
-/// GetOnlineUsersResponse represents the response containing online users
-class GetOnlineUsersResponse extends $pb.GeneratedMessage {
-  factory GetOnlineUsersResponse({
-    $core.Iterable<OnlineUser>? onlineUsers,
-    $core.int? totalCount,
+/// RequestClaim is the claim of a web user on a request they are answering. Only the user
+/// holding the claim can reply until it is released, on disconnect or after it was idle.
+class RequestClaim extends $pb.GeneratedMessage {
+  factory RequestClaim({
+    $core.String? requestId,
+    $core.String? clientId,
+    $core.String? nickname,
+    $fixnum.Int64? expiresAt,
+    $core.bool? success,
+    $core.String? errorMessage,
   }) {
     final $result = create();
-    if (onlineUsers != null) {
-      $result.onlineUsers.addAll(onlineUsers);
+    if (requestId != null) {
+      $result.requestId = requestId;
     }
-    if (totalCount != null) {
-      $result.totalCount = totalCount;
+    if (clientId != null) {
+      $result.clientId = clientId;
+    }
+    if (nickname != null) {
+      $result.nickname = nickname;
+    }
+    if (expiresAt != null) {
+      $result.expiresAt = expiresAt;
+    }
+    if (success != null) {
+      $result.success = success;
+    }
+    if (errorMessage != null) {
+      $result.errorMessage = errorMessage;
     }
     return $result;
   }
-  GetOnlineUsersResponse._() : super();
-  factory GetOnlineUsersResponse.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
-  factory GetOnlineUsersResponse.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+  RequestClaim._() : super();
+  factory RequestClaim.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory RequestClaim.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
 
-  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'GetOnlineUsersResponse', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
-    ..pc<OnlineUser>(1, _omitFieldNames ? '' : 'onlineUsers', $pb.PbFieldType.PM, subBuilder: OnlineUser.create)
-    ..a<$core.int>(2, _omitFieldNames ? '' : 'totalCount', $pb.PbFieldType.O3)
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'RequestClaim', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..aOS(1, _omitFieldNames ? '' : 'requestId')
+    ..aOS(2, _omitFieldNames ? '' : 'clientId')
+    ..aOS(3, _omitFieldNames ? '' : 'nickname')
+    ..aInt64(4, _omitFieldNames ? '' : 'expiresAt')
+    ..aOB(5, _omitFieldNames ? '' : 'success')
+    ..aOS(6, _omitFieldNames ? '' : 'errorMessage')
     ..hasRequiredFields = false
   ;
 
@@ -1908,85 +3480,121 @@ class GetOnlineUsersResponse extends $pb.GeneratedMessage {
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
   'Will be removed in next major version')
-  GetOnlineUsersResponse clone() => GetOnlineUsersResponse()..mergeFromMessage(this);
+  RequestClaim clone() => RequestClaim()..mergeFromMessage(this);
   @$core.Deprecated(
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
   'Will be removed in next major version')
-  GetOnlineUsersResponse copyWith(void Function(GetOnlineUsersResponse) updates) => super.copyWith((message) => updates(message as GetOnlineUsersResponse)) as GetOnlineUsersResponse;
+  RequestClaim copyWith(void Function(RequestClaim) updates) => super.copyWith((message) => updates(message as RequestClaim)) as RequestClaim;
 
   $pb.BuilderInfo get info_ => _i;
 
   @$core.pragma('dart2js:noInline')
-  static GetOnlineUsersResponse create() => GetOnlineUsersResponse._();
-  GetOnlineUsersResponse createEmptyInstance() => create();
-  static $pb.PbList<GetOnlineUsersResponse> createRepeated() => $pb.PbList<GetOnlineUsersResponse>();
+  static RequestClaim create() => RequestClaim._();
+  RequestClaim createEmptyInstance() => create();
+  static $pb.PbList<RequestClaim> createRepeated() => $pb.PbList<RequestClaim>();
   @$core.pragma('dart2js:noInline')
-  static GetOnlineUsersResponse getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<GetOnlineUsersResponse>(create);
-  static GetOnlineUsersResponse? _defaultInstance;
+  static RequestClaim getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<RequestClaim>(create);
+  static RequestClaim? _defaultInstance;
 
-  /// list of online users
+  /// request id
   @$pb.TagNumber(1)
-  $core.List<OnlineUser> get onlineUsers => $_getList(0);
+  $core.String get requestId => $_getSZ(0);
+  @$pb.TagNumber(1)
+  set requestId($core.String v) { $_setString(0, v); }
+  @$pb.TagNumber(1)
+  $core.bool hasRequestId() => $_has(0);
+  @$pb.TagNumber(1)
+  void clearRequestId() => clearField(1);
 
-  /// total count of online users
+  /// client id of the user holding the claim, empty if the request is not claimed
   @$pb.TagNumber(2)
-  $core.int get totalCount => $_getIZ(1);
+  $core.String get clientId => $_getSZ(1);
   @$pb.TagNumber(2)
-  set totalCount($core.int v) { $_setSignedInt32(1, v); }
+  set clientId($core.String v) { $_setString(1, v); }
   @$pb.TagNumber(2)
-  $core.bool hasTotalCount() => $_has(1);
+  $core.bool hasClientId() => $_has(1);
   @$pb.TagNumber(2)
-  void clearTotalCount() => clearField(2);
+  void clearClientId() => clearField(2);
+
+  /// nickname of the user holding the claim or who answered the request
+  @$pb.TagNumber(3)
+  $core.String get nickname => $_getSZ(2);
+  @$pb.TagNumber(3)
+  set nickname($core.String v) { $_setString(2, v); }
+  @$pb.TagNumber(3)
+  $core.bool hasNickname() => $_has(2);
+  @$pb.TagNumber(3)
+  void clearNickname() => clearField(3);
+
+  /// time the claim is released unless the user keeps typing, unix milliseconds
+  @$pb.TagNumber(4)
+  $fixnum.Int64 get expiresAt => $_getI64(3);
+  @$pb.TagNumber(4)
+  set expiresAt($fixnum.Int64 v) { $_setInt64(3, v); }
+  @$pb.TagNumber(4)
+  $core.bool hasExpiresAt() => $_has(3);
+  @$pb.TagNumber(4)
+  void clearExpiresAt() => clearField(4);
+
+  /// true if the claim was granted (ClaimRequest only)
+  @$pb.TagNumber(5)
+  $core.bool get success => $_getBF(4);
+  @$pb.TagNumber(5)
+  set success($core.bool v) { $_setBool(4, v); }
+  @$pb.TagNumber(5)
+  $core.bool hasSuccess() => $_has(4);
+  @$pb.TagNumber(5)
+  void clearSuccess() => clearField(5);
+
+  /// why a claim or reply was refused, e.g. "already answered by alice"
+  @$pb.TagNumber(6)
+  $core.String get errorMessage => $_getSZ(5);
+  @$pb.TagNumber(6)
+  set errorMessage($core.String v) { $_setString(5, v); }
+  @$pb.TagNumber(6)
+  $core.bool hasErrorMessage() => $_has(5);
+  @$pb.TagNumber(6)
+  void clearErrorMessage() => clearField(6);
 }
 
-/// ChatMessage represents a chat message between users
-class ChatMessage extends $pb.GeneratedMessage {
-  factory ChatMessage({
-    $core.String? messageId,
-    $core.String? senderClientId,
-    $core.String? senderNickname,
-    $core.String? receiverClientId,
-    $core.String? receiverNickname,
-    $core.String? content,
-    $fixnum.Int64? sentAt,
+/// ReplyAck tells the user who sent a reply whether it reached the waiting agent
+class ReplyAck extends $pb.GeneratedMessage {
+  factory ReplyAck({
+    $core.String? requestId,
+    $core.String? status,
+    $fixnum.Int64? deliveredAt,
+    $core.String? repliedBy,
+    $core.String? message,
   }) {
     final $result = create();
-    if (messageId != null) {
-      $result.messageId = messageId;
-    }
-    if (senderClientId != null) {
-      $result.senderClientId = senderClientId;
-    }
-    if (senderNickname != null) {
-      $result.senderNickname = senderNickname;
+    if (requestId != null) {
+      $result.requestId = requestId;
     }
-    if (receiverClientId != null) {
-      $result.receiverClientId = receiverClientId;
+    if (status != null) {
+      $result.status = status;
     }
-    if (receiverNickname != null) {
-      $result.receiverNickname = receiverNickname;
+    if (deliveredAt != null) {
+      $result.deliveredAt = deliveredAt;
     }
-    if (content != null) {
-      $result.content = content;
+    if (repliedBy != null) {
+      $result.repliedBy = repliedBy;
     }
-    if (sentAt != null) {
-      $result.sentAt = sentAt;
+    if (message != null) {
+      $result.message = message;
     }
     return $result;
   }
-  ChatMessage._() : super();
-  factory ChatMessage.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
-  factory ChatMessage.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+  ReplyAck._() : super();
+  factory ReplyAck.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory ReplyAck.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
 
-  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'ChatMessage', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
-    ..aOS(1, _omitFieldNames ? '' : 'messageId')
-    ..aOS(2, _omitFieldNames ? '' : 'senderClientId')
-    ..aOS(3, _omitFieldNames ? '' : 'senderNickname')
-    ..aOS(4, _omitFieldNames ? '' : 'receiverClientId')
-    ..aOS(5, _omitFieldNames ? '' : 'receiverNickname')
-    ..aOS(6, _omitFieldNames ? '' : 'content')
-    ..aInt64(7, _omitFieldNames ? '' : 'sentAt')
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'ReplyAck', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..aOS(1, _omitFieldNames ? '' : 'requestId')
+    ..aOS(2, _omitFieldNames ? '' : 'status')
+    ..aInt64(3, _omitFieldNames ? '' : 'deliveredAt')
+    ..aOS(4, _omitFieldNames ? '' : 'repliedBy')
+    ..aOS(5, _omitFieldNames ? '' : 'message')
     ..hasRequiredFields = false
   ;
 
@@ -1994,116 +3602,115 @@ class ChatMessage extends $pb.GeneratedMessage {
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
   'Will be removed in next major version')
-  ChatMessage clone() => ChatMessage()..mergeFromMessage(this);
+  ReplyAck clone() => ReplyAck()..mergeFromMessage(this);
   @$core.Deprecated(
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
   'Will be removed in next major version')
-  ChatMessage copyWith(void Function(ChatMessage) updates) => super.copyWith((message) => updates(message as ChatMessage)) as ChatMessage;
+  ReplyAck copyWith(void Function(ReplyAck) updates) => super.copyWith((message) => updates(message as ReplyAck)) as ReplyAck;
 
   $pb.BuilderInfo get info_ => _i;
 
   @$core.pragma('dart2js:noInline')
-  static ChatMessage create() => ChatMessage._();
-  ChatMessage createEmptyInstance() => create();
-  static $pb.PbList<ChatMessage> createRepeated() => $pb.PbList<ChatMessage>();
+  static ReplyAck create() => ReplyAck._();
+  ReplyAck createEmptyInstance() => create();
+  static $pb.PbList<ReplyAck> createRepeated() => $pb.PbList<ReplyAck>();
   @$core.pragma('dart2js:noInline')
-  static ChatMessage getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<ChatMessage>(create);
-  static ChatMessage? _defaultInstance;
+  static ReplyAck getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<ReplyAck>(create);
+  static ReplyAck? _defaultInstance;
 
-  /// message id
+  /// request id
   @$pb.TagNumber(1)
-  $core.String get messageId => $_getSZ(0);
+  $core.String get requestId => $_getSZ(0);
   @$pb.TagNumber(1)
-  set messageId($core.String v) { $_setString(0, v); }
+  set requestId($core.String v) { $_setString(0, v); }
   @$pb.TagNumber(1)
-  $core.bool hasMessageId() => $_has(0);
+  $core.bool hasRequestId() => $_has(0);
   @$pb.TagNumber(1)
-  void clearMessageId() => clearField(1);
+  void clearRequestId() => clearField(1);
 
-  /// sender client id
+  /// delivered, recorded (an approval that does not complete the quorum yet), unknown,
+  /// expired, already_answered, invalid (e.g. an unknown decision, see message) or failed
+  /// (the server was too busy to take the reply, it can be sent again)
   @$pb.TagNumber(2)
-  $core.String get senderClientId => $_getSZ(1);
+  $core.String get status => $_getSZ(1);
   @$pb.TagNumber(2)
-  set senderClientId($core.String v) { $_setString(1, v); }
+  set status($core.String v) { $_setString(1, v); }
   @$pb.TagNumber(2)
-  $core.bool hasSenderClientId() => $_has(1);
+  $core.bool hasStatus() => $_has(1);
   @$pb.TagNumber(2)
-  void clearSenderClientId() => clearField(2);
+  void clearStatus() => clearField(2);
 
-  /// sender nickname
+  /// time the reply was delivered, unix milliseconds; for already_answered the time the
+  /// earlier reply was, 0 if it is still being delivered
   @$pb.TagNumber(3)
-  $core.String get senderNickname => $_getSZ(2);
+  $fixnum.Int64 get deliveredAt => $_getI64(2);
   @$pb.TagNumber(3)
-  set senderNickname($core.String v) { $_setString(2, v); }
+  set deliveredAt($fixnum.Int64 v) { $_setInt64(2, v); }
   @$pb.TagNumber(3)
-  $core.bool hasSenderNickname() => $_has(2);
+  $core.bool hasDeliveredAt() => $_has(2);
   @$pb.TagNumber(3)
-  void clearSenderNickname() => clearField(3);
+  void clearDeliveredAt() => clearField(3);
 
-  /// receiver client id
+  /// nickname of the user who answered, for already_answered
   @$pb.TagNumber(4)
-  $core.String get receiverClientId => $_getSZ(3);
+  $core.String get repliedBy => $_getSZ(3);
   @$pb.TagNumber(4)
-  set receiverClientId($core.String v) { $_setString(3, v); }
+  set repliedBy($core.String v) { $_setString(3, v); }
   @$pb.TagNumber(4)
-  $core.bool hasReceiverClientId() => $_has(3);
+  $core.bool hasRepliedBy() => $_has(3);
   @$pb.TagNumber(4)
-  void clearReceiverClientId() => clearField(4);
+  void clearRepliedBy() => clearField(4);
 
-  /// receiver nickname
+  /// how an expired request ended, e.g. "Request timed out"
   @$pb.TagNumber(5)
-  $core.String get receiverNickname => $_getSZ(4);
+  $core.String get message => $_getSZ(4);
   @$pb.TagNumber(5)
-  set receiverNickname($core.String v) { $_setString(4, v); }
+  set message($core.String v) { $_setString(4, v); }
   @$pb.TagNumber(5)
-  $core.bool hasReceiverNickname() => $_has(4);
+  $core.bool hasMessage() => $_has(4);
   @$pb.TagNumber(5)
-  void clearReceiverNickname() => clearField(5);
-
-  /// message content
-  @$pb.TagNumber(6)
-  $core.String get content => $_getSZ(5);
-  @$pb.TagNumber(6)
-  set content($core.String v) { $_setString(5, v); }
-  @$pb.TagNumber(6)
-  $core.bool hasContent() => $_has(5);
-  @$pb.TagNumber(6)
-  void clearContent() => clearField(6);
-
-  /// timestamp when the message was sent
-  @$pb.TagNumber(7)
-  $fixnum.Int64 get sentAt => $_getI64(6);
-  @$pb.TagNumber(7)
-  set sentAt($fixnum.Int64 v) { $_setInt64(6, v); }
-  @$pb.TagNumber(7)
-  $core.bool hasSentAt() => $_has(6);
-  @$pb.TagNumber(7)
-  void clearSentAt() => clearField(7);
+  void clearMessage() => clearField(5);
 }
 
-/// SendChatMessageRequest represents a request to send a chat message
-class SendChatMessageRequest extends $pb.GeneratedMessage {
-  factory SendChatMessageRequest({
-    $core.String? receiverClientId,
-    $core.String? content,
+/// ApprovalProgress is the state of a work report that needs several approvals. A reply with
+/// a decision other than approved resolves the work report right away.
+class ApprovalProgress extends $pb.GeneratedMessage {
+  factory ApprovalProgress({
+    $core.String? requestId,
+    $core.int? requiredApprovals,
+    $core.Iterable<$core.String>? approvers,
+    $core.Iterable<$core.String>? approvedBy,
+    $core.Iterable<$core.String>? pendingApprovers,
   }) {
     final $result = create();
-    if (receiverClientId != null) {
-      $result.receiverClientId = receiverClientId;
+    if (requestId != null) {
+      $result.requestId = requestId;
     }
-    if (content != null) {
-      $result.content = content;
+    if (requiredApprovals != null) {
+      $result.requiredApprovals = requiredApprovals;
+    }
+    if (approvers != null) {
+      $result.approvers.addAll(approvers);
+    }
+    if (approvedBy != null) {
+      $result.approvedBy.addAll(approvedBy);
+    }
+    if (pendingApprovers != null) {
+      $result.pendingApprovers.addAll(pendingApprovers);
     }
     return $result;
   }
-  SendChatMessageRequest._() : super();
-  factory SendChatMessageRequest.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
-  factory SendChatMessageRequest.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+  ApprovalProgress._() : super();
+  factory ApprovalProgress.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory ApprovalProgress.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
 
-  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'SendChatMessageRequest', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
-    ..aOS(1, _omitFieldNames ? '' : 'receiverClientId')
-    ..aOS(2, _omitFieldNames ? '' : 'content')
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'ApprovalProgress', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..aOS(1, _omitFieldNames ? '' : 'requestId')
+    ..a<$core.int>(2, _omitFieldNames ? '' : 'requiredApprovals', $pb.PbFieldType.O3)
+    ..pPS(3, _omitFieldNames ? '' : 'approvers')
+    ..pPS(4, _omitFieldNames ? '' : 'approvedBy')
+    ..pPS(5, _omitFieldNames ? '' : 'pendingApprovers')
     ..hasRequiredFields = false
   ;
 
@@ -2111,61 +3718,98 @@ class SendChatMessageRequest extends $pb.GeneratedMessage {
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
   'Will be removed in next major version')
-  SendChatMessageRequest clone() => SendChatMessageRequest()..mergeFromMessage(this);
+  ApprovalProgress clone() => ApprovalProgress()..mergeFromMessage(this);
   @$core.Deprecated(
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
   'Will be removed in next major version')
-  SendChatMessageRequest copyWith(void Function(SendChatMessageRequest) updates) => super.copyWith((message) => updates(message as SendChatMessageRequest)) as SendChatMessageRequest;
+  ApprovalProgress copyWith(void Function(ApprovalProgress) updates) => super.copyWith((message) => updates(message as ApprovalProgress)) as ApprovalProgress;
 
   $pb.BuilderInfo get info_ => _i;
 
   @$core.pragma('dart2js:noInline')
-  static SendChatMessageRequest create() => SendChatMessageRequest._();
-  SendChatMessageRequest createEmptyInstance() => create();
-  static $pb.PbList<SendChatMessageRequest> createRepeated() => $pb.PbList<SendChatMessageRequest>();
+  static ApprovalProgress create() => ApprovalProgress._();
+  ApprovalProgress createEmptyInstance() => create();
+  static $pb.PbList<ApprovalProgress> createRepeated() => $pb.PbList<ApprovalProgress>();
   @$core.pragma('dart2js:noInline')
-  static SendChatMessageRequest getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<SendChatMessageRequest>(create);
-  static SendChatMessageRequest? _defaultInstance;
+  static ApprovalProgress getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<ApprovalProgress>(create);
+  static ApprovalProgress? _defaultInstance;
 
-  /// receiver client id
+  /// request id
   @$pb.TagNumber(1)
-  $core.String get receiverClientId => $_getSZ(0);
+  $core.String get requestId => $_getSZ(0);
   @$pb.TagNumber(1)
-  set receiverClientId($core.String v) { $_setString(0, v); }
+  set requestId($core.String v) { $_setString(0, v); }
   @$pb.TagNumber(1)
-  $core.bool hasReceiverClientId() => $_has(0);
+  $core.bool hasRequestId() => $_has(0);
   @$pb.TagNumber(1)
-  void clearReceiverClientId() => clearField(1);
+  void clearRequestId() => clearField(1);
 
-  /// message content
+  /// number of approvals required
   @$pb.TagNumber(2)
-  $core.String get content => $_getSZ(1);
+  $core.int get requiredApprovals => $_getIZ(1);
   @$pb.TagNumber(2)
-  set content($core.String v) { $_setString(1, v); }
+  set requiredApprovals($core.int v) { $_setSignedInt32(1, v); }
   @$pb.TagNumber(2)
-  $core.bool hasContent() => $_has(1);
+  $core.bool hasRequiredApprovals() => $_has(1);
   @$pb.TagNumber(2)
-  void clearContent() => clearField(2);
+  void clearRequiredApprovals() => clearField(2);
+
+  /// nicknames of the users who must all approve
+  @$pb.TagNumber(3)
+  $core.List<$core.String> get approvers => $_getList(2);
+
+  /// nicknames of the users who approved so far, in order
+  @$pb.TagNumber(4)
+  $core.List<$core.String> get approvedBy => $_getList(3);
+
+  /// named approvers who did not approve yet
+  @$pb.TagNumber(5)
+  $core.List<$core.String> get pendingApprovers => $_getList(4);
 }
 
-/// ChatMessageNotification represents a notification of a new chat message
-class ChatMessageNotification extends $pb.GeneratedMessage {
-  factory ChatMessageNotification({
-    ChatMessage? chatMessage,
+/// DeadlineExtension asks for more time to answer a pending request, or tells its new deadline
+class DeadlineExtension extends $pb.GeneratedMessage {
+  factory DeadlineExtension({
+    $core.String? requestId,
+    $core.int? extendSeconds,
+    $fixnum.Int64? deadline,
+    $core.String? nickname,
+    $core.bool? success,
+    $core.String? errorMessage,
   }) {
     final $result = create();
-    if (chatMessage != null) {
-      $result.chatMessage = chatMessage;
+    if (requestId != null) {
+      $result.requestId = requestId;
+    }
+    if (extendSeconds != null) {
+      $result.extendSeconds = extendSeconds;
+    }
+    if (deadline != null) {
+      $result.deadline = deadline;
+    }
+    if (nickname != null) {
+      $result.nickname = nickname;
+    }
+    if (success != null) {
+      $result.success = success;
+    }
+    if (errorMessage != null) {
+      $result.errorMessage = errorMessage;
     }
     return $result;
   }
-  ChatMessageNotification._() : super();
-  factory ChatMessageNotification.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
-  factory ChatMessageNotification.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+  DeadlineExtension._() : super();
+  factory DeadlineExtension.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory DeadlineExtension.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
 
-  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'ChatMessageNotification', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
-    ..aOM<ChatMessage>(1, _omitFieldNames ? '' : 'chatMessage', subBuilder: ChatMessage.create)
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'DeadlineExtension', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..aOS(1, _omitFieldNames ? '' : 'requestId')
+    ..a<$core.int>(2, _omitFieldNames ? '' : 'extendSeconds', $pb.PbFieldType.O3)
+    ..aInt64(3, _omitFieldNames ? '' : 'deadline')
+    ..aOS(4, _omitFieldNames ? '' : 'nickname')
+    ..aOB(5, _omitFieldNames ? '' : 'success')
+    ..aOS(6, _omitFieldNames ? '' : 'errorMessage')
     ..hasRequiredFields = false
   ;
 
@@ -2173,46 +3817,112 @@ class ChatMessageNotification extends $pb.GeneratedMessage {
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
   'Will be removed in next major version')
-  ChatMessageNotification clone() => ChatMessageNotification()..mergeFromMessage(this);
+  DeadlineExtension clone() => DeadlineExtension()..mergeFromMessage(this);
   @$core.Deprecated(
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
   'Will be removed in next major version')
-  ChatMessageNotification copyWith(void Function(ChatMessageNotification) updates) => super.copyWith((message) => updates(message as ChatMessageNotification)) as ChatMessageNotification;
+  DeadlineExtension copyWith(void Function(DeadlineExtension) updates) => super.copyWith((message) => updates(message as DeadlineExtension)) as DeadlineExtension;
 
   $pb.BuilderInfo get info_ => _i;
 
   @$core.pragma('dart2js:noInline')
-  static ChatMessageNotification create() => ChatMessageNotification._();
-  ChatMessageNotification createEmptyInstance() => create();
-  static $pb.PbList<ChatMessageNotification> createRepeated() => $pb.PbList<ChatMessageNotification>();
+  static DeadlineExtension create() => DeadlineExtension._();
+  DeadlineExtension createEmptyInstance() => create();
+  static $pb.PbList<DeadlineExtension> createRepeated() => $pb.PbList<DeadlineExtension>();
   @$core.pragma('dart2js:noInline')
-  static ChatMessageNotification getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<ChatMessageNotification>(create);
-  static ChatMessageNotification? _defaultInstance;
+  static DeadlineExtension getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<DeadlineExtension>(create);
+  static DeadlineExtension? _defaultInstance;
 
-  /// the chat message
-  @$pb.TagNumber(1)
-  ChatMessage get chatMessage => $_getN(0);
+  /// request id
   @$pb.TagNumber(1)
-  set chatMessage(ChatMessage v) { setField(1, v); }
+  $core.String get requestId => $_getSZ(0);
   @$pb.TagNumber(1)
-  $core.bool hasChatMessage() => $_has(0);
+  set requestId($core.String v) { $_setString(0, v); }
   @$pb.TagNumber(1)
-  void clearChatMessage() => clearField(1);
+  $core.bool hasRequestId() => $_has(0);
   @$pb.TagNumber(1)
-  ChatMessage ensureChatMessage() => $_ensure(0);
+  void clearRequestId() => clearField(1);
+
+  /// how many seconds to push the deadline out by (ExtendDeadline requests), 0 for the server
+  /// default
+  @$pb.TagNumber(2)
+  $core.int get extendSeconds => $_getIZ(1);
+  @$pb.TagNumber(2)
+  set extendSeconds($core.int v) { $_setSignedInt32(1, v); }
+  @$pb.TagNumber(2)
+  $core.bool hasExtendSeconds() => $_has(1);
+  @$pb.TagNumber(2)
+  void clearExtendSeconds() => clearField(2);
+
+  /// deadline of the request, unix milliseconds
+  @$pb.TagNumber(3)
+  $fixnum.Int64 get deadline => $_getI64(2);
+  @$pb.TagNumber(3)
+  set deadline($fixnum.Int64 v) { $_setInt64(2, v); }
+  @$pb.TagNumber(3)
+  $core.bool hasDeadline() => $_has(2);
+  @$pb.TagNumber(3)
+  void clearDeadline() => clearField(3);
+
+  /// nickname of the user who extended the deadline, empty if the server did
+  @$pb.TagNumber(4)
+  $core.String get nickname => $_getSZ(3);
+  @$pb.TagNumber(4)
+  set nickname($core.String v) { $_setString(3, v); }
+  @$pb.TagNumber(4)
+  $core.bool hasNickname() => $_has(3);
+  @$pb.TagNumber(4)
+  void clearNickname() => clearField(4);
+
+  /// true if the deadline was extended (ExtendDeadline responses)
+  @$pb.TagNumber(5)
+  $core.bool get success => $_getBF(4);
+  @$pb.TagNumber(5)
+  set success($core.bool v) { $_setBool(4, v); }
+  @$pb.TagNumber(5)
+  $core.bool hasSuccess() => $_has(4);
+  @$pb.TagNumber(5)
+  void clearSuccess() => clearField(5);
+
+  /// why the deadline could not be extended, e.g. "already answered by alice"
+  @$pb.TagNumber(6)
+  $core.String get errorMessage => $_getSZ(5);
+  @$pb.TagNumber(6)
+  set errorMessage($core.String v) { $_setString(5, v); }
+  @$pb.TagNumber(6)
+  $core.bool hasErrorMessage() => $_has(5);
+  @$pb.TagNumber(6)
+  void clearErrorMessage() => clearField(6);
 }
 
-/// UserLoginResponse represents the response to a user login
-class UserLoginResponse extends $pb.GeneratedMessage {
-  factory UserLoginResponse({
-    $core.String? clientId,
+/// InterimReply tells the waiting agent something without answering the request, e.g. that
+/// the user is looking into it. The request stays pending until the final reply.
+class InterimReply extends $pb.GeneratedMessage {
+  factory InterimReply({
+    $core.String? requestId,
+    $core.String? text,
+    $core.int? extendSeconds,
+    $core.String? nickname,
+    $fixnum.Int64? deadline,
     $core.bool? success,
     $core.String? errorMessage,
   }) {
     final $result = create();
-    if (clientId != null) {
-      $result.clientId = clientId;
+    if (requestId != null) {
+      $result.requestId = requestId;
+    }
+    if (text != null) {
+      $result.text = text;
+    }
+    if (extendSeconds != null) {
+      $result.extendSeconds = extendSeconds;
+    }
+    if (nickname != null) {
+      $result.nickname = nickname;
+    }
+    if (deadline != null) {
+      $result.deadline = deadline;
     }
     if (success != null) {
       $result.success = success;
@@ -2222,14 +3932,18 @@ class UserLoginResponse extends $pb.GeneratedMessage {
     }
     return $result;
   }
-  UserLoginResponse._() : super();
-  factory UserLoginResponse.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
-  factory UserLoginResponse.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+  InterimReply._() : super();
+  factory InterimReply.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory InterimReply.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
 
-  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'UserLoginResponse', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
-    ..aOS(1, _omitFieldNames ? '' : 'clientId')
-    ..aOB(2, _omitFieldNames ? '' : 'success')
-    ..aOS(3, _omitFieldNames ? '' : 'errorMessage')
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'InterimReply', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..aOS(1, _omitFieldNames ? '' : 'requestId')
+    ..aOS(2, _omitFieldNames ? '' : 'text')
+    ..a<$core.int>(3, _omitFieldNames ? '' : 'extendSeconds', $pb.PbFieldType.O3)
+    ..aOS(4, _omitFieldNames ? '' : 'nickname')
+    ..aInt64(5, _omitFieldNames ? '' : 'deadline')
+    ..aOB(6, _omitFieldNames ? '' : 'success')
+    ..aOS(7, _omitFieldNames ? '' : 'errorMessage')
     ..hasRequiredFields = false
   ;
 
@@ -2237,81 +3951,131 @@ class UserLoginResponse extends $pb.GeneratedMessage {
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
   'Will be removed in next major version')
-  UserLoginResponse clone() => UserLoginResponse()..mergeFromMessage(this);
+  InterimReply clone() => InterimReply()..mergeFromMessage(this);
   @$core.Deprecated(
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
   'Will be removed in next major version')
-  UserLoginResponse copyWith(void Function(UserLoginResponse) updates) => super.copyWith((message) => updates(message as UserLoginResponse)) as UserLoginResponse;
+  InterimReply copyWith(void Function(InterimReply) updates) => super.copyWith((message) => updates(message as InterimReply)) as InterimReply;
 
   $pb.BuilderInfo get info_ => _i;
 
   @$core.pragma('dart2js:noInline')
-  static UserLoginResponse create() => UserLoginResponse._();
-  UserLoginResponse createEmptyInstance() => create();
-  static $pb.PbList<UserLoginResponse> createRepeated() => $pb.PbList<UserLoginResponse>();
+  static InterimReply create() => InterimReply._();
+  InterimReply createEmptyInstance() => create();
+  static $pb.PbList<InterimReply> createRepeated() => $pb.PbList<InterimReply>();
   @$core.pragma('dart2js:noInline')
-  static UserLoginResponse getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<UserLoginResponse>(create);
-  static UserLoginResponse? _defaultInstance;
+  static InterimReply getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<InterimReply>(create);
+  static InterimReply? _defaultInstance;
 
-  /// client id assigned by server
+  /// request id
   @$pb.TagNumber(1)
-  $core.String get clientId => $_getSZ(0);
+  $core.String get requestId => $_getSZ(0);
   @$pb.TagNumber(1)
-  set clientId($core.String v) { $_setString(0, v); }
+  set requestId($core.String v) { $_setString(0, v); }
   @$pb.TagNumber(1)
-  $core.bool hasClientId() => $_has(0);
+  $core.bool hasRequestId() => $_has(0);
   @$pb.TagNumber(1)
-  void clearClientId() => clearField(1);
+  void clearRequestId() => clearField(1);
 
-  /// success status
+  /// text forwarded to the agent
   @$pb.TagNumber(2)
-  $core.bool get success => $_getBF(1);
+  $core.String get text => $_getSZ(1);
   @$pb.TagNumber(2)
-  set success($core.bool v) { $_setBool(1, v); }
+  set text($core.String v) { $_setString(1, v); }
   @$pb.TagNumber(2)
-  $core.bool hasSuccess() => $_has(1);
+  $core.bool hasText() => $_has(1);
   @$pb.TagNumber(2)
-  void clearSuccess() => clearField(2);
+  void clearText() => clearField(2);
 
-  /// error message if login failed
+  /// how many seconds to push the deadline out by, 0 to keep it
   @$pb.TagNumber(3)
-  $core.String get errorMessage => $_getSZ(2);
+  $core.int get extendSeconds => $_getIZ(2);
   @$pb.TagNumber(3)
-  set errorMessage($core.String v) { $_setString(2, v); }
+  set extendSeconds($core.int v) { $_setSignedInt32(2, v); }
   @$pb.TagNumber(3)
-  $core.bool hasErrorMessage() => $_has(2);
+  $core.bool hasExtendSeconds() => $_has(2);
   @$pb.TagNumber(3)
-  void clearErrorMessage() => clearField(3);
+  void clearExtendSeconds() => clearField(3);
+
+  /// nickname of the user who sent the interim reply
+  @$pb.TagNumber(4)
+  $core.String get nickname => $_getSZ(3);
+  @$pb.TagNumber(4)
+  set nickname($core.String v) { $_setString(3, v); }
+  @$pb.TagNumber(4)
+  $core.bool hasNickname() => $_has(3);
+  @$pb.TagNumber(4)
+  void clearNickname() => clearField(4);
+
+  /// deadline of the request after the interim reply, unix milliseconds
+  @$pb.TagNumber(5)
+  $fixnum.Int64 get deadline => $_getI64(4);
+  @$pb.TagNumber(5)
+  set deadline($fixnum.Int64 v) { $_setInt64(4, v); }
+  @$pb.TagNumber(5)
+  $core.bool hasDeadline() => $_has(4);
+  @$pb.TagNumber(5)
+  void clearDeadline() => clearField(5);
+
+  /// true if the interim reply was forwarded (InterimReply responses)
+  @$pb.TagNumber(6)
+  $core.bool get success => $_getBF(5);
+  @$pb.TagNumber(6)
+  set success($core.bool v) { $_setBool(5, v); }
+  @$pb.TagNumber(6)
+  $core.bool hasSuccess() => $_has(5);
+  @$pb.TagNumber(6)
+  void clearSuccess() => clearField(6);
+
+  /// why the interim reply was refused, e.g. "already answered by alice"
+  @$pb.TagNumber(7)
+  $core.String get errorMessage => $_getSZ(6);
+  @$pb.TagNumber(7)
+  set errorMessage($core.String v) { $_setString(6, v); }
+  @$pb.TagNumber(7)
+  $core.bool hasErrorMessage() => $_has(6);
+  @$pb.TagNumber(7)
+  void clearErrorMessage() => clearField(7);
 }
 
-/// UserConnectionStatusNotification represents a notification when a user connects or disconnects
-class UserConnectionStatusNotification extends $pb.GeneratedMessage {
-  factory UserConnectionStatusNotification({
-    OnlineUser? user,
-    $core.String? status,
-    $fixnum.Int64? timestamp,
+/// Reminder reminds the web users of a request that nobody answered for a while
+class Reminder extends $pb.GeneratedMessage {
+  factory Reminder({
+    $core.String? requestId,
+    $core.int? number,
+    $fixnum.Int64? unansweredSeconds,
+    $core.Iterable<$core.String>? escalatedTo,
+    $core.String? message,
   }) {
     final $result = create();
-    if (user != null) {
-      $result.user = user;
+    if (requestId != null) {
+      $result.requestId = requestId;
     }
-    if (status != null) {
-      $result.status = status;
+    if (number != null) {
+      $result.number = number;
     }
-    if (timestamp != null) {
-      $result.timestamp = timestamp;
+    if (unansweredSeconds != null) {
+      $result.unansweredSeconds = unansweredSeconds;
+    }
+    if (escalatedTo != null) {
+      $result.escalatedTo.addAll(escalatedTo);
+    }
+    if (message != null) {
+      $result.message = message;
     }
     return $result;
   }
-  UserConnectionStatusNotification._() : super();
-  factory UserConnectionStatusNotification.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
-  factory UserConnectionStatusNotification.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+  Reminder._() : super();
+  factory Reminder.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory Reminder.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
 
-  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'UserConnectionStatusNotification', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
-    ..aOM<OnlineUser>(1, _omitFieldNames ? '' : 'user', subBuilder: OnlineUser.create)
-    ..aOS(2, _omitFieldNames ? '' : 'status')
-    ..aInt64(3, _omitFieldNames ? '' : 'timestamp')
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'Reminder', package: const $pb.PackageName(_omitMessageNames ? '' : 'agentassistproto'), createEmptyInstance: create)
+    ..aOS(1, _omitFieldNames ? '' : 'requestId')
+    ..a<$core.int>(2, _omitFieldNames ? '' : 'number', $pb.PbFieldType.O3)
+    ..aInt64(3, _omitFieldNames ? '' : 'unansweredSeconds')
+    ..pPS(4, _omitFieldNames ? '' : 'escalatedTo')
+    ..aOS(5, _omitFieldNames ? '' : 'message')
     ..hasRequiredFields = false
   ;
 
@@ -2319,54 +4083,66 @@ class UserConnectionStatusNotification extends $pb.GeneratedMessage {
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
   'Will be removed in next major version')
-  UserConnectionStatusNotification clone() => UserConnectionStatusNotification()..mergeFromMessage(this);
+  Reminder clone() => Reminder()..mergeFromMessage(this);
   @$core.Deprecated(
   'Using this can add significant overhead to your binary. '
   'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
   'Will be removed in next major version')
-  UserConnectionStatusNotification copyWith(void Function(UserConnectionStatusNotification) updates) => super.copyWith((message) => updates(message as UserConnectionStatusNotification)) as UserConnectionStatusNotification;
+  Reminder copyWith(void Function(Reminder) updates) => super.copyWith((message) => updates(message as Reminder)) as Reminder;
 
   $pb.BuilderInfo get info_ => _i;
 
   @$core.pragma('dart2js:noInline')
-  static UserConnectionStatusNotification create() => UserConnectionStatusNotification._();
-  UserConnectionStatusNotification createEmptyInstance() => create();
-  static $pb.PbList<UserConnectionStatusNotification> createRepeated() => $pb.PbList<UserConnectionStatusNotification>();
+  static Reminder create() => Reminder._();
+  Reminder createEmptyInstance() => create();
+  static $pb.PbList<Reminder> createRepeated() => $pb.PbList<Reminder>();
   @$core.pragma('dart2js:noInline')
-  static UserConnectionStatusNotification getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<UserConnectionStatusNotification>(create);
-  static UserConnectionStatusNotification? _defaultInstance;
+  static Reminder getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<Reminder>(create);
+  static Reminder? _defaultInstance;
 
-  /// the user who connected/disconnected
-  @$pb.TagNumber(1)
-  OnlineUser get user => $_getN(0);
+  /// request id
   @$pb.TagNumber(1)
-  set user(OnlineUser v) { setField(1, v); }
+  $core.String get requestId => $_getSZ(0);
   @$pb.TagNumber(1)
-  $core.bool hasUser() => $_has(0);
+  set requestId($core.String v) { $_setString(0, v); }
   @$pb.TagNumber(1)
-  void clearUser() => clearField(1);
+  $core.bool hasRequestId() => $_has(0);
   @$pb.TagNumber(1)
-  OnlineUser ensureUser() => $_ensure(0);
+  void clearRequestId() => clearField(1);
 
-  /// connection status: "connected" or "disconnected"
+  /// number of the reminder, 1 for the first
   @$pb.TagNumber(2)
-  $core.String get status => $_getSZ(1);
+  $core.int get number => $_getIZ(1);
   @$pb.TagNumber(2)
-  set status($core.String v) { $_setString(1, v); }
+  set number($core.int v) { $_setSignedInt32(1, v); }
   @$pb.TagNumber(2)
-  $core.bool hasStatus() => $_has(1);
+  $core.bool hasNumber() => $_has(1);
   @$pb.TagNumber(2)
-  void clearStatus() => clearField(2);
+  void clearNumber() => clearField(2);
 
-  /// timestamp of the status change
+  /// how long the request has been waiting for an answer, in seconds
   @$pb.TagNumber(3)
-  $fixnum.Int64 get timestamp => $_getI64(2);
+  $fixnum.Int64 get unansweredSeconds => $_getI64(2);
   @$pb.TagNumber(3)
-  set timestamp($fixnum.Int64 v) { $_setInt64(2, v); }
+  set unansweredSeconds($fixnum.Int64 v) { $_setInt64(2, v); }
   @$pb.TagNumber(3)
-  $core.bool hasTimestamp() => $_has(2);
+  $core.bool hasUnansweredSeconds() => $_has(2);
   @$pb.TagNumber(3)
-  void clearTimestamp() => clearField(3);
+  void clearUnansweredSeconds() => clearField(3);
+
+  /// nicknames the request was escalated to by this reminder
+  @$pb.TagNumber(4)
+  $core.List<$core.String> get escalatedTo => $_getList(3);
+
+  /// human readable description, e.g. "not answered for 5m0s"
+  @$pb.TagNumber(5)
+  $core.String get message => $_getSZ(4);
+  @$pb.TagNumber(5)
+  set message($core.String v) { $_setString(4, v); }
+  @$pb.TagNumber(5)
+  $core.bool hasMessage() => $_has(4);
+  @$pb.TagNumber(5)
+  void clearMessage() => clearField(5);
 }
 
 class WebsocketMessage extends $pb.GeneratedMessage {
@@ -2389,6 +4165,12 @@ class WebsocketMessage extends $pb.GeneratedMessage {
     ChatMessageNotification? chatMessageNotification,
     UserLoginResponse? userLoginResponse,
     UserConnectionStatusNotification? userConnectionStatusNotification,
+    RequestClaim? requestClaim,
+    ReplyAck? replyAck,
+    ApprovalProgress? approvalProgress,
+    DeadlineExtension? deadlineExtension,
+    InterimReply? interimReply,
+    Reminder? reminder,
   }) {
     final $result = create();
     if (cmd != null) {
@@ -2445,6 +4227,24 @@ class WebsocketMessage extends $pb.GeneratedMessage {
     if (userConnectionStatusNotification != null) {
       $result.userConnectionStatusNotification = userConnectionStatusNotification;
     }
+    if (requestClaim != null) {
+      $result.requestClaim = requestClaim;
+    }
+    if (replyAck != null) {
+      $result.replyAck = replyAck;
+    }
+    if (approvalProgress != null) {
+      $result.approvalProgress = approvalProgress;
+    }
+    if (deadlineExtension != null) {
+      $result.deadlineExtension = deadlineExtension;
+    }
+    if (interimReply != null) {
+      $result.interimReply = interimReply;
+    }
+    if (reminder != null) {
+      $result.reminder = reminder;
+    }
     return $result;
   }
   WebsocketMessage._() : super();
@@ -2470,6 +4270,12 @@ class WebsocketMessage extends $pb.GeneratedMessage {
     ..aOM<ChatMessageNotification>(22, _omitFieldNames ? '' : 'ChatMessageNotification', protoName: 'ChatMessageNotification', subBuilder: ChatMessageNotification.create)
     ..aOM<UserLoginResponse>(23, _omitFieldNames ? '' : 'UserLoginResponse', protoName: 'UserLoginResponse', subBuilder: UserLoginResponse.create)
     ..aOM<UserConnectionStatusNotification>(24, _omitFieldNames ? '' : 'UserConnectionStatusNotification', protoName: 'UserConnectionStatusNotification', subBuilder: UserConnectionStatusNotification.create)
+    ..aOM<RequestClaim>(25, _omitFieldNames ? '' : 'RequestClaim', protoName: 'RequestClaim', subBuilder: RequestClaim.create)
+    ..aOM<ReplyAck>(26, _omitFieldNames ? '' : 'ReplyAck', protoName: 'ReplyAck', subBuilder: ReplyAck.create)
+    ..aOM<ApprovalProgress>(27, _omitFieldNames ? '' : 'ApprovalProgress', protoName: 'ApprovalProgress', subBuilder: ApprovalProgress.create)
+    ..aOM<DeadlineExtension>(28, _omitFieldNames ? '' : 'DeadlineExtension', protoName: 'DeadlineExtension', subBuilder: DeadlineExtension.create)
+    ..aOM<InterimReply>(29, _omitFieldNames ? '' : 'InterimReply', protoName: 'InterimReply', subBuilder: InterimReply.create)
+    ..aOM<Reminder>(30, _omitFieldNames ? '' : 'Reminder', protoName: 'Reminder', subBuilder: Reminder.create)
     ..hasRequiredFields = false
   ;
 
@@ -2508,6 +4314,24 @@ class WebsocketMessage extends $pb.GeneratedMessage {
   /// GetOnlineUsers: get online users with the same token
   /// SendChatMessage: send a chat message to another user
   /// ChatMessageNotification: notification of a new chat message
+  /// RequestViewed: user viewed a request, str param is the request id
+  /// ReplyDraft: user is typing a reply to a request, str param is the request id
+  /// ClaimRequest: user claims a request to answer it, str param is the request id;
+  ///   the server answers with the outcome in RequestClaim
+  /// ReleaseClaim: user gives up the claim on a request, str param is the request id
+  /// RequestClaimed: notification that a request was claimed or released, in RequestClaim
+  /// ReplyRejected: the reply of the user was refused because another user claimed the
+  ///   request, RequestClaim tells who
+  /// ReplyAck: outcome of an AskQuestionReply or WorkReportReply, in ReplyAck
+  /// ApprovalProgress: an approval of a work report needing several approvals was recorded,
+  ///   in ApprovalProgress
+  /// ExtendDeadline: user asks for more time to answer a request, in DeadlineExtension; the
+  ///   server answers with the outcome in DeadlineExtension
+  /// DeadlineExtended: notification of the new deadline of a request, in DeadlineExtension
+  /// InterimReply: user tells the agent something without answering a request, in
+  ///   InterimReply; the server answers with the outcome, and notifies the other users of
+  ///   the request with the same cmd
+  /// Reminder: a request nobody answered for a while, in Reminder
   @$pb.TagNumber(1)
   $core.String get cmd => $_getSZ(0);
   @$pb.TagNumber(1)
@@ -2716,6 +4540,78 @@ class WebsocketMessage extends $pb.GeneratedMessage {
   void clearUserConnectionStatusNotification() => clearField(24);
   @$pb.TagNumber(24)
   UserConnectionStatusNotification ensureUserConnectionStatusNotification() => $_ensure(17);
+
+  /// claim of a request, for ClaimRequest, RequestClaimed and ReplyRejected
+  @$pb.TagNumber(25)
+  RequestClaim get requestClaim => $_getN(18);
+  @$pb.TagNumber(25)
+  set requestClaim(RequestClaim v) { setField(25, v); }
+  @$pb.TagNumber(25)
+  $core.bool hasRequestClaim() => $_has(18);
+  @$pb.TagNumber(25)
+  void clearRequestClaim() => clearField(25);
+  @$pb.TagNumber(25)
+  RequestClaim ensureRequestClaim() => $_ensure(18);
+
+  /// reply acknowledgement
+  @$pb.TagNumber(26)
+  ReplyAck get replyAck => $_getN(19);
+  @$pb.TagNumber(26)
+  set replyAck(ReplyAck v) { setField(26, v); }
+  @$pb.TagNumber(26)
+  $core.bool hasReplyAck() => $_has(19);
+  @$pb.TagNumber(26)
+  void clearReplyAck() => clearField(26);
+  @$pb.TagNumber(26)
+  ReplyAck ensureReplyAck() => $_ensure(19);
+
+  /// approval progress of a work report
+  @$pb.TagNumber(27)
+  ApprovalProgress get approvalProgress => $_getN(20);
+  @$pb.TagNumber(27)
+  set approvalProgress(ApprovalProgress v) { setField(27, v); }
+  @$pb.TagNumber(27)
+  $core.bool hasApprovalProgress() => $_has(20);
+  @$pb.TagNumber(27)
+  void clearApprovalProgress() => clearField(27);
+  @$pb.TagNumber(27)
+  ApprovalProgress ensureApprovalProgress() => $_ensure(20);
+
+  /// deadline extension, for ExtendDeadline and DeadlineExtended
+  @$pb.TagNumber(28)
+  DeadlineExtension get deadlineExtension => $_getN(21);
+  @$pb.TagNumber(28)
+  set deadlineExtension(DeadlineExtension v) { setField(28, v); }
+  @$pb.TagNumber(28)
+  $core.bool hasDeadlineExtension() => $_has(21);
+  @$pb.TagNumber(28)
+  void clearDeadlineExtension() => clearField(28);
+  @$pb.TagNumber(28)
+  DeadlineExtension ensureDeadlineExtension() => $_ensure(21);
+
+  /// interim reply, for InterimReply
+  @$pb.TagNumber(29)
+  InterimReply get interimReply => $_getN(22);
+  @$pb.TagNumber(29)
+  set interimReply(InterimReply v) { setField(29, v); }
+  @$pb.TagNumber(29)
+  $core.bool hasInterimReply() => $_has(22);
+  @$pb.TagNumber(29)
+  void clearInterimReply() => clearField(29);
+  @$pb.TagNumber(29)
+  InterimReply ensureInterimReply() => $_ensure(22);
+
+  /// reminder of an unanswered request
+  @$pb.TagNumber(30)
+  Reminder get reminder => $_getN(23);
+  @$pb.TagNumber(30)
+  set reminder(Reminder v) { setField(30, v); }
+  @$pb.TagNumber(30)
+  $core.bool hasReminder() => $_has(23);
+  @$pb.TagNumber(30)
+  void clearReminder() => clearField(30);
+  @$pb.TagNumber(30)
+  Reminder ensureReminder() => $_ensure(23);
 }
 
 class SrvAgentAssistApi {
@@ -2731,6 +4627,18 @@ class SrvAgentAssistApi {
   $async.Future<McpClientInfoResponse> sendMcpClientInfo($pb.ClientContext? ctx, McpClientInfoRequest request) =>
     _client.invoke<McpClientInfoResponse>(ctx, 'SrvAgentAssist', 'SendMcpClientInfo', request, McpClientInfoResponse())
   ;
+  $async.Future<SubmitRequestResponse> submitRequest($pb.ClientContext? ctx, SubmitRequestRequest request) =>
+    _client.invoke<SubmitRequestResponse>(ctx, 'SrvAgentAssist', 'SubmitRequest', request, SubmitRequestResponse())
+  ;
+  $async.Future<AwaitResultResponse> awaitResult($pb.ClientContext? ctx, AwaitResultRequest request) =>
+    _client.invoke<AwaitResultResponse>(ctx, 'SrvAgentAssist', 'AwaitResult', request, AwaitResultResponse())
+  ;
+  $async.Future<CancelRequestResponse> cancelRequest($pb.ClientContext? ctx, CancelRequestRequest request) =>
+    _client.invoke<CancelRequestResponse>(ctx, 'SrvAgentAssist', 'CancelRequest', request, CancelRequestResponse())
+  ;
+  $async.Future<RequestEvent> watchRequest($pb.ClientContext? ctx, WatchRequestRequest request) =>
+    _client.invoke<RequestEvent>(ctx, 'SrvAgentAssist', 'WatchRequest', request, RequestEvent())
+  ;
 }
 
 
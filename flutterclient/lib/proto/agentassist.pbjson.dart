@@ -112,6 +112,8 @@ const McpAskQuestionRequest$json = {
     {'1': 'AgentName', '3': 4, '4': 1, '5': 9, '10': 'AgentName'},
     {'1': 'ReasoningModelName', '3': 5, '4': 1, '5': 9, '10': 'ReasoningModelName'},
     {'1': 'McpClientName', '3': 6, '4': 1, '5': 9, '10': 'McpClientName'},
+    {'1': 'McpSessionID', '3': 7, '4': 1, '5': 9, '10': 'McpSessionID'},
+    {'1': 'McpClientInfo', '3': 8, '4': 1, '5': 11, '6': '.agentassistproto.McpClientInfoData', '10': 'McpClientInfo'},
   ],
 };
 
@@ -121,7 +123,9 @@ final $typed_data.Uint8List mcpAskQuestionRequestDescriptor = $convert.base64Dec
     'VjdERpcmVjdG9yeRIaCghRdWVzdGlvbhgCIAEoCVIIUXVlc3Rpb24SGAoHVGltZW91dBgDIAEo'
     'BVIHVGltZW91dBIcCglBZ2VudE5hbWUYBCABKAlSCUFnZW50TmFtZRIuChJSZWFzb25pbmdNb2'
     'RlbE5hbWUYBSABKAlSElJlYXNvbmluZ01vZGVsTmFtZRIkCg1NY3BDbGllbnROYW1lGAYgASgJ'
-    'Ug1NY3BDbGllbnROYW1l');
+    'Ug1NY3BDbGllbnROYW1lEiIKDE1jcFNlc3Npb25JRBgHIAEoCVIMTWNwU2Vzc2lvbklEEkkKDU'
+    '1jcENsaWVudEluZm8YCCABKAsyIy5hZ2VudGFzc2lzdHByb3RvLk1jcENsaWVudEluZm9EYXRh'
+    'Ug1NY3BDbGllbnRJbmZv');
 
 @$core.Deprecated('Use askQuestionRequestDescriptor instead')
 const AskQuestionRequest$json = {
@@ -180,6 +184,10 @@ const McpWorkReportRequest$json = {
     {'1': 'AgentName', '3': 4, '4': 1, '5': 9, '10': 'AgentName'},
     {'1': 'ReasoningModelName', '3': 5, '4': 1, '5': 9, '10': 'ReasoningModelName'},
     {'1': 'McpClientName', '3': 6, '4': 1, '5': 9, '10': 'McpClientName'},
+    {'1': 'McpSessionID', '3': 7, '4': 1, '5': 9, '10': 'McpSessionID'},
+    {'1': 'McpClientInfo', '3': 8, '4': 1, '5': 11, '6': '.agentassistproto.McpClientInfoData', '10': 'McpClientInfo'},
+    {'1': 'RequiredApprovals', '3': 9, '4': 1, '5': 5, '10': 'RequiredApprovals'},
+    {'1': 'Approvers', '3': 10, '4': 3, '5': 9, '10': 'Approvers'},
   ],
 };
 
@@ -189,7 +197,10 @@ final $typed_data.Uint8List mcpWorkReportRequestDescriptor = $convert.base64Deco
     'N0RGlyZWN0b3J5EhgKB1N1bW1hcnkYAiABKAlSB1N1bW1hcnkSGAoHVGltZW91dBgDIAEoBVIH'
     'VGltZW91dBIcCglBZ2VudE5hbWUYBCABKAlSCUFnZW50TmFtZRIuChJSZWFzb25pbmdNb2RlbE'
     '5hbWUYBSABKAlSElJlYXNvbmluZ01vZGVsTmFtZRIkCg1NY3BDbGllbnROYW1lGAYgASgJUg1N'
-    'Y3BDbGllbnROYW1l');
+    'Y3BDbGllbnROYW1lEiIKDE1jcFNlc3Npb25JRBgHIAEoCVIMTWNwU2Vzc2lvbklEEkkKDU1jcE'
+    'NsaWVudEluZm8YCCABKAsyIy5hZ2VudGFzc2lzdHByb3RvLk1jcENsaWVudEluZm9EYXRhUg1N'
+    'Y3BDbGllbnRJbmZvEiwKEVJlcXVpcmVkQXBwcm92YWxzGAkgASgFUhFSZXF1aXJlZEFwcHJvdm'
+    'FscxIcCglBcHByb3ZlcnMYCiADKAlSCUFwcHJvdmVycw==');
 
 @$core.Deprecated('Use workReportRequestDescriptor instead')
 const WorkReportRequest$json = {
@@ -216,6 +227,7 @@ const WorkReportResponse$json = {
     {'1': 'IsError', '3': 2, '4': 1, '5': 8, '10': 'IsError'},
     {'1': 'Meta', '3': 3, '4': 3, '5': 11, '6': '.agentassistproto.WorkReportResponse.MetaEntry', '10': 'Meta'},
     {'1': 'contents', '3': 4, '4': 3, '5': 11, '6': '.agentassistproto.McpResultContent', '10': 'contents'},
+    {'1': 'Decision', '3': 5, '4': 1, '5': 11, '6': '.agentassistproto.WorkReportDecision', '10': 'Decision'},
   ],
   '3': [WorkReportResponse_MetaEntry$json],
 };
@@ -235,8 +247,38 @@ final $typed_data.Uint8List workReportResponseDescriptor = $convert.base64Decode
     'ChJXb3JrUmVwb3J0UmVzcG9uc2USDgoCSUQYASABKAlSAklEEhgKB0lzRXJyb3IYAiABKAhSB0'
     'lzRXJyb3ISQgoETWV0YRgDIAMoCzIuLmFnZW50YXNzaXN0cHJvdG8uV29ya1JlcG9ydFJlc3Bv'
     'bnNlLk1ldGFFbnRyeVIETWV0YRI+Cghjb250ZW50cxgEIAMoCzIiLmFnZW50YXNzaXN0cHJvdG'
-    '8uTWNwUmVzdWx0Q29udGVudFIIY29udGVudHMaNwoJTWV0YUVudHJ5EhAKA2tleRgBIAEoCVID'
-    'a2V5EhQKBXZhbHVlGAIgASgJUgV2YWx1ZToCOAE=');
+    '8uTWNwUmVzdWx0Q29udGVudFIIY29udGVudHMSQAoIRGVjaXNpb24YBSABKAsyJC5hZ2VudGFz'
+    'c2lzdHByb3RvLldvcmtSZXBvcnREZWNpc2lvblIIRGVjaXNpb24aNwoJTWV0YUVudHJ5EhAKA2'
+    'tleRgBIAEoCVIDa2V5EhQKBXZhbHVlGAIgASgJUgV2YWx1ZToCOAE=');
+
+@$core.Deprecated('Use workReportCommentDescriptor instead')
+const WorkReportComment$json = {
+  '1': 'WorkReportComment',
+  '2': [
+    {'1': 'Item', '3': 1, '4': 1, '5': 9, '10': 'Item'},
+    {'1': 'Comment', '3': 2, '4': 1, '5': 9, '10': 'Comment'},
+  ],
+};
+
+/// Descriptor for `WorkReportComment`. Decode as a `google.protobuf.DescriptorProto`.
+final $typed_data.Uint8List workReportCommentDescriptor = $convert.base64Decode(
+    'ChFXb3JrUmVwb3J0Q29tbWVudBISCgRJdGVtGAEgASgJUgRJdGVtEhgKB0NvbW1lbnQYAiABKA'
+    'lSB0NvbW1lbnQ=');
+
+@$core.Deprecated('Use workReportDecisionDescriptor instead')
+const WorkReportDecision$json = {
+  '1': 'WorkReportDecision',
+  '2': [
+    {'1': 'Decision', '3': 1, '4': 1, '5': 9, '10': 'Decision'},
+    {'1': 'Comments', '3': 2, '4': 3, '5': 11, '6': '.agentassistproto.WorkReportComment', '10': 'Comments'},
+  ],
+};
+
+/// Descriptor for `WorkReportDecision`. Decode as a `google.protobuf.DescriptorProto`.
+final $typed_data.Uint8List workReportDecisionDescriptor = $convert.base64Decode(
+    'ChJXb3JrUmVwb3J0RGVjaXNpb24SGgoIRGVjaXNpb24YASABKAlSCERlY2lzaW9uEj8KCENvbW'
+    '1lbnRzGAIgAygLMiMuYWdlbnRhc3Npc3Rwcm90by5Xb3JrUmVwb3J0Q29tbWVudFIIQ29tbWVu'
+    'dHM=');
 
 @$core.Deprecated('Use mcpClientInfoDataDescriptor instead')
 const McpClientInfoData$json = {
@@ -264,6 +306,7 @@ const McpClientInfoRequest$json = {
     {'1': 'UserToken', '3': 2, '4': 1, '5': 9, '10': 'UserToken'},
     {'1': 'Request', '3': 3, '4': 1, '5': 11, '6': '.agentassistproto.McpClientInfoData', '10': 'Request'},
     {'1': 'Timestamp', '3': 4, '4': 1, '5': 3, '10': 'Timestamp'},
+    {'1': 'McpSessionID', '3': 5, '4': 1, '5': 9, '10': 'McpSessionID'},
   ],
 };
 
@@ -271,7 +314,8 @@ const McpClientInfoRequest$json = {
 final $typed_data.Uint8List mcpClientInfoRequestDescriptor = $convert.base64Decode(
     'ChRNY3BDbGllbnRJbmZvUmVxdWVzdBIOCgJJRBgBIAEoCVICSUQSHAoJVXNlclRva2VuGAIgAS'
     'gJUglVc2VyVG9rZW4SPQoHUmVxdWVzdBgDIAEoCzIjLmFnZW50YXNzaXN0cHJvdG8uTWNwQ2xp'
-    'ZW50SW5mb0RhdGFSB1JlcXVlc3QSHAoJVGltZXN0YW1wGAQgASgDUglUaW1lc3RhbXA=');
+    'ZW50SW5mb0RhdGFSB1JlcXVlc3QSHAoJVGltZXN0YW1wGAQgASgDUglUaW1lc3RhbXASIgoMTW'
+    'NwU2Vzc2lvbklEGAUgASgJUgxNY3BTZXNzaW9uSUQ=');
 
 @$core.Deprecated('Use mcpClientInfoResponseDescriptor instead')
 const McpClientInfoResponse$json = {
@@ -285,6 +329,152 @@ const McpClientInfoResponse$json = {
 final $typed_data.Uint8List mcpClientInfoResponseDescriptor = $convert.base64Decode(
     'ChVNY3BDbGllbnRJbmZvUmVzcG9uc2USGAoHU3VjY2VzcxgBIAEoCFIHU3VjY2Vzcw==');
 
+@$core.Deprecated('Use submitRequestRequestDescriptor instead')
+const SubmitRequestRequest$json = {
+  '1': 'SubmitRequestRequest',
+  '2': [
+    {'1': 'AskQuestionRequest', '3': 1, '4': 1, '5': 11, '6': '.agentassistproto.AskQuestionRequest', '10': 'AskQuestionRequest'},
+    {'1': 'WorkReportRequest', '3': 2, '4': 1, '5': 11, '6': '.agentassistproto.WorkReportRequest', '10': 'WorkReportRequest'},
+  ],
+};
+
+/// Descriptor for `SubmitRequestRequest`. Decode as a `google.protobuf.DescriptorProto`.
+final $typed_data.Uint8List submitRequestRequestDescriptor = $convert.base64Decode(
+    'ChRTdWJtaXRSZXF1ZXN0UmVxdWVzdBJUChJBc2tRdWVzdGlvblJlcXVlc3QYASABKAsyJC5hZ2'
+    'VudGFzc2lzdHByb3RvLkFza1F1ZXN0aW9uUmVxdWVzdFISQXNrUXVlc3Rpb25SZXF1ZXN0ElEK'
+    'EVdvcmtSZXBvcnRSZXF1ZXN0GAIgASgLMiMuYWdlbnRhc3Npc3Rwcm90by5Xb3JrUmVwb3J0Um'
+    'VxdWVzdFIRV29ya1JlcG9ydFJlcXVlc3Q=');
+
+@$core.Deprecated('Use submitRequestResponseDescriptor instead')
+const SubmitRequestResponse$json = {
+  '1': 'SubmitRequestResponse',
+  '2': [
+    {'1': 'ID', '3': 1, '4': 1, '5': 9, '10': 'ID'},
+    {'1': 'Success', '3': 2, '4': 1, '5': 8, '10': 'Success'},
+    {'1': 'Resumed', '3': 3, '4': 1, '5': 8, '10': 'Resumed'},
+    {'1': 'Deadline', '3': 4, '4': 1, '5': 3, '10': 'Deadline'},
+    {'1': 'Meta', '3': 5, '4': 3, '5': 11, '6': '.agentassistproto.SubmitRequestResponse.MetaEntry', '10': 'Meta'},
+  ],
+  '3': [SubmitRequestResponse_MetaEntry$json],
+};
+
+@$core.Deprecated('Use submitRequestResponseDescriptor instead')
+const SubmitRequestResponse_MetaEntry$json = {
+  '1': 'MetaEntry',
+  '2': [
+    {'1': 'key', '3': 1, '4': 1, '5': 9, '10': 'key'},
+    {'1': 'value', '3': 2, '4': 1, '5': 9, '10': 'value'},
+  ],
+  '7': {'7': true},
+};
+
+/// Descriptor for `SubmitRequestResponse`. Decode as a `google.protobuf.DescriptorProto`.
+final $typed_data.Uint8List submitRequestResponseDescriptor = $convert.base64Decode(
+    'ChVTdWJtaXRSZXF1ZXN0UmVzcG9uc2USDgoCSUQYASABKAlSAklEEhgKB1N1Y2Nlc3MYAiABKA'
+    'hSB1N1Y2Nlc3MSGAoHUmVzdW1lZBgDIAEoCFIHUmVzdW1lZBIaCghEZWFkbGluZRgEIAEoA1II'
+    'RGVhZGxpbmUSRQoETWV0YRgFIAMoCzIxLmFnZW50YXNzaXN0cHJvdG8uU3VibWl0UmVxdWVzdF'
+    'Jlc3BvbnNlLk1ldGFFbnRyeVIETWV0YRo3CglNZXRhRW50cnkSEAoDa2V5GAEgASgJUgNrZXkS'
+    'FAoFdmFsdWUYAiABKAlSBXZhbHVlOgI4AQ==');
+
+@$core.Deprecated('Use awaitResultRequestDescriptor instead')
+const AwaitResultRequest$json = {
+  '1': 'AwaitResultRequest',
+  '2': [
+    {'1': 'ID', '3': 1, '4': 1, '5': 9, '10': 'ID'},
+    {'1': 'UserToken', '3': 2, '4': 1, '5': 9, '10': 'UserToken'},
+    {'1': 'WaitSeconds', '3': 3, '4': 1, '5': 5, '10': 'WaitSeconds'},
+  ],
+};
+
+/// Descriptor for `AwaitResultRequest`. Decode as a `google.protobuf.DescriptorProto`.
+final $typed_data.Uint8List awaitResultRequestDescriptor = $convert.base64Decode(
+    'ChJBd2FpdFJlc3VsdFJlcXVlc3QSDgoCSUQYASABKAlSAklEEhwKCVVzZXJUb2tlbhgCIAEoCV'
+    'IJVXNlclRva2VuEiAKC1dhaXRTZWNvbmRzGAMgASgFUgtXYWl0U2Vjb25kcw==');
+
+@$core.Deprecated('Use awaitResultResponseDescriptor instead')
+const AwaitResultResponse$json = {
+  '1': 'AwaitResultResponse',
+  '2': [
+    {'1': 'ID', '3': 1, '4': 1, '5': 9, '10': 'ID'},
+    {'1': 'Done', '3': 2, '4': 1, '5': 8, '10': 'Done'},
+    {'1': 'NotFound', '3': 3, '4': 1, '5': 8, '10': 'NotFound'},
+    {'1': 'AskQuestionResponse', '3': 4, '4': 1, '5': 11, '6': '.agentassistproto.AskQuestionResponse', '10': 'AskQuestionResponse'},
+    {'1': 'WorkReportResponse', '3': 5, '4': 1, '5': 11, '6': '.agentassistproto.WorkReportResponse', '10': 'WorkReportResponse'},
+    {'1': 'Deadline', '3': 6, '4': 1, '5': 3, '10': 'Deadline'},
+  ],
+};
+
+/// Descriptor for `AwaitResultResponse`. Decode as a `google.protobuf.DescriptorProto`.
+final $typed_data.Uint8List awaitResultResponseDescriptor = $convert.base64Decode(
+    'ChNBd2FpdFJlc3VsdFJlc3BvbnNlEg4KAklEGAEgASgJUgJJRBISCgREb25lGAIgASgIUgREb2'
+    '5lEhoKCE5vdEZvdW5kGAMgASgIUghOb3RGb3VuZBJXChNBc2tRdWVzdGlvblJlc3BvbnNlGAQg'
+    'ASgLMiUuYWdlbnRhc3Npc3Rwcm90by5Bc2tRdWVzdGlvblJlc3BvbnNlUhNBc2tRdWVzdGlvbl'
+    'Jlc3BvbnNlElQKEldvcmtSZXBvcnRSZXNwb25zZRgFIAEoCzIkLmFnZW50YXNzaXN0cHJvdG8u'
+    'V29ya1JlcG9ydFJlc3BvbnNlUhJXb3JrUmVwb3J0UmVzcG9uc2USGgoIRGVhZGxpbmUYBiABKA'
+    'NSCERlYWRsaW5l');
+
+@$core.Deprecated('Use cancelRequestRequestDescriptor instead')
+const CancelRequestRequest$json = {
+  '1': 'CancelRequestRequest',
+  '2': [
+    {'1': 'ID', '3': 1, '4': 1, '5': 9, '10': 'ID'},
+    {'1': 'UserToken', '3': 2, '4': 1, '5': 9, '10': 'UserToken'},
+    {'1': 'Reason', '3': 3, '4': 1, '5': 9, '10': 'Reason'},
+  ],
+};
+
+/// Descriptor for `CancelRequestRequest`. Decode as a `google.protobuf.DescriptorProto`.
+final $typed_data.Uint8List cancelRequestRequestDescriptor = $convert.base64Decode(
+    'ChRDYW5jZWxSZXF1ZXN0UmVxdWVzdBIOCgJJRBgBIAEoCVICSUQSHAoJVXNlclRva2VuGAIgAS'
+    'gJUglVc2VyVG9rZW4SFgoGUmVhc29uGAMgASgJUgZSZWFzb24=');
+
+@$core.Deprecated('Use cancelRequestResponseDescriptor instead')
+const CancelRequestResponse$json = {
+  '1': 'CancelRequestResponse',
+  '2': [
+    {'1': 'Success', '3': 1, '4': 1, '5': 8, '10': 'Success'},
+  ],
+};
+
+/// Descriptor for `CancelRequestResponse`. Decode as a `google.protobuf.DescriptorProto`.
+final $typed_data.Uint8List cancelRequestResponseDescriptor = $convert.base64Decode(
+    'ChVDYW5jZWxSZXF1ZXN0UmVzcG9uc2USGAoHU3VjY2VzcxgBIAEoCFIHU3VjY2Vzcw==');
+
+@$core.Deprecated('Use watchRequestRequestDescriptor instead')
+const WatchRequestRequest$json = {
+  '1': 'WatchRequestRequest',
+  '2': [
+    {'1': 'ID', '3': 1, '4': 1, '5': 9, '10': 'ID'},
+    {'1': 'UserToken', '3': 2, '4': 1, '5': 9, '10': 'UserToken'},
+  ],
+};
+
+/// Descriptor for `WatchRequestRequest`. Decode as a `google.protobuf.DescriptorProto`.
+final $typed_data.Uint8List watchRequestRequestDescriptor = $convert.base64Decode(
+    'ChNXYXRjaFJlcXVlc3RSZXF1ZXN0Eg4KAklEGAEgASgJUgJJRBIcCglVc2VyVG9rZW4YAiABKA'
+    'lSCVVzZXJUb2tlbg==');
+
+@$core.Deprecated('Use requestEventDescriptor instead')
+const RequestEvent$json = {
+  '1': 'RequestEvent',
+  '2': [
+    {'1': 'ID', '3': 1, '4': 1, '5': 9, '10': 'ID'},
+    {'1': 'Type', '3': 2, '4': 1, '5': 9, '10': 'Type'},
+    {'1': 'ClientCount', '3': 3, '4': 1, '5': 5, '10': 'ClientCount'},
+    {'1': 'Nickname', '3': 4, '4': 1, '5': 9, '10': 'Nickname'},
+    {'1': 'Message', '3': 5, '4': 1, '5': 9, '10': 'Message'},
+    {'1': 'Timestamp', '3': 6, '4': 1, '5': 3, '10': 'Timestamp'},
+    {'1': 'Final', '3': 7, '4': 1, '5': 8, '10': 'Final'},
+  ],
+};
+
+/// Descriptor for `RequestEvent`. Decode as a `google.protobuf.DescriptorProto`.
+final $typed_data.Uint8List requestEventDescriptor = $convert.base64Decode(
+    'CgxSZXF1ZXN0RXZlbnQSDgoCSUQYASABKAlSAklEEhIKBFR5cGUYAiABKAlSBFR5cGUSIAoLQ2'
+    'xpZW50Q291bnQYAyABKAVSC0NsaWVudENvdW50EhoKCE5pY2tuYW1lGAQgASgJUghOaWNrbmFt'
+    'ZRIYCgdNZXNzYWdlGAUgASgJUgdNZXNzYWdlEhwKCVRpbWVzdGFtcBgGIAEoA1IJVGltZXN0YW'
+    '1wEhQKBUZpbmFsGAcgASgIUgVGaW5hbA==');
+
 @$core.Deprecated('Use checkMessageValidityRequestDescriptor instead')
 const CheckMessageValidityRequest$json = {
   '1': 'CheckMessageValidityRequest',
@@ -346,6 +536,10 @@ const PendingMessage$json = {
     {'1': 'work_report_request', '3': 3, '4': 1, '5': 11, '6': '.agentassistproto.WorkReportRequest', '10': 'workReportRequest'},
     {'1': 'created_at', '3': 4, '4': 1, '5': 3, '10': 'createdAt'},
     {'1': 'timeout', '3': 5, '4': 1, '5': 5, '10': 'timeout'},
+    {'1': 'deadline', '3': 6, '4': 1, '5': 3, '10': 'deadline'},
+    {'1': 'delivery_count', '3': 7, '4': 1, '5': 5, '10': 'deliveryCount'},
+    {'1': 'first_viewed_at', '3': 8, '4': 1, '5': 3, '10': 'firstViewedAt'},
+    {'1': 'approval_progress', '3': 9, '4': 1, '5': 11, '6': '.agentassistproto.ApprovalProgress', '10': 'approvalProgress'},
   ],
 };
 
@@ -356,7 +550,10 @@ final $typed_data.Uint8List pendingMessageDescriptor = $convert.base64Decode(
     'blJlcXVlc3RSEmFza1F1ZXN0aW9uUmVxdWVzdBJTChN3b3JrX3JlcG9ydF9yZXF1ZXN0GAMgAS'
     'gLMiMuYWdlbnRhc3Npc3Rwcm90by5Xb3JrUmVwb3J0UmVxdWVzdFIRd29ya1JlcG9ydFJlcXVl'
     'c3QSHQoKY3JlYXRlZF9hdBgEIAEoA1IJY3JlYXRlZEF0EhgKB3RpbWVvdXQYBSABKAVSB3RpbW'
-    'VvdXQ=');
+    'VvdXQSGgoIZGVhZGxpbmUYBiABKANSCGRlYWRsaW5lEiUKDmRlbGl2ZXJ5X2NvdW50GAcgASgF'
+    'Ug1kZWxpdmVyeUNvdW50EiYKD2ZpcnN0X3ZpZXdlZF9hdBgIIAEoA1INZmlyc3RWaWV3ZWRBdB'
+    'JPChFhcHByb3ZhbF9wcm9ncmVzcxgJIAEoCzIiLmFnZW50YXNzaXN0cHJvdG8uQXBwcm92YWxQ'
+    'cm9ncmVzc1IQYXBwcm92YWxQcm9ncmVzcw==');
 
 @$core.Deprecated('Use getPendingMessagesResponseDescriptor instead')
 const GetPendingMessagesResponse$json = {
@@ -511,6 +708,124 @@ final $typed_data.Uint8List userConnectionStatusNotificationDescriptor = $conver
     'Rhc3Npc3Rwcm90by5PbmxpbmVVc2VyUgR1c2VyEhYKBnN0YXR1cxgCIAEoCVIGc3RhdHVzEhwK'
     'CXRpbWVzdGFtcBgDIAEoA1IJdGltZXN0YW1w');
 
+@$core.Deprecated('Use requestClaimDescriptor instead')
+const RequestClaim$json = {
+  '1': 'RequestClaim',
+  '2': [
+    {'1': 'request_id', '3': 1, '4': 1, '5': 9, '10': 'requestId'},
+    {'1': 'client_id', '3': 2, '4': 1, '5': 9, '10': 'clientId'},
+    {'1': 'nickname', '3': 3, '4': 1, '5': 9, '10': 'nickname'},
+    {'1': 'expires_at', '3': 4, '4': 1, '5': 3, '10': 'expiresAt'},
+    {'1': 'success', '3': 5, '4': 1, '5': 8, '10': 'success'},
+    {'1': 'error_message', '3': 6, '4': 1, '5': 9, '10': 'errorMessage'},
+  ],
+};
+
+/// Descriptor for `RequestClaim`. Decode as a `google.protobuf.DescriptorProto`.
+final $typed_data.Uint8List requestClaimDescriptor = $convert.base64Decode(
+    'CgxSZXF1ZXN0Q2xhaW0SHQoKcmVxdWVzdF9pZBgBIAEoCVIJcmVxdWVzdElkEhsKCWNsaWVudF'
+    '9pZBgCIAEoCVIIY2xpZW50SWQSGgoIbmlja25hbWUYAyABKAlSCG5pY2tuYW1lEh0KCmV4cGly'
+    'ZXNfYXQYBCABKANSCWV4cGlyZXNBdBIYCgdzdWNjZXNzGAUgASgIUgdzdWNjZXNzEiMKDWVycm'
+    '9yX21lc3NhZ2UYBiABKAlSDGVycm9yTWVzc2FnZQ==');
+
+@$core.Deprecated('Use replyAckDescriptor instead')
+const ReplyAck$json = {
+  '1': 'ReplyAck',
+  '2': [
+    {'1': 'request_id', '3': 1, '4': 1, '5': 9, '10': 'requestId'},
+    {'1': 'status', '3': 2, '4': 1, '5': 9, '10': 'status'},
+    {'1': 'delivered_at', '3': 3, '4': 1, '5': 3, '10': 'deliveredAt'},
+    {'1': 'replied_by', '3': 4, '4': 1, '5': 9, '10': 'repliedBy'},
+    {'1': 'message', '3': 5, '4': 1, '5': 9, '10': 'message'},
+  ],
+};
+
+/// Descriptor for `ReplyAck`. Decode as a `google.protobuf.DescriptorProto`.
+final $typed_data.Uint8List replyAckDescriptor = $convert.base64Decode(
+    'CghSZXBseUFjaxIdCgpyZXF1ZXN0X2lkGAEgASgJUglyZXF1ZXN0SWQSFgoGc3RhdHVzGAIgAS'
+    'gJUgZzdGF0dXMSIQoMZGVsaXZlcmVkX2F0GAMgASgDUgtkZWxpdmVyZWRBdBIdCgpyZXBsaWVk'
+    'X2J5GAQgASgJUglyZXBsaWVkQnkSGAoHbWVzc2FnZRgFIAEoCVIHbWVzc2FnZQ==');
+
+@$core.Deprecated('Use approvalProgressDescriptor instead')
+const ApprovalProgress$json = {
+  '1': 'ApprovalProgress',
+  '2': [
+    {'1': 'request_id', '3': 1, '4': 1, '5': 9, '10': 'requestId'},
+    {'1': 'required_approvals', '3': 2, '4': 1, '5': 5, '10': 'requiredApprovals'},
+    {'1': 'approvers', '3': 3, '4': 3, '5': 9, '10': 'approvers'},
+    {'1': 'approved_by', '3': 4, '4': 3, '5': 9, '10': 'approvedBy'},
+    {'1': 'pending_approvers', '3': 5, '4': 3, '5': 9, '10': 'pendingApprovers'},
+  ],
+};
+
+/// Descriptor for `ApprovalProgress`. Decode as a `google.protobuf.DescriptorProto`.
+final $typed_data.Uint8List approvalProgressDescriptor = $convert.base64Decode(
+    'ChBBcHByb3ZhbFByb2dyZXNzEh0KCnJlcXVlc3RfaWQYASABKAlSCXJlcXVlc3RJZBItChJyZX'
+    'F1aXJlZF9hcHByb3ZhbHMYAiABKAVSEXJlcXVpcmVkQXBwcm92YWxzEhwKCWFwcHJvdmVycxgD'
+    'IAMoCVIJYXBwcm92ZXJzEh8KC2FwcHJvdmVkX2J5GAQgAygJUgphcHByb3ZlZEJ5EisKEXBlbm'
+    'RpbmdfYXBwcm92ZXJzGAUgAygJUhBwZW5kaW5nQXBwcm92ZXJz');
+
+@$core.Deprecated('Use deadlineExtensionDescriptor instead')
+const DeadlineExtension$json = {
+  '1': 'DeadlineExtension',
+  '2': [
+    {'1': 'request_id', '3': 1, '4': 1, '5': 9, '10': 'requestId'},
+    {'1': 'extend_seconds', '3': 2, '4': 1, '5': 5, '10': 'extendSeconds'},
+    {'1': 'deadline', '3': 3, '4': 1, '5': 3, '10': 'deadline'},
+    {'1': 'nickname', '3': 4, '4': 1, '5': 9, '10': 'nickname'},
+    {'1': 'success', '3': 5, '4': 1, '5': 8, '10': 'success'},
+    {'1': 'error_message', '3': 6, '4': 1, '5': 9, '10': 'errorMessage'},
+  ],
+};
+
+/// Descriptor for `DeadlineExtension`. Decode as a `google.protobuf.DescriptorProto`.
+final $typed_data.Uint8List deadlineExtensionDescriptor = $convert.base64Decode(
+    'ChFEZWFkbGluZUV4dGVuc2lvbhIdCgpyZXF1ZXN0X2lkGAEgASgJUglyZXF1ZXN0SWQSJQoOZX'
+    'h0ZW5kX3NlY29uZHMYAiABKAVSDWV4dGVuZFNlY29uZHMSGgoIZGVhZGxpbmUYAyABKANSCGRl'
+    'YWRsaW5lEhoKCG5pY2tuYW1lGAQgASgJUghuaWNrbmFtZRIYCgdzdWNjZXNzGAUgASgIUgdzdW'
+    'NjZXNzEiMKDWVycm9yX21lc3NhZ2UYBiABKAlSDGVycm9yTWVzc2FnZQ==');
+
+@$core.Deprecated('Use interimReplyDescriptor instead')
+const InterimReply$json = {
+  '1': 'InterimReply',
+  '2': [
+    {'1': 'request_id', '3': 1, '4': 1, '5': 9, '10': 'requestId'},
+    {'1': 'text', '3': 2, '4': 1, '5': 9, '10': 'text'},
+    {'1': 'extend_seconds', '3': 3, '4': 1, '5': 5, '10': 'extendSeconds'},
+    {'1': 'nickname', '3': 4, '4': 1, '5': 9, '10': 'nickname'},
+    {'1': 'deadline', '3': 5, '4': 1, '5': 3, '10': 'deadline'},
+    {'1': 'success', '3': 6, '4': 1, '5': 8, '10': 'success'},
+    {'1': 'error_message', '3': 7, '4': 1, '5': 9, '10': 'errorMessage'},
+  ],
+};
+
+/// Descriptor for `InterimReply`. Decode as a `google.protobuf.DescriptorProto`.
+final $typed_data.Uint8List interimReplyDescriptor = $convert.base64Decode(
+    'CgxJbnRlcmltUmVwbHkSHQoKcmVxdWVzdF9pZBgBIAEoCVIJcmVxdWVzdElkEhIKBHRleHQYAi'
+    'ABKAlSBHRleHQSJQoOZXh0ZW5kX3NlY29uZHMYAyABKAVSDWV4dGVuZFNlY29uZHMSGgoIbmlj'
+    'a25hbWUYBCABKAlSCG5pY2tuYW1lEhoKCGRlYWRsaW5lGAUgASgDUghkZWFkbGluZRIYCgdzdW'
+    'NjZXNzGAYgASgIUgdzdWNjZXNzEiMKDWVycm9yX21lc3NhZ2UYByABKAlSDGVycm9yTWVzc2Fn'
+    'ZQ==');
+
+@$core.Deprecated('Use reminderDescriptor instead')
+const Reminder$json = {
+  '1': 'Reminder',
+  '2': [
+    {'1': 'request_id', '3': 1, '4': 1, '5': 9, '10': 'requestId'},
+    {'1': 'number', '3': 2, '4': 1, '5': 5, '10': 'number'},
+    {'1': 'unanswered_seconds', '3': 3, '4': 1, '5': 3, '10': 'unansweredSeconds'},
+    {'1': 'escalated_to', '3': 4, '4': 3, '5': 9, '10': 'escalatedTo'},
+    {'1': 'message', '3': 5, '4': 1, '5': 9, '10': 'message'},
+  ],
+};
+
+/// Descriptor for `Reminder`. Decode as a `google.protobuf.DescriptorProto`.
+final $typed_data.Uint8List reminderDescriptor = $convert.base64Decode(
+    'CghSZW1pbmRlchIdCgpyZXF1ZXN0X2lkGAEgASgJUglyZXF1ZXN0SWQSFgoGbnVtYmVyGAIgAS'
+    'gFUgZudW1iZXISLQoSdW5hbnN3ZXJlZF9zZWNvbmRzGAMgASgDUhF1bmFuc3dlcmVkU2Vjb25k'
+    'cxIhCgxlc2NhbGF0ZWRfdG8YBCADKAlSC2VzY2FsYXRlZFRvEhgKB21lc3NhZ2UYBSABKAlSB2'
+    '1lc3NhZ2U=');
+
 @$core.Deprecated('Use websocketMessageDescriptor instead')
 const WebsocketMessage$json = {
   '1': 'WebsocketMessage',
@@ -531,6 +846,12 @@ const WebsocketMessage$json = {
     {'1': 'ChatMessageNotification', '3': 22, '4': 1, '5': 11, '6': '.agentassistproto.ChatMessageNotification', '10': 'ChatMessageNotification'},
     {'1': 'UserLoginResponse', '3': 23, '4': 1, '5': 11, '6': '.agentassistproto.UserLoginResponse', '10': 'UserLoginResponse'},
     {'1': 'UserConnectionStatusNotification', '3': 24, '4': 1, '5': 11, '6': '.agentassistproto.UserConnectionStatusNotification', '10': 'UserConnectionStatusNotification'},
+    {'1': 'RequestClaim', '3': 25, '4': 1, '5': 11, '6': '.agentassistproto.RequestClaim', '10': 'RequestClaim'},
+    {'1': 'ReplyAck', '3': 26, '4': 1, '5': 11, '6': '.agentassistproto.ReplyAck', '10': 'ReplyAck'},
+    {'1': 'ApprovalProgress', '3': 27, '4': 1, '5': 11, '6': '.agentassistproto.ApprovalProgress', '10': 'ApprovalProgress'},
+    {'1': 'DeadlineExtension', '3': 28, '4': 1, '5': 11, '6': '.agentassistproto.DeadlineExtension', '10': 'DeadlineExtension'},
+    {'1': 'InterimReply', '3': 29, '4': 1, '5': 11, '6': '.agentassistproto.InterimReply', '10': 'InterimReply'},
+    {'1': 'Reminder', '3': 30, '4': 1, '5': 11, '6': '.agentassistproto.Reminder', '10': 'Reminder'},
     {'1': 'StrParam', '3': 12, '4': 1, '5': 9, '10': 'StrParam'},
     {'1': 'Nickname', '3': 18, '4': 1, '5': 9, '10': 'Nickname'},
   ],
@@ -565,8 +886,16 @@ final $typed_data.Uint8List websocketMessageDescriptor = $convert.base64Decode(
     'b24SUQoRVXNlckxvZ2luUmVzcG9uc2UYFyABKAsyIy5hZ2VudGFzc2lzdHByb3RvLlVzZXJMb2'
     'dpblJlc3BvbnNlUhFVc2VyTG9naW5SZXNwb25zZRJ+CiBVc2VyQ29ubmVjdGlvblN0YXR1c05v'
     'dGlmaWNhdGlvbhgYIAEoCzIyLmFnZW50YXNzaXN0cHJvdG8uVXNlckNvbm5lY3Rpb25TdGF0dX'
-    'NOb3RpZmljYXRpb25SIFVzZXJDb25uZWN0aW9uU3RhdHVzTm90aWZpY2F0aW9uEhoKCFN0clBh'
-    'cmFtGAwgASgJUghTdHJQYXJhbRIaCghOaWNrbmFtZRgSIAEoCVIITmlja25hbWU=');
+    'NOb3RpZmljYXRpb25SIFVzZXJDb25uZWN0aW9uU3RhdHVzTm90aWZpY2F0aW9uEkIKDFJlcXVl'
+    'c3RDbGFpbRgZIAEoCzIeLmFnZW50YXNzaXN0cHJvdG8uUmVxdWVzdENsYWltUgxSZXF1ZXN0Q2'
+    'xhaW0SNgoIUmVwbHlBY2sYGiABKAsyGi5hZ2VudGFzc2lzdHByb3RvLlJlcGx5QWNrUghSZXBs'
+    'eUFjaxJOChBBcHByb3ZhbFByb2dyZXNzGBsgASgLMiIuYWdlbnRhc3Npc3Rwcm90by5BcHByb3'
+    'ZhbFByb2dyZXNzUhBBcHByb3ZhbFByb2dyZXNzElEKEURlYWRsaW5lRXh0ZW5zaW9uGBwgASgL'
+    'MiMuYWdlbnRhc3Npc3Rwcm90by5EZWFkbGluZUV4dGVuc2lvblIRRGVhZGxpbmVFeHRlbnNpb2'
+    '4SQgoMSW50ZXJpbVJlcGx5GB0gASgLMh4uYWdlbnRhc3Npc3Rwcm90by5JbnRlcmltUmVwbHlS'
+    'DEludGVyaW1SZXBseRI2CghSZW1pbmRlchgeIAEoCzIaLmFnZW50YXNzaXN0cHJvdG8uUmVtaW'
+    '5kZXJSCFJlbWluZGVyEhoKCFN0clBhcmFtGAwgASgJUghTdHJQYXJhbRIaCghOaWNrbmFtZRgS'
+    'IAEoCVIITmlja25hbWU=');
 
 const $core.Map<$core.String, $core.dynamic> SrvAgentAssistServiceBase$json = {
   '1': 'SrvAgentAssist',
@@ -574,6 +903,10 @@ const $core.Map<$core.String, $core.dynamic> SrvAgentAssistServiceBase$json = {
     {'1': 'AskQuestion', '2': '.agentassistproto.AskQuestionRequest', '3': '.agentassistproto.AskQuestionResponse'},
     {'1': 'WorkReport', '2': '.agentassistproto.WorkReportRequest', '3': '.agentassistproto.WorkReportResponse'},
     {'1': 'SendMcpClientInfo', '2': '.agentassistproto.McpClientInfoRequest', '3': '.agentassistproto.McpClientInfoResponse'},
+    {'1': 'SubmitRequest', '2': '.agentassistproto.SubmitRequestRequest', '3': '.agentassistproto.SubmitRequestResponse'},
+    {'1': 'AwaitResult', '2': '.agentassistproto.AwaitResultRequest', '3': '.agentassistproto.AwaitResultResponse'},
+    {'1': 'CancelRequest', '2': '.agentassistproto.CancelRequestRequest', '3': '.agentassistproto.CancelRequestResponse'},
+    {'1': 'WatchRequest', '2': '.agentassistproto.WatchRequestRequest', '3': '.agentassistproto.RequestEvent', '6': true},
   ],
 };
 
@@ -581,6 +914,7 @@ const $core.Map<$core.String, $core.dynamic> SrvAgentAssistServiceBase$json = {
 const $core.Map<$core.String, $core.Map<$core.String, $core.dynamic>> SrvAgentAssistServiceBase$messageJson = {
   '.agentassistproto.AskQuestionRequest': AskQuestionRequest$json,
   '.agentassistproto.McpAskQuestionRequest': McpAskQuestionRequest$json,
+  '.agentassistproto.McpClientInfoData': McpClientInfoData$json,
   '.agentassistproto.AskQuestionResponse': AskQuestionResponse$json,
   '.agentassistproto.AskQuestionResponse.MetaEntry': AskQuestionResponse_MetaEntry$json,
   '.agentassistproto.McpResultContent': McpResultContent$json,
@@ -592,9 +926,19 @@ const $core.Map<$core.String, $core.Map<$core.String, $core.dynamic>> SrvAgentAs
   '.agentassistproto.McpWorkReportRequest': McpWorkReportRequest$json,
   '.agentassistproto.WorkReportResponse': WorkReportResponse$json,
   '.agentassistproto.WorkReportResponse.MetaEntry': WorkReportResponse_MetaEntry$json,
+  '.agentassistproto.WorkReportDecision': WorkReportDecision$json,
+  '.agentassistproto.WorkReportComment': WorkReportComment$json,
   '.agentassistproto.McpClientInfoRequest': McpClientInfoRequest$json,
-  '.agentassistproto.McpClientInfoData': McpClientInfoData$json,
   '.agentassistproto.McpClientInfoResponse': McpClientInfoResponse$json,
+  '.agentassistproto.SubmitRequestRequest': SubmitRequestRequest$json,
+  '.agentassistproto.SubmitRequestResponse': SubmitRequestResponse$json,
+  '.agentassistproto.SubmitRequestResponse.MetaEntry': SubmitRequestResponse_MetaEntry$json,
+  '.agentassistproto.AwaitResultRequest': AwaitResultRequest$json,
+  '.agentassistproto.AwaitResultResponse': AwaitResultResponse$json,
+  '.agentassistproto.CancelRequestRequest': CancelRequestRequest$json,
+  '.agentassistproto.CancelRequestResponse': CancelRequestResponse$json,
+  '.agentassistproto.WatchRequestRequest': WatchRequestRequest$json,
+  '.agentassistproto.RequestEvent': RequestEvent$json,
 };
 
 /// Descriptor for `SrvAgentAssist`. Decode as a `google.protobuf.ServiceDescriptorProto`.
@@ -604,5 +948,12 @@ final $typed_data.Uint8List srvAgentAssistServiceDescriptor = $convert.base64Dec
     'CldvcmtSZXBvcnQSIy5hZ2VudGFzc2lzdHByb3RvLldvcmtSZXBvcnRSZXF1ZXN0GiQuYWdlbn'
     'Rhc3Npc3Rwcm90by5Xb3JrUmVwb3J0UmVzcG9uc2USZAoRU2VuZE1jcENsaWVudEluZm8SJi5h'
     'Z2VudGFzc2lzdHByb3RvLk1jcENsaWVudEluZm9SZXF1ZXN0GicuYWdlbnRhc3Npc3Rwcm90by'
-    '5NY3BDbGllbnRJbmZvUmVzcG9uc2U=');
+    '5NY3BDbGllbnRJbmZvUmVzcG9uc2USYAoNU3VibWl0UmVxdWVzdBImLmFnZW50YXNzaXN0cHJv'
+    'dG8uU3VibWl0UmVxdWVzdFJlcXVlc3QaJy5hZ2VudGFzc2lzdHByb3RvLlN1Ym1pdFJlcXVlc3'
+    'RSZXNwb25zZRJaCgtBd2FpdFJlc3VsdBIkLmFnZW50YXNzaXN0cHJvdG8uQXdhaXRSZXN1bHRS'
+    'ZXF1ZXN0GiUuYWdlbnRhc3Npc3Rwcm90by5Bd2FpdFJlc3VsdFJlc3BvbnNlEmAKDUNhbmNlbF'
+    'JlcXVlc3QSJi5hZ2VudGFzc2lzdHByb3RvLkNhbmNlbFJlcXVlc3RSZXF1ZXN0GicuYWdlbnRh'
+    'c3Npc3Rwcm90by5DYW5jZWxSZXF1ZXN0UmVzcG9uc2USVwoMV2F0Y2hSZXF1ZXN0EiUuYWdlbn'
+    'Rhc3Npc3Rwcm90by5XYXRjaFJlcXVlc3RSZXF1ZXN0Gh4uYWdlbnRhc3Npc3Rwcm90by5SZXF1'
+    'ZXN0RXZlbnQwAQ==');
 
@@ -24,12 +24,20 @@ abstract class SrvAgentAssistServiceBase extends $pb.GeneratedService {
   $async.Future<$0.AskQuestionResponse> askQuestion($pb.ServerContext ctx, $0.AskQuestionRequest request);
   $async.Future<$0.WorkReportResponse> workReport($pb.ServerContext ctx, $0.WorkReportRequest request);
   $async.Future<$0.McpClientInfoResponse> sendMcpClientInfo($pb.ServerContext ctx, $0.McpClientInfoRequest request);
+  $async.Future<$0.SubmitRequestResponse> submitRequest($pb.ServerContext ctx, $0.SubmitRequestRequest request);
+  $async.Future<$0.AwaitResultResponse> awaitResult($pb.ServerContext ctx, $0.AwaitResultRequest request);
+  $async.Future<$0.CancelRequestResponse> cancelRequest($pb.ServerContext ctx, $0.CancelRequestRequest request);
+  $async.Future<$0.RequestEvent> watchRequest($pb.ServerContext ctx, $0.WatchRequestRequest request);
 
   $pb.GeneratedMessage createRequest($core.String methodName) {
     switch (methodName) {
       case 'AskQuestion': return $0.AskQuestionRequest();
       case 'WorkReport': return $0.WorkReportRequest();
       case 'SendMcpClientInfo': return $0.McpClientInfoRequest();
+      case 'SubmitRequest': return $0.SubmitRequestRequest();
+      case 'AwaitResult': return $0.AwaitResultRequest();
+      case 'CancelRequest': return $0.CancelRequestRequest();
+      case 'WatchRequest': return $0.WatchRequestRequest();
       default: throw $core.ArgumentError('Unknown method: $methodName');
     }
   }
@@ -39,6 +47,10 @@ abstract class SrvAgentAssistServiceBase extends $pb.GeneratedService {
       case 'AskQuestion': return this.askQuestion(ctx, request as $0.AskQuestionRequest);
       case 'WorkReport': return this.workReport(ctx, request as $0.WorkReportRequest);
       case 'SendMcpClientInfo': return this.sendMcpClientInfo(ctx, request as $0.McpClientInfoRequest);
+      case 'SubmitRequest': return this.submitRequest(ctx, request as $0.SubmitRequestRequest);
+      case 'AwaitResult': return this.awaitResult(ctx, request as $0.AwaitResultRequest);
+      case 'CancelRequest': return this.cancelRequest(ctx, request as $0.CancelRequestRequest);
+      case 'WatchRequest': return this.watchRequest(ctx, request as $0.WatchRequestRequest);
       default: throw $core.ArgumentError('Unknown method: $methodName');
     }
   }
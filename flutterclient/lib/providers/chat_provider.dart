@@ -680,6 +680,31 @@ class ChatProvider extends ChangeNotifier with WidgetsBindingObserver {
         _handleUserConnectionStatusNotification(message,
             serverId: serverId, serverName: serverName);
         break;
+      case WebSocketCommands.replyRejected:
+        _handleReplyRejected(message,
+            serverId: serverId, serverName: serverName);
+        break;
+      case WebSocketCommands.replyAck:
+        _handleReplyAck(message, serverId: serverId, serverName: serverName);
+        break;
+      case WebSocketCommands.approvalProgress:
+        final progress = message.approvalProgress;
+        final waitingFor = progress.pendingApprovers.isEmpty
+            ? 'more approvals'
+            : progress.pendingApprovers.join(', ');
+        _logger.i('Work report ${progress.requestId} approved by '
+            '${progress.approvedBy.join(', ')}, waiting for $waitingFor');
+        break;
+      case WebSocketCommands.extendDeadline:
+      case WebSocketCommands.deadlineExtended:
+        _handleDeadlineExtension(message);
+        break;
+      case WebSocketCommands.interimReply:
+        _handleInterimReply(message);
+        break;
+      case WebSocketCommands.reminder:
+        _handleReminder(message);
+        break;
       default:
         _logger.w('Unknown message command: ${message.cmd}');
     }
@@ -691,6 +716,13 @@ class ChatProvider extends ChangeNotifier with WidgetsBindingObserver {
     required String serverId,
     required String serverName,
   }) {
+    // Requests held while no client was online may also come with the
+    // pending messages
+    if (_messages
+        .any((m) => m.requestId == request.iD && m.serverId == serverId)) {
+      _logger.d('Ignoring duplicate question ${request.iD}');
+      return;
+    }
     final chatMessage = ChatMessage.fromAskQuestionRequest(
       request,
       serverId: serverId,
@@ -712,6 +744,13 @@ class ChatProvider extends ChangeNotifier with WidgetsBindingObserver {
     required String serverId,
     required String serverName,
   }) {
+    // Requests held while no client was online may also come with the
+    // pending messages
+    if (_messages
+        .any((m) => m.requestId == request.iD && m.serverId == serverId)) {
+      _logger.d('Ignoring duplicate work report ${request.iD}');
+      return;
+    }
     final chatMessage = ChatMessage.fromWorkReportRequest(
       request,
       serverId: serverId,
@@ -982,6 +1021,197 @@ class ChatProvider extends ChangeNotifier with WidgetsBindingObserver {
     }
   }
 
+  /// Handle a reply the server refused because another user claimed the
+  /// request
+  void _handleReplyRejected(
+    pb.WebsocketMessage message, {
+    required String serverId,
+    required String serverName,
+  }) {
+    if (!message.hasRequestClaim()) {
+      _logger.w('ReplyRejected message missing claim data');
+      return;
+    }
+
+    final refusal = message.requestClaim;
+    _logger.w('Reply to ${refusal.requestId} rejected: ${refusal.errorMessage}');
+
+    final messageIndex = _messages.indexWhere(
+        (m) => m.requestId == refusal.requestId && m.serverId == serverId);
+    if (messageIndex != -1) {
+      // The reply can be sent again once the claim is released
+      _messages[messageIndex] = _messages[messageIndex].copyWith(
+        status: MessageStatus.pending,
+        repliedByCurrentUser: false,
+      );
+    }
+    _connectionError = 'Reply rejected: ${refusal.errorMessage}';
+    notifyListeners();
+    _updatePendingState();
+  }
+
+  /// Handle the outcome of our own deadline extension or the new deadline
+  /// set by someone else
+  void _handleDeadlineExtension(pb.WebsocketMessage message) {
+    if (!message.hasDeadlineExtension()) {
+      _logger.w('${message.cmd} message missing deadline data');
+      return;
+    }
+
+    final update = message.deadlineExtension;
+    if (message.cmd == WebSocketCommands.extendDeadline && !update.success) {
+      _connectionError = 'Cannot extend deadline: ${update.errorMessage}';
+      notifyListeners();
+      return;
+    }
+    final deadline =
+        DateTime.fromMillisecondsSinceEpoch(update.deadline.toInt());
+    final by = update.nickname.isEmpty ? 'the server' : update.nickname;
+    _logger.i('Deadline of ${update.requestId} extended by $by to $deadline');
+  }
+
+  /// Ask the server for more time to answer a pending request
+  Future<void> extendDeadline(String messageId,
+      {int extendSeconds = 600}) async {
+    final message = _messages.firstWhere((m) => m.id == messageId);
+    final serverId = message.serverId;
+    if (serverId == null || !_services.containsKey(serverId)) {
+      _logger.w('Cannot extend: unknown serverId for message $messageId');
+      return;
+    }
+
+    try {
+      await _services[serverId]!
+          .sendExtendDeadline(message.requestId, extendSeconds);
+    } catch (error) {
+      _logger.e('Failed to extend deadline: $error');
+      _connectionError = 'Extend deadline failed: $error';
+      notifyListeners();
+    }
+  }
+
+  /// Handle the outcome of our own interim reply or the interim reply of
+  /// someone else
+  void _handleInterimReply(pb.WebsocketMessage message) {
+    if (!message.hasInterimReply()) {
+      _logger.w('${message.cmd} message missing interim reply data');
+      return;
+    }
+
+    final interim = message.interimReply;
+    if (!interim.success) {
+      _connectionError = 'Cannot send interim reply: ${interim.errorMessage}';
+      notifyListeners();
+      return;
+    }
+    _logger.i('Interim reply to ${interim.requestId} by ${interim.nickname}: '
+        '${interim.text}');
+  }
+
+  /// Draw attention again to a request nobody answered for a while
+  void _handleReminder(pb.WebsocketMessage message) {
+    if (!message.hasReminder()) {
+      _logger.w('${message.cmd} message missing reminder data');
+      return;
+    }
+
+    final reminder = message.reminder;
+    final index = _messages.indexWhere(
+        (m) => m.requestId == reminder.requestId && m.needsUserAction);
+    if (index == -1) {
+      return;
+    }
+    _logger.i('Reminder ${reminder.number} for ${reminder.requestId}: '
+        '${reminder.message}');
+
+    final pending = _messages[index];
+    final isTask = pending.type == MessageType.task;
+    _handleDesktopAttentionOnNewPendingItem(
+      title: isTask ? 'Workreport still waiting' : 'Question still waiting',
+      body: (isTask ? pending.summary : pending.question) ?? reminder.message,
+      mode: isTask ? _workReportAttentionMode : _askQuestionAttentionMode,
+    );
+  }
+
+  /// Tell the agent waiting for a request to hold on, the request stays
+  /// pending and its deadline is pushed out by [extendSeconds]
+  Future<void> sendInterimReply(String messageId, String text,
+      {int extendSeconds = 600}) async {
+    final message = _messages.firstWhere((m) => m.id == messageId);
+    final serverId = message.serverId;
+    if (serverId == null || !_services.containsKey(serverId)) {
+      _logger.w('Cannot send interim reply: unknown serverId for message '
+          '$messageId');
+      return;
+    }
+
+    try {
+      await _services[serverId]!
+          .sendInterimReply(message.requestId, text, extendSeconds);
+    } catch (error) {
+      _logger.e('Failed to send interim reply: $error');
+      _connectionError = 'Interim reply failed: $error';
+      notifyListeners();
+    }
+  }
+
+  /// Handle the acknowledgement telling whether a reply reached the agent
+  void _handleReplyAck(
+    pb.WebsocketMessage message, {
+    required String serverId,
+    required String serverName,
+  }) {
+    if (!message.hasReplyAck()) {
+      _logger.w('ReplyAck message missing ack data');
+      return;
+    }
+
+    final ack = message.replyAck;
+    _logger.i('Reply to ${ack.requestId}: ${ack.status}');
+    // A recorded approval counts, the agent waits for the other approvers
+    if (ack.status == 'delivered' || ack.status == 'recorded') {
+      return;
+    }
+
+    final messageIndex = _messages.indexWhere(
+        (m) => m.requestId == ack.requestId && m.serverId == serverId);
+    switch (ack.status) {
+      case 'already_answered':
+        _connectionError =
+            'Reply not delivered: already answered by ${ack.repliedBy}';
+        if (messageIndex != -1) {
+          _messages[messageIndex] = _messages[messageIndex].copyWith(
+            repliedByCurrentUser: false,
+            repliedByNickname: ack.repliedBy,
+          );
+        }
+        break;
+      case 'failed':
+        // The server was busy, the request can be answered again
+        _connectionError = 'Reply not delivered: ${ack.message}';
+        if (messageIndex != -1) {
+          _messages[messageIndex] =
+              _messages[messageIndex].copyWith(status: MessageStatus.pending);
+        }
+        break;
+      case 'expired':
+        _connectionError = 'Reply not delivered: ${ack.message}';
+        if (messageIndex != -1) {
+          _messages[messageIndex] =
+              _messages[messageIndex].copyWith(status: MessageStatus.expired);
+        }
+        break;
+      default:
+        _connectionError = 'Reply not delivered: unknown request';
+        if (messageIndex != -1) {
+          _messages[messageIndex] =
+              _messages[messageIndex].copyWith(status: MessageStatus.error);
+        }
+    }
+    notifyListeners();
+    _updatePendingState();
+  }
+
   /// Handle get pending messages response
   void _handleGetPendingMessagesResponse(
     pb.WebsocketMessage message, {
@@ -1137,6 +1367,7 @@ class ChatProvider extends ChangeNotifier with WidgetsBindingObserver {
     String? confirmText, {
     List<AttachmentItem>? attachments,
     bool? applyWrapping,
+    String decision = WorkReportDecisions.approved,
   }) async {
     final message = _messages.firstWhere((m) => m.id == messageId);
     if (message.type != MessageType.task) return;
@@ -1155,7 +1386,8 @@ class ChatProvider extends ChangeNotifier with WidgetsBindingObserver {
       // Create response
       final response = pb.WorkReportResponse()
         ..iD = message.requestId
-        ..isError = false;
+        ..isError = false
+        ..decision = (pb.WorkReportDecision()..decision = decision);
 
       if (formattedConfirmText != null && formattedConfirmText.isNotEmpty) {
         response.contents.add(
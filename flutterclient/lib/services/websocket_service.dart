@@ -232,6 +232,32 @@ class WebSocketService {
     _logger.d('Get pending messages request sent');
   }
 
+  /// Ask for more time to answer a pending request
+  Future<void> sendExtendDeadline(String requestId, int extendSeconds) async {
+    final message = WebsocketMessage()
+      ..cmd = WebSocketCommands.extendDeadline
+      ..deadlineExtension = (DeadlineExtension()
+        ..requestId = requestId
+        ..extendSeconds = extendSeconds);
+
+    await _sendMessage(message);
+    _logger.d('Extend deadline request sent for $requestId');
+  }
+
+  /// Tell the agent waiting for a request to hold on without answering it
+  Future<void> sendInterimReply(
+      String requestId, String text, int extendSeconds) async {
+    final message = WebsocketMessage()
+      ..cmd = WebSocketCommands.interimReply
+      ..interimReply = (InterimReply()
+        ..requestId = requestId
+        ..text = text
+        ..extendSeconds = extendSeconds);
+
+    await _sendMessage(message);
+    _logger.d('Interim reply sent for $requestId');
+  }
+
   /// Send get online users request
   Future<void> sendGetOnlineUsers() async {
     final message = WebsocketMessage()
@@ -589,6 +589,20 @@ class _InlineReplyWidgetState extends State<InlineReplyWidget> {
     }
   }
 
+  /// Send a rejection or change request for a work report, with the typed
+  /// text as explanation
+  Future<void> _handleDecision(String decision) {
+    final text = _controller.text.trim();
+    if (text.isNotEmpty) {
+      return _handleSubmit(null, decision);
+    }
+    return _handleSubmit(
+        decision == WorkReportDecisions.rejected
+            ? 'Rejected.'
+            : 'Changes requested.',
+        decision);
+  }
+
   /// Remove attachment at index
   void _removeAttachment(int index) {
     setState(() {
@@ -596,7 +610,19 @@ class _InlineReplyWidgetState extends State<InlineReplyWidget> {
     });
   }
 
-  Future<void> _handleSubmit([String? quickReply]) async {
+  /// Tell the agent to hold on, with the typed text if there is any
+  Future<void> _handleInterimReply() async {
+    final text = _controller.text.trim();
+    await context.read<ChatProvider>().sendInterimReply(
+        widget.message.id, text.isEmpty ? "I'm on it, please hold on" : text);
+    if (text.isNotEmpty && mounted) {
+      _controller.clear();
+    }
+  }
+
+  Future<void> _handleSubmit(
+      [String? quickReply,
+      String decision = WorkReportDecisions.approved]) async {
     var replyText = quickReply ?? _controller.text.trim();
     // If input is empty and no attachments, use default reply text
     if (replyText.isEmpty && _attachments.isEmpty) {
@@ -628,6 +654,7 @@ class _InlineReplyWidgetState extends State<InlineReplyWidget> {
           replyText,
           attachments: _attachments,
           applyWrapping: applyReplyTextWrapping,
+          decision: decision,
         );
       }
 
@@ -1069,6 +1096,73 @@ class _InlineReplyWidgetState extends State<InlineReplyWidget> {
                                   horizontal: 12, vertical: 8),
                             ),
                           ),
+                          const SizedBox(width: 8),
+                          OutlinedButton.icon(
+                            onPressed: _isSubmitting
+                                ? null
+                                : () => context
+                                    .read<ChatProvider>()
+                                    .extendDeadline(widget.message.id),
+                            icon: const Icon(Icons.more_time, size: 16),
+                            label: const Text('Extend 10 min'),
+                            style: OutlinedButton.styleFrom(
+                              foregroundColor: Colors.blueGrey,
+                              side: BorderSide(
+                                  color:
+                                      Colors.blueGrey.withValues(alpha: 0.5)),
+                              padding: const EdgeInsets.symmetric(
+                                  horizontal: 12, vertical: 8),
+                            ),
+                          ),
+                          const SizedBox(width: 8),
+                          OutlinedButton.icon(
+                            onPressed:
+                                _isSubmitting ? null : _handleInterimReply,
+                            icon: const Icon(Icons.hourglass_empty, size: 16),
+                            label: const Text('Hold on'),
+                            style: OutlinedButton.styleFrom(
+                              foregroundColor: Colors.blueGrey,
+                              side: BorderSide(
+                                  color:
+                                      Colors.blueGrey.withValues(alpha: 0.5)),
+                              padding: const EdgeInsets.symmetric(
+                                  horizontal: 12, vertical: 8),
+                            ),
+                          ),
+                          if (widget.message.type == MessageType.task) ...[
+                            const SizedBox(width: 8),
+                            OutlinedButton.icon(
+                              onPressed: _isSubmitting
+                                  ? null
+                                  : () => _handleDecision(
+                                      WorkReportDecisions.changesRequested),
+                              icon: const Icon(Icons.edit_note, size: 16),
+                              label: const Text('Request changes'),
+                              style: OutlinedButton.styleFrom(
+                                foregroundColor: Colors.amber.shade800,
+                                side: BorderSide(
+                                    color: Colors.amber.withValues(alpha: 0.5)),
+                                padding: const EdgeInsets.symmetric(
+                                    horizontal: 12, vertical: 8),
+                              ),
+                            ),
+                            const SizedBox(width: 8),
+                            OutlinedButton.icon(
+                              onPressed: _isSubmitting
+                                  ? null
+                                  : () => _handleDecision(
+                                      WorkReportDecisions.rejected),
+                              icon: const Icon(Icons.close, size: 16),
+                              label: const Text('Reject'),
+                              style: OutlinedButton.styleFrom(
+                                foregroundColor: Colors.red,
+                                side: BorderSide(
+                                    color: Colors.red.withValues(alpha: 0.5)),
+                                padding: const EdgeInsets.symmetric(
+                                    horizontal: 12, vertical: 8),
+                              ),
+                            ),
+                          ],
                         ],
                       ),
 
@@ -0,0 +1,133 @@
+package mcptools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ErrToolCallCancelled is the cause of the context of a tool call the client cancelled
+// with notifications/cancelled
+var ErrToolCallCancelled = errors.New("tool call cancelled by the MCP client")
+
+// toolCallKey is the context key of the key of a tool call in toolCalls
+type toolCallKey struct{}
+
+// toolCallRegistry keeps the cancel functions of running tool calls by session ID and
+// JSON-RPC request ID
+type toolCallRegistry struct {
+	mu    sync.Mutex
+	calls map[string]context.CancelCauseFunc
+}
+
+var toolCalls = &toolCallRegistry{calls: make(map[string]context.CancelCauseFunc)}
+
+func toolCallID(sessionID string, requestID json.RawMessage) string {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, requestID); err != nil {
+		return sessionID + "/" + string(requestID)
+	}
+	return sessionID + "/" + compact.String()
+}
+
+// cancel cancels the tool call with the given key, if it is running
+func (r *toolCallRegistry) cancel(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cancel, exists := r.calls[key]
+	if exists {
+		cancel(ErrToolCallCancelled)
+	}
+	return exists
+}
+
+// cancellableToolCalls is a tool handler middleware that makes the tool calls of messages
+// marked by withToolCall cancellable with notifications/cancelled
+func cancellableToolCalls(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		key, ok := ctx.Value(toolCallKey{}).(string)
+		if !ok {
+			return next(ctx, request)
+		}
+
+		ctx, cancel := context.WithCancelCause(ctx)
+		toolCalls.mu.Lock()
+		toolCalls.calls[key] = cancel
+		toolCalls.mu.Unlock()
+
+		defer func() {
+			toolCalls.mu.Lock()
+			delete(toolCalls.calls, key)
+			toolCalls.mu.Unlock()
+			cancel(nil)
+		}()
+
+		return next(ctx, request)
+	}
+}
+
+// jsonrpcMessage holds the fields of a JSON-RPC message needed to track cancellation
+type jsonrpcMessage struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params struct {
+		RequestID json.RawMessage `json:"requestId"`
+		Reason    string          `json:"reason"`
+	} `json:"params"`
+}
+
+// trackCancellation inspects a message of a session. A tools/call request gets its key in the
+// returned context, a notifications/cancelled cancels the tool call it refers to.
+func trackCancellation(ctx context.Context, sessionID string, raw []byte) context.Context {
+	var message jsonrpcMessage
+	if err := json.Unmarshal(raw, &message); err != nil {
+		return ctx
+	}
+
+	switch mcp.MCPMethod(message.Method) {
+	case mcp.MethodToolsCall:
+		if len(message.ID) > 0 {
+			return context.WithValue(ctx, toolCallKey{}, toolCallID(sessionID, message.ID))
+		}
+	case "notifications/cancelled":
+		if toolCalls.cancel(toolCallID(sessionID, message.Params.RequestID)) {
+			log.Printf("MCP client cancelled tool call %s: %s", message.Params.RequestID, message.Params.Reason)
+		}
+	}
+	return ctx
+}
+
+// CancellationHandler wraps the handler of a streamable HTTP or SSE server, so that the
+// tool calls it serves can be cancelled by the client with notifications/cancelled
+func CancellationHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "Failed to read request body", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		// Streamable HTTP sends the session in a header, SSE in the query
+		sessionID := r.Header.Get("Mcp-Session-Id")
+		if sessionID == "" {
+			sessionID = r.URL.Query().Get("sessionId")
+		}
+
+		h.ServeHTTP(w, r.WithContext(trackCancellation(r.Context(), sessionID, body)))
+	})
+}
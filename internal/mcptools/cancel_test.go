@@ -0,0 +1,60 @@
+package mcptools
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+// blockingBackend waits until the tool call is cancelled and reports the cause
+type blockingBackend struct {
+	cause chan error
+}
+
+func (b *blockingBackend) Submit(ctx context.Context, submit *agentassistproto.SubmitRequestRequest, timeout int) (*Result, error) {
+	<-ctx.Done()
+	b.cause <- context.Cause(ctx)
+	return nil, ctx.Err()
+}
+
+func TestServeStdio_CancelToolCall(t *testing.T) {
+	backend := &blockingBackend{cause: make(chan error, 1)}
+	stdinReader, stdin := io.Pipe()
+	stdout, stdoutWriter := io.Pipe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go serveStdio(ctx, NewServer("test", "1.0", backend), stdinReader, stdoutWriter)
+
+	responses := bufio.NewScanner(stdout)
+	send := func(message string) {
+		if _, err := io.WriteString(stdin, message+"\n"); err != nil {
+			t.Fatalf("Failed to write message: %v", err)
+		}
+	}
+
+	send(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test-ide","version":"1.0"}}}`)
+	if !responses.Scan() {
+		t.Fatal("Expected an initialize response")
+	}
+	send(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)
+	send(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"ask_question","arguments":{"project_directory":"/test/project","question":"May I?","timeout":60}}}`)
+
+	// The tool call blocks, but the cancellation is still read and delivered
+	time.Sleep(100 * time.Millisecond)
+	send(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":2,"reason":"user pressed stop"}}`)
+
+	select {
+	case cause := <-backend.cause:
+		if !errors.Is(cause, ErrToolCallCancelled) {
+			t.Errorf("Expected ErrToolCallCancelled, got: %v", cause)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the tool call to be cancelled")
+	}
+}
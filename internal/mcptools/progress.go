@@ -0,0 +1,89 @@
+package mcptools
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type progressKey struct{}
+
+// progressReporter sends the notifications/progress of one tool call
+type progressReporter struct {
+	token mcp.ProgressToken
+
+	mu       sync.Mutex
+	progress int
+}
+
+// withProgress returns ctx with a progress reporter if the client asked for progress
+// notifications of the tool call
+func withProgress(ctx context.Context, request mcp.CallToolRequest) context.Context {
+	if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, progressKey{}, &progressReporter{token: request.Params.Meta.ProgressToken})
+}
+
+// progressToolCalls is a tool handler middleware that lets the backend report progress of
+// tool calls whose client asked for it
+func progressToolCalls(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return next(withProgress(ctx, request), request)
+	}
+}
+
+// ProgressRequested reports whether the client of the tool call of ctx wants progress notifications
+func ProgressRequested(ctx context.Context) bool {
+	_, ok := ctx.Value(progressKey{}).(*progressReporter)
+	return ok
+}
+
+// ReportProgress sends message as a notifications/progress of the tool call of ctx, if its
+// client asked for progress notifications. The progress value counts the notifications,
+// the total is unknown while the human has not answered.
+func ReportProgress(ctx context.Context, message string) {
+	reporter, ok := ctx.Value(progressKey{}).(*progressReporter)
+	if !ok {
+		return
+	}
+	s := server.ServerFromContext(ctx)
+	if s == nil {
+		return
+	}
+
+	reporter.mu.Lock()
+	reporter.progress++
+	progress := reporter.progress
+	reporter.mu.Unlock()
+
+	err := s.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+		"progressToken": reporter.token,
+		"progress":      progress,
+		"message":       message,
+	})
+	if err != nil {
+		log.Printf("Failed to send progress notification: %v", err)
+	}
+}
+
+// ReportInterimReply sends an interim reply of the human, e.g. "alice: looking into it", to the
+// client of the tool call of ctx as a notifications/message log message, and as progress if
+// the client asked for progress notifications. Unlike other progress it is sent regardless,
+// since the human wrote it for the agent.
+func ReportInterimReply(ctx context.Context, message string) {
+	if s := server.ServerFromContext(ctx); s != nil {
+		err := s.SendNotificationToClient(ctx, "notifications/message", map[string]any{
+			"level":  mcp.LoggingLevelInfo,
+			"logger": "agentassistant",
+			"data":   message,
+		})
+		if err != nil {
+			log.Printf("Failed to send interim reply notification: %v", err)
+		}
+	}
+	ReportProgress(ctx, message)
+}
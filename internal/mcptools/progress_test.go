@@ -0,0 +1,120 @@
+package mcptools
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+// progressBackend reports progress once and answers
+type progressBackend struct{}
+
+func (progressBackend) Submit(ctx context.Context, submit *agentassistproto.SubmitRequestRequest, timeout int) (*Result, error) {
+	ReportProgress(ctx, "viewed by alice")
+	return &Result{Response: &agentassistproto.AwaitResultResponse{
+		ID:                  submit.AskQuestionRequest.ID,
+		Done:                true,
+		AskQuestionResponse: &agentassistproto.AskQuestionResponse{ID: submit.AskQuestionRequest.ID},
+	}}, nil
+}
+
+func TestReportProgress(t *testing.T) {
+	stdinReader, stdin := io.Pipe()
+	stdout, stdoutWriter := io.Pipe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go serveStdio(ctx, NewServer("test", "1.0", progressBackend{}), stdinReader, stdoutWriter)
+
+	responses := bufio.NewScanner(stdout)
+	send := func(message string) {
+		if _, err := io.WriteString(stdin, message+"\n"); err != nil {
+			t.Fatalf("Failed to write message: %v", err)
+		}
+	}
+
+	send(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test-ide","version":"1.0"}}}`)
+	responses.Scan()
+	send(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)
+	send(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"ask_question","arguments":{"project_directory":"/test/project","question":"May I?","timeout":60},"_meta":{"progressToken":"token-1"}}}`)
+
+	// The notification and the result are written independently, in either order
+	for i := 0; i < 2 && responses.Scan(); i++ {
+		var message struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params struct {
+				ProgressToken string `json:"progressToken"`
+				Progress      int    `json:"progress"`
+				Message       string `json:"message"`
+			} `json:"params"`
+		}
+		if err := json.Unmarshal(responses.Bytes(), &message); err != nil {
+			t.Fatalf("Failed to parse %s: %v", responses.Text(), err)
+		}
+		if message.Method == "notifications/progress" {
+			if message.Params.ProgressToken != "token-1" || message.Params.Progress != 1 || message.Params.Message != "viewed by alice" {
+				t.Errorf("Unexpected progress notification: %s", responses.Text())
+			}
+			return
+		}
+	}
+	t.Fatal("Expected a progress notification")
+}
+
+// interimBackend forwards an interim reply once and answers
+type interimBackend struct{}
+
+func (interimBackend) Submit(ctx context.Context, submit *agentassistproto.SubmitRequestRequest, timeout int) (*Result, error) {
+	ReportInterimReply(ctx, "alice: looking into it")
+	return progressBackend{}.Submit(context.Background(), submit, timeout)
+}
+
+func TestReportInterimReply(t *testing.T) {
+	stdinReader, stdin := io.Pipe()
+	stdout, stdoutWriter := io.Pipe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go serveStdio(ctx, NewServer("test", "1.0", interimBackend{}), stdinReader, stdoutWriter)
+
+	responses := bufio.NewScanner(stdout)
+	send := func(message string) {
+		if _, err := io.WriteString(stdin, message+"\n"); err != nil {
+			t.Fatalf("Failed to write message: %v", err)
+		}
+	}
+
+	send(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test-ide","version":"1.0"}}}`)
+	responses.Scan()
+	send(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)
+	// Without a progress token the interim reply still arrives as a log message
+	send(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"ask_question","arguments":{"project_directory":"/test/project","question":"May I?","timeout":60}}}`)
+
+	for i := 0; i < 2 && responses.Scan(); i++ {
+		var message struct {
+			Method string `json:"method"`
+			Params struct {
+				Level string `json:"level"`
+				Data  string `json:"data"`
+			} `json:"params"`
+		}
+		if err := json.Unmarshal(responses.Bytes(), &message); err != nil {
+			t.Fatalf("Failed to parse %s: %v", responses.Text(), err)
+		}
+		switch message.Method {
+		case "notifications/progress":
+			t.Errorf("Expected no progress notification without a progress token: %s", responses.Text())
+		case "notifications/message":
+			if message.Params.Level != "info" || message.Params.Data != "alice: looking into it" {
+				t.Errorf("Unexpected log notification: %s", responses.Text())
+			}
+			return
+		}
+	}
+	t.Fatal("Expected a log notification")
+}
@@ -0,0 +1,72 @@
+package mcptools
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// sessionClientStore keeps the client info of each MCP session, so that a server used by
+// several agents over HTTP reports the right client name for every tool call
+type sessionClientStore struct {
+	mu      sync.RWMutex
+	clients map[string]*ClientInfo // Map session ID to client info
+}
+
+var sessionClients = &sessionClientStore{clients: make(map[string]*ClientInfo)}
+
+func (s *sessionClientStore) set(sessionID string, info *ClientInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clients[sessionID] = info
+}
+
+func (s *sessionClientStore) get(sessionID string) *ClientInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.clients[sessionID]
+}
+
+func (s *sessionClientStore) delete(sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.clients, sessionID)
+}
+
+// SessionIDFromContext returns the ID of the MCP session of a request
+func SessionIDFromContext(ctx context.Context) string {
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		return session.SessionID()
+	}
+	return ""
+}
+
+// ClientInfoFromContext returns the client info of the MCP session of a tool call
+func ClientInfoFromContext(ctx context.Context) *ClientInfo {
+	return sessionClients.get(SessionIDFromContext(ctx))
+}
+
+// ClientNameFromContext returns the client name of the MCP session of a tool call
+func ClientNameFromContext(ctx context.Context) string {
+	if info := ClientInfoFromContext(ctx); info != nil {
+		return info.ClientName
+	}
+	return ""
+}
+
+// NewStreamableHTTPServer creates a streamable HTTP server for an MCP server created by NewServer
+func NewStreamableHTTPServer(s *server.MCPServer, opts ...server.StreamableHTTPOption) *server.StreamableHTTPServer {
+	opts = append([]server.StreamableHTTPOption{server.WithSessionIdManager(&sessionIdManager{})}, opts...)
+	return server.NewStreamableHTTPServer(s, opts...)
+}
+
+// sessionIdManager forgets the client info of streamable HTTP sessions when they are terminated
+type sessionIdManager struct {
+	server.InsecureStatefulSessionIdManager
+}
+
+func (m *sessionIdManager) Terminate(sessionID string) (bool, error) {
+	sessionClients.delete(sessionID)
+	return m.InsecureStatefulSessionIdManager.Terminate(sessionID)
+}
@@ -0,0 +1,139 @@
+package mcptools
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// stdioSession is the single session of the stdio transport
+type stdioSession struct {
+	notifications chan mcp.JSONRPCNotification
+	initialized   atomic.Bool
+}
+
+func (s *stdioSession) SessionID() string {
+	return "stdio"
+}
+
+func (s *stdioSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+func (s *stdioSession) Initialize() {
+	s.initialized.Store(true)
+}
+
+func (s *stdioSession) Initialized() bool {
+	return s.initialized.Load()
+}
+
+// ServeStdio serves s on stdin and stdout until stdin is closed or the process is signalled.
+// Unlike server.ServeStdio it handles tool calls concurrently, so that the client can still
+// cancel a tool call that waits for the human with notifications/cancelled.
+func ServeStdio(s *server.MCPServer) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer cancel()
+
+	return serveStdio(ctx, s, os.Stdin, os.Stdout)
+}
+
+func serveStdio(ctx context.Context, s *server.MCPServer, stdin io.Reader, stdout io.Writer) error {
+	session := &stdioSession{notifications: make(chan mcp.JSONRPCNotification, 100)}
+	if err := s.RegisterSession(ctx, session); err != nil {
+		return fmt.Errorf("register session: %w", err)
+	}
+	defer s.UnregisterSession(ctx, session.SessionID())
+	ctx = s.WithContext(ctx, session)
+
+	// Tool calls still running when stdin is closed are cancelled, the client is gone
+	var calls sync.WaitGroup
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		cancel()
+		calls.Wait()
+	}()
+
+	var writeMu sync.Mutex
+	write := func(message mcp.JSONRPCMessage) {
+		data, err := json.Marshal(message)
+		if err != nil {
+			log.Printf("Failed to marshal MCP message: %v", err)
+			return
+		}
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		if _, err := fmt.Fprintf(stdout, "%s\n", data); err != nil {
+			log.Printf("Failed to write MCP message: %v", err)
+		}
+	}
+
+	go func() {
+		for {
+			select {
+			case notification := <-session.notifications:
+				write(notification)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	lines := make(chan string)
+	readErr := make(chan error, 1)
+	go func() {
+		reader := bufio.NewReader(stdin)
+		for {
+			line, err := reader.ReadString('\n')
+			if line != "" {
+				lines <- line
+			}
+			if err != nil {
+				readErr <- err
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-readErr:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		case line := <-lines:
+			raw := json.RawMessage(line)
+			messageCtx := trackCancellation(ctx, session.SessionID(), raw)
+			if messageCtx == ctx {
+				// Everything but tool calls is handled in order
+				if response := s.HandleMessage(ctx, raw); response != nil {
+					write(response)
+				}
+				continue
+			}
+
+			calls.Add(1)
+			go func() {
+				defer calls.Done()
+				response := s.HandleMessage(messageCtx, raw)
+				if response != nil {
+					write(response)
+				}
+			}()
+		}
+	}
+}
@@ -0,0 +1,483 @@
+// Package mcptools implements the ask_question and work_report MCP tools. The tools are
+// served by agentassistant-mcp, which forwards requests to agentassistant-srv, and by
+// agentassistant-srv itself, which handles them in-process.
+package mcptools
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+// Backend delivers the requests of the tools to the human and waits for their result
+type Backend interface {
+	// Submit sends the request in submit, filling in the user token, and waits for its result
+	// for up to timeout seconds
+	Submit(ctx context.Context, submit *agentassistproto.SubmitRequestRequest, timeout int) (*Result, error)
+}
+
+// Result is the result of a request submitted by a Backend
+type Result struct {
+	// Response holds the AskQuestionResponse or WorkReportResponse of the request
+	Response *agentassistproto.AwaitResultResponse
+	// Meta is added to the _meta field of the tool result
+	Meta map[string]any
+	// Note is appended to the content of the tool result if not empty
+	Note string
+}
+
+// toolResult adds the metadata and note of the result to a converted tool result
+func (r *Result) toolResult(result *mcp.CallToolResult) *mcp.CallToolResult {
+	if len(r.Meta) > 0 {
+		if result.Meta == nil {
+			result.Meta = make(map[string]any)
+		}
+		for key, value := range r.Meta {
+			result.Meta[key] = value
+		}
+	}
+	if r.Note != "" {
+		result.Content = append(result.Content, mcp.NewTextContent(r.Note))
+	}
+	return result
+}
+
+type tools struct {
+	backend Backend
+}
+
+// NewServer creates an MCP server with the ask_question and work_report tools
+func NewServer(name, version string, backend Backend) *server.MCPServer {
+	t := &tools{backend: backend}
+
+	hooks := &server.Hooks{}
+	hooks.AddAfterInitialize(func(ctx context.Context, id any, message *mcp.InitializeRequest, result *mcp.InitializeResult) {
+		cacheClientInfo(SessionIDFromContext(ctx), message.Params)
+	})
+	hooks.AddOnUnregisterSession(func(ctx context.Context, session server.ClientSession) {
+		sessionClients.delete(session.SessionID())
+	})
+
+	s := server.NewMCPServer(
+		name,
+		version,
+		server.WithToolCapabilities(false),
+		server.WithLogging(),
+		server.WithHooks(hooks),
+		server.WithToolHandlerMiddleware(cancellableToolCalls),
+		server.WithToolHandlerMiddleware(progressToolCalls),
+	)
+
+	// ask_question tool
+	tool := mcp.NewTool("ask_question",
+		mcp.WithDescription(`
+	Ask a question to the Agent-Assistant
+
+	This tool allows you to ask a question to the Agent-Assistant. The Agent-Assistant/User will send the answer/feedback to you.
+
+	Args:
+	- project_directory: The current project directory
+	- question: The question to ask
+	- timeout: The timeout in seconds, default is 3600s (1 hour)
+	- agent_name: The name of the AI agent/client calling this tool (e.g., Antigravity, Cascade)
+	- reasoning_model_name: The name of the actual LLM/inference model currently being used for this task (e.g., GPT-4, Gemini 3 Pro)
+
+	Returns:
+	- List of TextContent, ImageContent, AudioContent, or EmbeddedResource from Agent-Assistant
+	`),
+		//ProjectDirectory
+		mcp.WithString("project_directory",
+			mcp.Required(),
+			mcp.Description("Current project directory"),
+		),
+		//question
+		mcp.WithString("question",
+			mcp.Required(),
+			mcp.Description("The question to ask"),
+		),
+		//timeout
+		mcp.WithNumber("timeout",
+			mcp.DefaultNumber(3600),
+			mcp.Description("Timeout in seconds, default is 3600s (1 hour)"),
+		),
+		//agent_name
+		mcp.WithString("agent_name",
+			mcp.Required(),
+			mcp.Description("The name of the AI agent/client calling this tool (e.g., Antigravity, Cascade)"),
+		),
+		//reasoning_model_name
+		mcp.WithString("reasoning_model_name",
+			mcp.Required(),
+			mcp.Description("The specific identifier of the LLM/inference model being used (e.g., 'gpt-4o', 'claude-3-5-sonnet', 'gemini-1.5-pro'). Do NOT use generic agent names like 'cascade' or 'windsurf'."),
+		),
+	)
+
+	workReportTool := mcp.NewTool("work_report",
+		mcp.WithDescription(`
+	before finish task/work, send a work report to Agent-Assistant/User asking for confirmation/approval.
+
+	This tool allows you to ask for confirmation/approval from Agent-Assistant/User by sending a work report.
+
+	Args:
+	- project_directory: The current project directory
+	- summary: The summary of the task/work report
+	- timeout: The timeout in seconds, default is 3600s (1 hour)
+	- agent_name: The name of the AI agent/client calling this tool (e.g., Antigravity, Cascade)
+	- reasoning_model_name: The name of the actual LLM/inference model currently being used for this task (e.g., GPT-4, Gemini 3 Pro)
+	- required_approvals: For risky work such as deploys or migrations, how many users must approve, default is 1
+	- approvers: Nicknames of the users who must all approve, optional
+
+	Returns:
+	- A leading "Decision: APPROVED", "Decision: REJECTED" or "Decision: CHANGES REQUESTED" text with the user's comments, when the user decided explicitly
+	- List of TextContent, ImageContent, AudioContent, or EmbeddedResource from Agent-Assistant
+	`),
+		//ProjectDirectory
+		mcp.WithString("project_directory",
+			mcp.Required(),
+			mcp.Description("Current project directory"),
+		),
+		//summary
+		mcp.WithString("summary",
+			mcp.Required(),
+			mcp.Description("Summary of the task/work report"),
+		),
+		//timeout
+		mcp.WithNumber("timeout",
+			mcp.DefaultNumber(3600),
+			mcp.Description("Timeout in seconds, default is 3600s (1 hour)"),
+		),
+		//agent_name
+		mcp.WithString("agent_name",
+			mcp.Required(),
+			mcp.Description("The name of the AI agent/client calling this tool (e.g., Antigravity, Cascade)"),
+		),
+		//reasoning_model_name
+		mcp.WithString("reasoning_model_name",
+			mcp.Required(),
+			mcp.Description("The specific identifier of the LLM/inference model being used (e.g., 'gpt-4o', 'claude-3-5-sonnet', 'gemini-1.5-pro'). Do NOT use generic agent names like 'cascade' or 'windsurf'."),
+		),
+		//required_approvals
+		mcp.WithNumber("required_approvals",
+			mcp.DefaultNumber(1),
+			mcp.Description("Number of users who must approve, for risky work such as deploys or migrations, default is 1"),
+		),
+		//approvers
+		mcp.WithArray("approvers",
+			mcp.Items(map[string]any{"type": "string"}),
+			mcp.Description("Nicknames of the users who must all approve"),
+		),
+	)
+
+	// Add tool handler
+	s.AddTool(tool, t.askQuestionHandler)
+	s.AddTool(workReportTool, t.workReportHandler)
+
+	return s
+}
+
+// ClientInfo is the client information an MCP session sent in its initialize request
+type ClientInfo struct {
+	// SessionID identifies the MCP session to agentassistant-srv. It is unique across
+	// processes, unlike the session ID of the stdio transport.
+	SessionID        string
+	ProtocolVersion  string
+	CapabilitiesJson string
+	ClientName       string
+	ClientVersion    string
+}
+
+// Request returns the McpClientInfo RPC request that reports the client info to the server
+func (i *ClientInfo) Request(userToken string) *agentassistproto.McpClientInfoRequest {
+	return &agentassistproto.McpClientInfoRequest{
+		ID:        generateRequestID(),
+		UserToken: userToken,
+		Request: &agentassistproto.McpClientInfoData{
+			ProtocolVersion:  i.ProtocolVersion,
+			CapabilitiesJson: i.CapabilitiesJson,
+			ClientName:       i.ClientName,
+			ClientVersion:    i.ClientVersion,
+		},
+		Timestamp:    time.Now().UnixMilli(),
+		McpSessionID: i.SessionID,
+	}
+}
+
+// cacheClientInfo remembers the client info of a session
+func cacheClientInfo(sessionID string, params mcp.InitializeParams) {
+	capabilitiesBytes, err := json.Marshal(params.Capabilities)
+	if err != nil {
+		log.Printf("Failed to marshal MCP capabilities: %v", err)
+		capabilitiesBytes = []byte("{}")
+	}
+
+	info := &ClientInfo{
+		SessionID:        generateRequestID(),
+		ProtocolVersion:  params.ProtocolVersion,
+		CapabilitiesJson: string(capabilitiesBytes),
+		ClientName:       params.ClientInfo.Name,
+		ClientVersion:    params.ClientInfo.Version,
+	}
+
+	sessionClients.set(sessionID, info)
+}
+
+// askQuestionHandler handles the ask_question tool
+func (t *tools) askQuestionHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectDirectory, err := request.RequireString("project_directory")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	question, err := request.RequireString("question")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	timeout, err := request.RequireInt("timeout")
+	if err != nil {
+		timeout = 3600 // Default timeout (1 hour)
+	}
+
+	// Get optional agent_name and reasoning_model_name
+	agentName, _ := request.RequireString("agent_name")
+	reasoningModelName, _ := request.RequireString("reasoning_model_name")
+
+	currentMcpClientName, mcpSessionID := ClientNameFromContext(ctx), ""
+	if info := ClientInfoFromContext(ctx); info != nil {
+		mcpSessionID = info.SessionID
+	}
+
+	// Create RPC request, the backend sets the user token
+	req := &agentassistproto.AskQuestionRequest{
+		ID: generateRequestID(),
+		Request: &agentassistproto.McpAskQuestionRequest{
+			ProjectDirectory:   projectDirectory,
+			Question:           question,
+			Timeout:            int32(timeout),
+			AgentName:          agentName,
+			ReasoningModelName: reasoningModelName,
+			McpClientName:      currentMcpClientName,
+			McpSessionID:       mcpSessionID,
+		},
+	}
+
+	// Submit the request and wait for its result
+	result, err := t.backend.Submit(ctx, &agentassistproto.SubmitRequestRequest{AskQuestionRequest: req}, timeout)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("RPC call failed: %v", err)), nil
+	}
+
+	// Convert response to MCP result
+	return result.toolResult(ConvertToMCPResult(result.Response.AskQuestionResponse)), nil
+}
+
+// workReportHandler handles the work_report tool
+func (t *tools) workReportHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectDirectory, err := request.RequireString("project_directory")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	summary, err := request.RequireString("summary")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	timeout, err := request.RequireInt("timeout")
+	if err != nil {
+		timeout = 3600 // Default timeout (1 hour)
+	}
+
+	// Get optional agent_name and reasoning_model_name
+	agentName, _ := request.RequireString("agent_name")
+	reasoningModelName, _ := request.RequireString("reasoning_model_name")
+
+	// Get the optional approval quorum
+	requiredApprovals := request.GetInt("required_approvals", 1)
+	approvers := request.GetStringSlice("approvers", nil)
+
+	currentMcpClientName, mcpSessionID := ClientNameFromContext(ctx), ""
+	if info := ClientInfoFromContext(ctx); info != nil {
+		mcpSessionID = info.SessionID
+	}
+
+	// Create RPC request, the backend sets the user token
+	req := &agentassistproto.WorkReportRequest{
+		ID: generateRequestID(),
+		Request: &agentassistproto.McpWorkReportRequest{
+			ProjectDirectory:   projectDirectory,
+			Summary:            summary,
+			Timeout:            int32(timeout),
+			AgentName:          agentName,
+			ReasoningModelName: reasoningModelName,
+			McpClientName:      currentMcpClientName,
+			McpSessionID:       mcpSessionID,
+			RequiredApprovals:  int32(requiredApprovals),
+			Approvers:          approvers,
+		},
+	}
+
+	// Submit the request and wait for its result
+	result, err := t.backend.Submit(ctx, &agentassistproto.SubmitRequestRequest{WorkReportRequest: req}, timeout)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("RPC call failed: %v", err)), nil
+	}
+
+	// Convert response to MCP result
+	return result.toolResult(ConvertToMCPResult(result.Response.WorkReportResponse)), nil
+}
+
+// generateRequestID generates a unique request ID using UUID V7
+func generateRequestID() string {
+	return uuid.Must(uuid.NewV7()).String()
+}
+
+// ConvertToMCPResult converts an RPC response to MCP result
+func ConvertToMCPResult(resp interface{}) *mcp.CallToolResult {
+	var isError bool
+	var meta map[string]string
+	var contents []*agentassistproto.McpResultContent
+	var decision *agentassistproto.WorkReportDecision
+
+	switch r := resp.(type) {
+	case *agentassistproto.AskQuestionResponse:
+		isError = r.IsError
+		meta = r.Meta
+		contents = r.Contents
+	case *agentassistproto.WorkReportResponse:
+		isError = r.IsError
+		meta = r.Meta
+		contents = r.Contents
+		decision = r.Decision
+	default:
+		return mcp.NewToolResultError("Unknown response type")
+	}
+
+	// Convert contents to MCP format. An automatic answer of a timeout policy says so first,
+	// then comes the decision on a work report.
+	var mcpContents []mcp.Content
+	if meta["timeout_policy"] != "" && meta["message"] != "" {
+		mcpContents = append(mcpContents, mcp.NewTextContent(meta["message"]))
+	}
+	if decision != nil {
+		mcpContents = append(mcpContents, mcp.NewTextContent(decisionText(decision)))
+	}
+	for i, content := range contents {
+		switch content.Type {
+		case 1: // Text content
+			if content.Text != nil {
+				mcpContents = append(mcpContents, mcp.NewTextContent(content.Text.Text))
+			}
+		case 2: // Image content
+			if content.Image != nil {
+				mcpContents = append(mcpContents, mcp.NewImageContent(content.Image.Data, content.Image.MimeType))
+				mcpContents = append(mcpContents, mcp.NewEmbeddedResource(mcp.BlobResourceContents{
+					URI:      fmt.Sprintf("attachment://image/%d", i),
+					MIMEType: content.Image.MimeType,
+					Blob:     content.Image.Data,
+				}))
+			}
+		case 3: // Audio content
+			if content.Audio != nil {
+				mcpContents = append(mcpContents, mcp.NewAudioContent(content.Audio.Data, content.Audio.MimeType))
+				mcpContents = append(mcpContents, mcp.NewEmbeddedResource(mcp.BlobResourceContents{
+					URI:      fmt.Sprintf("attachment://audio/%d", i),
+					MIMEType: content.Audio.MimeType,
+					Blob:     content.Audio.Data,
+				}))
+			}
+		case 4: // Embedded resource
+			if content.EmbeddedResource != nil {
+				// Prefer returning the embedded bytes to the MCP client.
+				// MCP-Go expects embedded resources as EmbeddedResource{type:"resource", resource: BlobResourceContents/TextResourceContents}.
+				if len(content.EmbeddedResource.Data) > 0 {
+					// if strings.HasPrefix(strings.ToLower(content.EmbeddedResource.MimeType), "image/") {
+					// 	mcpContents = append(
+					// 		mcpContents,
+					// 		mcp.NewImageContent(
+					// 			base64.StdEncoding.EncodeToString(content.EmbeddedResource.Data),
+					// 			content.EmbeddedResource.MimeType,
+					// 		),
+					// 	)
+
+					// }
+
+					mcpContents = append(mcpContents, mcp.NewEmbeddedResource(mcp.BlobResourceContents{
+						URI:      content.EmbeddedResource.Uri,
+						MIMEType: content.EmbeddedResource.MimeType,
+						Blob:     base64.StdEncoding.EncodeToString(content.EmbeddedResource.Data),
+					}))
+				} else {
+					// If there is no inline data, fall back to a text description.
+					mcpContents = append(mcpContents, mcp.NewTextContent(fmt.Sprintf("Resource: %s", content.EmbeddedResource.Uri)))
+				}
+			}
+		}
+	}
+
+	if isError {
+		return &mcp.CallToolResult{
+			Content: mcpContents,
+			IsError: true,
+		}
+	}
+
+	// if len(mcpContents) == 0 {
+	// 	mcpContents = append(mcpContents, mcp.NewTextContent("Request completed successfully"))
+	// }
+
+	// Create a tool result with the contents
+	result := &mcp.CallToolResult{
+		Content: mcpContents,
+	}
+	return result
+}
+
+// decisionText renders the decision of the user on a work report as a text the agent can act on
+func decisionText(decision *agentassistproto.WorkReportDecision) string {
+	var text strings.Builder
+	switch decision.Decision {
+	case "approved":
+		text.WriteString("Decision: APPROVED. The user approved the work report, go ahead.")
+	case "rejected":
+		text.WriteString("Decision: REJECTED. The user rejected the work report, do not go ahead with this work.")
+	case "changes_requested":
+		text.WriteString("Decision: CHANGES REQUESTED. Make the requested changes, then send a new work report.")
+	default:
+		fmt.Fprintf(&text, "Decision: %s", decision.Decision)
+	}
+
+	if len(decision.Comments) > 0 {
+		text.WriteString("\n\nComments:")
+		for _, comment := range decision.Comments {
+			if comment.Item != "" {
+				fmt.Fprintf(&text, "\n- %s: %s", comment.Item, comment.Comment)
+			} else {
+				fmt.Fprintf(&text, "\n- %s", comment.Comment)
+			}
+		}
+	}
+	return text.String()
+}
+
+func isTextMimeType(mimeType string) bool {
+	m := strings.ToLower(strings.TrimSpace(mimeType))
+	if strings.HasPrefix(m, "text/") {
+		return true
+	}
+	switch m {
+	case "application/json", "application/xml", "application/javascript", "application/x-yaml", "application/yaml":
+		return true
+	default:
+		return false
+	}
+}
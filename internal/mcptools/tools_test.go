@@ -0,0 +1,139 @@
+package mcptools
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+type recordingBackend struct {
+	submitted *agentassistproto.SubmitRequestRequest
+}
+
+func (b *recordingBackend) Submit(ctx context.Context, submit *agentassistproto.SubmitRequestRequest, timeout int) (*Result, error) {
+	b.submitted = submit
+	return &Result{
+		Response: &agentassistproto.AwaitResultResponse{
+			ID:   submit.AskQuestionRequest.ID,
+			Done: true,
+			AskQuestionResponse: &agentassistproto.AskQuestionResponse{
+				ID: submit.AskQuestionRequest.ID,
+				Contents: []*agentassistproto.McpResultContent{
+					{Type: 1, Text: &agentassistproto.TextContent{Type: "text", Text: "Go ahead"}},
+				},
+			},
+		},
+		Meta: map[string]any{"agentassistant_server": "test"},
+		Note: "(note)",
+	}, nil
+}
+
+func TestAskQuestionTool(t *testing.T) {
+	backend := &recordingBackend{}
+	server := httptest.NewServer(NewStreamableHTTPServer(NewServer("test", "1.0", backend)))
+	defer server.Close()
+
+	c, err := client.NewStreamableHttpClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{Name: "test-ide", Version: "2.0"}
+	if _, err := c.Initialize(ctx, initRequest); err != nil {
+		t.Fatalf("Failed to initialize: %v", err)
+	}
+
+	callRequest := mcp.CallToolRequest{}
+	callRequest.Params.Name = "ask_question"
+	callRequest.Params.Arguments = map[string]any{
+		"project_directory":    "/test/project",
+		"question":             "May I?",
+		"timeout":              60,
+		"agent_name":           "agent",
+		"reasoning_model_name": "model",
+	}
+	result, err := c.CallTool(ctx, callRequest)
+	if err != nil {
+		t.Fatalf("Failed to call tool: %v", err)
+	}
+
+	req := backend.submitted.GetAskQuestionRequest()
+	if req == nil || req.ID == "" {
+		t.Fatal("Expected an ask question request with an ID")
+	}
+	if req.Request.Question != "May I?" || req.Request.Timeout != 60 {
+		t.Errorf("Unexpected request: %v", req.Request)
+	}
+	if req.Request.McpClientName != "test-ide" {
+		t.Errorf("Expected client name of the session, got %q", req.Request.McpClientName)
+	}
+	if req.Request.McpSessionID == "" {
+		t.Error("Expected the MCP session ID to be set")
+	}
+
+	if result.IsError || len(result.Content) != 2 {
+		t.Fatalf("Expected answer and note, got: %+v", result)
+	}
+	if text, ok := result.Content[0].(mcp.TextContent); !ok || text.Text != "Go ahead" {
+		t.Errorf("Unexpected answer: %+v", result.Content[0])
+	}
+	if result.Meta["agentassistant_server"] != "test" {
+		t.Errorf("Expected result metadata, got: %v", result.Meta)
+	}
+}
+
+func TestConvertToMCPResult_Decision(t *testing.T) {
+	result := ConvertToMCPResult(&agentassistproto.WorkReportResponse{
+		Contents: []*agentassistproto.McpResultContent{
+			{Type: 1, Text: &agentassistproto.TextContent{Type: "text", Text: "Almost there"}},
+		},
+		Decision: &agentassistproto.WorkReportDecision{
+			Decision: "changes_requested",
+			Comments: []*agentassistproto.WorkReportComment{
+				{Item: "main.go", Comment: "Handle the error"},
+				{Comment: "Add a test"},
+			},
+		},
+	})
+
+	if result.IsError || len(result.Content) != 2 {
+		t.Fatalf("Expected the decision and the reply, got: %+v", result)
+	}
+	want := "Decision: CHANGES REQUESTED. Make the requested changes, then send a new work report.\n\n" +
+		"Comments:\n- main.go: Handle the error\n- Add a test"
+	if text, ok := result.Content[0].(mcp.TextContent); !ok || text.Text != want {
+		t.Errorf("Unexpected decision block: %+v", result.Content[0])
+	}
+	if text, ok := result.Content[1].(mcp.TextContent); !ok || text.Text != "Almost there" {
+		t.Errorf("Unexpected reply: %+v", result.Content[1])
+	}
+}
+
+func TestConvertToMCPResult_TimeoutPolicy(t *testing.T) {
+	result := ConvertToMCPResult(&agentassistproto.WorkReportResponse{
+		Meta: map[string]string{
+			"timeout_policy": "approve",
+			"message":        "Nobody answered within 600 seconds, this answer was given automatically by the timeout policy 1 (approve)",
+		},
+		Decision: &agentassistproto.WorkReportDecision{Decision: "approved"},
+	})
+
+	if result.IsError || len(result.Content) != 2 {
+		t.Fatalf("Expected the timeout notice and the decision, got: %+v", result)
+	}
+	if text, ok := result.Content[0].(mcp.TextContent); !ok || !strings.Contains(text.Text, "given automatically") {
+		t.Errorf("Expected the timeout notice first, got: %+v", result.Content[0])
+	}
+	if text, ok := result.Content[1].(mcp.TextContent); !ok || !strings.HasPrefix(text.Text, "Decision: APPROVED") {
+		t.Errorf("Unexpected decision block: %+v", result.Content[1])
+	}
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	agentassistproto "github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+// approvalQuorum collects the approvals of a work report that needs more than one
+type approvalQuorum struct {
+	required  int
+	approvers []string
+	approvals []approval // In the order they were given
+}
+
+// approval is the reply of a web user who approved a work report
+type approval struct {
+	nickname string
+	response *WebResponse
+}
+
+// newApprovalQuorum returns the quorum a request asks for, nil if the first reply decides
+func newApprovalQuorum(message *agentassistproto.WebsocketMessage) *approvalQuorum {
+	request := message.GetWorkReportRequest().GetRequest()
+	if request == nil || (request.RequiredApprovals <= 1 && len(request.Approvers) == 0) {
+		return nil
+	}
+	return &approvalQuorum{required: int(request.RequiredApprovals), approvers: request.Approvers}
+}
+
+// approvedBy reports whether a user already approved
+func (q *approvalQuorum) approvedBy(nickname string) bool {
+	return slices.ContainsFunc(q.approvals, func(a approval) bool { return a.nickname == nickname })
+}
+
+// pendingApprovers returns the named approvers who did not approve yet
+func (q *approvalQuorum) pendingApprovers() []string {
+	var pending []string
+	for _, nickname := range q.approvers {
+		if !q.approvedBy(nickname) {
+			pending = append(pending, nickname)
+		}
+	}
+	return pending
+}
+
+// met reports whether enough users approved
+func (q *approvalQuorum) met() bool {
+	return len(q.approvals) >= q.required && len(q.pendingApprovers()) == 0
+}
+
+// progress returns the ApprovalProgress message describing the quorum of a request
+func (q *approvalQuorum) progress(requestID string) *agentassistproto.ApprovalProgress {
+	progress := &agentassistproto.ApprovalProgress{
+		RequestId:         requestID,
+		RequiredApprovals: int32(q.required),
+		Approvers:         q.approvers,
+		PendingApprovers:  q.pendingApprovers(),
+	}
+	for _, a := range q.approvals {
+		progress.ApprovedBy = append(progress.ApprovedBy, a.nickname)
+	}
+	return progress
+}
+
+// response returns the response the agent gets once the quorum is met or a user decides
+// otherwise. It has the contents and comments of every approval, each introduced by who gave
+// it, and lists the approvers in Meta["approved_by"].
+func (q *approvalQuorum) response(final *WebResponse) *WebResponse {
+	response := &WebResponse{
+		IsError:   final.IsError,
+		Meta:      make(map[string]string),
+		Decision:  &agentassistproto.WorkReportDecision{Decision: decision(final)},
+		RepliedBy: final.RepliedBy,
+	}
+	for key, value := range final.Meta {
+		response.Meta[key] = value
+	}
+
+	var approvedBy []string
+	for _, a := range q.approvals {
+		approvedBy = append(approvedBy, a.nickname)
+		response.Contents = append(response.Contents, CreateTextContent(fmt.Sprintf("Approved by %s:", a.nickname)))
+		response.Contents = append(response.Contents, a.response.Contents...)
+		response.Decision.Comments = append(response.Decision.Comments, a.response.Decision.GetComments()...)
+	}
+	switch decision(final) {
+	case DecisionRejected:
+		response.Contents = append(response.Contents, CreateTextContent(fmt.Sprintf("Rejected by %s:", final.RepliedBy)))
+		response.Contents = append(response.Contents, final.Contents...)
+		response.Decision.Comments = append(response.Decision.Comments, final.Decision.GetComments()...)
+	case DecisionChangesRequested:
+		response.Contents = append(response.Contents, CreateTextContent(fmt.Sprintf("Changes requested by %s:", final.RepliedBy)))
+		response.Contents = append(response.Contents, final.Contents...)
+		response.Decision.Comments = append(response.Decision.Comments, final.Decision.GetComments()...)
+	}
+	response.Meta["approved_by"] = strings.Join(approvedBy, ",")
+	return response
+}
+
+// AcceptWorkReportReply decides whether a reply of client to a work report is accepted, like
+// AcceptReply. For a work report that needs several approvals, an approval that does not
+// complete the quorum is recorded instead: it is acknowledged as such and its progress is
+// sent to all the web clients that see the request, but the agent keeps waiting.
+func (b *Broadcaster) AcceptWorkReportReply(client *WebClient, requestID string, response *WebResponse) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.refuseReplyLocked(client, requestID) {
+		return false
+	}
+
+	request := b.pendingRequests[requestID]
+	quorum := request.approval
+	nickname := client.GetNickname()
+	if quorum != nil && decision(response) == DecisionApproved {
+		if quorum.approvedBy(nickname) {
+			sendReplyAck(client, &agentassistproto.ReplyAck{
+				RequestId: requestID,
+				Status:    ReplyStatusAlreadyAnswered,
+				RepliedBy: nickname,
+			})
+			return false
+		}
+
+		quorum.approvals = append(quorum.approvals, approval{nickname: nickname, response: response})
+		b.persistApprovalsLocked(requestID, quorum)
+		if !quorum.met() {
+			log.Printf("Request %s approved by %s, %d approvals so far", requestID, nickname, len(quorum.approvals))
+			if request.claim != nil && request.claim.heldBy(client) {
+				b.releaseClaimLocked(requestID, request)
+			}
+			b.publishEventLocked(requestID, request, &agentassistproto.RequestEvent{
+				Type:     RequestEventApproved,
+				Nickname: nickname,
+				Message:  fmt.Sprintf("approved by %s, waiting for more approvals", nickname),
+			})
+			b.notifyApprovalLocked(requestID, request)
+			sendReplyAck(client, &agentassistproto.ReplyAck{RequestId: requestID, Status: ReplyStatusRecorded})
+			return false
+		}
+	}
+
+	b.acceptReplyLocked(client, requestID, request)
+	return true
+}
+
+// notifyApprovalLocked sends the approval progress of a request to the web clients that can
+// see it. It must be called with b.mu held.
+func (b *Broadcaster) notifyApprovalLocked(requestID string, request *WebsocketRequest) {
+	notification := &agentassistproto.WebsocketMessage{
+		Cmd:              "ApprovalProgress",
+		ApprovalProgress: request.approval.progress(requestID),
+	}
+	b.broadcastToRequestLocked(request, notification, "")
+}
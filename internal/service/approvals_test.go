@@ -0,0 +1,96 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	agentassistproto "github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+func newTestQuorumMessage(requestID string, requiredApprovals int32, approvers ...string) *agentassistproto.WebsocketMessage {
+	return &agentassistproto.WebsocketMessage{
+		Cmd: "WorkReport",
+		WorkReportRequest: &agentassistproto.WorkReportRequest{
+			ID: requestID,
+			Request: &agentassistproto.McpWorkReportRequest{
+				ProjectDirectory:  "/test/project",
+				Summary:           "Deploy to production",
+				RequiredApprovals: requiredApprovals,
+				Approvers:         approvers,
+			},
+		},
+	}
+}
+
+func newTestDecision(nickname, decision, text string) *WebResponse {
+	return &WebResponse{
+		Decision:  &agentassistproto.WorkReportDecision{Decision: decision},
+		Contents:  []*agentassistproto.McpResultContent{CreateTextContent(text)},
+		RepliedBy: nickname,
+	}
+}
+
+func TestBroadcaster_ApprovalQuorum(t *testing.T) {
+	b := NewBroadcaster()
+	var clients []*WebClient
+	for _, nickname := range []string{"alice", "bob", "carol"} {
+		client := NewWebClient(nickname + "-client")
+		client.SetToken("test-token")
+		client.SetNickname(nickname)
+		b.RegisterClient(client)
+		clients = append(clients, client)
+	}
+	alice, bob, carol := clients[0], clients[1], clients[2]
+	time.Sleep(100 * time.Millisecond)
+
+	responseChan := make(chan *WebResponse, 2)
+	b.BroadcastToToken(newTestQuorumMessage("req-1", 2, "carol"), "test-token", time.Now().Add(time.Minute), responseChan)
+	time.Sleep(100 * time.Millisecond)
+
+	// The first approval is recorded and its progress sent to everyone
+	if b.AcceptWorkReportReply(alice, "req-1", newTestDecision("alice", DecisionApproved, "LGTM")) {
+		t.Fatal("Expected the first approval to be recorded only")
+	}
+	if ack := nextMessage(t, alice, "ReplyAck").ReplyAck; ack.Status != ReplyStatusRecorded {
+		t.Errorf("Expected the approval to be recorded, got %v", ack)
+	}
+	progress := nextMessage(t, bob, "ApprovalProgress").ApprovalProgress
+	if len(progress.ApprovedBy) != 1 || progress.ApprovedBy[0] != "alice" || len(progress.PendingApprovers) != 1 || progress.PendingApprovers[0] != "carol" {
+		t.Errorf("Unexpected approval progress: %v", progress)
+	}
+	if b.AcceptWorkReportReply(alice, "req-1", newTestDecision("alice", DecisionApproved, "LGTM")) {
+		t.Error("Expected a second approval of alice not to count")
+	}
+
+	// Two approvals are not enough without the named approver
+	if b.AcceptWorkReportReply(bob, "req-1", newTestDecision("bob", DecisionApproved, "Fine")) {
+		t.Fatal("Expected the approval of bob to be recorded only, carol has to approve")
+	}
+	if pending := b.GetPendingMessages("test-token"); len(pending) != 1 || len(pending[0].ApprovalProgress.GetApprovedBy()) != 2 {
+		t.Errorf("Expected the pending request to show two approvals, got %v", pending)
+	}
+
+	final := newTestDecision("carol", DecisionApproved, "Ship it")
+	if !b.AcceptWorkReportReply(carol, "req-1", final) {
+		t.Fatal("Expected the approval of carol to complete the quorum")
+	}
+	b.HandleClientResponse(carol, "req-1", final)
+	response := <-responseChan
+	if response.Decision.GetDecision() != DecisionApproved || response.Meta["approved_by"] != "alice,bob,carol" || len(response.Contents) != 6 {
+		t.Errorf("Expected the response to carry all approvals, got %v", response)
+	}
+
+	// A rejection decides right away
+	b.BroadcastToToken(newTestQuorumMessage("req-2", 3), "test-token", time.Now().Add(time.Minute), responseChan)
+	time.Sleep(100 * time.Millisecond)
+	b.AcceptWorkReportReply(alice, "req-2", newTestDecision("alice", DecisionApproved, "LGTM"))
+	rejection := newTestDecision("bob", DecisionRejected, "Not on a Friday")
+	if !b.AcceptWorkReportReply(bob, "req-2", rejection) {
+		t.Fatal("Expected the rejection to be accepted")
+	}
+	b.HandleClientResponse(bob, "req-2", rejection)
+	response = <-responseChan
+	if response.Decision.GetDecision() != DecisionRejected || response.Meta["approved_by"] != "alice" || len(response.Contents) != 4 {
+		t.Errorf("Expected the rejection with the earlier approval, got %v", response)
+	}
+}
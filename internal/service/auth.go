@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+)
+
+// Token scopes
+const (
+	// ScopeAgent allows calling the SrvAgentAssist RPCs, i.e. agentassistant-mcp
+	ScopeAgent = "agent"
+	// ScopeHuman allows logging in to the web interface and answering requests
+	ScopeHuman = "human"
+)
+
+// Token authentication errors
+var (
+	ErrUnknownToken = errors.New("unknown token")
+	ErrScopeDenied  = errors.New("token is not allowed for this use")
+)
+
+// TokenConfig is a token entry of the server configuration
+type TokenConfig struct {
+	// User is the name of the user the token belongs to
+	User string `toml:"user"`
+	// SecretSHA256 is the hex encoded SHA-256 hash of the token secret
+	SecretSHA256 string `toml:"secret_sha256"`
+	// Scopes lists what the token may be used for: "agent" and/or "human"
+	Scopes []string `toml:"scopes"`
+	// Group routes requests between tokens: requests sent with an agent token are
+	// delivered to the web users logged in with a human token of the same group.
+	// Defaults to the user name.
+	Group string `toml:"group"`
+}
+
+// Identity is who a token authenticates as
+type Identity struct {
+	User   string
+	Group  string
+	Scopes []string
+}
+
+// HasScope reports whether the identity's token allows scope
+func (i *Identity) HasScope(scope string) bool {
+	return slices.Contains(i.Scopes, scope)
+}
+
+// TokenRegistry authenticates tokens against their hashed secrets
+type TokenRegistry struct {
+	identities map[string]*Identity // Map secret hash to identity
+}
+
+// NewTokenRegistry creates a registry from the configured tokens
+func NewTokenRegistry(tokens []TokenConfig) (*TokenRegistry, error) {
+	r := &TokenRegistry{identities: make(map[string]*Identity)}
+
+	for i, token := range tokens {
+		if token.User == "" {
+			return nil, fmt.Errorf("token %d: user is required", i+1)
+		}
+
+		hash := strings.ToLower(strings.TrimSpace(token.SecretSHA256))
+		if decoded, err := hex.DecodeString(hash); err != nil || len(decoded) != sha256.Size {
+			return nil, fmt.Errorf("token %d (%s): secret_sha256 must be a hex encoded SHA-256 hash", i+1, token.User)
+		}
+		if _, exists := r.identities[hash]; exists {
+			return nil, fmt.Errorf("token %d (%s): duplicate secret", i+1, token.User)
+		}
+
+		if len(token.Scopes) == 0 {
+			return nil, fmt.Errorf("token %d (%s): at least one scope is required", i+1, token.User)
+		}
+		for _, scope := range token.Scopes {
+			if scope != ScopeAgent && scope != ScopeHuman {
+				return nil, fmt.Errorf("token %d (%s): unknown scope %q", i+1, token.User, scope)
+			}
+		}
+
+		group := token.Group
+		if group == "" {
+			group = token.User
+		}
+
+		r.identities[hash] = &Identity{
+			User:   token.User,
+			Group:  group,
+			Scopes: token.Scopes,
+		}
+	}
+
+	return r, nil
+}
+
+// HashToken returns the hex encoded SHA-256 hash of a token secret, as used in the configuration
+func HashToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// Authenticate returns the identity of a token secret if the token allows scope
+func (r *TokenRegistry) Authenticate(secret string, scope string) (*Identity, error) {
+	identity, exists := r.identities[HashToken(secret)]
+	if !exists {
+		return nil, ErrUnknownToken
+	}
+	if !identity.HasScope(scope) {
+		return nil, ErrScopeDenied
+	}
+	return identity, nil
+}
+
+type identityKey struct{}
+
+// ContextWithIdentity returns a context carrying the authenticated identity
+func ContextWithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext returns the identity authenticated for the current RPC call
+func IdentityFromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok
+}
+
+// requestToken returns the token that routes a request: the group of the authenticated caller
+// if token authentication is enabled, the token sent in the request body otherwise
+func requestToken(ctx context.Context, bodyToken string) string {
+	if identity, ok := IdentityFromContext(ctx); ok {
+		return identity.Group
+	}
+	return bodyToken
+}
+
+// BearerToken returns the token of the Authorization header, empty if there is none
+func BearerToken(header http.Header) string {
+	token, found := strings.CutPrefix(header.Get("Authorization"), "Bearer ")
+	if !found {
+		return ""
+	}
+	return token
+}
+
+// NewAuthInterceptor returns a Connect interceptor that requires an agent token in the
+// Authorization header of every SrvAgentAssist call
+func NewAuthInterceptor(registry *TokenRegistry) connect.Interceptor {
+	return &authInterceptor{registry: registry}
+}
+
+type authInterceptor struct {
+	registry *TokenRegistry
+}
+
+func (a *authInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		ctx, err := a.authenticate(ctx, req.Header(), req.Spec().Procedure, req.Peer().Addr)
+		if err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (a *authInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (a *authInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx, err := a.authenticate(ctx, conn.RequestHeader(), conn.Spec().Procedure, conn.Peer().Addr)
+		if err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
+
+// authenticate checks the bearer token of a call and returns ctx with the identity of its user
+func (a *authInterceptor) authenticate(ctx context.Context, header http.Header, procedure, peer string) (context.Context, error) {
+	secret := BearerToken(header)
+	if secret == "" {
+		log.Printf("Rejected %s call from %s: missing bearer token", procedure, peer)
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("missing bearer token"))
+	}
+
+	identity, err := a.registry.Authenticate(secret, ScopeAgent)
+	if errors.Is(err, ErrScopeDenied) {
+		log.Printf("Rejected %s call from %s: token is not an agent token", procedure, peer)
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+	if err != nil {
+		log.Printf("Rejected %s call from %s: %v", procedure, peer, err)
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	return ContextWithIdentity(ctx, identity), nil
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/gorilla/websocket"
+	agentassistproto "github.com/yangjuncode/agentassistant/agentassistproto"
+	"google.golang.org/protobuf/proto"
+)
+
+func newTestTokenRegistry(t *testing.T) *TokenRegistry {
+	t.Helper()
+
+	registry, err := NewTokenRegistry([]TokenConfig{
+		{User: "alice", SecretSHA256: HashToken("alice-agent"), Scopes: []string{ScopeAgent}, Group: "team"},
+		{User: "bob", SecretSHA256: HashToken("bob-human"), Scopes: []string{ScopeHuman}, Group: "team"},
+		{User: "carol", SecretSHA256: HashToken("carol-both"), Scopes: []string{ScopeAgent, ScopeHuman}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create token registry: %v", err)
+	}
+	return registry
+}
+
+func TestNewTokenRegistry_Invalid(t *testing.T) {
+	tests := map[string][]TokenConfig{
+		"missing user":     {{SecretSHA256: HashToken("a"), Scopes: []string{ScopeAgent}}},
+		"plain secret":     {{User: "a", SecretSHA256: "secret", Scopes: []string{ScopeAgent}}},
+		"missing scopes":   {{User: "a", SecretSHA256: HashToken("a")}},
+		"unknown scope":    {{User: "a", SecretSHA256: HashToken("a"), Scopes: []string{"admin"}}},
+		"duplicate secret": {{User: "a", SecretSHA256: HashToken("a"), Scopes: []string{ScopeAgent}}, {User: "b", SecretSHA256: strings.ToUpper(HashToken("a")), Scopes: []string{ScopeHuman}}},
+	}
+
+	for name, tokens := range tests {
+		if _, err := NewTokenRegistry(tokens); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestTokenRegistry_Authenticate(t *testing.T) {
+	registry := newTestTokenRegistry(t)
+
+	identity, err := registry.Authenticate("alice-agent", ScopeAgent)
+	if err != nil {
+		t.Fatalf("Expected alice to authenticate, got: %v", err)
+	}
+	if identity.User != "alice" || identity.Group != "team" {
+		t.Errorf("Unexpected identity: %+v", identity)
+	}
+
+	identity, err = registry.Authenticate("carol-both", ScopeHuman)
+	if err != nil {
+		t.Fatalf("Expected carol to authenticate, got: %v", err)
+	}
+	if identity.Group != "carol" {
+		t.Errorf("Expected group to default to the user name, got %s", identity.Group)
+	}
+
+	if _, err := registry.Authenticate("alice-agent", ScopeHuman); !errors.Is(err, ErrScopeDenied) {
+		t.Errorf("Expected ErrScopeDenied, got: %v", err)
+	}
+	if _, err := registry.Authenticate("unknown", ScopeAgent); !errors.Is(err, ErrUnknownToken) {
+		t.Errorf("Expected ErrUnknownToken, got: %v", err)
+	}
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	registry := newTestTokenRegistry(t)
+	svc := NewAgentAssistService()
+
+	mux := http.NewServeMux()
+	mux.Handle(agentassistproto.NewSrvAgentAssistHandler(svc, connect.WithInterceptors(NewAuthInterceptor(registry))))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	call := func(token string) (*connect.Response[agentassistproto.SubmitRequestResponse], error) {
+		client := agentassistproto.NewSrvAgentAssistClient(server.Client(), server.URL)
+		req := connect.NewRequest(&agentassistproto.SubmitRequestRequest{
+			AskQuestionRequest: newTestAskQuestionMessage("auth-request-" + token).AskQuestionRequest,
+		})
+		if token != "" {
+			req.Header().Set("Authorization", "Bearer "+token)
+		}
+		return client.SubmitRequest(context.Background(), req)
+	}
+
+	if _, err := call(""); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("Expected CodeUnauthenticated without token, got: %v", err)
+	}
+	if _, err := call("unknown"); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("Expected CodeUnauthenticated with unknown token, got: %v", err)
+	}
+	if _, err := call("bob-human"); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("Expected CodePermissionDenied with human token, got: %v", err)
+	}
+
+	resp, err := call("alice-agent")
+	if err != nil {
+		t.Fatalf("Expected agent token to be accepted, got: %v", err)
+	}
+	if !resp.Msg.Success {
+		t.Fatalf("Expected request to be accepted, got: %v", resp.Msg.Meta)
+	}
+
+	// The request is routed by the group of the token, not by the token in the body
+	info, exists := svc.GetBroadcaster().lookupRequest(resp.Msg.ID)
+	if !exists {
+		t.Fatal("Expected submitted request to be known")
+	}
+	if info.UserToken != "team" {
+		t.Errorf("Expected request to be routed to group team, got %s", info.UserToken)
+	}
+}
+
+func TestWebSocketLogin_TokenAuthentication(t *testing.T) {
+	registry := newTestTokenRegistry(t)
+	svc := NewAgentAssistService()
+
+	server := httptest.NewServer(http.HandlerFunc(NewWebSocketHandlerWithOptions(svc.GetBroadcaster(), WebSocketHandlerOptions{Tokens: registry}).HandleWebSocket))
+	defer server.Close()
+
+	login := func(token string) *agentassistproto.UserLoginResponse {
+		conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+		if err != nil {
+			t.Fatalf("Failed to connect: %v", err)
+		}
+		defer conn.Close()
+
+		mb, err := proto.Marshal(&agentassistproto.WebsocketMessage{Cmd: "UserLogin", StrParam: token})
+		if err != nil {
+			t.Fatalf("Failed to marshal login: %v", err)
+		}
+		if err := conn.WriteMessage(websocket.BinaryMessage, mb); err != nil {
+			t.Fatalf("Failed to send login: %v", err)
+		}
+
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		for {
+			_, mb, err := conn.ReadMessage()
+			if err != nil {
+				t.Fatalf("Failed to read login response: %v", err)
+			}
+			var message agentassistproto.WebsocketMessage
+			if err := proto.Unmarshal(mb, &message); err != nil {
+				t.Fatalf("Failed to unmarshal message: %v", err)
+			}
+			if message.UserLoginResponse != nil {
+				return message.UserLoginResponse
+			}
+		}
+	}
+
+	if resp := login("bob-human"); !resp.Success {
+		t.Errorf("Expected human token to log in, got: %s", resp.ErrorMessage)
+	}
+	if resp := login("alice-agent"); resp.Success {
+		t.Error("Expected agent-only token to be rejected")
+	}
+	if resp := login("unknown"); resp.Success {
+		t.Error("Expected unknown token to be rejected")
+	}
+}
@@ -7,13 +7,16 @@ import (
 	agentassistproto "github.com/yangjuncode/agentassistant/agentassistproto"
 )
 
-func TestBroadcastToAllExcept(t *testing.T) {
+func TestBroadcastToRequestClients(t *testing.T) {
 	broadcaster := NewBroadcaster()
 
-	// Create test clients
+	// Create test clients, client3 uses another token
 	client1 := NewWebClient("client1")
+	client1.SetToken("test-token")
 	client2 := NewWebClient("client2")
+	client2.SetToken("test-token")
 	client3 := NewWebClient("client3")
+	client3.SetToken("other-token")
 
 	// Register clients
 	broadcaster.RegisterClient(client1)
@@ -23,14 +26,20 @@ func TestBroadcastToAllExcept(t *testing.T) {
 	// Give some time for registration
 	time.Sleep(100 * time.Millisecond)
 
+	responseChan := make(chan *WebResponse, 1)
+	broadcaster.BroadcastToToken(newTestAskQuestionMessage("req-1"), "test-token", time.Now().Add(time.Minute), responseChan)
+	time.Sleep(100 * time.Millisecond)
+	<-client1.SendChan
+	<-client2.SendChan
+
 	// Create a test message
 	testMessage := &agentassistproto.WebsocketMessage{
 		Cmd:      "TestMessage",
 		StrParam: "Hello from test",
 	}
 
-	// Broadcast to all except client1
-	broadcaster.BroadcastToAllExcept(testMessage, "client1")
+	// Broadcast to the clients of the request except client1
+	broadcaster.BroadcastToRequestClients("req-1", testMessage, "client1")
 
 	// Give some time for message delivery
 	time.Sleep(100 * time.Millisecond)
@@ -53,14 +62,22 @@ func TestBroadcastToAllExcept(t *testing.T) {
 		t.Error("client2 should have received the message")
 	}
 
-	// Check that client3 received the message
+	// Check that client3 of another token did not receive the message
 	select {
 	case msg := <-client3.SendChan:
-		if msg.Cmd != "TestMessage" || msg.StrParam != "Hello from test" {
-			t.Errorf("client3 received wrong message: %+v", msg)
-		}
+		t.Errorf("client3 should not have received the message, got %+v", msg)
 	default:
-		t.Error("client3 should have received the message")
+	}
+
+	// Messages about unknown requests go nowhere
+	broadcaster.BroadcastToRequestClients("unknown", testMessage, "")
+	time.Sleep(100 * time.Millisecond)
+	for _, client := range []*WebClient{client1, client2, client3} {
+		select {
+		case msg := <-client.SendChan:
+			t.Errorf("%s should not have received a message about an unknown request, got %+v", client.ID, msg)
+		default:
+		}
 	}
 
 	// Clean up
@@ -75,7 +92,9 @@ func TestBroadcastAskQuestionReply(t *testing.T) {
 
 	// Create test clients
 	sender := NewWebClient("sender")
+	sender.SetToken("test-token")
 	receiver := NewWebClient("receiver")
+	receiver.SetToken("test-token")
 
 	// Register clients
 	broadcaster.RegisterClient(sender)
@@ -84,6 +103,12 @@ func TestBroadcastAskQuestionReply(t *testing.T) {
 	// Give some time for registration
 	time.Sleep(100 * time.Millisecond)
 
+	// The request the clients got
+	broadcaster.BroadcastToToken(newTestAskQuestionMessage("test-request-123"), "test-token", time.Now().Add(time.Minute), make(chan *WebResponse, 1))
+	time.Sleep(100 * time.Millisecond)
+	<-sender.SendChan
+	<-receiver.SendChan
+
 	// Create a test AskQuestionReply message
 	testMessage := &agentassistproto.WebsocketMessage{
 		Cmd: "AskQuestionReply",
@@ -142,7 +167,9 @@ func TestBroadcastWorkReportReply(t *testing.T) {
 
 	// Create test clients
 	sender := NewWebClient("sender")
+	sender.SetToken("test-token")
 	receiver := NewWebClient("receiver")
+	receiver.SetToken("test-token")
 
 	// Register clients
 	broadcaster.RegisterClient(sender)
@@ -151,6 +178,12 @@ func TestBroadcastWorkReportReply(t *testing.T) {
 	// Give some time for registration
 	time.Sleep(100 * time.Millisecond)
 
+	// The request the clients got
+	broadcaster.BroadcastToToken(newTestQuorumMessage("test-task-456", 1), "test-token", time.Now().Add(time.Minute), make(chan *WebResponse, 1))
+	time.Sleep(100 * time.Millisecond)
+	<-sender.SendChan
+	<-receiver.SendChan
+
 	// Create a test WorkReportReply message
 	testMessage := &agentassistproto.WebsocketMessage{
 		Cmd: "WorkReportReply",
@@ -202,3 +235,38 @@ func TestBroadcastWorkReportReply(t *testing.T) {
 	broadcaster.UnregisterClient(sender)
 	broadcaster.UnregisterClient(receiver)
 }
+
+func TestGetPendingMessages_Timing(t *testing.T) {
+	broadcaster := NewBroadcaster()
+	client := NewWebClient("client1")
+	client.SetToken("test-token")
+	client.SetNickname("alice")
+	broadcaster.RegisterClient(client)
+	time.Sleep(100 * time.Millisecond)
+
+	// The agent asked for a 60 second timeout
+	before := time.Now()
+	responseChan := make(chan *WebResponse, 1)
+	message := newTestAskQuestionMessage("req-1")
+	broadcaster.BroadcastToToken(message, "test-token", time.Now().Add(60*time.Second), responseChan)
+	time.Sleep(100 * time.Millisecond)
+
+	pending := broadcaster.GetPendingMessages("test-token")
+	if len(pending) != 1 {
+		t.Fatalf("Expected 1 pending message, got %d", len(pending))
+	}
+	if pending[0].Timeout != 60 {
+		t.Errorf("Expected timeout 60, got %d", pending[0].Timeout)
+	}
+	if pending[0].CreatedAt < before.UnixMilli() || pending[0].Deadline-pending[0].CreatedAt > 60000 || pending[0].Deadline-pending[0].CreatedAt < 59000 {
+		t.Errorf("Unexpected creation time %d and deadline %d", pending[0].CreatedAt, pending[0].Deadline)
+	}
+	if pending[0].DeliveryCount != 1 || pending[0].FirstViewedAt != 0 {
+		t.Errorf("Expected 1 delivery and no view, got %d and %d", pending[0].DeliveryCount, pending[0].FirstViewedAt)
+	}
+
+	broadcaster.RequestViewed(client, "req-1")
+	if pending = broadcaster.GetPendingMessages("test-token"); pending[0].FirstViewedAt < pending[0].CreatedAt {
+		t.Errorf("Expected the first view to be recorded, got %d", pending[0].FirstViewedAt)
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"sync"
@@ -12,8 +13,39 @@ import (
 // WebsocketRequest represents a request with response channel for internal use
 type WebsocketRequest struct {
 	Message      *agentassistproto.WebsocketMessage
-	ResponseChan chan *WebResponse
-	UserToken    string // Token of the user who should receive this message
+	ResponseChan chan *WebResponse // nil while no RPC call is waiting for the response
+	UserToken    string            // Token of the user who should receive this message
+	CreatedAt    time.Time         // Time the server accepted the request
+	Deadline     time.Time         // Time after which the request expires
+	Response     *WebResponse      // Final response, set once the request has completed
+	CompletedAt  time.Time         // Time the request completed, zero while it is pending
+
+	DeliveryCount int       // Number of web clients the request was sent to
+	FirstViewedAt time.Time // Time a web user first viewed the request, zero until then
+
+	accepted      chan struct{} // Closed once the broadcaster has registered the request
+	deadlineTimer *time.Timer   // Times the request out at its deadline
+
+	events   []*agentassistproto.RequestEvent // Lifecycle events so far, replayed to new watchers
+	viewedBy map[string]bool                  // Nicknames of the users who viewed the request
+	held     bool                             // Waiting for a web client to log in, not delivered yet
+
+	route         *Route          // Routing rule that matched the request, nil if none did
+	fallenBack    bool            // Delivered to everyone, the routed users did not answer in time
+	fallbackTimer *time.Timer     // Delivers the request to everyone after the route's delay
+	deliveredTo   map[string]bool // IDs of the web clients the request was sent to
+
+	claim      *requestClaim // Claim of the web user answering the request, nil if unclaimed
+	answeredBy string        // Nickname of the user whose reply was accepted, empty until then
+
+	approval *approvalQuorum // Approvals collected so far, nil unless several are required
+
+	escalated   bool            // Escalated by a timeout policy, which happens only once
+	escalatedTo map[string]bool // Nicknames of the users the request was escalated to
+
+	reminders     []*Reminder // Reminders still to be sent, the earliest first
+	reminderTimer *time.Timer // Sends the next reminder, nil while none is scheduled
+	reminded      int         // Number of reminders sent so far
 }
 
 // WebResponse represents a response from web users
@@ -21,6 +53,12 @@ type WebResponse struct {
 	IsError  bool                                 `json:"is_error"`
 	Meta     map[string]string                    `json:"meta"`
 	Contents []*agentassistproto.McpResultContent `json:"contents"`
+
+	// Decision of the web user on a work report, nil for plain confirmations and answers
+	Decision *agentassistproto.WorkReportDecision `json:"decision,omitempty"`
+
+	// Nickname of the web user who replied, empty for responses made by the server
+	RepliedBy string `json:"-"`
 }
 
 // WebClient represents a connected web client
@@ -110,30 +148,108 @@ func (c *WebClient) Send(msg *agentassistproto.WebsocketMessage) bool {
 
 // Broadcaster manages broadcasting requests to web clients
 type Broadcaster struct {
-	clients          map[string]*WebClient
-	pendingRequests  map[string]*WebsocketRequest // Map request ID to WebsocketRequest
-	register         chan *WebClient
-	unregister       chan *WebClient
-	broadcast        chan *WebsocketRequest
-	responseReceived chan *ResponseWithID
-	mu               sync.RWMutex
+	clients           map[string]*WebClient
+	pendingRequests   map[string]*WebsocketRequest                                // Map request ID to WebsocketRequest
+	completedRequests map[string]*WebsocketRequest                                // Completed requests kept for callers collecting their result
+	store             RequestStore                                                // Optional persistence for pending requests
+	watchers          map[string]map[chan *agentassistproto.RequestEvent]struct{} // Lifecycle event subscribers by request ID
+	register          chan *WebClient
+	unregister        chan *WebClient
+	broadcast         chan *WebsocketRequest
+	responseReceived  chan *ResponseWithID
+	noClientsPolicy   string           // What happens to requests no web client is online for
+	router            *Router          // Routing rules, nil delivers every request to all clients of its token
+	timeoutPolicies   *TimeoutPolicies // What requests nobody answered in time get, nil times them out with an error
+	maxLifetime       time.Duration    // How long after its creation a web user may extend a request to
+	reminders         *Reminders       // Reminders of unanswered requests, nil for none
+	webhooks          *Webhooks        // External services notified of the lifecycle of requests, nil for none
+	mu                sync.RWMutex
+}
+
+// BroadcasterOptions configures a broadcaster
+type BroadcasterOptions struct {
+	// Store persists pending requests. Nil keeps them in memory only.
+	Store RequestStore
+	// NoClientsPolicy is NoClientsPolicyQueue (the default) or NoClientsPolicyFail
+	NoClientsPolicy string
+	// Router restricts requests to the web users targeted by routing rules. Nil delivers
+	// every request to all clients of its token.
+	Router *Router
+	// TimeoutPolicies answer or escalate the requests nobody answered in time. Nil times
+	// them out with an error.
+	TimeoutPolicies *TimeoutPolicies
+	// MaxRequestLifetime is how long after its creation web users may extend the deadline
+	// of a request to, zero means DefaultMaxRequestLifetime
+	MaxRequestLifetime time.Duration
+	// Reminders remind the web users of the requests nobody answered for a while. Nil
+	// sends no reminders.
+	Reminders *Reminders
+	// Webhooks notify external services of new requests and how they ended. Nil
+	// notifies none.
+	Webhooks *Webhooks
 }
 
 // ResponseWithID represents a response with its associated request ID
 type ResponseWithID struct {
 	RequestID string
 	Response  *WebResponse
+	// Client is the web client that replied, it gets a ReplyAck once the reply is matched
+	Client *WebClient
 }
 
-// NewBroadcaster creates a new broadcaster
+const (
+	// expiredRequestSweepInterval is how often completed requests past their retention are purged
+	expiredRequestSweepInterval = 30 * time.Second
+	// completedRequestRetention is how long the result of a completed request is kept after
+	// it completed (or after its deadline, whichever is later) for callers that lost their connection
+	completedRequestRetention = 10 * time.Minute
+	// defaultRequestTimeout is the timeout in seconds of requests that do not specify one
+	defaultRequestTimeout = 600
+)
+
+// NewBroadcaster creates a new broadcaster that keeps pending requests in memory only
 func NewBroadcaster() *Broadcaster {
+	return NewBroadcasterWithStore(nil)
+}
+
+// NewBroadcasterWithStore creates a new broadcaster that persists pending requests to store
+// and restores the requests left in it by a previous run
+func NewBroadcasterWithStore(store RequestStore) *Broadcaster {
+	return NewBroadcasterWithOptions(BroadcasterOptions{Store: store})
+}
+
+// NewBroadcasterWithOptions creates a new broadcaster configured by options
+func NewBroadcasterWithOptions(options BroadcasterOptions) *Broadcaster {
+	store := options.Store
+	noClientsPolicy := options.NoClientsPolicy
+	if noClientsPolicy == "" {
+		noClientsPolicy = NoClientsPolicyQueue
+	}
+	maxLifetime := options.MaxRequestLifetime
+	if maxLifetime <= 0 {
+		maxLifetime = DefaultMaxRequestLifetime
+	}
+
 	b := &Broadcaster{
-		clients:          make(map[string]*WebClient),
-		pendingRequests:  make(map[string]*WebsocketRequest),
-		register:         make(chan *WebClient),
-		unregister:       make(chan *WebClient),
-		broadcast:        make(chan *WebsocketRequest),
-		responseReceived: make(chan *ResponseWithID),
+		clients:           make(map[string]*WebClient),
+		pendingRequests:   make(map[string]*WebsocketRequest),
+		completedRequests: make(map[string]*WebsocketRequest),
+		store:             store,
+		watchers:          make(map[string]map[chan *agentassistproto.RequestEvent]struct{}),
+		register:          make(chan *WebClient),
+		unregister:        make(chan *WebClient),
+		broadcast:         make(chan *WebsocketRequest),
+		responseReceived:  make(chan *ResponseWithID, 64), // Buffered so replies are not dropped while the loop is busy
+		noClientsPolicy:   noClientsPolicy,
+		router:            options.Router,
+		timeoutPolicies:   options.TimeoutPolicies,
+		maxLifetime:       maxLifetime,
+		reminders:         options.Reminders,
+		webhooks:          options.Webhooks,
+	}
+
+	if store != nil {
+		b.restoreRequests()
 	}
 
 	// Start the broadcaster goroutine
@@ -144,6 +260,9 @@ func NewBroadcaster() *Broadcaster {
 
 // run handles the broadcaster's main loop
 func (b *Broadcaster) run() {
+	sweepTicker := time.NewTicker(expiredRequestSweepInterval)
+	defer sweepTicker.Stop()
+
 	for {
 		select {
 		case client := <-b.register:
@@ -157,21 +276,46 @@ func (b *Broadcaster) run() {
 			if _, exists := b.clients[client.ID]; exists {
 				delete(b.clients, client.ID)
 				client.Close()
+				b.releaseClaimsLocked(client)
 			}
 			b.mu.Unlock()
 			log.Printf("Web client %s unregistered. Total clients: %d", client.ID, len(b.clients))
 
 		case request := <-b.broadcast:
 			// Get request ID from the message
-			var requestID string
-			if request.Message.AskQuestionRequest != nil {
-				requestID = request.Message.AskQuestionRequest.ID
-			} else if request.Message.WorkReportRequest != nil {
-				requestID = request.Message.WorkReportRequest.ID
-			} else {
+			requestID, _ := requestIDAndType(request.Message)
+			if requestID == "" {
 				log.Printf("Invalid request: no ID found")
+				request.markAccepted()
+				continue
+			}
+
+			// A request that is already known is not broadcast again
+			b.mu.Lock()
+			resumed, err := b.resumeRequestLocked(requestID, request.UserToken, request.ResponseChan)
+			if err != nil {
+				b.mu.Unlock()
+				log.Printf("Refusing request %s: %v", requestID, err)
+				if request.ResponseChan != nil {
+					select {
+					case request.ResponseChan <- requestIDTakenResponse():
+					default:
+					}
+				}
+				request.markAccepted()
+				continue
+			}
+			if resumed {
+				b.mu.Unlock()
+				log.Printf("Request %s is already known, not broadcasting it again", requestID)
+				request.markAccepted()
 				continue
 			}
+			b.publishEventLocked(requestID, request, &agentassistproto.RequestEvent{
+				Type:    RequestEventQueued,
+				Message: "queued",
+			})
+			b.mu.Unlock()
 
 			// Filter clients by token if specified
 			var targetClients []*WebClient
@@ -193,32 +337,53 @@ func (b *Broadcaster) run() {
 			}
 			b.mu.RUnlock()
 
-			if len(targetClients) == 0 {
+			if len(targetClients) == 0 && b.noClientsPolicy == NoClientsPolicyFail {
 				log.Printf("No web clients available to handle request %s", requestID)
-				// Send error response
-				go func() {
-					select {
-					case request.ResponseChan <- &WebResponse{
-						IsError: true,
-						Meta: map[string]string{
-							"error":   "no_clients",
-							"message": "No web clients available to handle the request",
-						},
-						Contents: nil,
-					}:
-					default:
-					}
-				}()
+				// Record the error as the result of the request so that callers
+				// collecting it later get the same answer
+				b.mu.Lock()
+				b.pendingRequests[requestID] = request
+				b.completeRequestLocked(requestID, &WebResponse{
+					IsError: true,
+					Meta: map[string]string{
+						"error":   "no_clients",
+						"message": "No web clients available to handle the request",
+					},
+					Contents: nil,
+				})
+				b.mu.Unlock()
+				request.markAccepted()
 				continue
 			}
 
-			log.Printf("Broadcasting request %s to %d web clients", requestID, len(targetClients))
+			if len(targetClients) == 0 {
+				log.Printf("No web clients available, holding request %s until one logs in", requestID)
+				b.mu.Lock()
+				b.holdRequestLocked(requestID, request)
+				b.routeRequestLocked(requestID, request, nil)
+				b.persistRequestLocked(requestID, request)
+				b.mu.Unlock()
+				request.markAccepted()
+				continue
+			}
 
 			// Store the request for response matching
 			b.mu.Lock()
 			b.pendingRequests[requestID] = request
+			b.startDeadlineTimer(requestID, request)
+			b.startRemindersLocked(requestID, request)
+			targetClients = b.routeRequestLocked(requestID, request, targetClients)
+			if len(targetClients) > 0 {
+				b.recordDeliveryLocked(requestID, request, targetClients)
+			}
+			// Persisted under the lock, so that nothing completing the request meanwhile can
+			// write its response to the store first
+			b.persistRequestLocked(requestID, request)
 			b.mu.Unlock()
 
+			log.Printf("Broadcasting request %s to %d web clients", requestID, len(targetClients))
+			request.markAccepted()
+
 			// Send to target clients
 			for _, client := range targetClients {
 				go func(c *WebClient) {
@@ -232,26 +397,454 @@ func (b *Broadcaster) run() {
 		case responseWithID := <-b.responseReceived:
 			// Handle response from web client
 			b.mu.Lock()
-			if request, exists := b.pendingRequests[responseWithID.RequestID]; exists {
-				delete(b.pendingRequests, responseWithID.RequestID)
-				b.mu.Unlock()
+			response := responseWithID.Response
+			if request, pending := b.pendingRequests[responseWithID.RequestID]; pending && request.approval != nil {
+				// Give the agent all the approvals that were collected
+				response = request.approval.response(response)
+			}
+			completed := b.completeRequestLocked(responseWithID.RequestID, response)
+			if responseWithID.Client != nil {
+				sendReplyAck(responseWithID.Client, b.replyAckLocked(responseWithID.RequestID, completed))
+			}
+			b.mu.Unlock()
+			if !completed {
+				log.Printf("Received response for unknown request ID: %s", responseWithID.RequestID)
+			}
 
-				// Send response to the waiting RPC call
-				go func() {
-					select {
-					case request.ResponseChan <- responseWithID.Response:
-					default:
-						log.Printf("Failed to send response for request %s: channel not available", responseWithID.RequestID)
-					}
-				}()
+		case <-sweepTicker.C:
+			b.sweepExpiredRequests()
+		}
+	}
+}
+
+// restoreRequests loads the requests persisted by a previous run
+func (b *Broadcaster) restoreRequests() {
+	requests, err := b.store.LoadRequests()
+	if err != nil {
+		log.Printf("Failed to load persisted requests: %v", err)
+		return
+	}
+
+	now := time.Now()
+	restored := 0
+	for _, stored := range requests {
+		if stored.Response != nil && !now.Before(stored.Deadline) {
+			if err := b.store.DeleteRequest(stored.RequestID); err != nil {
+				log.Printf("Failed to delete expired request %s: %v", stored.RequestID, err)
+			}
+			continue
+		}
+
+		request := &WebsocketRequest{
+			Message:   stored.Message,
+			UserToken: stored.UserToken,
+			CreatedAt: stored.CreatedAt,
+			Deadline:  stored.Deadline,
+			Response:  stored.Response,
+			approval:  newApprovalQuorum(stored.Message),
+		}
+		if request.CreatedAt.IsZero() {
+			// Older stores lack the creation time, derive it from the requested timeout
+			request.CreatedAt = request.Deadline.Add(-time.Duration(requestTimeout(request.Message)) * time.Second)
+		}
+		if stored.Response != nil {
+			// The completion time is not persisted, keep the result for a full retention period
+			request.CompletedAt = now
+			b.completedRequests[stored.RequestID] = request
+		} else {
+			b.pendingRequests[stored.RequestID] = request
+			if request.approval != nil {
+				for _, a := range stored.Approvals {
+					request.approval.approvals = append(request.approval.approvals, approval{nickname: a.Nickname, response: a.Response})
+				}
+			}
+			if now.Before(stored.Deadline) {
+				b.startDeadlineTimer(stored.RequestID, request)
+				b.startRemindersLocked(stored.RequestID, request)
+				if stored.ClaimedBy != "" {
+					b.restoreClaimLocked(stored.RequestID, request, stored.ClaimedBy)
+				}
 			} else {
-				b.mu.Unlock()
-				log.Printf("Received response for unknown request ID: %s", responseWithID.RequestID)
+				// The deadline passed while the server was down, there is no point in escalating
+				b.completeRequestLocked(stored.RequestID, b.timeoutPolicies.Policy(request, false).response(request))
 			}
 		}
+		restored++
+	}
+
+	log.Printf("Restored %d persisted requests (%d pending)", restored, len(b.pendingRequests))
+}
+
+// persistRequestLocked saves a newly broadcast request to the store. It must be called with
+// b.mu held.
+func (b *Broadcaster) persistRequestLocked(requestID string, request *WebsocketRequest) {
+	if b.store == nil {
+		return
+	}
+
+	err := b.store.SaveRequest(&StoredRequest{
+		RequestID: requestID,
+		UserToken: request.UserToken,
+		Message:   request.Message,
+		CreatedAt: request.CreatedAt,
+		Deadline:  request.Deadline,
+	})
+	if err != nil {
+		log.Printf("Failed to persist request %s: %v", requestID, err)
+	}
+}
+
+// persistApprovalsLocked saves the approvals a pending work report collected so far. It must
+// be called with b.mu held.
+func (b *Broadcaster) persistApprovalsLocked(requestID string, quorum *approvalQuorum) {
+	if b.store == nil {
+		return
+	}
+
+	var approvals []StoredApproval
+	for _, a := range quorum.approvals {
+		approvals = append(approvals, StoredApproval{Nickname: a.nickname, Response: a.response})
+	}
+	if err := b.store.SaveApprovals(requestID, approvals); err != nil {
+		log.Printf("Failed to persist approvals for request %s: %v", requestID, err)
+	}
+}
+
+// persistClaimLocked saves who claimed a pending request, if anybody. It must be called with
+// b.mu held.
+func (b *Broadcaster) persistClaimLocked(requestID string, request *WebsocketRequest) {
+	if b.store == nil {
+		return
+	}
+
+	nickname := ""
+	if request.claim != nil {
+		nickname = request.claim.nickname
+	}
+	if err := b.store.SaveClaim(requestID, nickname); err != nil {
+		log.Printf("Failed to persist claim on request %s: %v", requestID, err)
+	}
+}
+
+// forgetRequestLocked removes a request from the store. It must be called with b.mu held.
+func (b *Broadcaster) forgetRequestLocked(requestID string) {
+	if b.store == nil {
+		return
+	}
+
+	if err := b.store.DeleteRequest(requestID); err != nil {
+		log.Printf("Failed to delete persisted request %s: %v", requestID, err)
+	}
+}
+
+// sweepExpiredRequests drops completed requests whose result has been retained long enough
+func (b *Broadcaster) sweepExpiredRequests() {
+	now := time.Now()
+
+	b.mu.Lock()
+	for requestID, request := range b.completedRequests {
+		retainUntil := request.CompletedAt.Add(completedRequestRetention)
+		if request.Deadline.After(retainUntil) {
+			retainUntil = request.Deadline
+		}
+		if now.After(retainUntil) {
+			delete(b.completedRequests, requestID)
+			// A new request may take the ID once the lock is released, its record must
+			// come after the deletion
+			b.forgetRequestLocked(requestID)
+		}
+	}
+	b.mu.Unlock()
+}
+
+// startDeadlineTimer times the request out once its deadline passes.
+// It must be called with b.mu held.
+func (b *Broadcaster) startDeadlineTimer(requestID string, request *WebsocketRequest) {
+	request.deadlineTimer = time.AfterFunc(time.Until(request.Deadline), func() {
+		b.expireRequest(requestID)
+	})
+}
+
+// completeRequestLocked records the final response of a pending request, keeps it for callers
+// that collect it later and delivers it to the waiting caller, if any. It must be called with
+// b.mu held and reports whether the request was pending.
+func (b *Broadcaster) completeRequestLocked(requestID string, response *WebResponse) bool {
+	request, exists := b.pendingRequests[requestID]
+	if !exists {
+		return false
+	}
+
+	delete(b.pendingRequests, requestID)
+	if request.deadlineTimer != nil {
+		request.deadlineTimer.Stop()
+	}
+	if request.fallbackTimer != nil {
+		request.fallbackTimer.Stop()
+	}
+	b.stopRemindersLocked(request)
+	if request.claim != nil {
+		request.claim.timer.Stop()
+		request.claim = nil
+	}
+	request.Response = response
+	request.CompletedAt = time.Now()
+	b.completedRequests[requestID] = request
+	b.completeWatchersLocked(requestID, request, response)
+
+	if b.store != nil {
+		if err := b.store.SaveResponse(requestID, response); err != nil {
+			log.Printf("Failed to persist response for request %s: %v", requestID, err)
+		}
+	}
+
+	responseChan := request.ResponseChan
+	if responseChan == nil {
+		log.Printf("No caller waiting for request %s, keeping its result until it is collected", requestID)
+		return true
+	}
+
+	// Send response to the waiting RPC call
+	go func() {
+		select {
+		case responseChan <- response:
+		default:
+			log.Printf("Failed to send response for request %s: channel not available", requestID)
+		}
+	}()
+	return true
+}
+
+// ErrRequestIDTaken means a request ID is already used by a request of another token, whose
+// caller must not be able to take over its answer
+var ErrRequestIDTaken = errors.New("request ID is already used by another token")
+
+// requestIDTakenResponse is the response of a request whose ID another token uses
+func requestIDTakenResponse() *WebResponse {
+	return &WebResponse{
+		IsError: true,
+		Meta: map[string]string{
+			"error":   "duplicate_request",
+			"message": ErrRequestIDTaken.Error(),
+		},
+	}
+}
+
+// resumeRequestLocked attaches responseChan to a known request of userToken, delivering the
+// result right away if the request has already completed. It must be called with b.mu held
+// and reports whether the request was found, or ErrRequestIDTaken if the request belongs to
+// another token.
+func (b *Broadcaster) resumeRequestLocked(requestID string, userToken string, responseChan chan *WebResponse) (bool, error) {
+	request, completed := b.completedRequests[requestID]
+	if !completed {
+		var pending bool
+		if request, pending = b.pendingRequests[requestID]; !pending {
+			return false, nil
+		}
+	}
+	if request.UserToken != userToken {
+		return false, ErrRequestIDTaken
+	}
+
+	switch {
+	case responseChan == nil:
+	case completed:
+		select {
+		case responseChan <- request.Response:
+		default:
+		}
+	default:
+		request.ResponseChan = responseChan
+	}
+	return true, nil
+}
+
+// ResumeRequest attaches responseChan to a request of userToken that is already known to the
+// broadcaster, e.g. because the caller re-sent it after a reconnect or a server restart.
+// If the request has already completed the response is delivered immediately.
+// It returns the deadline of the request and whether the request was found, or
+// ErrRequestIDTaken if the request belongs to another token.
+func (b *Broadcaster) ResumeRequest(requestID string, userToken string, responseChan chan *WebResponse) (time.Time, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	found, err := b.resumeRequestLocked(requestID, userToken, responseChan)
+	if !found {
+		return time.Time{}, false, err
+	}
+	if request, exists := b.completedRequests[requestID]; exists {
+		return request.Deadline, true, nil
+	}
+	return b.pendingRequests[requestID].Deadline, true, nil
+}
+
+// SubmitRequest registers a request that expires at deadline and broadcasts it to the web
+// clients with a specific token, without waiting for the response. Submitting a request that
+// is already known does not broadcast it again. It returns the deadline of the request and
+// whether the request was already known, or ErrRequestIDTaken if the ID is used by a request
+// of another token.
+func (b *Broadcaster) SubmitRequest(message *agentassistproto.WebsocketMessage, userToken string, deadline time.Time) (time.Time, bool, error) {
+	requestID, _ := requestIDAndType(message)
+	if info, exists := b.lookupRequest(requestID); exists {
+		if info.UserToken != userToken {
+			return time.Time{}, false, ErrRequestIDTaken
+		}
+		return info.Deadline, true, nil
+	}
+
+	request := &WebsocketRequest{
+		Message:   message,
+		UserToken: userToken,
+		CreatedAt: time.Now(),
+		Deadline:  deadline,
+		accepted:  make(chan struct{}),
+		approval:  newApprovalQuorum(message),
+	}
+	b.broadcast <- request
+	// Wait until the request is registered, so that its result can be awaited right away
+	<-request.accepted
+
+	if info, exists := b.lookupRequest(requestID); exists && info.UserToken == userToken {
+		return info.Deadline, false, nil
+	}
+	return deadline, false, nil
+}
+
+// requestInfo describes a request known to the broadcaster
+type requestInfo struct {
+	MessageType string
+	UserToken   string
+	Deadline    time.Time
+}
+
+// lookupRequest returns information about a pending or completed request
+func (b *Broadcaster) lookupRequest(requestID string) (requestInfo, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	request, exists := b.pendingRequests[requestID]
+	if !exists {
+		request, exists = b.completedRequests[requestID]
+	}
+	if !exists {
+		return requestInfo{}, false
+	}
+
+	_, messageType := requestIDAndType(request.Message)
+	return requestInfo{
+		MessageType: messageType,
+		UserToken:   request.UserToken,
+		Deadline:    request.Deadline,
+	}, true
+}
+
+// forClient reports whether a request is meant for the token of client
+func (r *WebsocketRequest) forClient(client *WebClient) bool {
+	return r.UserToken == "" || client.GetToken() == r.UserToken
+}
+
+// mayAnswer reports whether client may answer a request: the request must be meant for its
+// token and routed to it, and with sameToken the client must use the token of the request.
+// Unknown requests may be answered, the reply is refused later on.
+func (b *Broadcaster) mayAnswer(client *WebClient, requestID string, sameToken bool) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	request, exists := b.pendingRequests[requestID]
+	if !exists {
+		request, exists = b.completedRequests[requestID]
+	}
+	if !exists {
+		return true
+	}
+	if sameToken && request.UserToken != client.GetToken() {
+		return false
+	}
+	return request.forClient(client) && request.routedTo(client)
+}
+
+// recordDeliveryLocked records that a request is sent to clients. It must be called with
+// b.mu held.
+func (b *Broadcaster) recordDeliveryLocked(requestID string, request *WebsocketRequest, clients []*WebClient) {
+	if request.deliveredTo == nil {
+		request.deliveredTo = make(map[string]bool)
+	}
+	for _, client := range clients {
+		request.deliveredTo[client.ID] = true
+	}
+	request.DeliveryCount += len(clients)
+	b.publishEventLocked(requestID, request, broadcastEvent(len(clients)))
+}
+
+// markAccepted signals that the broadcaster has registered the request
+func (r *WebsocketRequest) markAccepted() {
+	if r.accepted != nil {
+		close(r.accepted)
+	}
+}
+
+// DetachRequest stops delivering the response of a request to responseChan while
+// keeping the request pending, so that the caller can resume it later
+func (b *Broadcaster) DetachRequest(requestID string, responseChan chan *WebResponse) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if request, exists := b.pendingRequests[requestID]; exists && request.ResponseChan == responseChan {
+		request.ResponseChan = nil
+	}
+}
+
+// requestIDAndType returns the request ID and message type of a request message
+func requestIDAndType(message *agentassistproto.WebsocketMessage) (string, string) {
+	if message.AskQuestionRequest != nil {
+		return message.AskQuestionRequest.ID, "AskQuestion"
+	}
+	if message.WorkReportRequest != nil {
+		return message.WorkReportRequest.ID, "WorkReport"
+	}
+	return "", ""
+}
+
+// requestTimestamp returns the time in milliseconds the agent made a request
+func requestTimestamp(message *agentassistproto.WebsocketMessage) int64 {
+	if message.AskQuestionRequest != nil {
+		return message.AskQuestionRequest.Timestamp
+	}
+	if message.WorkReportRequest != nil {
+		return message.WorkReportRequest.Timestamp
+	}
+	return 0
+}
+
+// requestTimeout returns the timeout in seconds requested by a request message
+func requestTimeout(message *agentassistproto.WebsocketMessage) int32 {
+	var timeout int32
+	if message.AskQuestionRequest != nil && message.AskQuestionRequest.Request != nil {
+		timeout = message.AskQuestionRequest.Request.Timeout
+	} else if message.WorkReportRequest != nil && message.WorkReportRequest.Request != nil {
+		timeout = message.WorkReportRequest.Request.Timeout
+	}
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+	return timeout
+}
+
+// timeoutResponse returns the result of a request that timed out
+func timeoutResponse(request *WebsocketRequest) *WebResponse {
+	return &WebResponse{
+		IsError: true,
+		Meta: map[string]string{
+			"error":   "timeout",
+			"message": fmt.Sprintf("Request timed out after %d seconds", request.timeoutSeconds()),
+		},
 	}
 }
 
+// timeoutSeconds returns how many seconds the request waits for a web user in total
+func (r *WebsocketRequest) timeoutSeconds() int32 {
+	return int32(r.Deadline.Sub(r.CreatedAt).Round(time.Second) / time.Second)
+}
+
 // RegisterClient registers a new web client
 func (b *Broadcaster) RegisterClient(client *WebClient) {
 	b.register <- client
@@ -262,26 +855,43 @@ func (b *Broadcaster) UnregisterClient(client *WebClient) {
 	b.unregister <- client
 }
 
-// BroadcastToToken sends a request to web clients with a specific token
-func (b *Broadcaster) BroadcastToToken(message *agentassistproto.WebsocketMessage, userToken string, responseChan chan *WebResponse) {
+// BroadcastToToken sends a request that expires at deadline to web clients with a specific token
+func (b *Broadcaster) BroadcastToToken(message *agentassistproto.WebsocketMessage, userToken string, deadline time.Time, responseChan chan *WebResponse) {
 	request := &WebsocketRequest{
 		Message:      message,
 		ResponseChan: responseChan,
 		UserToken:    userToken,
+		CreatedAt:    time.Now(),
+		Deadline:     deadline,
+		approval:     newApprovalQuorum(message),
 	}
 	b.broadcast <- request
 }
 
-// BroadcastToAllExcept sends a message to all connected clients except the specified client
-func (b *Broadcaster) BroadcastToAllExcept(message *agentassistproto.WebsocketMessage, excludeClientID string) {
+// BroadcastToRequestClients sends a message about a request to the connected clients that
+// see the request, except the specified client. Clients of other tokens, and users a routed
+// request was not delivered to, are not told about it.
+func (b *Broadcaster) BroadcastToRequestClients(requestID string, message *agentassistproto.WebsocketMessage, excludeClientID string) {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
 
-	log.Printf("Broadcasting message to all clients except %s", excludeClientID)
+	request, exists := b.pendingRequests[requestID]
+	if !exists {
+		request, exists = b.completedRequests[requestID]
+	}
+	if !exists {
+		log.Printf("Not broadcasting message about unknown request %s", requestID)
+		return
+	}
+	b.broadcastToRequestLocked(request, message, excludeClientID)
+}
 
+// broadcastToRequestLocked sends a message about a request to the active clients that see
+// it but the excluded one. It must be called with b.mu held.
+func (b *Broadcaster) broadcastToRequestLocked(request *WebsocketRequest, message *agentassistproto.WebsocketMessage, excludeClientID string) {
 	sentCount := 0
 	for _, client := range b.clients {
-		if client.IsActive() && client.ID != excludeClientID {
+		if client.IsActive() && client.ID != excludeClientID && request.forClient(client) && request.routedTo(client) {
 			go func(c *WebClient) {
 				if !c.Send(message) {
 					// Client failed to receive, unregister it
@@ -295,52 +905,73 @@ func (b *Broadcaster) BroadcastToAllExcept(message *agentassistproto.WebsocketMe
 	log.Printf("Broadcasted message to %d clients (excluding %s)", sentCount, excludeClientID)
 }
 
-// CancelRequest cancels a pending request and notifies all clients
-func (b *Broadcaster) CancelRequest(requestID string, reason string, messageType string) {
+// CancelRequest cancels a pending request and notifies the clients that see it. It reports
+// whether the request was still pending.
+func (b *Broadcaster) CancelRequest(requestID string, reason string, messageType string) bool {
+	return b.finishRequest(requestID, messageType, reason, cancelledResponse(reason))
+}
+
+// CancelAttachedRequest cancels a pending request like CancelRequest, but only while its
+// response still goes to responseChan. A request another caller resumed meanwhile is left
+// pending for that caller. It reports whether the request was cancelled.
+func (b *Broadcaster) CancelAttachedRequest(requestID string, responseChan chan *WebResponse, reason string, messageType string) bool {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	// Check if the request exists
 	request, exists := b.pendingRequests[requestID]
-	if !exists {
+	if !exists || request.ResponseChan != responseChan {
+		return false
+	}
+	b.cancelRequestLocked(requestID, messageType, reason, cancelledResponse(reason))
+	return true
+}
+
+// cancelledResponse is the response of a request that was cancelled for reason
+func cancelledResponse(reason string) *WebResponse {
+	return &WebResponse{
+		IsError: true,
+		Meta: map[string]string{
+			"error":   "cancelled",
+			"message": reason,
+		},
+		Contents: nil,
+	}
+}
+
+// finishRequest completes a pending request with an error response that was not given by
+// a web user and notifies all clients that the request is gone
+func (b *Broadcaster) finishRequest(requestID string, messageType string, reason string, response *WebResponse) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// Check if the request exists
+	if _, exists := b.pendingRequests[requestID]; !exists {
 		log.Printf("Request %s not found for cancellation", requestID)
-		return
+		return false
 	}
 
+	b.cancelRequestLocked(requestID, messageType, reason, response)
+	return true
+}
+
+// cancelRequestLocked completes a pending request with response and tells the clients that
+// see it that it was cancelled. It must be called with b.mu held.
+func (b *Broadcaster) cancelRequestLocked(requestID string, messageType string, reason string, response *WebResponse) {
 	log.Printf("Cancelling request %s with reason: %s", requestID, reason)
 
-	// Remove the request from pending requests
-	delete(b.pendingRequests, requestID)
+	// Record the result and send it to the original requester
+	request := b.pendingRequests[requestID]
+	b.completeRequestLocked(requestID, response)
 
-	// Send error response to the original requester
-	go func() {
-		select {
-		case request.ResponseChan <- &WebResponse{
-			IsError: true,
-			Meta: map[string]string{
-				"error":   "cancelled",
-				"message": reason,
-			},
-			Contents: nil,
-		}:
-		default:
-		}
-	}()
-
-	// Create cancellation notification message
-	cancelMessage := &agentassistproto.WebsocketMessage{
+	// Broadcast cancellation to the clients that see the request
+	b.broadcastToRequestLocked(request, &agentassistproto.WebsocketMessage{
 		Cmd: "RequestCancelled",
 		RequestCancelledNotification: &agentassistproto.RequestCancelledNotification{
 			RequestId:   requestID,
 			Reason:      reason,
 			MessageType: messageType,
 		},
-	}
-
-	// Broadcast cancellation to all clients
-	b.mu.Unlock() // Unlock before broadcasting to avoid deadlock
-	b.BroadcastToAllExcept(cancelMessage, "")
-	b.mu.Lock() // Re-lock for defer unlock
+	}, "")
 }
 
 // GetClientCount returns the number of connected clients
@@ -360,12 +991,7 @@ func (b *Broadcaster) HandleResponse(requestID string, response *WebResponse) {
 	}
 
 	// Send the response to the broadcaster
-	select {
-	case b.responseReceived <- responseWithID:
-		log.Printf("Response for request %s queued for processing", requestID)
-	default:
-		log.Printf("Failed to queue response for request %s: channel full", requestID)
-	}
+	b.queueResponse(responseWithID)
 }
 
 // GetOnlineUsers returns a list of online users with the same token, excluding the requester
@@ -515,6 +1141,19 @@ func (b *Broadcaster) CheckMessageValidity(requestIDs []string) map[string]bool
 
 // GetPendingMessages returns all pending messages for a specific user token
 func (b *Broadcaster) GetPendingMessages(userToken string) []*agentassistproto.PendingMessage {
+	return b.pendingMessages(userToken, func(*WebsocketRequest) bool { return true })
+}
+
+// GetPendingMessagesForClient returns the pending messages of the token of client that the
+// routing rules let it see
+func (b *Broadcaster) GetPendingMessagesForClient(client *WebClient) []*agentassistproto.PendingMessage {
+	return b.pendingMessages(client.GetToken(), func(request *WebsocketRequest) bool {
+		return request.routedTo(client)
+	})
+}
+
+// pendingMessages returns the pending messages for a user token that visible accepts
+func (b *Broadcaster) pendingMessages(userToken string, visible func(*WebsocketRequest) bool) []*agentassistproto.PendingMessage {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
 
@@ -525,27 +1164,30 @@ func (b *Broadcaster) GetPendingMessages(userToken string) []*agentassistproto.P
 		if userToken != "" && request.UserToken != userToken {
 			continue
 		}
+		if !visible(request) {
+			continue
+		}
 
 		// Convert WebsocketRequest to PendingMessage
 		pendingMessage := &agentassistproto.PendingMessage{
-			CreatedAt: 0,   // We don't have timestamp info in current structure
-			Timeout:   600, // Default timeout
+			CreatedAt:     request.CreatedAt.UnixMilli(),
+			Timeout:       request.timeoutSeconds(),
+			Deadline:      request.Deadline.UnixMilli(),
+			DeliveryCount: int32(request.DeliveryCount),
+		}
+		if !request.FirstViewedAt.IsZero() {
+			pendingMessage.FirstViewedAt = request.FirstViewedAt.UnixMilli()
+		}
+		if request.approval != nil {
+			pendingMessage.ApprovalProgress = request.approval.progress(requestID)
 		}
 
 		if request.Message.AskQuestionRequest != nil {
 			pendingMessage.MessageType = "AskQuestion"
 			pendingMessage.AskQuestionRequest = request.Message.AskQuestionRequest
-			pendingMessage.CreatedAt = request.Message.AskQuestionRequest.Timestamp
-			if request.Message.AskQuestionRequest.Request != nil {
-				pendingMessage.Timeout = request.Message.AskQuestionRequest.Request.Timeout
-			}
 		} else if request.Message.WorkReportRequest != nil {
 			pendingMessage.MessageType = "WorkReport"
 			pendingMessage.WorkReportRequest = request.Message.WorkReportRequest
-			pendingMessage.CreatedAt = request.Message.WorkReportRequest.Timestamp
-			if request.Message.WorkReportRequest.Request != nil {
-				pendingMessage.Timeout = request.Message.WorkReportRequest.Request.Timeout
-			}
 		} else {
 			// Skip unknown message types
 			log.Printf("Skipping unknown message type for request ID: %s", requestID)
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	agentassistproto "github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+// claimIdleTimeout is how long a claim lasts unless its user keeps typing or claims again
+const claimIdleTimeout = 2 * time.Minute
+
+// requestClaim is the claim of a web client on a pending request it is answering
+type requestClaim struct {
+	clientID  string
+	nickname  string
+	expiresAt time.Time
+	timer     *time.Timer // Releases the claim once it has been idle for claimIdleTimeout
+}
+
+// heldBy reports whether client holds the claim. A claim restored after a restart has no
+// client yet and is held by the connections of the user who made it.
+func (c *requestClaim) heldBy(client *WebClient) bool {
+	return c.clientID == client.ID || (c.clientID == "" && c.nickname == client.GetNickname())
+}
+
+// claimMessage returns the RequestClaim message describing the claim on a request
+func claimMessage(requestID string, claim *requestClaim) *agentassistproto.RequestClaim {
+	message := &agentassistproto.RequestClaim{RequestId: requestID}
+	if claim != nil {
+		message.ClientId = claim.clientID
+		message.Nickname = claim.nickname
+		message.ExpiresAt = claim.expiresAt.UnixMilli()
+	}
+	return message
+}
+
+// ClaimRequest claims a pending request for client, so that only client can answer it, or
+// renews the claim client already holds. Success is false in the result if the request is
+// claimed by someone else or cannot be answered anymore.
+func (b *Broadcaster) ClaimRequest(client *WebClient, requestID string) *agentassistproto.RequestClaim {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if refusal := b.replyRefusalLocked(client, requestID); refusal != nil {
+		return refusal
+	}
+
+	request := b.pendingRequests[requestID]
+	renewed := request.claim != nil && request.claim.clientID == client.ID
+	if request.claim != nil {
+		request.claim.timer.Stop()
+	}
+
+	nickname := client.GetNickname()
+	request.claim = &requestClaim{
+		clientID:  client.ID,
+		nickname:  nickname,
+		expiresAt: time.Now().Add(claimIdleTimeout),
+		timer: time.AfterFunc(claimIdleTimeout, func() {
+			b.expireClaim(requestID, client.ID)
+		}),
+	}
+
+	if !renewed {
+		// Nobody needs to be reminded of a request that is being answered
+		b.stopRemindersLocked(request)
+		log.Printf("Request %s claimed by client %s (%s)", requestID, client.ID, nickname)
+		b.publishEventLocked(requestID, request, &agentassistproto.RequestEvent{
+			Type:     RequestEventClaimed,
+			Nickname: nickname,
+			Message:  fmt.Sprintf("claimed by %s", nickname),
+		})
+		b.notifyClaimLocked(requestID, request, client.ID)
+		b.persistClaimLocked(requestID, request)
+	}
+
+	result := claimMessage(requestID, request.claim)
+	result.Success = true
+	return result
+}
+
+// ReleaseClaim gives up the claim client holds on a request
+func (b *Broadcaster) ReleaseClaim(client *WebClient, requestID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	request, exists := b.pendingRequests[requestID]
+	if !exists || request.claim == nil || !request.claim.heldBy(client) {
+		return
+	}
+	log.Printf("Client %s released its claim on request %s", client.ID, requestID)
+	b.releaseClaimLocked(requestID, request)
+}
+
+// AcceptReply decides whether a reply of client to a request is accepted. Only the first reply
+// is, and only from the user holding the claim if the request is claimed. A refused reply is
+// answered right away, with ReplyRejected if another user claimed the request and with a
+// ReplyAck otherwise; accepted replies are acknowledged once they are delivered.
+func (b *Broadcaster) AcceptReply(client *WebClient, requestID string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.refuseReplyLocked(client, requestID) {
+		return false
+	}
+	b.acceptReplyLocked(client, requestID, b.pendingRequests[requestID])
+	return true
+}
+
+// refuseReplyLocked tells client why its reply to a request is refused and returns true, or
+// returns false if the reply can be accepted. It must be called with b.mu held.
+func (b *Broadcaster) refuseReplyLocked(client *WebClient, requestID string) bool {
+	request, pending := b.pendingRequests[requestID]
+	switch {
+	case pending && request.answeredBy == "" && request.claim != nil && !request.claim.heldBy(client):
+		refusal := b.replyRefusalLocked(client, requestID)
+		log.Printf("Rejected reply to request %s from client %s: %s", requestID, client.ID, refusal.ErrorMessage)
+		rejection := &agentassistproto.WebsocketMessage{
+			Cmd:          "ReplyRejected",
+			RequestClaim: refusal,
+		}
+		if !client.Send(rejection) {
+			log.Printf("Failed to send ReplyRejected to client %s", client.ID)
+		}
+		return true
+	case !pending || request.answeredBy != "":
+		sendReplyAck(client, b.replyAckLocked(requestID, false))
+		return true
+	}
+	return false
+}
+
+// acceptReplyLocked records that the reply of client to a pending request was accepted, so
+// that later replies are refused even before the broadcaster completes the request. It must
+// be called with b.mu held.
+func (b *Broadcaster) acceptReplyLocked(client *WebClient, requestID string, request *WebsocketRequest) {
+	request.answeredBy = client.GetNickname()
+	if request.claim != nil {
+		request.claim.timer.Stop()
+		request.claim = nil
+		b.persistClaimLocked(requestID, request)
+	}
+}
+
+// replyRefusalLocked returns why client cannot claim or answer a request, nil if it can.
+// It must be called with b.mu held.
+func (b *Broadcaster) replyRefusalLocked(client *WebClient, requestID string) *agentassistproto.RequestClaim {
+	refusal := &agentassistproto.RequestClaim{RequestId: requestID}
+
+	request, pending := b.pendingRequests[requestID]
+	switch {
+	case pending && request.answeredBy != "":
+		refusal.Nickname = request.answeredBy
+		refusal.ErrorMessage = fmt.Sprintf("already answered by %s", request.answeredBy)
+	case pending && request.claim != nil && !request.claim.heldBy(client):
+		refusal = claimMessage(requestID, request.claim)
+		refusal.ErrorMessage = fmt.Sprintf("being answered by %s", request.claim.nickname)
+	case pending:
+		return nil
+	default:
+		completed, exists := b.completedRequests[requestID]
+		switch {
+		case !exists:
+			refusal.ErrorMessage = "unknown request"
+		case completed.Response.RepliedBy != "":
+			refusal.Nickname = completed.Response.RepliedBy
+			refusal.ErrorMessage = fmt.Sprintf("already answered by %s", completed.Response.RepliedBy)
+		default:
+			refusal.ErrorMessage = fmt.Sprintf("request is no longer pending: %s", completed.Response.Meta["message"])
+		}
+	}
+	return refusal
+}
+
+// renewClaimLocked extends the claim client holds on a request, if any. It must be called
+// with b.mu held.
+func (b *Broadcaster) renewClaimLocked(client *WebClient, request *WebsocketRequest) {
+	if request.claim == nil || !request.claim.heldBy(client) {
+		return
+	}
+	request.claim.expiresAt = time.Now().Add(claimIdleTimeout)
+	request.claim.timer.Reset(claimIdleTimeout)
+}
+
+// expireClaim releases the claim of a client on a request that has been idle for too long
+func (b *Broadcaster) expireClaim(requestID, clientID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	request, exists := b.pendingRequests[requestID]
+	if !exists || request.claim == nil || request.claim.clientID != clientID || time.Now().Before(request.claim.expiresAt) {
+		return
+	}
+	log.Printf("Claim of client %s on request %s expired", clientID, requestID)
+	b.releaseClaimLocked(requestID, request)
+}
+
+// releaseClaimsLocked releases all claims of a client that went away. It must be called
+// with b.mu held.
+func (b *Broadcaster) releaseClaimsLocked(client *WebClient) {
+	for requestID, request := range b.pendingRequests {
+		if request.claim != nil && request.claim.clientID == client.ID {
+			log.Printf("Client %s disconnected, releasing its claim on request %s", client.ID, requestID)
+			b.releaseClaimLocked(requestID, request)
+		}
+	}
+}
+
+// releaseClaimLocked removes the claim on a pending request and tells the other web clients
+// that it can be answered again. It must be called with b.mu held.
+func (b *Broadcaster) releaseClaimLocked(requestID string, request *WebsocketRequest) {
+	if request.claim == nil {
+		return
+	}
+	clientID := request.claim.clientID
+	request.claim.timer.Stop()
+	request.claim = nil
+	b.notifyClaimLocked(requestID, request, clientID)
+	b.persistClaimLocked(requestID, request)
+	// Reminders due while the request was claimed are sent right away
+	b.scheduleReminderLocked(requestID, request)
+}
+
+// notifyClaimLocked sends the current claim on a request to the web clients that can see the
+// request, except the one with excludeClientID. It must be called with b.mu held.
+func (b *Broadcaster) notifyClaimLocked(requestID string, request *WebsocketRequest, excludeClientID string) {
+	notification := &agentassistproto.WebsocketMessage{
+		Cmd:          "RequestClaimed",
+		RequestClaim: claimMessage(requestID, request.claim),
+	}
+	b.broadcastToRequestLocked(request, notification, excludeClientID)
+}
+
+// restoreClaimLocked gives the claim a user held on a request before a restart back to them for
+// another claimIdleTimeout, so they can finish their answer once they reconnect. It must be
+// called with b.mu held.
+func (b *Broadcaster) restoreClaimLocked(requestID string, request *WebsocketRequest, nickname string) {
+	request.claim = &requestClaim{
+		nickname:  nickname,
+		expiresAt: time.Now().Add(claimIdleTimeout),
+		timer: time.AfterFunc(claimIdleTimeout, func() {
+			b.expireClaim(requestID, "")
+		}),
+	}
+	b.stopRemindersLocked(request)
+}
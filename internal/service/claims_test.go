@@ -0,0 +1,178 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	agentassistproto "github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+// nextMessage returns the next message with cmd sent to client
+func nextMessage(t *testing.T, client *WebClient, cmd string) *agentassistproto.WebsocketMessage {
+	t.Helper()
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case message := <-client.SendChan:
+			if message.Cmd == cmd {
+				return message
+			}
+		case <-timeout:
+			t.Fatalf("Expected a %s message", cmd)
+			return nil
+		}
+	}
+}
+
+// nextClaimNotification returns the next RequestClaimed notification sent to client
+func nextClaimNotification(t *testing.T, client *WebClient) *agentassistproto.RequestClaim {
+	t.Helper()
+	return nextMessage(t, client, "RequestClaimed").RequestClaim
+}
+
+func TestBroadcaster_ClaimRequest(t *testing.T) {
+	b := NewBroadcaster()
+	alice := NewWebClient("alice-client")
+	alice.SetToken("test-token")
+	alice.SetNickname("alice")
+	bob := NewWebClient("bob-client")
+	bob.SetToken("test-token")
+	bob.SetNickname("bob")
+	b.RegisterClient(alice)
+	b.RegisterClient(bob)
+	time.Sleep(100 * time.Millisecond)
+
+	responseChan := make(chan *WebResponse, 2)
+	for _, requestID := range []string{"req-1", "req-2"} {
+		b.BroadcastToToken(newTestAskQuestionMessage(requestID), "test-token", time.Now().Add(time.Minute), responseChan)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	if claim := b.ClaimRequest(alice, "req-1"); !claim.Success || claim.Nickname != "alice" {
+		t.Fatalf("Expected alice to claim req-1, got %v", claim)
+	}
+	if claim := nextClaimNotification(t, bob); claim.RequestId != "req-1" || claim.Nickname != "alice" {
+		t.Errorf("Expected bob to learn about the claim of alice, got %v", claim)
+	}
+
+	// Bob can neither claim nor answer the claimed request
+	if claim := b.ClaimRequest(bob, "req-1"); claim.Success || claim.ErrorMessage != "being answered by alice" {
+		t.Errorf("Expected the claim of bob to be refused, got %v", claim)
+	}
+	if b.AcceptReply(bob, "req-1") {
+		t.Error("Expected the reply of bob to be refused")
+	}
+	if refusal := nextMessage(t, bob, "ReplyRejected").RequestClaim; refusal.ErrorMessage != "being answered by alice" {
+		t.Errorf("Expected bob to be told alice is answering, got %v", refusal)
+	}
+
+	// Alice answers, later replies are told who answered
+	if !b.AcceptReply(alice, "req-1") {
+		t.Fatal("Expected the reply of alice to be accepted")
+	}
+	if b.AcceptReply(bob, "req-1") {
+		t.Error("Expected the second reply to be refused")
+	}
+	if ack := nextMessage(t, bob, "ReplyAck").ReplyAck; ack.Status != ReplyStatusAlreadyAnswered || ack.RepliedBy != "alice" {
+		t.Errorf("Expected bob to be told alice answered, got %v", ack)
+	}
+
+	// Claims are released when they are idle for too long
+	b.ClaimRequest(alice, "req-2")
+	nextClaimNotification(t, bob)
+	b.mu.Lock()
+	b.pendingRequests["req-2"].claim.expiresAt = time.Now()
+	b.mu.Unlock()
+	b.expireClaim("req-2", alice.ID)
+	if claim := nextClaimNotification(t, bob); claim.RequestId != "req-2" || claim.ClientId != "" {
+		t.Errorf("Expected the idle claim to be released, got %v", claim)
+	}
+
+	// And when their client disconnects
+	if claim := b.ClaimRequest(bob, "req-2"); !claim.Success {
+		t.Fatalf("Expected bob to claim the released request, got %v", claim)
+	}
+	b.UnregisterClient(bob)
+	time.Sleep(100 * time.Millisecond)
+	if claim := b.ClaimRequest(alice, "req-2"); !claim.Success {
+		t.Errorf("Expected the claim of the disconnected client to be released, got %v", claim)
+	}
+}
+
+func TestWebSocketHandler_AuthorizeReplyWithoutTokens(t *testing.T) {
+	router, err := NewRouter([]RouteConfig{{Project: "/work/acme", To: []string{"alice"}}}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create router: %v", err)
+	}
+	b := NewBroadcasterWithOptions(BroadcasterOptions{Router: router})
+	h := NewWebSocketHandler(b)
+
+	alice := NewWebClient("alice-client")
+	alice.SetToken("test-token")
+	alice.SetNickname("alice")
+	bob := NewWebClient("bob-client")
+	bob.SetToken("test-token")
+	bob.SetNickname("bob")
+	other := NewWebClient("other-client")
+	other.SetToken("other-token")
+	other.SetNickname("alice")
+	for _, client := range []*WebClient{alice, bob, other} {
+		b.RegisterClient(client)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	responseChan := make(chan *WebResponse, 1)
+	b.BroadcastToToken(newTestRoutedMessage("req-1", "/work/acme/app", ""), "test-token", time.Now().Add(time.Minute), responseChan)
+	time.Sleep(50 * time.Millisecond)
+
+	if !h.authorizeReply(alice, "req-1") {
+		t.Error("Expected the targeted user to be allowed to answer")
+	}
+	if h.authorizeReply(bob, "req-1") {
+		t.Error("Expected a user the request is not routed to to be refused")
+	}
+	if h.authorizeReply(other, "req-1") {
+		t.Error("Expected a user of another token to be refused")
+	}
+	if !h.authorizeReply(bob, "unknown") {
+		t.Error("Expected replies to unknown requests to be left to the reply handling")
+	}
+}
+
+func TestWebSocketHandler_ReplyWithoutResponse(t *testing.T) {
+	b := NewBroadcaster()
+	h := NewWebSocketHandler(b)
+	client := NewWebClient("client-1")
+	client.SetToken("test-token")
+	client.SetNickname("alice")
+	b.RegisterClient(client)
+	time.Sleep(50 * time.Millisecond)
+
+	responseChan := make(chan *WebResponse, 1)
+	b.BroadcastToToken(newTestAskQuestionMessage("req-1"), "test-token", time.Now().Add(time.Minute), responseChan)
+	nextMessage(t, client, "AskQuestion")
+
+	// A reply without a response is refused and leaves the request open
+	message := &agentassistproto.WebsocketMessage{
+		Cmd:                "AskQuestionReply",
+		AskQuestionRequest: &agentassistproto.AskQuestionRequest{ID: "req-1"},
+	}
+	if h.handleAskQuestionReply(client, message) {
+		t.Fatal("Expected a reply without a response to be refused")
+	}
+	if ack := nextMessage(t, client, "ReplyAck").ReplyAck; ack.Status != ReplyStatusInvalid {
+		t.Errorf("Expected an invalid reply ack, got %v", ack)
+	}
+	message = &agentassistproto.WebsocketMessage{
+		Cmd:               "WorkReportReply",
+		WorkReportRequest: &agentassistproto.WorkReportRequest{ID: "req-1"},
+	}
+	if h.handleWorkReportReply(client, message) {
+		t.Fatal("Expected a work report reply without a response to be refused")
+	}
+	nextMessage(t, client, "ReplyAck")
+
+	if !b.AcceptReply(client, "req-1") {
+		t.Error("Expected the request to still accept a reply")
+	}
+}
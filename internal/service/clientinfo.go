@@ -0,0 +1,64 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+// mcpClientInfoRetention is how long the client info of an MCP session is kept after it
+// was last reported or used
+const mcpClientInfoRetention = 24 * time.Hour
+
+type mcpClientInfoEntry struct {
+	info     *agentassistproto.McpClientInfoData
+	lastUsed time.Time
+}
+
+// mcpClientInfoStore keeps the client info each MCP session reported, keyed by the user
+// token and the session ID, so several IDEs sharing one token are told apart
+type mcpClientInfoStore struct {
+	mu       sync.Mutex
+	sessions map[string]*mcpClientInfoEntry
+}
+
+func newMcpClientInfoStore() *mcpClientInfoStore {
+	return &mcpClientInfoStore{sessions: make(map[string]*mcpClientInfoEntry)}
+}
+
+func mcpClientInfoKey(userToken, sessionID string) string {
+	return userToken + "/" + sessionID
+}
+
+// set stores the client info of a session and drops sessions that have not been used
+// within mcpClientInfoRetention
+func (s *mcpClientInfoStore) set(userToken, sessionID string, info *agentassistproto.McpClientInfoData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for key, entry := range s.sessions {
+		if now.Sub(entry.lastUsed) > mcpClientInfoRetention {
+			delete(s.sessions, key)
+		}
+	}
+	s.sessions[mcpClientInfoKey(userToken, sessionID)] = &mcpClientInfoEntry{info: info, lastUsed: now}
+}
+
+// get returns the client info of a session, or nil if the session did not report one
+func (s *mcpClientInfoStore) get(userToken, sessionID string) *agentassistproto.McpClientInfoData {
+	if sessionID == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, exists := s.sessions[mcpClientInfoKey(userToken, sessionID)]
+	if !exists {
+		return nil
+	}
+	entry.lastUsed = time.Now()
+	return entry.info
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	agentassistproto "github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+const (
+	// defaultDeadlineExtension is how much more time ExtendDeadline gives when the web user
+	// does not say
+	defaultDeadlineExtension = 10 * time.Minute
+	// DefaultMaxRequestLifetime is how long after its creation a request may be extended to
+	// unless the server is configured otherwise
+	DefaultMaxRequestLifetime = 4 * time.Hour
+)
+
+// ExtendDeadline pushes the deadline of a pending request out by extendBy for a web user who
+// needs more time to answer it, at most to MaxRequestLifetime after the request was created.
+// The web clients that see the request are told the new deadline. Success is false in the
+// result if the request cannot be extended.
+func (b *Broadcaster) ExtendDeadline(client *WebClient, requestID string, extendBy time.Duration) *agentassistproto.DeadlineExtension {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	result := &agentassistproto.DeadlineExtension{RequestId: requestID}
+	request, pending := b.pendingRequests[requestID]
+	switch {
+	case !pending:
+		result.ErrorMessage = "request is no longer pending"
+		return result
+	case request.answeredBy != "":
+		result.ErrorMessage = fmt.Sprintf("already answered by %s", request.answeredBy)
+		return result
+	}
+	result.Deadline = request.Deadline.UnixMilli()
+
+	nickname := client.GetNickname()
+	deadline, err := b.extendDeadlineLocked(requestID, request, nickname, extendBy)
+	if err != nil {
+		result.ErrorMessage = err.Error()
+		return result
+	}
+	b.notifyDeadlineLocked(requestID, request, nickname, client.ID)
+
+	result.Success = true
+	result.Deadline = deadline.UnixMilli()
+	result.Nickname = nickname
+	return result
+}
+
+// extendDeadlineLocked pushes the deadline of a pending request out by extendBy for the web
+// user nickname, at most to the maximum lifetime of the request, and publishes the extended
+// event. It must be called with b.mu held.
+func (b *Broadcaster) extendDeadlineLocked(requestID string, request *WebsocketRequest, nickname string, extendBy time.Duration) (time.Time, error) {
+	if extendBy <= 0 {
+		extendBy = defaultDeadlineExtension
+	}
+	deadline := request.Deadline.Add(extendBy)
+	if limit := request.CreatedAt.Add(b.maxLifetime); deadline.After(limit) {
+		deadline = limit
+	}
+	if !deadline.After(request.Deadline) {
+		return request.Deadline, fmt.Errorf("requests may wait at most %s", b.maxLifetime)
+	}
+
+	log.Printf("%s extended the deadline of request %s to %s", nickname, requestID, deadline.Format(time.RFC3339))
+	b.setDeadlineLocked(requestID, request, deadline)
+	b.publishEventLocked(requestID, request, &agentassistproto.RequestEvent{
+		Type:     RequestEventExtended,
+		Nickname: nickname,
+		Message:  fmt.Sprintf("%s needs more time, the deadline is now %s", nickname, deadline.Format(time.RFC3339)),
+	})
+	return deadline, nil
+}
+
+// setDeadlineLocked moves the deadline of a pending request and re-arms its timer. It must be
+// called with b.mu held.
+func (b *Broadcaster) setDeadlineLocked(requestID string, request *WebsocketRequest, deadline time.Time) {
+	request.Deadline = deadline
+	if request.deadlineTimer != nil {
+		request.deadlineTimer.Stop()
+	}
+	b.startDeadlineTimer(requestID, request)
+
+	if b.store != nil {
+		if err := b.store.SaveDeadline(requestID, deadline); err != nil {
+			log.Printf("Failed to persist deadline for request %s: %v", requestID, err)
+		}
+	}
+}
+
+// notifyDeadlineLocked sends the deadline of a request to the web clients that can see it,
+// except the one with excludeClientID. nickname is who moved the deadline, empty for the
+// server. It must be called with b.mu held.
+func (b *Broadcaster) notifyDeadlineLocked(requestID string, request *WebsocketRequest, nickname, excludeClientID string) {
+	notification := &agentassistproto.WebsocketMessage{
+		Cmd: "DeadlineExtended",
+		DeadlineExtension: &agentassistproto.DeadlineExtension{
+			RequestId: requestID,
+			Deadline:  request.Deadline.UnixMilli(),
+			Nickname:  nickname,
+		},
+	}
+	b.broadcastToRequestLocked(request, notification, excludeClientID)
+}
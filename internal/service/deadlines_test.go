@@ -0,0 +1,60 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBroadcaster_ExtendDeadline(t *testing.T) {
+	b := NewBroadcasterWithOptions(BroadcasterOptions{MaxRequestLifetime: time.Second})
+	alice := NewWebClient("alice-client")
+	alice.SetToken("test-token")
+	alice.SetNickname("alice")
+	bob := NewWebClient("bob-client")
+	bob.SetToken("test-token")
+	bob.SetNickname("bob")
+	b.RegisterClient(alice)
+	b.RegisterClient(bob)
+	time.Sleep(100 * time.Millisecond)
+
+	responseChan := make(chan *WebResponse, 1)
+	b.BroadcastToToken(newTestAskQuestionMessage("req-1"), "test-token", time.Now().Add(300*time.Millisecond), responseChan)
+	time.Sleep(100 * time.Millisecond)
+
+	// Alice needs more time, bob is told the new deadline
+	extension := b.ExtendDeadline(alice, "req-1", 400*time.Millisecond)
+	if !extension.Success || extension.Nickname != "alice" {
+		t.Fatalf("Expected the deadline to be extended, got %v", extension)
+	}
+	notification := nextMessage(t, bob, "DeadlineExtended").DeadlineExtension
+	if notification.Deadline != extension.Deadline || notification.Nickname != "alice" {
+		t.Errorf("Expected bob to get the new deadline, got %v", notification)
+	}
+	if pending := b.GetPendingMessages("test-token"); len(pending) != 1 || pending[0].Deadline != extension.Deadline {
+		t.Errorf("Expected the pending request to have the new deadline, got %v", pending)
+	}
+
+	// The request outlives its original deadline
+	time.Sleep(300 * time.Millisecond)
+	select {
+	case response := <-responseChan:
+		t.Fatalf("Expected the extended request to be pending, got %v", response)
+	default:
+	}
+
+	// Extensions stop at the maximum lifetime of a request
+	extension = b.ExtendDeadline(bob, "req-1", time.Hour)
+	if !extension.Success || time.UnixMilli(extension.Deadline).After(time.Now().Add(time.Second)) {
+		t.Errorf("Expected the deadline to be capped by the maximum lifetime, got %v", extension)
+	}
+	if extension = b.ExtendDeadline(bob, "req-1", time.Hour); extension.Success || extension.ErrorMessage == "" {
+		t.Errorf("Expected an extension past the maximum lifetime to be refused, got %v", extension)
+	}
+
+	if response := <-responseChan; response.Meta["error"] != "timeout" {
+		t.Errorf("Expected the request to time out at the extended deadline, got %v", response)
+	}
+	if extension = b.ExtendDeadline(alice, "req-1", time.Minute); extension.Success {
+		t.Errorf("Expected a timed out request not to be extended, got %v", extension)
+	}
+}
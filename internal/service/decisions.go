@@ -0,0 +1,42 @@
+package service
+
+import (
+	"fmt"
+
+	agentassistproto "github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+// Decisions of a web user on a work report, see WorkReportDecision in agentassist.proto
+const (
+	DecisionApproved         = "approved"
+	DecisionRejected         = "rejected"
+	DecisionChangesRequested = "changes_requested"
+)
+
+// ValidateDecision checks the decision of a work report reply, a missing decision is valid
+func ValidateDecision(decision *agentassistproto.WorkReportDecision) error {
+	if decision == nil {
+		return nil
+	}
+
+	switch decision.Decision {
+	case DecisionApproved, DecisionRejected, DecisionChangesRequested:
+	default:
+		return fmt.Errorf("unknown decision %q, must be %s, %s or %s",
+			decision.Decision, DecisionApproved, DecisionRejected, DecisionChangesRequested)
+	}
+	for i, comment := range decision.Comments {
+		if comment.Comment == "" {
+			return fmt.Errorf("comment %d is empty", i+1)
+		}
+	}
+	return nil
+}
+
+// decision returns the decision of a work report reply, plain confirmations approve
+func decision(response *WebResponse) string {
+	if response.Decision == nil {
+		return DecisionApproved
+	}
+	return response.Decision.Decision
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"testing"
+
+	agentassistproto "github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+func TestValidateDecision(t *testing.T) {
+	valid := []*agentassistproto.WorkReportDecision{
+		nil,
+		{Decision: DecisionApproved},
+		{Decision: DecisionRejected},
+		{Decision: DecisionChangesRequested, Comments: []*agentassistproto.WorkReportComment{
+			{Item: "main.go", Comment: "Handle the error"},
+			{Comment: "Add a test"},
+		}},
+	}
+	for _, decision := range valid {
+		if err := ValidateDecision(decision); err != nil {
+			t.Errorf("Expected %v to be valid, got: %v", decision, err)
+		}
+	}
+
+	invalid := []*agentassistproto.WorkReportDecision{
+		{},
+		{Decision: "maybe"},
+		{Decision: DecisionChangesRequested, Comments: []*agentassistproto.WorkReportComment{{Item: "main.go"}}},
+	}
+	for _, decision := range invalid {
+		if err := ValidateDecision(decision); err == nil {
+			t.Errorf("Expected %v to be rejected", decision)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	agentassistproto "github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+// Types of the lifecycle events of a request, see RequestEvent in agentassist.proto
+const (
+	RequestEventQueued     = "queued"
+	RequestEventBroadcast  = "broadcast"
+	RequestEventViewed     = "viewed"
+	RequestEventReplyDraft = "reply_draft"
+	RequestEventClaimed    = "claimed"
+	RequestEventApproved   = "approved"
+	RequestEventEscalated  = "escalated"
+	RequestEventExtended   = "extended"
+	RequestEventInterim    = "interim_reply"
+	RequestEventReminded   = "reminded"
+	// Final events, one of them ends the lifecycle of every request
+	RequestEventNoClients = "no_clients"
+	RequestEventAnswered  = "answered"
+	RequestEventCancelled = "cancelled"
+	RequestEventTimedOut  = "timed_out"
+)
+
+// requestEventBuffer is how many events a watcher may fall behind before events are dropped
+const requestEventBuffer = 16
+
+// WatchRequest subscribes to the lifecycle events of a request. The events so far are replayed
+// first and the channel is closed after the final event, right away if the request has already
+// completed. The returned function ends the subscription. The last result is false if the
+// request is not known.
+func (b *Broadcaster) WatchRequest(requestID string) (<-chan *agentassistproto.RequestEvent, func(), bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	request, pending := b.pendingRequests[requestID]
+	if !pending {
+		completed, exists := b.completedRequests[requestID]
+		if !exists {
+			return nil, nil, false
+		}
+		request = completed
+	}
+
+	events := make(chan *agentassistproto.RequestEvent, requestEventBuffer+len(request.events))
+	for _, event := range request.events {
+		events <- event
+	}
+	if !pending {
+		close(events)
+		return events, func() {}, true
+	}
+
+	if b.watchers[requestID] == nil {
+		b.watchers[requestID] = make(map[chan *agentassistproto.RequestEvent]struct{})
+	}
+	b.watchers[requestID][events] = struct{}{}
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, watching := b.watchers[requestID][events]; watching {
+			delete(b.watchers[requestID], events)
+			close(events)
+		}
+	}
+	return events, unsubscribe, true
+}
+
+// broadcastEvent returns the event of a request delivered to clientCount web clients
+func broadcastEvent(clientCount int) *agentassistproto.RequestEvent {
+	message := fmt.Sprintf("delivered to %d clients", clientCount)
+	if clientCount == 1 {
+		message = "delivered to 1 client"
+	}
+	return &agentassistproto.RequestEvent{
+		Type:        RequestEventBroadcast,
+		ClientCount: int32(clientCount),
+		Message:     message,
+	}
+}
+
+// finalEvent returns the event of a request that completed with response
+func finalEvent(response *WebResponse) *agentassistproto.RequestEvent {
+	event := &agentassistproto.RequestEvent{Final: true, Message: response.Meta["message"]}
+	if response.Meta["timeout_policy"] != "" {
+		// Answered automatically, nobody answered in time
+		event.Type = RequestEventTimedOut
+		return event
+	}
+	switch response.Meta["error"] {
+	case "no_clients":
+		event.Type = RequestEventNoClients
+	case "cancelled":
+		event.Type = RequestEventCancelled
+	case "timeout":
+		event.Type = RequestEventTimedOut
+	default:
+		event.Type = RequestEventAnswered
+		event.Nickname = response.RepliedBy
+		event.Message = "answered"
+		if response.RepliedBy != "" {
+			event.Message = fmt.Sprintf("answered by %s", response.RepliedBy)
+		}
+	}
+	return event
+}
+
+// RequestViewed records that the user of client viewed a request
+func (b *Broadcaster) RequestViewed(client *WebClient, requestID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	request := b.watchableRequestLocked(client, requestID)
+	if request == nil {
+		return
+	}
+
+	nickname := client.GetNickname()
+	if request.viewedBy == nil {
+		request.viewedBy = make(map[string]bool)
+	}
+	if request.viewedBy[nickname] {
+		return
+	}
+	request.viewedBy[nickname] = true
+	if request.FirstViewedAt.IsZero() {
+		request.FirstViewedAt = time.Now()
+	}
+
+	b.publishEventLocked(requestID, request, &agentassistproto.RequestEvent{
+		Type:     RequestEventViewed,
+		Nickname: nickname,
+		Message:  fmt.Sprintf("viewed by %s", nickname),
+	})
+}
+
+// ReplyDraft records that the user of client is typing a reply to a request
+func (b *Broadcaster) ReplyDraft(client *WebClient, requestID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	request := b.watchableRequestLocked(client, requestID)
+	if request == nil {
+		return
+	}
+
+	// Typing keeps the claim of the user alive
+	b.renewClaimLocked(client, request)
+
+	nickname := client.GetNickname()
+	b.publishEventLocked(requestID, request, &agentassistproto.RequestEvent{
+		Type:     RequestEventReplyDraft,
+		Nickname: nickname,
+		Message:  fmt.Sprintf("%s is typing", nickname),
+	})
+}
+
+// watchableRequestLocked returns the pending request with the given ID if client may see it.
+// It must be called with b.mu held.
+func (b *Broadcaster) watchableRequestLocked(client *WebClient, requestID string) *WebsocketRequest {
+	request, exists := b.pendingRequests[requestID]
+	if !exists {
+		return nil
+	}
+	if request.UserToken != "" && request.UserToken != client.GetToken() {
+		log.Printf("Client %s reported activity on request %s of another user", client.ID, requestID)
+		return nil
+	}
+	return request
+}
+
+// publishEventLocked records an event of a pending request and sends it to its watchers.
+// Drafts are not recorded, they are only of interest while they happen. It must be called
+// with b.mu held.
+func (b *Broadcaster) publishEventLocked(requestID string, request *WebsocketRequest, event *agentassistproto.RequestEvent) {
+	event.ID = requestID
+	event.Timestamp = time.Now().UnixMilli()
+	if event.Type != RequestEventReplyDraft {
+		request.events = append(request.events, event)
+	}
+	b.webhooks.notify(requestID, request, event)
+
+	for events := range b.watchers[requestID] {
+		select {
+		case events <- event:
+		default:
+			log.Printf("Dropped %s event of request %s: watcher is not keeping up", event.Type, requestID)
+		}
+	}
+}
+
+// completeWatchersLocked publishes the final event of a request that completed with response
+// and ends the subscriptions to it. It must be called with b.mu held.
+func (b *Broadcaster) completeWatchersLocked(requestID string, request *WebsocketRequest, response *WebResponse) {
+	b.publishEventLocked(requestID, request, finalEvent(response))
+	for events := range b.watchers[requestID] {
+		close(events)
+	}
+	delete(b.watchers, requestID)
+}
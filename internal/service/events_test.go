@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	agentassistproto "github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+func TestBroadcaster_WatchRequest(t *testing.T) {
+	b := NewBroadcaster()
+	alice := NewWebClient("client1")
+	alice.SetToken("test-token")
+	alice.SetNickname("alice")
+	b.RegisterClient(alice)
+	time.Sleep(100 * time.Millisecond)
+
+	responseChan := make(chan *WebResponse, 1)
+	b.BroadcastToToken(newTestAskQuestionMessage("req-1"), "test-token", time.Now().Add(time.Minute), responseChan)
+	<-alice.SendChan
+
+	// Events before the subscription are replayed
+	events, unsubscribe, pending := b.WatchRequest("req-1")
+	if !pending {
+		t.Fatal("Expected the request to be pending")
+	}
+	defer unsubscribe()
+
+	b.RequestViewed(alice, "req-1")
+	b.RequestViewed(alice, "req-1")
+	b.ReplyDraft(alice, "req-1")
+
+	other := NewWebClient("client2")
+	other.SetToken("other-token")
+	b.RequestViewed(other, "req-1")
+
+	b.HandleResponse("req-1", &WebResponse{RepliedBy: "alice"})
+
+	var types []string
+	for event := range events {
+		if event.ID != "req-1" {
+			t.Errorf("Unexpected request ID in event: %v", event)
+		}
+		types = append(types, event.Type)
+		if event.Type == RequestEventBroadcast && event.ClientCount != 1 {
+			t.Errorf("Expected delivery to 1 client, got %d", event.ClientCount)
+		}
+		if event.Type == RequestEventViewed && event.Message != "viewed by alice" {
+			t.Errorf("Unexpected viewed message: %q", event.Message)
+		}
+		if event.Final != (event.Type == RequestEventAnswered) {
+			t.Errorf("Expected only the answered event to be final, got %v", event)
+		}
+		if event.Type == RequestEventAnswered && event.Nickname != "alice" {
+			t.Errorf("Expected the request to be answered by alice, got %v", event)
+		}
+	}
+
+	expected := []string{RequestEventQueued, RequestEventBroadcast, RequestEventViewed, RequestEventReplyDraft, RequestEventAnswered}
+	if len(types) != len(expected) {
+		t.Fatalf("Expected events %v, got %v", expected, types)
+	}
+	for i := range expected {
+		if types[i] != expected[i] {
+			t.Errorf("Expected events %v, got %v", expected, types)
+		}
+	}
+}
+
+func TestWatchRequestRPC(t *testing.T) {
+	registry := newTestTokenRegistry(t)
+	svc := NewAgentAssistService()
+	bob := NewWebClient("client1")
+	bob.SetToken("team")
+	bob.SetNickname("bob")
+	svc.GetBroadcaster().RegisterClient(bob)
+	time.Sleep(100 * time.Millisecond)
+
+	mux := http.NewServeMux()
+	mux.Handle(agentassistproto.NewSrvAgentAssistHandler(svc, connect.WithInterceptors(NewAuthInterceptor(registry))))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := agentassistproto.NewSrvAgentAssistClient(server.Client(), server.URL)
+	withToken := func(req connect.AnyRequest, token string) {
+		req.Header().Set("Authorization", "Bearer "+token)
+	}
+
+	submit := connect.NewRequest(&agentassistproto.SubmitRequestRequest{
+		AskQuestionRequest: newTestAskQuestionMessage("req-1").AskQuestionRequest,
+	})
+	withToken(submit, "alice-agent")
+	if _, err := client.SubmitRequest(context.Background(), submit); err != nil {
+		t.Fatalf("Failed to submit request: %v", err)
+	}
+
+	// Streaming calls are authenticated as well
+	watch := connect.NewRequest(&agentassistproto.WatchRequestRequest{ID: "req-1"})
+	withToken(watch, "bob-human")
+	stream, err := client.WatchRequest(context.Background(), watch)
+	if err == nil {
+		for stream.Receive() {
+		}
+		err = stream.Err()
+	}
+	if connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("Expected CodePermissionDenied with human token, got: %v", err)
+	}
+
+	watch = connect.NewRequest(&agentassistproto.WatchRequestRequest{ID: "req-1"})
+	withToken(watch, "alice-agent")
+	stream, err = client.WatchRequest(context.Background(), watch)
+	if err != nil {
+		t.Fatalf("Failed to watch request: %v", err)
+	}
+	defer stream.Close()
+
+	for _, eventType := range []string{RequestEventQueued, RequestEventBroadcast} {
+		if !stream.Receive() || stream.Msg().Type != eventType {
+			t.Fatalf("Expected the %s event, got %v (%v)", eventType, stream.Msg(), stream.Err())
+		}
+	}
+
+	svc.GetBroadcaster().RequestViewed(bob, "req-1")
+	if !stream.Receive() || stream.Msg().Message != "viewed by bob" {
+		t.Fatalf("Expected the viewed event, got %v (%v)", stream.Msg(), stream.Err())
+	}
+
+	// The stream ends with the final event of the request
+	svc.GetBroadcaster().CancelRequest("req-1", AgentCancelledReason, "AskQuestion")
+	if !stream.Receive() || stream.Msg().Type != RequestEventCancelled || stream.Msg().Message != AgentCancelledReason {
+		t.Fatalf("Expected the cancelled event, got %v (%v)", stream.Msg(), stream.Err())
+	}
+	if stream.Receive() {
+		t.Errorf("Expected the stream to end, got %v", stream.Msg())
+	}
+	if err := stream.Err(); err != nil {
+		t.Errorf("Expected the stream to end cleanly, got: %v", err)
+	}
+}
+
+func TestBroadcaster_WatchCompletedRequest(t *testing.T) {
+	b := NewBroadcasterWithOptions(BroadcasterOptions{NoClientsPolicy: NoClientsPolicyFail})
+
+	// Without web clients the request fails right away
+	responseChan := make(chan *WebResponse, 1)
+	b.BroadcastToToken(newTestAskQuestionMessage("req-1"), "test-token", time.Now().Add(time.Minute), responseChan)
+	<-responseChan
+
+	events, unsubscribe, exists := b.WatchRequest("req-1")
+	if !exists {
+		t.Fatal("Expected the completed request to be known")
+	}
+	defer unsubscribe()
+
+	var last *agentassistproto.RequestEvent
+	for event := range events {
+		last = event
+	}
+	if last == nil || last.Type != RequestEventNoClients || !last.Final {
+		t.Errorf("Expected the no_clients event last, got %v", last)
+	}
+
+	if _, _, exists := b.WatchRequest("req-2"); exists {
+		t.Error("Expected an unknown request not to be watchable")
+	}
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	agentassistproto "github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+// InterimReply forwards reply.Text to the agent waiting for a pending request without
+// answering it, e.g. "looking into it, hold on". The request stays pending until the final
+// reply; its deadline is pushed out by reply.ExtendSeconds if set. The other web clients that
+// see the request are told about the interim reply. Success is false in the result if the
+// request cannot be answered by client anymore.
+func (b *Broadcaster) InterimReply(client *WebClient, reply *agentassistproto.InterimReply) *agentassistproto.InterimReply {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	requestID := reply.GetRequestId()
+	result := &agentassistproto.InterimReply{RequestId: requestID}
+	text := strings.TrimSpace(reply.GetText())
+	if text == "" {
+		result.ErrorMessage = "interim reply is empty"
+		return result
+	}
+	if refusal := b.replyRefusalLocked(client, requestID); refusal != nil {
+		result.ErrorMessage = refusal.ErrorMessage
+		return result
+	}
+
+	request := b.pendingRequests[requestID]
+	// Telling the agent to hold on keeps the claim of the user alive
+	b.renewClaimLocked(client, request)
+
+	nickname := client.GetNickname()
+	log.Printf("Client %s (%s) sent an interim reply to request %s", client.ID, nickname, requestID)
+	b.publishEventLocked(requestID, request, &agentassistproto.RequestEvent{
+		Type:     RequestEventInterim,
+		Nickname: nickname,
+		Message:  fmt.Sprintf("%s: %s", nickname, text),
+	})
+
+	if reply.GetExtendSeconds() > 0 {
+		extendBy := time.Duration(reply.GetExtendSeconds()) * time.Second
+		if _, err := b.extendDeadlineLocked(requestID, request, nickname, extendBy); err != nil {
+			// The agent got the interim reply anyway, the deadline just stays
+			log.Printf("Interim reply to request %s did not extend its deadline: %v", requestID, err)
+		} else {
+			b.notifyDeadlineLocked(requestID, request, nickname, client.ID)
+		}
+	}
+
+	result.Text = text
+	result.ExtendSeconds = reply.GetExtendSeconds()
+	result.Nickname = nickname
+	result.Deadline = request.Deadline.UnixMilli()
+	result.Success = true
+
+	notification := &agentassistproto.WebsocketMessage{
+		Cmd:          "InterimReply",
+		InterimReply: result,
+	}
+	b.broadcastToRequestLocked(request, notification, client.ID)
+	return result
+}
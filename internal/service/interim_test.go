@@ -0,0 +1,83 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	agentassistproto "github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+func TestBroadcaster_InterimReply(t *testing.T) {
+	b := NewBroadcaster()
+	alice := NewWebClient("alice-client")
+	alice.SetToken("test-token")
+	alice.SetNickname("alice")
+	bob := NewWebClient("bob-client")
+	bob.SetToken("test-token")
+	bob.SetNickname("bob")
+	b.RegisterClient(alice)
+	b.RegisterClient(bob)
+	time.Sleep(100 * time.Millisecond)
+
+	responseChan := make(chan *WebResponse, 1)
+	deadline := time.Now().Add(time.Minute)
+	b.BroadcastToToken(newTestAskQuestionMessage("req-1"), "test-token", deadline, responseChan)
+	time.Sleep(100 * time.Millisecond)
+	events, stop, watched := b.WatchRequest("req-1")
+	if !watched {
+		t.Fatal("Expected the request to be watchable")
+	}
+	defer stop()
+
+	// The agent is told to hold on, the request stays pending with a later deadline
+	result := b.InterimReply(alice, &agentassistproto.InterimReply{RequestId: "req-1", Text: " Looking into it ", ExtendSeconds: 600})
+	if !result.Success || result.Text != "Looking into it" || result.Nickname != "alice" ||
+		!time.UnixMilli(result.Deadline).After(deadline.Add(9*time.Minute)) {
+		t.Fatalf("Expected the interim reply to be forwarded with a later deadline, got %v", result)
+	}
+	if notification := nextMessage(t, bob, "InterimReply").InterimReply; notification.Text != "Looking into it" || notification.Nickname != "alice" {
+		t.Errorf("Expected bob to see the interim reply, got %v", notification)
+	}
+	var interim, extended *agentassistproto.RequestEvent
+	for interim == nil || extended == nil {
+		select {
+		case event := <-events:
+			switch event.Type {
+			case RequestEventInterim:
+				interim = event
+			case RequestEventExtended:
+				extended = event
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Expected the interim reply and extended events")
+		}
+	}
+	if interim.Nickname != "alice" || interim.Message != "alice: Looking into it" || extended.Nickname != "alice" {
+		t.Errorf("Expected the events to forward the interim reply of alice, got %v and %v", interim, extended)
+	}
+	select {
+	case response := <-responseChan:
+		t.Fatalf("Expected the request to stay pending, got %v", response)
+	default:
+	}
+
+	// Empty interim replies and those of users the request is not claimed by are refused
+	if result := b.InterimReply(alice, &agentassistproto.InterimReply{RequestId: "req-1", Text: " "}); result.Success {
+		t.Errorf("Expected an empty interim reply to be refused, got %v", result)
+	}
+	if claim := b.ClaimRequest(bob, "req-1"); !claim.Success {
+		t.Fatalf("Expected bob to claim the request, got %v", claim)
+	}
+	if result := b.InterimReply(alice, &agentassistproto.InterimReply{RequestId: "req-1", Text: "Still here"}); result.Success || result.ErrorMessage == "" {
+		t.Errorf("Expected the interim reply of alice to be refused, got %v", result)
+	}
+
+	// The final reply still answers the request
+	b.HandleClientResponse(bob, "req-1", &WebResponse{Contents: []*agentassistproto.McpResultContent{CreateTextContent("Done")}, RepliedBy: "bob"})
+	if response := <-responseChan; response.RepliedBy != "bob" {
+		t.Errorf("Expected the final reply of bob, got %v", response)
+	}
+	if result := b.InterimReply(bob, &agentassistproto.InterimReply{RequestId: "req-1", Text: "One more thing"}); result.Success {
+		t.Errorf("Expected an interim reply to an answered request to be refused, got %v", result)
+	}
+}
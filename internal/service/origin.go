@@ -0,0 +1,101 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// OriginPolicy decides which browser origins may open WebSocket connections and call the
+// server cross-origin. Requests without an Origin header (agentassistant-mcp, native clients)
+// and same-origin requests (the embedded web interface) are always allowed.
+type OriginPolicy struct {
+	allowed  map[string]bool // Map normalized origin to true
+	allowAll bool
+}
+
+// NewOriginPolicy creates a policy allowing the given origins, e.g. "https://chat.example.com".
+// allowAll disables origin checks entirely and is meant for development only.
+func NewOriginPolicy(origins []string, allowAll bool) (*OriginPolicy, error) {
+	p := &OriginPolicy{
+		allowed:  make(map[string]bool),
+		allowAll: allowAll,
+	}
+
+	for _, origin := range origins {
+		normalized, err := normalizeOrigin(origin)
+		if err != nil {
+			return nil, err
+		}
+		p.allowed[normalized] = true
+	}
+
+	return p, nil
+}
+
+// normalizeOrigin returns origin as scheme://host[:port] in lower case
+func normalizeOrigin(origin string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(origin))
+	if err != nil || u.Scheme == "" || u.Host == "" || (u.Path != "" && u.Path != "/") {
+		return "", fmt.Errorf("invalid origin %q, expected scheme://host[:port]", origin)
+	}
+	return strings.ToLower(u.Scheme + "://" + u.Host), nil
+}
+
+// Allowed reports whether the request may be served
+func (p *OriginPolicy) Allowed(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || p.allowAll {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+
+	normalized, err := normalizeOrigin(origin)
+	return err == nil && p.allowed[normalized]
+}
+
+// CheckOrigin is the websocket.Upgrader CheckOrigin function of the policy
+func (p *OriginPolicy) CheckOrigin(r *http.Request) bool {
+	if !p.Allowed(r) {
+		log.Printf("Rejected WebSocket connection from %s: origin %s is not allowed", r.RemoteAddr, r.Header.Get("Origin"))
+		return false
+	}
+	return true
+}
+
+// Handler wraps h with CORS headers for allowed origins and rejects requests from other origins
+func (p *OriginPolicy) Handler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin != "" {
+			w.Header().Add("Vary", "Origin")
+
+			if !p.Allowed(r) {
+				log.Printf("Rejected %s %s from %s: origin %s is not allowed", r.Method, r.URL.Path, r.RemoteAddr, origin)
+				http.Error(w, "origin not allowed", http.StatusForbidden)
+				return
+			}
+
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Connect-Protocol-Version, Connect-Timeout-Ms")
+			w.Header().Set("Access-Control-Expose-Headers", "Connect-Protocol-Version")
+		}
+
+		if r.Method == "OPTIONS" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
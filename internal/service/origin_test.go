@@ -0,0 +1,101 @@
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestOriginPolicy_Allowed(t *testing.T) {
+	policy, err := NewOriginPolicy([]string{"https://Chat.Example.com/"}, false)
+	if err != nil {
+		t.Fatalf("Failed to create origin policy: %v", err)
+	}
+
+	tests := []struct {
+		origin  string
+		allowed bool
+	}{
+		{"", true},                         // non-browser client
+		{"http://server.local:8080", true}, // same origin
+		{"https://chat.example.com", true}, // allow-listed
+		{"https://chat.example.com:8443", false},
+		{"https://evil.example.com", false},
+		{"null", false},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "http://server.local:8080/ws", nil)
+		if tt.origin != "" {
+			r.Header.Set("Origin", tt.origin)
+		}
+		if got := policy.Allowed(r); got != tt.allowed {
+			t.Errorf("Origin %q: expected allowed=%v, got %v", tt.origin, tt.allowed, got)
+		}
+	}
+
+	allowAll, _ := NewOriginPolicy(nil, true)
+	r := httptest.NewRequest("GET", "http://server.local:8080/ws", nil)
+	r.Header.Set("Origin", "https://evil.example.com")
+	if !allowAll.Allowed(r) {
+		t.Error("Expected allow-all policy to allow any origin")
+	}
+}
+
+func TestNewOriginPolicy_Invalid(t *testing.T) {
+	for _, origin := range []string{"chat.example.com", "https://chat.example.com/app", "*"} {
+		if _, err := NewOriginPolicy([]string{origin}, false); err == nil {
+			t.Errorf("Origin %q: expected an error", origin)
+		}
+	}
+}
+
+func TestOriginPolicy_Handler(t *testing.T) {
+	policy, _ := NewOriginPolicy([]string{"https://chat.example.com"}, false)
+	handler := policy.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	r := httptest.NewRequest("OPTIONS", "http://server.local/agentassistproto.SrvAgentAssist/SubmitRequest", nil)
+	r.Header.Set("Origin", "https://chat.example.com")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusOK || w.Header().Get("Access-Control-Allow-Origin") != "https://chat.example.com" {
+		t.Errorf("Expected preflight of allowed origin to succeed, got %d %q", w.Code, w.Header().Get("Access-Control-Allow-Origin"))
+	}
+
+	r = httptest.NewRequest("POST", "http://server.local/agentassistproto.SrvAgentAssist/SubmitRequest", nil)
+	r.Header.Set("Origin", "https://evil.example.com")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected request of other origin to be rejected, got %d", w.Code)
+	}
+	if w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Error("Expected no CORS headers for a rejected origin")
+	}
+}
+
+func TestWebSocketHandler_RejectsOrigin(t *testing.T) {
+	policy, _ := NewOriginPolicy([]string{"https://chat.example.com"}, false)
+	wsHandler := NewWebSocketHandlerWithOptions(NewBroadcaster(), WebSocketHandlerOptions{Origins: policy})
+	server := httptest.NewServer(http.HandlerFunc(wsHandler.HandleWebSocket))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	header := http.Header{"Origin": []string{"https://evil.example.com"}}
+	if _, resp, err := websocket.DefaultDialer.Dial(wsURL, header); err == nil || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected connection from other origin to be rejected, got: %v", err)
+	}
+
+	header = http.Header{"Origin": []string{"https://chat.example.com"}}
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, header)
+	if err != nil {
+		t.Fatalf("Expected connection from allowed origin, got: %v", err)
+	}
+	conn.Close()
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"cmp"
+	"fmt"
+	"log"
+	"slices"
+)
+
+// Policies for requests that find no web client online, see agentassistant_server_no_clients_policy
+const (
+	// NoClientsPolicyQueue holds the request until its deadline and delivers it to the first
+	// web client that logs in with its token
+	NoClientsPolicyQueue = "queue"
+	// NoClientsPolicyFail fails the request right away with the no_clients error
+	NoClientsPolicyFail = "fail"
+)
+
+// ValidateNoClientsPolicy checks a configured no clients policy. Empty selects NoClientsPolicyQueue.
+func ValidateNoClientsPolicy(policy string) error {
+	switch policy {
+	case "", NoClientsPolicyQueue, NoClientsPolicyFail:
+		return nil
+	default:
+		return fmt.Errorf("unknown no clients policy %q, expected %q or %q", policy, NoClientsPolicyQueue, NoClientsPolicyFail)
+	}
+}
+
+// holdRequestLocked keeps a request that no web client was online for pending until a client
+// logs in or its deadline passes. It must be called with b.mu held.
+func (b *Broadcaster) holdRequestLocked(requestID string, request *WebsocketRequest) {
+	request.held = true
+	b.pendingRequests[requestID] = request
+	b.startDeadlineTimer(requestID, request)
+	b.startRemindersLocked(requestID, request)
+}
+
+// DeliverHeldRequests sends the requests held while no web client was online to client, which
+// has just logged in, if they are meant for its token. Requests the client cannot take in stay
+// held for the next client, and routed requests stay held until one of their targeted users
+// logs in or they fall back.
+func (b *Broadcaster) DeliverHeldRequests(client *WebClient) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var held []string
+	for requestID, request := range b.pendingRequests {
+		if request.held && request.forClient(client) && request.routedTo(client) {
+			held = append(held, requestID)
+		}
+	}
+	if len(held) == 0 {
+		return
+	}
+
+	// Deliver in the order the agents asked
+	slices.SortFunc(held, func(x, y string) int {
+		return cmp.Compare(requestTimestamp(b.pendingRequests[x].Message), requestTimestamp(b.pendingRequests[y].Message))
+	})
+
+	delivered := 0
+	for _, requestID := range held {
+		request := b.pendingRequests[requestID]
+		if !client.Send(request.Message) {
+			break
+		}
+		request.held = false
+		b.recordDeliveryLocked(requestID, request, []*WebClient{client})
+		delivered++
+	}
+
+	log.Printf("Delivered %d of %d held requests to client %s", delivered, len(held), client.ID)
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	agentassistproto "github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+func TestBroadcaster_HoldsRequestsUntilLogin(t *testing.T) {
+	b := NewBroadcaster()
+
+	// Without web clients the request is held instead of failing
+	responseChan := make(chan *WebResponse, 1)
+	for i, requestID := range []string{"req-2", "req-1"} {
+		message := newTestAskQuestionMessage(requestID)
+		message.AskQuestionRequest.Timestamp = int64(2 - i)
+		b.BroadcastToToken(message, "test-token", time.Now().Add(time.Minute), responseChan)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	select {
+	case response := <-responseChan:
+		t.Fatalf("Expected the request to be held, got %+v", response)
+	default:
+	}
+	if validity := b.CheckMessageValidity([]string{"req-1", "req-2"}); !validity["req-1"] || !validity["req-2"] {
+		t.Fatalf("Expected the held requests to be pending, got %v", validity)
+	}
+
+	// A client of another user does not get them
+	other := NewWebClient("other")
+	other.SetToken("other-token")
+	b.DeliverHeldRequests(other)
+	if len(other.SendChan) != 0 {
+		t.Errorf("Expected no requests for another token, got %d", len(other.SendChan))
+	}
+
+	// The first client of the user gets them in the order they were asked
+	client := NewWebClient("client1")
+	client.SetToken("test-token")
+	b.DeliverHeldRequests(client)
+	for _, requestID := range []string{"req-1", "req-2"} {
+		select {
+		case message := <-client.SendChan:
+			if message.AskQuestionRequest.GetID() != requestID {
+				t.Errorf("Expected %s to be delivered, got %s", requestID, message.AskQuestionRequest.GetID())
+			}
+		default:
+			t.Fatalf("Expected %s to be delivered on login", requestID)
+		}
+	}
+
+	// They are delivered only once
+	b.DeliverHeldRequests(client)
+	if len(client.SendChan) != 0 {
+		t.Errorf("Expected held requests to be delivered once, got %d more", len(client.SendChan))
+	}
+
+	events, unsubscribe, _ := b.WatchRequest("req-1")
+	unsubscribe()
+	var types []string
+	for event := range events {
+		types = append(types, event.Type)
+	}
+	if len(types) != 2 || types[0] != RequestEventQueued || types[1] != RequestEventBroadcast {
+		t.Errorf("Expected queued and broadcast events, got %v", types)
+	}
+
+	b.HandleResponse("req-1", &WebResponse{
+		Contents: []*agentassistproto.McpResultContent{CreateTextContent("Go ahead")},
+	})
+	select {
+	case response := <-responseChan:
+		if response.IsError {
+			t.Errorf("Expected an answer, got %+v", response)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the held request to be answered")
+	}
+}
+
+func TestValidateNoClientsPolicy(t *testing.T) {
+	for _, policy := range []string{"", NoClientsPolicyQueue, NoClientsPolicyFail} {
+		if err := ValidateNoClientsPolicy(policy); err != nil {
+			t.Errorf("Expected policy %q to be valid, got: %v", policy, err)
+		}
+	}
+	if err := ValidateNoClientsPolicy("drop"); err == nil {
+		t.Error("Expected an unknown policy to be rejected")
+	}
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"os/exec"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	agentassistproto "github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+// reminderCommandTimeout bounds the external notifier command of a reminder
+const reminderCommandTimeout = 30 * time.Second
+
+// ReminderConfig is a reminder of the server configuration. Requests nobody answered After
+// their creation are sent to the web users again as a Reminder, optionally escalated to
+// more web users and announced by an external command. Empty patterns match anything.
+type ReminderConfig struct {
+	// Token is a glob on the token of the request, which is the token group when token
+	// authentication is enabled
+	Token string `toml:"token"`
+	// Project is a glob on the project directory, like in routing rules
+	Project string `toml:"project"`
+	// After is how long after its creation an unanswered request is reminded of, e.g. "5m"
+	After string `toml:"after"`
+	// EscalateTo lists nicknames of the same token the request is delivered to as well,
+	// "@name" refers to a client group
+	EscalateTo []string `toml:"escalate_to"`
+	// Command is run with the reminder, e.g. ["notify-send", "Agent Assistant"]. The text
+	// of the reminder is appended as the last argument and the request is described by
+	// AGENTASSISTANT_* environment variables.
+	Command []string `toml:"command"`
+}
+
+// Reminder is a reminder that applies to a request
+type Reminder struct {
+	// Name describes the reminder in logs
+	Name string
+	// After is how long after its creation the request is reminded of
+	After time.Duration
+	// EscalateTo are the web users the request is delivered to with the reminder
+	EscalateTo map[string]bool
+	// Command is the external notifier, empty for none
+	Command []string
+
+	token, project string
+}
+
+// Reminders picks the reminders of unanswered requests
+type Reminders struct {
+	reminders []*Reminder // Sorted by After
+}
+
+// NewReminders creates reminders from the configured ones and client groups. Groups map a
+// group name to the nicknames of its members.
+func NewReminders(configs []ReminderConfig, groups map[string][]string) (*Reminders, error) {
+	r := &Reminders{}
+
+	for i, config := range configs {
+		reminder := &Reminder{
+			Name:       fmt.Sprintf("reminder %d", i+1),
+			EscalateTo: make(map[string]bool),
+			Command:    config.Command,
+			token:      config.Token,
+			project:    config.Project,
+		}
+
+		for _, pattern := range []string{config.Token, config.Project} {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("%s: invalid pattern %q: %w", reminder.Name, pattern, err)
+			}
+		}
+		after, err := time.ParseDuration(config.After)
+		if err != nil || after <= 0 {
+			return nil, fmt.Errorf("%s: after must be a positive duration such as \"5m\"", reminder.Name)
+		}
+		reminder.After = after
+		if err := addTargets(reminder.EscalateTo, config.EscalateTo, groups); err != nil {
+			return nil, fmt.Errorf("%s: %w", reminder.Name, err)
+		}
+		if len(config.Command) > 0 && config.Command[0] == "" {
+			return nil, fmt.Errorf("%s: command must start with the program to run", reminder.Name)
+		}
+
+		r.reminders = append(r.reminders, reminder)
+	}
+
+	slices.SortStableFunc(r.reminders, func(x, y *Reminder) int {
+		return cmp.Compare(x.After, y.After)
+	})
+	return r, nil
+}
+
+// For returns the reminders that apply to a request, the earliest first
+func (r *Reminders) For(request *WebsocketRequest) []*Reminder {
+	if r == nil {
+		return nil
+	}
+
+	projectDirectory := request.Message.GetAskQuestionRequest().GetRequest().GetProjectDirectory()
+	if request.Message.WorkReportRequest != nil {
+		projectDirectory = request.Message.GetWorkReportRequest().GetRequest().GetProjectDirectory()
+	}
+
+	var reminders []*Reminder
+	for _, reminder := range r.reminders {
+		if matchPattern(reminder.token, request.UserToken) && matchProject(reminder.project, projectDirectory) {
+			reminders = append(reminders, reminder)
+		}
+	}
+	return reminders
+}
+
+// startRemindersLocked schedules the reminders of a new or restored request. It must be
+// called with b.mu held.
+func (b *Broadcaster) startRemindersLocked(requestID string, request *WebsocketRequest) {
+	request.reminders = b.reminders.For(request)
+	b.scheduleReminderLocked(requestID, request)
+}
+
+// scheduleReminderLocked arms the timer of the next reminder of a request, if any. It must
+// be called with b.mu held.
+func (b *Broadcaster) scheduleReminderLocked(requestID string, request *WebsocketRequest) {
+	b.stopRemindersLocked(request)
+	if len(request.reminders) == 0 {
+		return
+	}
+	request.reminderTimer = time.AfterFunc(time.Until(request.CreatedAt.Add(request.reminders[0].After)), func() {
+		b.remind(requestID)
+	})
+}
+
+// stopRemindersLocked stops the reminders of a request until they are scheduled again. It
+// must be called with b.mu held.
+func (b *Broadcaster) stopRemindersLocked(request *WebsocketRequest) {
+	if request.reminderTimer != nil {
+		request.reminderTimer.Stop()
+		request.reminderTimer = nil
+	}
+}
+
+// remind sends the reminders of a request that are due. Reminders that became due together,
+// e.g. while the request was claimed or the server was down, are sent as one. Claimed and
+// answered requests are not reminded of.
+func (b *Broadcaster) remind(requestID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	request, exists := b.pendingRequests[requestID]
+	if !exists || request.claim != nil || request.answeredBy != "" {
+		return
+	}
+
+	unanswered := time.Since(request.CreatedAt)
+	var reminder *Reminder
+	escalateTo := make(map[string]bool)
+	for len(request.reminders) > 0 && request.reminders[0].After <= unanswered {
+		reminder = request.reminders[0]
+		maps.Copy(escalateTo, reminder.EscalateTo)
+		request.reminders = request.reminders[1:]
+		request.reminded++
+	}
+	if reminder == nil {
+		b.scheduleReminderLocked(requestID, request)
+		return
+	}
+
+	message := fmt.Sprintf("not answered for %s", unanswered.Round(time.Second))
+	notification := &agentassistproto.Reminder{
+		RequestId:         requestID,
+		Number:            int32(request.reminded),
+		UnansweredSeconds: int64(unanswered.Seconds()),
+		EscalatedTo:       slices.Sorted(maps.Keys(escalateTo)),
+		Message:           message,
+	}
+	if len(escalateTo) > 0 {
+		b.escalateToLocked(request, escalateTo)
+	}
+
+	// Users who got the request are reminded of it, the ones it was escalated to get it now
+	var reminded, delivered []*WebClient
+	for _, client := range b.clients {
+		if !client.IsActive() || !request.forClient(client) || !request.routedTo(client) {
+			continue
+		}
+		if request.deliveredTo[client.ID] {
+			reminded = append(reminded, client)
+		} else {
+			delivered = append(delivered, client)
+		}
+	}
+	log.Printf("Request %s %s, %s sent to %d web clients and delivered to %d more",
+		requestID, message, reminder.Name, len(reminded), len(delivered))
+
+	b.publishEventLocked(requestID, request, &agentassistproto.RequestEvent{
+		Type:        RequestEventReminded,
+		ClientCount: int32(len(reminded) + len(delivered)),
+		Message:     fmt.Sprintf("%s, reminder %d sent", message, request.reminded),
+	})
+	if len(delivered) > 0 {
+		request.held = false
+		b.recordDeliveryLocked(requestID, request, delivered)
+	}
+	send := func(c *WebClient, message *agentassistproto.WebsocketMessage) {
+		if !c.Send(message) {
+			// Client failed to receive, unregister it
+			b.unregister <- c
+		}
+	}
+	for _, client := range reminded {
+		go send(client, &agentassistproto.WebsocketMessage{Cmd: "Reminder", Reminder: notification})
+	}
+	for _, client := range delivered {
+		go send(client, request.Message)
+	}
+	if len(reminder.Command) > 0 {
+		go runReminderCommand(reminder, request, notification)
+	}
+
+	b.scheduleReminderLocked(requestID, request)
+}
+
+// escalateToLocked lets the web users with the given nicknames see a routed request. It must
+// be called with b.mu held.
+func (b *Broadcaster) escalateToLocked(request *WebsocketRequest, nicknames map[string]bool) {
+	// The targets may be shared with a policy, do not add to them in place
+	escalatedTo := maps.Clone(request.escalatedTo)
+	if escalatedTo == nil {
+		escalatedTo = make(map[string]bool)
+	}
+	maps.Copy(escalatedTo, nicknames)
+	request.escalatedTo = escalatedTo
+}
+
+// runReminderCommand runs the external notifier of a reminder
+func runReminderCommand(reminder *Reminder, request *WebsocketRequest, notification *agentassistproto.Reminder) {
+	ctx, cancel := context.WithTimeout(context.Background(), reminderCommandTimeout)
+	defer cancel()
+
+	projectDirectory, text := request.Message.GetAskQuestionRequest().GetRequest().GetProjectDirectory(),
+		request.Message.GetAskQuestionRequest().GetRequest().GetQuestion()
+	if report := request.Message.GetWorkReportRequest(); report != nil {
+		projectDirectory, text = report.GetRequest().GetProjectDirectory(), report.GetRequest().GetSummary()
+	}
+
+	args := append(slices.Clone(reminder.Command[1:]), fmt.Sprintf("Request %s %s: %s", notification.RequestId, notification.Message, text))
+	cmd := exec.CommandContext(ctx, reminder.Command[0], args...)
+	cmd.Env = append(os.Environ(),
+		"AGENTASSISTANT_REQUEST_ID="+notification.RequestId,
+		"AGENTASSISTANT_PROJECT_DIRECTORY="+projectDirectory,
+		"AGENTASSISTANT_TEXT="+text,
+		fmt.Sprintf("AGENTASSISTANT_REMINDER=%d", notification.Number),
+		fmt.Sprintf("AGENTASSISTANT_UNANSWERED_SECONDS=%d", notification.UnansweredSeconds),
+		"AGENTASSISTANT_ESCALATED_TO="+strings.Join(notification.EscalatedTo, ","),
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		log.Printf("Command of %s for request %s failed: %v: %s", reminder.Name, notification.RequestId, err, output)
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewReminders(t *testing.T) {
+	reminders, err := NewReminders([]ReminderConfig{
+		{After: "30m", EscalateTo: []string{"@ops"}},
+		{Project: "/work/acme", After: "5m"},
+	}, map[string][]string{"ops": {"bob"}})
+	if err != nil {
+		t.Fatalf("Failed to create reminders: %v", err)
+	}
+
+	request := &WebsocketRequest{Message: newTestRoutedMessage("req-1", "/work/acme/app", "")}
+	if got := reminders.For(request); len(got) != 2 || got[0].After != 5*time.Minute || !got[1].EscalateTo["bob"] {
+		t.Errorf("Expected both reminders, the earliest first, got %+v", got)
+	}
+	other := &WebsocketRequest{Message: newTestRoutedMessage("req-2", "/work/other", "")}
+	if got := reminders.For(other); len(got) != 1 || got[0].Name != "reminder 1" {
+		t.Errorf("Expected only the reminder for every project, got %+v", got)
+	}
+
+	for _, configs := range [][]ReminderConfig{
+		{{}},
+		{{After: "-5m"}},
+		{{After: "5m", Token: "["}},
+		{{After: "5m", EscalateTo: []string{"@unknown"}}},
+		{{After: "5m", Command: []string{""}}},
+	} {
+		if _, err := NewReminders(configs, nil); err == nil {
+			t.Errorf("Expected reminders %+v to be rejected", configs)
+		}
+	}
+}
+
+func TestBroadcaster_Reminders(t *testing.T) {
+	router, err := NewRouter([]RouteConfig{{Project: "/work/acme", To: []string{"alice"}}}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create router: %v", err)
+	}
+	notified := filepath.Join(t.TempDir(), "notified")
+	reminders, err := NewReminders([]ReminderConfig{
+		{After: "100ms"},
+		{After: "200ms", EscalateTo: []string{"bob"}, Command: []string{"sh", "-c", `echo "$AGENTASSISTANT_REMINDER $1" > ` + notified, "sh"}},
+		{After: "400ms"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create reminders: %v", err)
+	}
+	b := NewBroadcasterWithOptions(BroadcasterOptions{Router: router, Reminders: reminders})
+
+	alice := NewWebClient("alice-client")
+	alice.SetToken("test-token")
+	alice.SetNickname("alice")
+	bob := NewWebClient("bob-client")
+	bob.SetToken("test-token")
+	bob.SetNickname("bob")
+	b.RegisterClient(alice)
+	b.RegisterClient(bob)
+	time.Sleep(100 * time.Millisecond)
+
+	responseChan := make(chan *WebResponse, 1)
+	b.BroadcastToToken(newTestRoutedMessage("req-1", "/work/acme/app", ""), "test-token", time.Now().Add(time.Minute), responseChan)
+	nextMessage(t, alice, "AskQuestion")
+
+	if reminder := nextMessage(t, alice, "Reminder").Reminder; reminder.Number != 1 || len(reminder.EscalatedTo) != 0 {
+		t.Errorf("Expected the first reminder, got %v", reminder)
+	}
+	// The second reminder escalates the request to bob and runs the external notifier
+	if reminder := nextMessage(t, alice, "Reminder").Reminder; reminder.Number != 2 || len(reminder.EscalatedTo) != 1 || reminder.EscalatedTo[0] != "bob" {
+		t.Errorf("Expected the second reminder to escalate to bob, got %v", reminder)
+	}
+	nextMessage(t, bob, "AskQuestion")
+	time.Sleep(100 * time.Millisecond)
+	if output, err := os.ReadFile(notified); err != nil || !strings.HasPrefix(string(output), "2 Request req-1 not answered for") {
+		t.Errorf("Expected the notifier to run, got %q (%v)", output, err)
+	}
+
+	// Claimed requests are not reminded of until the claim is released
+	if claim := b.ClaimRequest(alice, "req-1"); !claim.Success {
+		t.Fatalf("Expected alice to claim the request, got %v", claim)
+	}
+	time.Sleep(300 * time.Millisecond)
+	for len(alice.SendChan) > 0 {
+		if message := <-alice.SendChan; message.Cmd == "Reminder" {
+			t.Fatalf("Expected no reminder of a claimed request, got %v", message.Reminder)
+		}
+	}
+	b.ReleaseClaim(alice, "req-1")
+	if reminder := nextMessage(t, bob, "Reminder").Reminder; reminder.Number != 3 {
+		t.Errorf("Expected the third reminder once the claim was released, got %v", reminder)
+	}
+}
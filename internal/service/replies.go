@@ -0,0 +1,130 @@
+package service
+
+import (
+	"log"
+	"slices"
+	"time"
+
+	agentassistproto "github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+// Reply acknowledgement statuses
+const (
+	// ReplyStatusDelivered means the reply reached the waiting agent
+	ReplyStatusDelivered = "delivered"
+	// ReplyStatusRecorded means the approval was counted, the work report needs more of them
+	ReplyStatusRecorded = "recorded"
+	// ReplyStatusUnknown means the server knows no such request
+	ReplyStatusUnknown = "unknown"
+	// ReplyStatusExpired means the request timed out or was cancelled before the reply
+	ReplyStatusExpired = "expired"
+	// ReplyStatusAlreadyAnswered means another reply was accepted first
+	ReplyStatusAlreadyAnswered = "already_answered"
+	// ReplyStatusInvalid means the reply is malformed, e.g. its decision is unknown
+	ReplyStatusInvalid = "invalid"
+	// ReplyStatusFailed means the server was too busy to take the reply, it can be sent again
+	ReplyStatusFailed = "failed"
+)
+
+// responseQueueTimeout bounds how long a reply waits for room in the queue of the run loop
+const responseQueueTimeout = 5 * time.Second
+
+// HandleClientResponse handles the reply of a web client to a request like HandleResponse, and
+// acknowledges it to client once it has been matched against the pending requests. If the
+// reply cannot be queued, the request can be answered again and client gets a failed
+// ReplyAck; false is returned then.
+func (b *Broadcaster) HandleClientResponse(client *WebClient, requestID string, response *WebResponse) bool {
+	log.Printf("Handling response of client %s for request ID: %s", client.ID, requestID)
+
+	if b.queueResponse(&ResponseWithID{RequestID: requestID, Response: response, Client: client}) {
+		return true
+	}
+
+	b.mu.Lock()
+	b.withdrawReplyLocked(client, requestID)
+	b.mu.Unlock()
+	sendReplyAck(client, &agentassistproto.ReplyAck{
+		RequestId: requestID,
+		Status:    ReplyStatusFailed,
+		Message:   "server busy, please send the reply again",
+	})
+	return false
+}
+
+// queueResponse hands a response to the run loop, waiting up to responseQueueTimeout for
+// room in the queue. It returns false if the response could not be queued.
+func (b *Broadcaster) queueResponse(responseWithID *ResponseWithID) bool {
+	timer := time.NewTimer(responseQueueTimeout)
+	defer timer.Stop()
+
+	select {
+	case b.responseReceived <- responseWithID:
+		log.Printf("Response for request %s queued for processing", responseWithID.RequestID)
+		return true
+	case <-timer.C:
+		log.Printf("Failed to queue response for request %s: channel full", responseWithID.RequestID)
+		return false
+	}
+}
+
+// withdrawReplyLocked undoes the acceptance of a reply of client that never reached the run
+// loop, so that the request can be answered again. It must be called with b.mu held.
+func (b *Broadcaster) withdrawReplyLocked(client *WebClient, requestID string) {
+	request, pending := b.pendingRequests[requestID]
+	if !pending {
+		return
+	}
+	nickname := client.GetNickname()
+	if request.answeredBy == nickname {
+		request.answeredBy = ""
+		b.scheduleReminderLocked(requestID, request)
+	}
+	if quorum := request.approval; quorum != nil {
+		quorum.approvals = slices.DeleteFunc(quorum.approvals, func(a approval) bool {
+			return a.nickname == nickname
+		})
+		b.persistApprovalsLocked(requestID, quorum)
+	}
+}
+
+// replyAckLocked returns the acknowledgement of a reply to a request that was just matched.
+// delivered tells whether the reply completed the request. It must be called with b.mu held.
+func (b *Broadcaster) replyAckLocked(requestID string, delivered bool) *agentassistproto.ReplyAck {
+	ack := &agentassistproto.ReplyAck{RequestId: requestID}
+
+	if request, pending := b.pendingRequests[requestID]; pending {
+		// An earlier reply was accepted and is on its way through the run loop
+		ack.Status = ReplyStatusAlreadyAnswered
+		ack.RepliedBy = request.answeredBy
+		return ack
+	}
+
+	request, exists := b.completedRequests[requestID]
+	switch {
+	case !exists:
+		ack.Status = ReplyStatusUnknown
+	case delivered:
+		ack.Status = ReplyStatusDelivered
+		ack.DeliveredAt = request.CompletedAt.UnixMilli()
+	case request.Response.RepliedBy != "":
+		ack.Status = ReplyStatusAlreadyAnswered
+		ack.DeliveredAt = request.CompletedAt.UnixMilli()
+		ack.RepliedBy = request.Response.RepliedBy
+	default:
+		ack.Status = ReplyStatusExpired
+		ack.Message = request.Response.Meta["message"]
+	}
+	return ack
+}
+
+// sendReplyAck sends the acknowledgement of its reply to a web client
+func sendReplyAck(client *WebClient, ack *agentassistproto.ReplyAck) {
+	log.Printf("Reply of client %s to request %s: %s", client.ID, ack.RequestId, ack.Status)
+	message := &agentassistproto.WebsocketMessage{
+		Cmd:      "ReplyAck",
+		ReplyAck: ack,
+	}
+	if !client.Send(message) {
+		log.Printf("Failed to send ReplyAck to client %s", client.ID)
+	}
+}
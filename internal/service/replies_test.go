@@ -0,0 +1,96 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBroadcaster_ReplyAck(t *testing.T) {
+	b := NewBroadcaster()
+	alice := NewWebClient("alice-client")
+	alice.SetToken("test-token")
+	alice.SetNickname("alice")
+	bob := NewWebClient("bob-client")
+	bob.SetToken("test-token")
+	bob.SetNickname("bob")
+	b.RegisterClient(alice)
+	b.RegisterClient(bob)
+	time.Sleep(100 * time.Millisecond)
+
+	responseChan := make(chan *WebResponse, 2)
+	b.BroadcastToToken(newTestAskQuestionMessage("req-1"), "test-token", time.Now().Add(time.Minute), responseChan)
+	b.BroadcastToToken(newTestAskQuestionMessage("req-2"), "test-token", time.Now().Add(200*time.Millisecond), responseChan)
+	time.Sleep(100 * time.Millisecond)
+
+	// The reply of alice reaches the agent
+	if !b.AcceptReply(alice, "req-1") {
+		t.Fatal("Expected the reply of alice to be accepted")
+	}
+	b.HandleClientResponse(alice, "req-1", &WebResponse{RepliedBy: "alice"})
+	<-responseChan
+	delivered := nextMessage(t, alice, "ReplyAck").ReplyAck
+	if delivered.Status != ReplyStatusDelivered || delivered.DeliveredAt == 0 {
+		t.Errorf("Expected alice to be told her reply was delivered, got %v", delivered)
+	}
+
+	// Bob is told who answered first and when
+	if b.AcceptReply(bob, "req-1") {
+		t.Error("Expected the reply of bob to be refused")
+	}
+	ack := nextMessage(t, bob, "ReplyAck").ReplyAck
+	if ack.Status != ReplyStatusAlreadyAnswered || ack.RepliedBy != "alice" || ack.DeliveredAt != delivered.DeliveredAt {
+		t.Errorf("Expected bob to be told alice answered, got %v", ack)
+	}
+
+	// Replies after the timeout
+	<-responseChan
+	if b.AcceptReply(bob, "req-2") {
+		t.Error("Expected a reply to a timed out request to be refused")
+	}
+	if ack := nextMessage(t, bob, "ReplyAck").ReplyAck; ack.Status != ReplyStatusExpired || !strings.Contains(ack.Message, "timed out") {
+		t.Errorf("Expected bob to be told the request expired, got %v", ack)
+	}
+
+	// Replies to requests the server does not know, also when they get past AcceptReply
+	if b.AcceptReply(bob, "unknown") {
+		t.Error("Expected a reply to an unknown request to be refused")
+	}
+	if ack := nextMessage(t, bob, "ReplyAck").ReplyAck; ack.Status != ReplyStatusUnknown {
+		t.Errorf("Expected bob to be told the request is unknown, got %v", ack)
+	}
+	b.HandleClientResponse(bob, "unknown", &WebResponse{RepliedBy: "bob"})
+	if ack := nextMessage(t, bob, "ReplyAck").ReplyAck; ack.Status != ReplyStatusUnknown {
+		t.Errorf("Expected the unmatched response to be acknowledged as unknown, got %v", ack)
+	}
+}
+
+func TestBroadcaster_WithdrawReply(t *testing.T) {
+	b := NewBroadcaster()
+	alice := NewWebClient("alice-client")
+	alice.SetToken("test-token")
+	alice.SetNickname("alice")
+	b.RegisterClient(alice)
+	time.Sleep(100 * time.Millisecond)
+
+	responseChan := make(chan *WebResponse, 1)
+	b.BroadcastToToken(newTestAskQuestionMessage("req-1"), "test-token", time.Now().Add(time.Minute), responseChan)
+	time.Sleep(100 * time.Millisecond)
+
+	if !b.AcceptReply(alice, "req-1") {
+		t.Fatal("Expected the reply of alice to be accepted")
+	}
+
+	// A reply that could not be queued does not block the request
+	b.mu.Lock()
+	b.withdrawReplyLocked(alice, "req-1")
+	b.mu.Unlock()
+	if !b.AcceptReply(alice, "req-1") {
+		t.Fatal("Expected the request to be answerable again after the reply was withdrawn")
+	}
+	b.HandleClientResponse(alice, "req-1", &WebResponse{RepliedBy: "alice"})
+	<-responseChan
+	if ack := nextMessage(t, alice, "ReplyAck").ReplyAck; ack.Status != ReplyStatusDelivered {
+		t.Errorf("Expected the reply sent again to be delivered, got %v", ack)
+	}
+}
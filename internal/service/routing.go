@@ -0,0 +1,239 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	agentassistproto "github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+// RouteConfig is a routing rule of the server configuration. A request that matches all the
+// patterns of a rule is delivered only to the web users the rule targets; empty patterns
+// match anything. The first matching rule wins.
+type RouteConfig struct {
+	// Project is a glob on the project directory, it also matches the subdirectories of
+	// the directories it matches, e.g. "/work/acme/*"
+	Project string `toml:"project"`
+	// Agent, McpClient and Model are globs on the agent name, MCP client name and
+	// reasoning model name of the request
+	Agent     string `toml:"agent"`
+	McpClient string `toml:"mcp_client"`
+	Model     string `toml:"model"`
+	// To lists the nicknames the request is delivered to, "@name" refers to a client group
+	To []string `toml:"to"`
+	// FallbackAfter is how long the targeted users have to answer before the request is
+	// delivered to everyone else, e.g. "5m". Empty never falls back.
+	FallbackAfter string `toml:"fallback_after"`
+}
+
+// Route is a routing rule that matched a request
+type Route struct {
+	// Name describes the rule in logs and events
+	Name string
+	// Nicknames are the web users the request is delivered to
+	Nicknames map[string]bool
+	// FallbackAfter is how long to wait before delivering to everyone, zero never falls back
+	FallbackAfter time.Duration
+
+	project, agent, mcpClient, model string
+}
+
+// Router picks the web users a request is delivered to by the configured rules
+type Router struct {
+	routes []*Route
+}
+
+// NewRouter creates a router from the configured rules and client groups. Groups map a
+// group name to the nicknames of its members.
+func NewRouter(routes []RouteConfig, groups map[string][]string) (*Router, error) {
+	r := &Router{}
+
+	for i, config := range routes {
+		route := &Route{
+			Name:      fmt.Sprintf("route %d", i+1),
+			Nicknames: make(map[string]bool),
+			project:   config.Project,
+			agent:     config.Agent,
+			mcpClient: config.McpClient,
+			model:     config.Model,
+		}
+
+		for _, pattern := range []string{config.Project, config.Agent, config.McpClient, config.Model} {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("%s: invalid pattern %q: %w", route.Name, pattern, err)
+			}
+		}
+
+		if len(config.To) == 0 {
+			return nil, fmt.Errorf("%s: at least one target is required", route.Name)
+		}
+		if err := addTargets(route.Nicknames, config.To, groups); err != nil {
+			return nil, fmt.Errorf("%s: %w", route.Name, err)
+		}
+
+		if config.FallbackAfter != "" {
+			fallbackAfter, err := time.ParseDuration(config.FallbackAfter)
+			if err != nil || fallbackAfter <= 0 {
+				return nil, fmt.Errorf("%s: fallback_after must be a positive duration such as \"5m\"", route.Name)
+			}
+			route.FallbackAfter = fallbackAfter
+		}
+
+		r.routes = append(r.routes, route)
+	}
+
+	return r, nil
+}
+
+// addTargets adds the nicknames targets refer to to nicknames, "@name" targets expand to the
+// members of a client group
+func addTargets(nicknames map[string]bool, targets []string, groups map[string][]string) error {
+	for _, target := range targets {
+		groupName, isGroup := strings.CutPrefix(target, "@")
+		if !isGroup {
+			nicknames[target] = true
+			continue
+		}
+		members, exists := groups[groupName]
+		if !exists {
+			return fmt.Errorf("unknown client group %q", groupName)
+		}
+		for _, nickname := range members {
+			nicknames[nickname] = true
+		}
+	}
+	return nil
+}
+
+// Route returns the first rule that matches a request message, nil if none does
+func (r *Router) Route(message *agentassistproto.WebsocketMessage) *Route {
+	if r == nil {
+		return nil
+	}
+
+	var projectDirectory, agentName, mcpClientName, modelName string
+	if request := message.GetAskQuestionRequest().GetRequest(); request != nil {
+		projectDirectory, agentName, mcpClientName, modelName = request.ProjectDirectory, request.AgentName, request.McpClientName, request.ReasoningModelName
+	} else if request := message.GetWorkReportRequest().GetRequest(); request != nil {
+		projectDirectory, agentName, mcpClientName, modelName = request.ProjectDirectory, request.AgentName, request.McpClientName, request.ReasoningModelName
+	} else {
+		return nil
+	}
+
+	for _, route := range r.routes {
+		if matchProject(route.project, projectDirectory) &&
+			matchPattern(route.agent, agentName) &&
+			matchPattern(route.mcpClient, mcpClientName) &&
+			matchPattern(route.model, modelName) {
+			return route
+		}
+	}
+	return nil
+}
+
+// matchPattern reports whether value matches a glob, an empty glob matches anything
+func matchPattern(pattern, value string) bool {
+	if pattern == "" {
+		return true
+	}
+	matched, _ := path.Match(pattern, value)
+	return matched
+}
+
+// matchProject reports whether a project directory or one of its parents matches a glob
+func matchProject(pattern, directory string) bool {
+	if pattern == "" {
+		return true
+	}
+	if directory == "" {
+		return false
+	}
+
+	directory = filepath.ToSlash(filepath.Clean(directory))
+	for {
+		if matchPattern(pattern, directory) {
+			return true
+		}
+		parent := path.Dir(directory)
+		if parent == directory {
+			return false
+		}
+		directory = parent
+	}
+}
+
+// routedTo reports whether the routing of a request lets client see it
+func (r *WebsocketRequest) routedTo(client *WebClient) bool {
+	return r.route == nil || r.fallenBack || r.route.Nicknames[client.GetNickname()] || r.escalatedTo[client.GetNickname()]
+}
+
+// routeRequestLocked applies the routing rules to a new request and returns the clients it is
+// delivered to out of the clients online for its token. If none of the targeted users is
+// online the request is held until one logs in or the fallback passes. It must be called with
+// b.mu held.
+func (b *Broadcaster) routeRequestLocked(requestID string, request *WebsocketRequest, clients []*WebClient) []*WebClient {
+	route := b.router.Route(request.Message)
+	if route == nil {
+		return clients
+	}
+	request.route = route
+
+	var routed []*WebClient
+	for _, client := range clients {
+		if route.Nicknames[client.GetNickname()] {
+			routed = append(routed, client)
+		}
+	}
+	if len(routed) == 0 {
+		log.Printf("No web user of %s is online, holding request %s for them", route.Name, requestID)
+		request.held = true
+	} else {
+		log.Printf("Routing request %s to the web users of %s", requestID, route.Name)
+	}
+	if route.FallbackAfter > 0 {
+		request.fallbackTimer = time.AfterFunc(route.FallbackAfter, func() {
+			b.fallBack(requestID)
+		})
+	}
+	return routed
+}
+
+// fallBack delivers a routed request that the targeted users did not answer in time to all
+// the other web clients of its token
+func (b *Broadcaster) fallBack(requestID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	request, exists := b.pendingRequests[requestID]
+	if !exists || request.fallenBack {
+		return
+	}
+	request.fallenBack = true
+
+	var clients []*WebClient
+	for _, client := range b.clients {
+		if client.IsActive() && request.forClient(client) && !request.deliveredTo[client.ID] {
+			clients = append(clients, client)
+		}
+	}
+	log.Printf("Request %s was not answered within %s, delivering it to %d more web clients",
+		requestID, request.route.FallbackAfter, len(clients))
+	if len(clients) == 0 {
+		return
+	}
+
+	request.held = false
+	b.recordDeliveryLocked(requestID, request, clients)
+	for _, client := range clients {
+		go func(c *WebClient) {
+			if !c.Send(request.Message) {
+				// Client failed to receive, unregister it
+				b.unregister <- c
+			}
+		}(client)
+	}
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	agentassistproto "github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+func newTestRoutedMessage(requestID, projectDirectory, agentName string) *agentassistproto.WebsocketMessage {
+	message := newTestAskQuestionMessage(requestID)
+	message.AskQuestionRequest.Request.ProjectDirectory = projectDirectory
+	message.AskQuestionRequest.Request.AgentName = agentName
+	return message
+}
+
+func TestRouter_Route(t *testing.T) {
+	router, err := NewRouter([]RouteConfig{
+		{Project: "/work/acme/*", Agent: "reviewer*", To: []string{"alice"}},
+		{Project: "/work/acme", To: []string{"@ops"}},
+	}, map[string][]string{"ops": {"bob", "carol"}})
+	if err != nil {
+		t.Fatalf("Failed to create router: %v", err)
+	}
+
+	tests := []struct {
+		project, agent string
+		want           string
+	}{
+		{"/work/acme/app", "reviewer-1", "route 1"},
+		{"/work/acme/app/sub/dir", "reviewer-1", "route 1"},
+		{"/work/acme/app", "coder", "route 2"},
+		{"/work/acme", "reviewer-1", "route 2"},
+		{"/work/other", "reviewer-1", ""},
+	}
+	for _, tt := range tests {
+		route := router.Route(newTestRoutedMessage("req-1", tt.project, tt.agent))
+		got := ""
+		if route != nil {
+			got = route.Name
+		}
+		if got != tt.want {
+			t.Errorf("Route(%s, %s) = %q, want %q", tt.project, tt.agent, got, tt.want)
+		}
+	}
+
+	if route := router.Route(newTestRoutedMessage("req-1", "/work/acme", "")); !route.Nicknames["bob"] || !route.Nicknames["carol"] {
+		t.Errorf("Expected the members of @ops to be targeted, got %v", route.Nicknames)
+	}
+}
+
+func TestNewRouter_Invalid(t *testing.T) {
+	for _, routes := range [][]RouteConfig{
+		{{Project: "/work/["}},
+		{{Project: "/work/[", To: []string{"alice"}}},
+		{{To: []string{"@unknown"}}},
+		{{To: []string{"alice"}, FallbackAfter: "soon"}},
+	} {
+		if _, err := NewRouter(routes, nil); err == nil {
+			t.Errorf("Expected routes %+v to be rejected", routes)
+		}
+	}
+}
+
+func TestBroadcaster_RoutesWithFallback(t *testing.T) {
+	router, err := NewRouter([]RouteConfig{
+		{Project: "/work/acme", To: []string{"alice"}, FallbackAfter: "200ms"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create router: %v", err)
+	}
+	b := NewBroadcasterWithOptions(BroadcasterOptions{Router: router})
+
+	alice := NewWebClient("alice-client")
+	alice.SetToken("test-token")
+	alice.SetNickname("alice")
+	bob := NewWebClient("bob-client")
+	bob.SetToken("test-token")
+	bob.SetNickname("bob")
+	b.RegisterClient(alice)
+	b.RegisterClient(bob)
+	time.Sleep(100 * time.Millisecond)
+
+	responseChan := make(chan *WebResponse, 1)
+	b.BroadcastToToken(newTestRoutedMessage("req-1", "/work/acme/app", ""), "test-token", time.Now().Add(time.Minute), responseChan)
+	time.Sleep(100 * time.Millisecond)
+
+	if len(alice.SendChan) != 1 || len(bob.SendChan) != 0 {
+		t.Fatalf("Expected only alice to get the request, got %d and %d", len(alice.SendChan), len(bob.SendChan))
+	}
+	if pending := b.GetPendingMessagesForClient(bob); len(pending) != 0 {
+		t.Errorf("Expected bob not to see the routed request, got %d", len(pending))
+	}
+
+	// Alice does not answer in time, everyone else gets the request once
+	time.Sleep(300 * time.Millisecond)
+	if len(alice.SendChan) != 1 || len(bob.SendChan) != 1 {
+		t.Fatalf("Expected the request to fall back to bob only, got %d and %d", len(alice.SendChan), len(bob.SendChan))
+	}
+	if pending := b.GetPendingMessagesForClient(bob); len(pending) != 1 || pending[0].DeliveryCount != 2 {
+		t.Errorf("Expected bob to see the request delivered twice, got %v", pending)
+	}
+
+	// Requests no rule matches go to everyone
+	b.BroadcastToToken(newTestRoutedMessage("req-2", "/work/other", ""), "test-token", time.Now().Add(time.Minute), responseChan)
+	time.Sleep(100 * time.Millisecond)
+	if len(alice.SendChan) != 2 || len(bob.SendChan) != 2 {
+		t.Errorf("Expected an unrouted request to reach everyone, got %d and %d", len(alice.SendChan), len(bob.SendChan))
+	}
+}
+
+func TestBroadcaster_HoldsRoutedRequestsForTheirTargets(t *testing.T) {
+	router, err := NewRouter([]RouteConfig{
+		{Project: "/work/acme", To: []string{"alice"}, FallbackAfter: "300ms"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create router: %v", err)
+	}
+	b := NewBroadcasterWithOptions(BroadcasterOptions{Router: router})
+
+	// Nobody is online when the request arrives
+	responseChan := make(chan *WebResponse, 1)
+	b.BroadcastToToken(newTestRoutedMessage("req-1", "/work/acme/app", ""), "test-token", time.Now().Add(time.Minute), responseChan)
+	time.Sleep(50 * time.Millisecond)
+
+	// A user the request is not meant for logs in first and does not get it
+	bob := NewWebClient("bob-client")
+	bob.SetToken("test-token")
+	bob.SetNickname("bob")
+	b.RegisterClient(bob)
+	time.Sleep(50 * time.Millisecond)
+	b.DeliverHeldRequests(bob)
+	if len(bob.SendChan) != 0 {
+		t.Fatalf("Expected bob not to get the routed request, got %d messages", len(bob.SendChan))
+	}
+	if pending := b.GetPendingMessagesForClient(bob); len(pending) != 0 {
+		t.Errorf("Expected bob not to see the routed request, got %d", len(pending))
+	}
+
+	// The targeted user gets it when they log in
+	alice := NewWebClient("alice-client")
+	alice.SetToken("test-token")
+	alice.SetNickname("alice")
+	b.RegisterClient(alice)
+	time.Sleep(50 * time.Millisecond)
+	b.DeliverHeldRequests(alice)
+	if len(alice.SendChan) != 1 || len(bob.SendChan) != 0 {
+		t.Fatalf("Expected only alice to get the request, got %d and %d", len(alice.SendChan), len(bob.SendChan))
+	}
+
+	// Everyone gets it once the fallback passes
+	time.Sleep(300 * time.Millisecond)
+	if len(alice.SendChan) != 1 || len(bob.SendChan) != 1 {
+		t.Errorf("Expected the request to fall back to bob, got %d and %d", len(alice.SendChan), len(bob.SendChan))
+	}
+}
@@ -10,12 +10,19 @@ import (
 	"github.com/yangjuncode/agentassistant/agentassistproto"
 )
 
+// AgentCancelledReason is the reason shown to the web users when the agent stops waiting for
+// a request, e.g. because the IDE cancelled the tool call
+const AgentCancelledReason = "agent cancelled"
+
 // AgentAssistService implements the SrvAgentAssist service
 type AgentAssistService struct {
 	agentassistproto.UnimplementedSrvAgentAssistHandler
 
 	// Broadcast manager for web users
 	broadcaster *Broadcaster
+
+	// Client info reported by each MCP session
+	clientInfos *mcpClientInfoStore
 }
 
 // SendMcpClientInfo handles the initial MCP client info RPC.
@@ -37,13 +44,30 @@ func (s *AgentAssistService) SendMcpClientInfo(
 		info.CapabilitiesJson,
 	)
 
+	if req.Msg.McpSessionID != "" {
+		s.clientInfos.set(requestToken(ctx, req.Msg.UserToken), req.Msg.McpSessionID, info)
+	}
+
 	return connect.NewResponse(&agentassistproto.McpClientInfoResponse{Success: true}), nil
 }
 
 // NewAgentAssistService creates a new instance of the service
 func NewAgentAssistService() *AgentAssistService {
+	return NewAgentAssistServiceWithStore(nil)
+}
+
+// NewAgentAssistServiceWithStore creates a new instance of the service whose pending
+// requests are persisted to store, so they survive a server restart
+func NewAgentAssistServiceWithStore(store RequestStore) *AgentAssistService {
+	return NewAgentAssistServiceWithOptions(BroadcasterOptions{Store: store})
+}
+
+// NewAgentAssistServiceWithOptions creates a new instance of the service whose broadcaster
+// is configured by options
+func NewAgentAssistServiceWithOptions(options BroadcasterOptions) *AgentAssistService {
 	return &AgentAssistService{
-		broadcaster: NewBroadcaster(),
+		broadcaster: NewBroadcasterWithOptions(options),
+		clientInfos: newMcpClientInfoStore(),
 	}
 }
 
@@ -70,106 +94,43 @@ func (s *AgentAssistService) AskQuestion(
 	log.Printf("Received AskQuestion request: ProjectDirectory=%s, Question=%s, Timeout=%d",
 		req.Msg.Request.ProjectDirectory, req.Msg.Request.Question, req.Msg.Request.Timeout)
 
-	// Set default timeout if not provided
-	timeout := req.Msg.Request.Timeout
-	if timeout <= 0 {
-		timeout = 600 // Default 600 seconds
-	}
-
-	// Create a context with timeout
-	timeoutCtx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
-	defer cancel()
-
-	// Create WebsocketMessage for web users
 	requestID := req.Msg.ID
 
+	// Route the request by the authenticated user rather than the token in the body
+	req.Msg.UserToken = requestToken(ctx, req.Msg.UserToken)
+	if info := s.clientInfos.get(req.Msg.UserToken, req.Msg.Request.McpSessionID); info != nil {
+		req.Msg.Request.McpClientInfo = info
+	}
+
 	// Set timestamp
 	req.Msg.Timestamp = time.Now().UnixMilli()
 
+	// Create WebsocketMessage for web users
 	websocketMessage := &agentassistproto.WebsocketMessage{
 		Cmd:                "AskQuestion",
 		AskQuestionRequest: req.Msg,
 	}
 
-	// Create response channel
-	responseChan := make(chan *WebResponse, 1)
-
-	// Broadcast to web users with token filtering
-	s.broadcaster.BroadcastToToken(websocketMessage, req.Msg.UserToken, responseChan)
-
-	// Wait for response, timeout, or cancellation
-	select {
-	case response := <-responseChan:
-		if response.IsError {
-			return &connect.Response[agentassistproto.AskQuestionResponse]{
-				Msg: &agentassistproto.AskQuestionResponse{
-					ID:       requestID,
-					IsError:  true,
-					Meta:     response.Meta,
-					Contents: nil,
-				},
-			}, nil
-		}
-
+	response := s.waitForWebResponse(ctx, requestID, "AskQuestion", req.Msg.UserToken, websocketMessage)
+	if response.IsError {
 		return &connect.Response[agentassistproto.AskQuestionResponse]{
 			Msg: &agentassistproto.AskQuestionResponse{
 				ID:       requestID,
-				IsError:  false,
+				IsError:  true,
 				Meta:     response.Meta,
-				Contents: response.Contents,
-			},
-		}, nil
-
-	case <-timeoutCtx.Done():
-		if timeoutCtx.Err() == context.DeadlineExceeded {
-			log.Printf("AskQuestion request timed out after %d seconds", timeout)
-			// Cancel the request in broadcaster and notify clients
-			s.broadcaster.CancelRequest(requestID, fmt.Sprintf("Request timed out after %d seconds", timeout), "AskQuestion")
-			return &connect.Response[agentassistproto.AskQuestionResponse]{
-				Msg: &agentassistproto.AskQuestionResponse{
-					ID:      requestID,
-					IsError: true,
-					Meta: map[string]string{
-						"error":   "timeout",
-						"message": fmt.Sprintf("Request timed out after %d seconds", timeout),
-					},
-					Contents: nil,
-				},
-			}, nil
-		}
-		// Context was cancelled (not timeout)
-		log.Printf("AskQuestion request was cancelled: %s", requestID)
-		// Cancel the request in broadcaster and notify clients
-		s.broadcaster.CancelRequest(requestID, "Request was cancelled by client", "AskQuestion")
-		return &connect.Response[agentassistproto.AskQuestionResponse]{
-			Msg: &agentassistproto.AskQuestionResponse{
-				ID:      requestID,
-				IsError: true,
-				Meta: map[string]string{
-					"error":   "cancelled",
-					"message": "Request was cancelled by client",
-				},
-				Contents: nil,
-			},
-		}, nil
-
-	case <-ctx.Done():
-		// Original context was cancelled
-		log.Printf("AskQuestion request was cancelled by original context: %s", requestID)
-		// Cancel the request in broadcaster and notify clients
-		s.broadcaster.CancelRequest(requestID, "Request was cancelled", "AskQuestion")
-		return &connect.Response[agentassistproto.AskQuestionResponse]{
-			Msg: &agentassistproto.AskQuestionResponse{
-				ID:      requestID,
-				IsError: true,
-				Meta: map[string]string{
-					"error":   "cancelled",
-					"message": "Request was cancelled",
-				},
 				Contents: nil,
 			},
 		}, nil
 	}
+
+	return &connect.Response[agentassistproto.AskQuestionResponse]{
+		Msg: &agentassistproto.AskQuestionResponse{
+			ID:       requestID,
+			IsError:  false,
+			Meta:     response.Meta,
+			Contents: response.Contents,
+		},
+	}, nil
 }
 
 // WorkReport implements the WorkReport RPC method
@@ -195,105 +156,335 @@ func (s *AgentAssistService) WorkReport(
 	log.Printf("Received WorkReport request: ProjectDirectory=%s, Summary=%s, Timeout=%d",
 		req.Msg.Request.ProjectDirectory, req.Msg.Request.Summary, req.Msg.Request.Timeout)
 
-	// Set default timeout if not provided
-	timeout := req.Msg.Request.Timeout
-	if timeout <= 0 {
-		timeout = 600 // Default 600 seconds
-	}
-
-	// Create a context with timeout
-	timeoutCtx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
-	defer cancel()
-
-	// Create WebsocketMessage for web users
 	requestID := req.Msg.ID
 
+	// Route the request by the authenticated user rather than the token in the body
+	req.Msg.UserToken = requestToken(ctx, req.Msg.UserToken)
+	if info := s.clientInfos.get(req.Msg.UserToken, req.Msg.Request.McpSessionID); info != nil {
+		req.Msg.Request.McpClientInfo = info
+	}
+
 	// Set timestamp
 	req.Msg.Timestamp = time.Now().UnixMilli()
 
+	// Create WebsocketMessage for web users
 	websocketMessage := &agentassistproto.WebsocketMessage{
 		Cmd:               "WorkReport",
 		WorkReportRequest: req.Msg,
 	}
 
-	// Create response channel
-	responseChan := make(chan *WebResponse, 1)
-
-	// Broadcast to web users with token filtering
-	s.broadcaster.BroadcastToToken(websocketMessage, req.Msg.UserToken, responseChan)
-
-	// Wait for response, timeout, or cancellation
-	select {
-	case response := <-responseChan:
-		if response.IsError {
-			return &connect.Response[agentassistproto.WorkReportResponse]{
-				Msg: &agentassistproto.WorkReportResponse{
-					ID:       requestID,
-					IsError:  true,
-					Meta:     response.Meta,
-					Contents: nil,
-				},
-			}, nil
-		}
-
+	response := s.waitForWebResponse(ctx, requestID, "WorkReport", req.Msg.UserToken, websocketMessage)
+	if response.IsError {
 		return &connect.Response[agentassistproto.WorkReportResponse]{
 			Msg: &agentassistproto.WorkReportResponse{
 				ID:       requestID,
-				IsError:  false,
+				IsError:  true,
 				Meta:     response.Meta,
-				Contents: response.Contents,
+				Contents: nil,
 			},
 		}, nil
+	}
 
-	case <-timeoutCtx.Done():
-		if timeoutCtx.Err() == context.DeadlineExceeded {
-			log.Printf("WorkReport request timed out after %d seconds", timeout)
-			// Cancel the request in broadcaster and notify clients
-			s.broadcaster.CancelRequest(requestID, fmt.Sprintf("Request timed out after %d seconds", timeout), "WorkReport")
-			return &connect.Response[agentassistproto.WorkReportResponse]{
-				Msg: &agentassistproto.WorkReportResponse{
-					ID:      requestID,
-					IsError: true,
-					Meta: map[string]string{
-						"error":   "timeout",
-						"message": fmt.Sprintf("Request timed out after %d seconds", timeout),
-					},
-					Contents: nil,
-				},
-			}, nil
+	return &connect.Response[agentassistproto.WorkReportResponse]{
+		Msg: &agentassistproto.WorkReportResponse{
+			ID:       requestID,
+			IsError:  false,
+			Meta:     response.Meta,
+			Contents: response.Contents,
+			Decision: response.Decision,
+		},
+	}, nil
+}
+
+// SubmitRequest implements the SubmitRequest RPC method. It registers an ask_question or
+// work_report request and returns without waiting for the web users, the result is collected
+// with AwaitResult.
+func (s *AgentAssistService) SubmitRequest(
+	ctx context.Context,
+	req *connect.Request[agentassistproto.SubmitRequestRequest],
+) (*connect.Response[agentassistproto.SubmitRequestResponse], error) {
+	askQuestion, workReport := req.Msg.AskQuestionRequest, req.Msg.WorkReportRequest
+
+	var websocketMessage *agentassistproto.WebsocketMessage
+	var userToken string
+	switch {
+	case askQuestion != nil && workReport == nil && askQuestion.Request != nil:
+		log.Printf("Received AskQuestion submission %s: ProjectDirectory=%s, Question=%s, Timeout=%d",
+			askQuestion.ID, askQuestion.Request.ProjectDirectory, askQuestion.Request.Question, askQuestion.Request.Timeout)
+		askQuestion.Timestamp = time.Now().UnixMilli()
+		askQuestion.UserToken = requestToken(ctx, askQuestion.UserToken)
+		if info := s.clientInfos.get(askQuestion.UserToken, askQuestion.Request.McpSessionID); info != nil {
+			askQuestion.Request.McpClientInfo = info
 		}
-		// Context was cancelled (not timeout)
-		log.Printf("WorkReport request was cancelled: %s", requestID)
-		// Cancel the request in broadcaster and notify clients
-		s.broadcaster.CancelRequest(requestID, "Request was cancelled by client", "WorkReport")
-		return &connect.Response[agentassistproto.WorkReportResponse]{
-			Msg: &agentassistproto.WorkReportResponse{
-				ID:      requestID,
-				IsError: true,
-				Meta: map[string]string{
-					"error":   "cancelled",
-					"message": "Request was cancelled by client",
-				},
-				Contents: nil,
+		userToken = askQuestion.UserToken
+		websocketMessage = &agentassistproto.WebsocketMessage{
+			Cmd:                "AskQuestion",
+			AskQuestionRequest: askQuestion,
+		}
+	case workReport != nil && askQuestion == nil && workReport.Request != nil:
+		log.Printf("Received WorkReport submission %s: ProjectDirectory=%s, Summary=%s, Timeout=%d",
+			workReport.ID, workReport.Request.ProjectDirectory, workReport.Request.Summary, workReport.Request.Timeout)
+		workReport.Timestamp = time.Now().UnixMilli()
+		workReport.UserToken = requestToken(ctx, workReport.UserToken)
+		if info := s.clientInfos.get(workReport.UserToken, workReport.Request.McpSessionID); info != nil {
+			workReport.Request.McpClientInfo = info
+		}
+		userToken = workReport.UserToken
+		websocketMessage = &agentassistproto.WebsocketMessage{
+			Cmd:               "WorkReport",
+			WorkReportRequest: workReport,
+		}
+	default:
+		log.Printf("Received SubmitRequest without exactly one valid request")
+		return connect.NewResponse(&agentassistproto.SubmitRequestResponse{
+			Success: false,
+			Meta: map[string]string{
+				"error":   "invalid_request",
+				"message": "Exactly one of AskQuestionRequest and WorkReportRequest with its Request field is required",
 			},
-		}, nil
+		}), nil
+	}
 
-	case <-ctx.Done():
-		// Original context was cancelled
-		log.Printf("WorkReport request was cancelled by original context: %s", requestID)
-		// Cancel the request in broadcaster and notify clients
-		s.broadcaster.CancelRequest(requestID, "Request was cancelled", "WorkReport")
-		return &connect.Response[agentassistproto.WorkReportResponse]{
-			Msg: &agentassistproto.WorkReportResponse{
-				ID:      requestID,
-				IsError: true,
-				Meta: map[string]string{
-					"error":   "cancelled",
-					"message": "Request was cancelled",
-				},
-				Contents: nil,
+	requestID, _ := requestIDAndType(websocketMessage)
+	if requestID == "" {
+		return connect.NewResponse(&agentassistproto.SubmitRequestResponse{
+			Success: false,
+			Meta: map[string]string{
+				"error":   "invalid_request",
+				"message": "Request ID is required",
 			},
-		}, nil
+		}), nil
+	}
+
+	timeout := requestTimeout(websocketMessage)
+	deadline, resumed, err := s.broadcaster.SubmitRequest(websocketMessage, userToken, time.Now().Add(time.Duration(timeout)*time.Second))
+	if err != nil {
+		log.Printf("Refusing submission %s: %v", requestID, err)
+		return connect.NewResponse(&agentassistproto.SubmitRequestResponse{
+			ID:      requestID,
+			Success: false,
+			Meta:    requestIDTakenResponse().Meta,
+		}), nil
+	}
+	if resumed {
+		log.Printf("Request %s was submitted again, re-attaching to it", requestID)
+	}
+
+	return connect.NewResponse(&agentassistproto.SubmitRequestResponse{
+		ID:       requestID,
+		Success:  true,
+		Resumed:  resumed,
+		Deadline: deadline.UnixMilli(),
+	}), nil
+}
+
+// AwaitResult implements the AwaitResult RPC method. It waits up to WaitSeconds for the result
+// of a submitted request; callers poll it again until the result is available.
+func (s *AgentAssistService) AwaitResult(
+	ctx context.Context,
+	req *connect.Request[agentassistproto.AwaitResultRequest],
+) (*connect.Response[agentassistproto.AwaitResultResponse], error) {
+	requestID := req.Msg.ID
+
+	info, exists := s.broadcaster.lookupRequest(requestID)
+	if !exists || info.UserToken != requestToken(ctx, req.Msg.UserToken) {
+		log.Printf("AwaitResult for unknown request %s", requestID)
+		return connect.NewResponse(&agentassistproto.AwaitResultResponse{
+			ID:       requestID,
+			NotFound: true,
+		}), nil
+	}
+
+	waitSeconds := req.Msg.WaitSeconds
+	if waitSeconds <= 0 {
+		waitSeconds = defaultAwaitWaitSeconds
+	}
+	if waitSeconds > maxAwaitWaitSeconds {
+		waitSeconds = maxAwaitWaitSeconds
+	}
+
+	response, found := s.awaitResult(ctx, requestID, info.UserToken, time.Duration(waitSeconds)*time.Second)
+	if !found {
+		return connect.NewResponse(&agentassistproto.AwaitResultResponse{
+			ID:       requestID,
+			NotFound: true,
+		}), nil
+	}
+	if response == nil {
+		// The deadline may have moved while waiting
+		info, _ = s.broadcaster.lookupRequest(requestID)
+		return connect.NewResponse(&agentassistproto.AwaitResultResponse{
+			ID:       requestID,
+			Done:     false,
+			Deadline: info.Deadline.UnixMilli(),
+		}), nil
+	}
+
+	result := &agentassistproto.AwaitResultResponse{
+		ID:   requestID,
+		Done: true,
+	}
+	switch info.MessageType {
+	case "AskQuestion":
+		result.AskQuestionResponse = &agentassistproto.AskQuestionResponse{
+			ID:       requestID,
+			IsError:  response.IsError,
+			Meta:     response.Meta,
+			Contents: response.Contents,
+		}
+	case "WorkReport":
+		result.WorkReportResponse = &agentassistproto.WorkReportResponse{
+			ID:       requestID,
+			IsError:  response.IsError,
+			Meta:     response.Meta,
+			Contents: response.Contents,
+			Decision: response.Decision,
+		}
+	}
+	return connect.NewResponse(result), nil
+}
+
+// WatchRequest implements the WatchRequest RPC method. It streams the lifecycle events of a
+// request, starting with the ones that already happened, until its final event or until the
+// caller goes away.
+func (s *AgentAssistService) WatchRequest(
+	ctx context.Context,
+	req *connect.Request[agentassistproto.WatchRequestRequest],
+	stream *connect.ServerStream[agentassistproto.RequestEvent],
+) error {
+	requestID := req.Msg.ID
+
+	info, exists := s.broadcaster.lookupRequest(requestID)
+	if !exists || info.UserToken != requestToken(ctx, req.Msg.UserToken) {
+		return connect.NewError(connect.CodeNotFound, fmt.Errorf("unknown request %s", requestID))
+	}
+
+	events, unsubscribe, exists := s.broadcaster.WatchRequest(requestID)
+	if !exists {
+		// Forgotten since the lookup
+		return connect.NewError(connect.CodeNotFound, fmt.Errorf("unknown request %s", requestID))
+	}
+	defer unsubscribe()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// CancelRequest implements the CancelRequest RPC method. It withdraws a pending request from
+// the web users, who are notified with the given reason.
+func (s *AgentAssistService) CancelRequest(
+	ctx context.Context,
+	req *connect.Request[agentassistproto.CancelRequestRequest],
+) (*connect.Response[agentassistproto.CancelRequestResponse], error) {
+	requestID := req.Msg.ID
+
+	info, exists := s.broadcaster.lookupRequest(requestID)
+	if !exists || info.UserToken != requestToken(ctx, req.Msg.UserToken) {
+		log.Printf("CancelRequest for unknown request %s", requestID)
+		return connect.NewResponse(&agentassistproto.CancelRequestResponse{Success: false}), nil
+	}
+
+	reason := req.Msg.Reason
+	if reason == "" {
+		reason = AgentCancelledReason
+	}
+
+	cancelled := s.broadcaster.CancelRequest(requestID, reason, info.MessageType)
+	return connect.NewResponse(&agentassistproto.CancelRequestResponse{Success: cancelled}), nil
+}
+
+// Bounds of the AwaitResult wait time in seconds
+const (
+	defaultAwaitWaitSeconds = 30
+	maxAwaitWaitSeconds     = 300
+)
+
+// awaitResult waits up to wait for the result of a request. It returns a nil response if the
+// request is still pending when wait elapses or ctx is cancelled, and found=false if the
+// request of userToken is unknown.
+func (s *AgentAssistService) awaitResult(ctx context.Context, requestID, userToken string, wait time.Duration) (*WebResponse, bool) {
+	responseChan := make(chan *WebResponse, 1)
+	if _, found, _ := s.broadcaster.ResumeRequest(requestID, userToken, responseChan); !found {
+		return nil, false
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case response := <-responseChan:
+		return response, true
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+
+	// Keep the request pending for the next poll
+	s.broadcaster.DetachRequest(requestID, responseChan)
+
+	// The result may have arrived while detaching
+	select {
+	case response := <-responseChan:
+		return response, true
+	default:
+		return nil, true
+	}
+}
+
+// waitForWebResponse broadcasts a request to the web users and waits for their response,
+// the request timeout or the cancellation of ctx, which cancels the request unless another
+// caller resumed it meanwhile. A request that is already known to the broadcaster (the
+// caller re-sent it after a reconnect or a server restart) is resumed instead of being
+// broadcast again.
+func (s *AgentAssistService) waitForWebResponse(
+	ctx context.Context,
+	requestID string,
+	messageType string,
+	userToken string,
+	message *agentassistproto.WebsocketMessage,
+) *WebResponse {
+	// Create response channel
+	responseChan := make(chan *WebResponse, 1)
+
+	deadline, resumed, err := s.broadcaster.ResumeRequest(requestID, userToken, responseChan)
+	if err != nil {
+		log.Printf("Refusing %s request %s: %v", messageType, requestID, err)
+		return requestIDTakenResponse()
+	}
+	if resumed {
+		log.Printf("%s request %s resumed, deadline %s", messageType, requestID, deadline.Format(time.RFC3339))
+	} else {
+		deadline = time.Now().Add(time.Duration(requestTimeout(message)) * time.Second)
+		// Broadcast to web users with token filtering, the broadcaster times the
+		// request out at its deadline
+		s.broadcaster.BroadcastToToken(message, userToken, deadline, responseChan)
+	}
+
+	// Wait for response, timeout, or cancellation
+	select {
+	case response := <-responseChan:
+		return response
+
+	case <-ctx.Done():
+		// Blocking callers do not come back for the result, only SubmitRequest callers
+		// poll AwaitResult, so the request is withdrawn rather than kept pending. If the
+		// caller already re-sent it, the newer call waits for the answer instead.
+		if s.broadcaster.CancelAttachedRequest(requestID, responseChan, AgentCancelledReason, messageType) {
+			log.Printf("%s request was cancelled: %s", messageType, requestID)
+		} else {
+			log.Printf("%s request %s lost its caller, another caller is waiting for it", messageType, requestID)
+		}
+		return cancelledResponse(AgentCancelledReason)
 	}
 }
 
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -10,8 +11,8 @@ import (
 )
 
 func TestAgentAssistService_AskQuestion(t *testing.T) {
-	// Create service
-	svc := NewAgentAssistService()
+	// Create service that fails requests no web client is online for
+	svc := NewAgentAssistServiceWithOptions(BroadcasterOptions{NoClientsPolicy: NoClientsPolicyFail})
 
 	// Create test request
 	req := &connect.Request[agentassistproto.AskQuestionRequest]{
@@ -26,7 +27,7 @@ func TestAgentAssistService_AskQuestion(t *testing.T) {
 		},
 	}
 
-	// Test with no clients (should timeout)
+	// Test with no clients (should fail right away)
 	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
 	defer cancel()
 
@@ -45,8 +46,8 @@ func TestAgentAssistService_AskQuestion(t *testing.T) {
 }
 
 func TestAgentAssistService_WorkReport(t *testing.T) {
-	// Create service
-	svc := NewAgentAssistService()
+	// Create service that fails requests no web client is online for
+	svc := NewAgentAssistServiceWithOptions(BroadcasterOptions{NoClientsPolicy: NoClientsPolicyFail})
 
 	// Create test request
 	req := &connect.Request[agentassistproto.WorkReportRequest]{
@@ -61,7 +62,7 @@ func TestAgentAssistService_WorkReport(t *testing.T) {
 		},
 	}
 
-	// Test with no clients (should timeout)
+	// Test with no clients (should fail right away)
 	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
 	defer cancel()
 
@@ -170,3 +171,331 @@ func TestBroadcaster(t *testing.T) {
 		t.Errorf("Expected 0 clients after unregistration, got %d", count)
 	}
 }
+
+func TestAgentAssistService_SubmitRequestAndAwaitResult(t *testing.T) {
+	svc := NewAgentAssistService()
+	client := NewWebClient("client1")
+	client.SetToken("test-token")
+	svc.GetBroadcaster().RegisterClient(client)
+	time.Sleep(100 * time.Millisecond)
+
+	submit := &agentassistproto.SubmitRequestRequest{
+		AskQuestionRequest: newTestAskQuestionMessage("req-1").AskQuestionRequest,
+	}
+	submitResp, err := svc.SubmitRequest(context.Background(), connect.NewRequest(submit))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !submitResp.Msg.Success || submitResp.Msg.Resumed {
+		t.Fatalf("Expected a new accepted request, got %+v", submitResp.Msg)
+	}
+
+	// Submitting the same request again re-attaches to it
+	submitResp, err = svc.SubmitRequest(context.Background(), connect.NewRequest(submit))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !submitResp.Msg.Resumed {
+		t.Errorf("Expected the re-submitted request to be resumed, got %+v", submitResp.Msg)
+	}
+
+	await := &agentassistproto.AwaitResultRequest{ID: "req-1", UserToken: "test-token", WaitSeconds: 1}
+	awaitResp, err := svc.AwaitResult(context.Background(), connect.NewRequest(await))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if awaitResp.Msg.Done || awaitResp.Msg.NotFound {
+		t.Fatalf("Expected the request to be pending, got %+v", awaitResp.Msg)
+	}
+
+	svc.GetBroadcaster().HandleResponse("req-1", &WebResponse{
+		Contents: []*agentassistproto.McpResultContent{CreateTextContent("Go ahead")},
+	})
+
+	// The result is retained and can be collected more than once
+	for i := 0; i < 2; i++ {
+		awaitResp, err = svc.AwaitResult(context.Background(), connect.NewRequest(await))
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if !awaitResp.Msg.Done || awaitResp.Msg.AskQuestionResponse == nil {
+			t.Fatalf("Expected the request to be done, got %+v", awaitResp.Msg)
+		}
+		if contents := awaitResp.Msg.AskQuestionResponse.Contents; len(contents) != 1 || contents[0].Text.Text != "Go ahead" {
+			t.Errorf("Unexpected result contents: %v", contents)
+		}
+	}
+
+	// Unknown requests and other users' requests are not found
+	for _, req := range []*agentassistproto.AwaitResultRequest{
+		{ID: "unknown", UserToken: "test-token"},
+		{ID: "req-1", UserToken: "other-token"},
+	} {
+		awaitResp, err = svc.AwaitResult(context.Background(), connect.NewRequest(req))
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if !awaitResp.Msg.NotFound {
+			t.Errorf("Expected request %s with token %s to be not found, got %+v", req.ID, req.UserToken, awaitResp.Msg)
+		}
+	}
+}
+
+func TestAgentAssistService_SubmittedRequestTimesOut(t *testing.T) {
+	svc := NewAgentAssistService()
+	client := NewWebClient("client1")
+	client.SetToken("test-token")
+	svc.GetBroadcaster().RegisterClient(client)
+	time.Sleep(100 * time.Millisecond)
+
+	request := newTestAskQuestionMessage("req-1").AskQuestionRequest
+	request.Request.Timeout = 1
+	_, err := svc.SubmitRequest(context.Background(), connect.NewRequest(&agentassistproto.SubmitRequestRequest{
+		AskQuestionRequest: request,
+	}))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Nobody is waiting for the request, the server times it out on its own
+	awaitResp, err := svc.AwaitResult(context.Background(), connect.NewRequest(&agentassistproto.AwaitResultRequest{
+		ID:          "req-1",
+		UserToken:   "test-token",
+		WaitSeconds: 3,
+	}))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !awaitResp.Msg.Done || awaitResp.Msg.AskQuestionResponse == nil {
+		t.Fatalf("Expected the request to be done, got %+v", awaitResp.Msg)
+	}
+	if awaitResp.Msg.AskQuestionResponse.Meta["error"] != "timeout" {
+		t.Errorf("Expected 'timeout' error, got: %v", awaitResp.Msg.AskQuestionResponse.Meta)
+	}
+}
+
+func TestAgentAssistService_SubmitRequest_Invalid(t *testing.T) {
+	svc := NewAgentAssistService()
+
+	resp, err := svc.SubmitRequest(context.Background(), connect.NewRequest(&agentassistproto.SubmitRequestRequest{}))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if resp.Msg.Success || resp.Msg.Meta["error"] != "invalid_request" {
+		t.Errorf("Expected an invalid_request rejection, got %+v", resp.Msg)
+	}
+}
+
+func TestAgentAssistService_AttachesMcpClientInfo(t *testing.T) {
+	svc := NewAgentAssistService()
+
+	for _, session := range []struct{ id, client string }{{"session-1", "ide-1"}, {"session-2", "ide-2"}} {
+		_, err := svc.SendMcpClientInfo(context.Background(), connect.NewRequest(&agentassistproto.McpClientInfoRequest{
+			UserToken:    "test-token",
+			McpSessionID: session.id,
+			Request:      &agentassistproto.McpClientInfoData{ClientName: session.client, ClientVersion: "1.0"},
+		}))
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+	}
+
+	submit := func(requestID, sessionID string) *agentassistproto.McpAskQuestionRequest {
+		request := newTestAskQuestionMessage(requestID).AskQuestionRequest
+		request.Request.McpSessionID = sessionID
+		if _, err := svc.SubmitRequest(context.Background(), connect.NewRequest(&agentassistproto.SubmitRequestRequest{
+			AskQuestionRequest: request,
+		})); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		return request.Request
+	}
+
+	if info := submit("req-1", "session-2").McpClientInfo; info == nil || info.ClientName != "ide-2" {
+		t.Errorf("Expected the client info of session-2, got %v", info)
+	}
+	if info := submit("req-2", "session-3").McpClientInfo; info != nil {
+		t.Errorf("Expected no client info for an unknown session, got %v", info)
+	}
+}
+
+func TestAgentAssistService_CancelRequest(t *testing.T) {
+	svc := NewAgentAssistService()
+	client := NewWebClient("client1")
+	client.SetToken("test-token")
+	svc.GetBroadcaster().RegisterClient(client)
+	time.Sleep(100 * time.Millisecond)
+
+	_, err := svc.SubmitRequest(context.Background(), connect.NewRequest(&agentassistproto.SubmitRequestRequest{
+		AskQuestionRequest: newTestAskQuestionMessage("req-1").AskQuestionRequest,
+	}))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	cancel := func(userToken string) bool {
+		resp, err := svc.CancelRequest(context.Background(), connect.NewRequest(&agentassistproto.CancelRequestRequest{
+			ID:        "req-1",
+			UserToken: userToken,
+		}))
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		return resp.Msg.Success
+	}
+
+	if cancel("other-token") {
+		t.Error("Expected cancellation with another token to fail")
+	}
+	if !cancel("test-token") {
+		t.Fatal("Expected the pending request to be cancelled")
+	}
+	if cancel("test-token") {
+		t.Error("Expected a completed request not to be cancelled again")
+	}
+
+	awaitResp, err := svc.AwaitResult(context.Background(), connect.NewRequest(&agentassistproto.AwaitResultRequest{
+		ID:        "req-1",
+		UserToken: "test-token",
+	}))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	meta := awaitResp.Msg.GetAskQuestionResponse().GetMeta()
+	if meta["error"] != "cancelled" || meta["message"] != AgentCancelledReason {
+		t.Errorf("Expected an agent cancelled result, got: %v", meta)
+	}
+
+	// The web users are told why the request is gone
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case message := <-client.SendChan:
+			if n := message.RequestCancelledNotification; n != nil {
+				if n.RequestId != "req-1" || n.Reason != AgentCancelledReason {
+					t.Errorf("Unexpected cancellation notification: %v", n)
+				}
+				return
+			}
+		case <-timeout:
+			t.Fatal("Expected a RequestCancelled notification")
+		}
+	}
+}
+
+func TestAgentAssistService_CancelledCallCancelsRequest(t *testing.T) {
+	// Even with a store, a blocking call does not leave its request pending
+	store, err := OpenFileRequestStore(filepath.Join(t.TempDir(), "requests.log"))
+	if err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+	defer store.Close()
+	svc := NewAgentAssistServiceWithStore(store)
+	client := NewWebClient("client1")
+	client.SetToken("test-token")
+	svc.GetBroadcaster().RegisterClient(client)
+	time.Sleep(100 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	resp, err := svc.AskQuestion(ctx, connect.NewRequest(newTestAskQuestionMessage("req-1").AskQuestionRequest))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if resp.Msg.Meta["error"] != "cancelled" {
+		t.Errorf("Expected the call to be cancelled, got: %v", resp.Msg.Meta)
+	}
+	if nextMessage(t, client, "RequestCancelled").RequestCancelledNotification.RequestId != "req-1" {
+		t.Error("Expected the web user to be told the request was cancelled")
+	}
+}
+
+func TestAgentAssistService_StaleCallerDoesNotCancelResumedRequest(t *testing.T) {
+	svc := NewAgentAssistService()
+	client := NewWebClient("client1")
+	client.SetToken("test-token")
+	svc.GetBroadcaster().RegisterClient(client)
+	time.Sleep(100 * time.Millisecond)
+
+	ask := func(ctx context.Context, responses chan<- *agentassistproto.AskQuestionResponse) {
+		resp, err := svc.AskQuestion(ctx, connect.NewRequest(newTestAskQuestionMessage("req-1").AskQuestionRequest))
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+			responses <- nil
+			return
+		}
+		responses <- resp.Msg
+	}
+
+	// The first call loses its connection after the caller re-sent the request
+	staleCtx, cancelStale := context.WithCancel(context.Background())
+	stale := make(chan *agentassistproto.AskQuestionResponse, 1)
+	go ask(staleCtx, stale)
+	time.Sleep(100 * time.Millisecond)
+	resumed := make(chan *agentassistproto.AskQuestionResponse, 1)
+	go ask(context.Background(), resumed)
+	time.Sleep(100 * time.Millisecond)
+	cancelStale()
+	<-stale
+
+	svc.GetBroadcaster().HandleResponse("req-1", &WebResponse{
+		Contents: []*agentassistproto.McpResultContent{CreateTextContent("Go ahead")},
+	})
+	select {
+	case resp := <-resumed:
+		if resp.GetIsError() || len(resp.GetContents()) != 1 {
+			t.Errorf("Expected the resumed call to get the reply, got %v", resp)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the resumed call to get the reply")
+	}
+}
+
+func TestAgentAssistService_RequestIDOfAnotherToken(t *testing.T) {
+	svc := NewAgentAssistService()
+	client := NewWebClient("client1")
+	client.SetToken("test-token")
+	svc.GetBroadcaster().RegisterClient(client)
+	time.Sleep(100 * time.Millisecond)
+
+	submit := &agentassistproto.SubmitRequestRequest{
+		AskQuestionRequest: newTestAskQuestionMessage("req-1").AskQuestionRequest,
+	}
+	if resp, err := svc.SubmitRequest(context.Background(), connect.NewRequest(submit)); err != nil || !resp.Msg.Success {
+		t.Fatalf("Expected the request to be accepted, got %v, %v", resp, err)
+	}
+
+	// Another token can neither re-submit nor wait for the request
+	other := newTestAskQuestionMessage("req-1").AskQuestionRequest
+	other.UserToken = "other-token"
+	resp, err := svc.SubmitRequest(context.Background(), connect.NewRequest(&agentassistproto.SubmitRequestRequest{AskQuestionRequest: other}))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if resp.Msg.Success || resp.Msg.Deadline != 0 || resp.Msg.Meta["error"] != "duplicate_request" {
+		t.Errorf("Expected the submission of another token to be refused, got %+v", resp.Msg)
+	}
+	askResp, err := svc.AskQuestion(context.Background(), connect.NewRequest(other))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !askResp.Msg.IsError || askResp.Msg.Meta["error"] != "duplicate_request" {
+		t.Errorf("Expected the call of another token to be refused, got %+v", askResp.Msg)
+	}
+
+	// The answer still goes to the owner
+	svc.GetBroadcaster().HandleResponse("req-1", &WebResponse{
+		Contents: []*agentassistproto.McpResultContent{CreateTextContent("Go ahead")},
+	})
+	awaitResp, err := svc.AwaitResult(context.Background(), connect.NewRequest(&agentassistproto.AwaitResultRequest{
+		ID:          "req-1",
+		UserToken:   "test-token",
+		WaitSeconds: 1,
+	}))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !awaitResp.Msg.Done || len(awaitResp.Msg.GetAskQuestionResponse().GetContents()) != 1 {
+		t.Errorf("Expected the owner to get the answer, got %+v", awaitResp.Msg)
+	}
+}
@@ -0,0 +1,532 @@
+package service
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	agentassistproto "github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+// StoredRequest is the persisted form of a WebsocketRequest
+type StoredRequest struct {
+	RequestID string
+	UserToken string
+	Message   *agentassistproto.WebsocketMessage
+	CreatedAt time.Time
+	Deadline  time.Time
+	// Response is the final response from the web users, nil while the request is pending
+	Response *WebResponse
+	// Approvals are the approvals a work report that needs several collected so far
+	Approvals []StoredApproval
+	// ClaimedBy is the nickname of the user who claimed the request, empty if nobody did
+	ClaimedBy string
+}
+
+// StoredApproval is the persisted approval of a work report by a web user
+type StoredApproval struct {
+	Nickname string
+	Response *WebResponse
+}
+
+// RequestStore persists pending requests so they survive a server restart
+type RequestStore interface {
+	// SaveRequest records a new pending request
+	SaveRequest(request *StoredRequest) error
+	// SaveDeadline records that the deadline of a pending request moved
+	SaveDeadline(requestID string, deadline time.Time) error
+	// SaveResponse records the final response of a request
+	SaveResponse(requestID string, response *WebResponse) error
+	// SaveApprovals records the approvals a pending work report collected so far
+	SaveApprovals(requestID string, approvals []StoredApproval) error
+	// SaveClaim records who claimed a pending request, an empty nickname once it is released
+	SaveClaim(requestID string, nickname string) error
+	// DeleteRequest forgets a request and its response
+	DeleteRequest(requestID string) error
+	// LoadRequests returns all requests that have not been deleted
+	LoadRequests() ([]*StoredRequest, error)
+	// Close releases the resources held by the store
+	Close() error
+}
+
+// Store log operations
+const (
+	storeOpSave      = "save"
+	storeOpDeadline  = "deadline"
+	storeOpResponse  = "response"
+	storeOpApprovals = "approvals"
+	storeOpClaim     = "claim"
+	storeOpDelete    = "delete"
+)
+
+// storeRecord is a single line of the store log
+type storeRecord struct {
+	Op        string          `json:"op"`
+	RequestID string          `json:"request_id"`
+	UserToken string          `json:"user_token,omitempty"`
+	Message   []byte          `json:"message,omitempty"`    // protobuf encoded WebsocketMessage
+	CreatedAt int64           `json:"created_at,omitempty"` // unix milliseconds
+	Deadline  int64           `json:"deadline,omitempty"`   // unix milliseconds
+	Response  *storeResponse  `json:"response,omitempty"`
+	Approvals []storeApproval `json:"approvals,omitempty"`
+	ClaimedBy string          `json:"claimed_by,omitempty"`
+}
+
+// storeApproval is the persisted form of a StoredApproval
+type storeApproval struct {
+	Nickname string         `json:"nickname"`
+	Response *storeResponse `json:"response"`
+}
+
+// storeResponse is the persisted form of a WebResponse
+type storeResponse struct {
+	IsError  bool              `json:"is_error"`
+	Meta     map[string]string `json:"meta,omitempty"`
+	Contents [][]byte          `json:"contents,omitempty"` // protobuf encoded McpResultContent
+	Decision []byte            `json:"decision,omitempty"` // protobuf encoded WorkReportDecision
+}
+
+// storeCompactInterval is how many requests are deleted from the store log between two
+// compactions of it
+const storeCompactInterval = 100
+
+// FileRequestStore is a RequestStore backed by an append-only JSON lines file.
+// The log is compacted every time the store is opened and every storeCompactInterval
+// deleted requests.
+type FileRequestStore struct {
+	path    string
+	file    *os.File
+	mu      sync.Mutex
+	deleted int // Requests deleted since the last compaction
+}
+
+// OpenFileRequestStore opens (or creates) the store log at path
+func OpenFileRequestStore(path string) (*FileRequestStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create store directory: %w", err)
+	}
+
+	s := &FileRequestStore{path: path}
+
+	// Rewrite the log with only the live requests before appending to it
+	requests, err := s.LoadRequests()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.compact(requests); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open store file %s: %w", path, err)
+	}
+	s.file = file
+
+	return s, nil
+}
+
+// storeTime returns t in unix milliseconds, or 0 if t is the zero time
+func storeTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+// SaveRequest records a new pending request
+func (s *FileRequestStore) SaveRequest(request *StoredRequest) error {
+	message, err := proto.Marshal(request.Message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request %s: %w", request.RequestID, err)
+	}
+
+	return s.append(&storeRecord{
+		Op:        storeOpSave,
+		RequestID: request.RequestID,
+		UserToken: request.UserToken,
+		Message:   message,
+		CreatedAt: storeTime(request.CreatedAt),
+		Deadline:  request.Deadline.UnixMilli(),
+	})
+}
+
+// SaveDeadline records that the deadline of a pending request moved
+func (s *FileRequestStore) SaveDeadline(requestID string, deadline time.Time) error {
+	return s.append(&storeRecord{
+		Op:        storeOpDeadline,
+		RequestID: requestID,
+		Deadline:  deadline.UnixMilli(),
+	})
+}
+
+// SaveResponse records the final response of a request
+func (s *FileRequestStore) SaveResponse(requestID string, response *WebResponse) error {
+	stored, err := encodeStoreResponse(response)
+	if err != nil {
+		return fmt.Errorf("failed to marshal response for request %s: %w", requestID, err)
+	}
+
+	return s.append(&storeRecord{
+		Op:        storeOpResponse,
+		RequestID: requestID,
+		Response:  stored,
+	})
+}
+
+// SaveApprovals records the approvals a pending work report collected so far
+func (s *FileRequestStore) SaveApprovals(requestID string, approvals []StoredApproval) error {
+	stored, err := encodeStoreApprovals(approvals)
+	if err != nil {
+		return fmt.Errorf("failed to marshal approvals for request %s: %w", requestID, err)
+	}
+
+	return s.append(&storeRecord{
+		Op:        storeOpApprovals,
+		RequestID: requestID,
+		Approvals: stored,
+	})
+}
+
+// SaveClaim records who claimed a pending request, an empty nickname once it is released
+func (s *FileRequestStore) SaveClaim(requestID string, nickname string) error {
+	return s.append(&storeRecord{
+		Op:        storeOpClaim,
+		RequestID: requestID,
+		ClaimedBy: nickname,
+	})
+}
+
+// DeleteRequest forgets a request and its response
+func (s *FileRequestStore) DeleteRequest(requestID string) error {
+	if err := s.append(&storeRecord{
+		Op:        storeOpDelete,
+		RequestID: requestID,
+	}); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deleted++
+	if s.deleted < storeCompactInterval || s.file == nil {
+		return nil
+	}
+	return s.compactLocked()
+}
+
+// LoadRequests replays the store log and returns all requests that have not been deleted
+func (s *FileRequestStore) LoadRequests() ([]*StoredRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.loadLocked()
+}
+
+// loadLocked replays the store log. It must be called with s.mu held.
+func (s *FileRequestStore) loadLocked() ([]*StoredRequest, error) {
+	file, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open store file %s: %w", s.path, err)
+	}
+	defer file.Close()
+
+	var order []string
+	requests := make(map[string]*StoredRequest)
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 128*1024*1024)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		var record storeRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			// A partially written line is expected after a crash
+			log.Printf("Skipping invalid record at %s:%d: %v", s.path, lineNo, err)
+			continue
+		}
+
+		switch record.Op {
+		case storeOpSave:
+			message := &agentassistproto.WebsocketMessage{}
+			if err := proto.Unmarshal(record.Message, message); err != nil {
+				log.Printf("Skipping request %s with invalid message: %v", record.RequestID, err)
+				continue
+			}
+			if _, exists := requests[record.RequestID]; !exists {
+				order = append(order, record.RequestID)
+			}
+			requests[record.RequestID] = &StoredRequest{
+				RequestID: record.RequestID,
+				UserToken: record.UserToken,
+				Message:   message,
+				Deadline:  time.UnixMilli(record.Deadline),
+			}
+			if record.CreatedAt != 0 {
+				requests[record.RequestID].CreatedAt = time.UnixMilli(record.CreatedAt)
+			}
+		case storeOpDeadline:
+			if request, exists := requests[record.RequestID]; exists {
+				request.Deadline = time.UnixMilli(record.Deadline)
+			}
+		case storeOpResponse:
+			request, exists := requests[record.RequestID]
+			if !exists || record.Response == nil {
+				continue
+			}
+			response, err := decodeStoreResponse(record.Response)
+			if err != nil {
+				log.Printf("Skipping invalid response for request %s: %v", record.RequestID, err)
+				continue
+			}
+			request.Response = response
+		case storeOpApprovals:
+			request, exists := requests[record.RequestID]
+			if !exists {
+				continue
+			}
+			approvals, err := decodeStoreApprovals(record.Approvals)
+			if err != nil {
+				log.Printf("Skipping invalid approvals for request %s: %v", record.RequestID, err)
+				continue
+			}
+			request.Approvals = approvals
+		case storeOpClaim:
+			if request, exists := requests[record.RequestID]; exists {
+				request.ClaimedBy = record.ClaimedBy
+			}
+		case storeOpDelete:
+			delete(requests, record.RequestID)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read store file %s: %w", s.path, err)
+	}
+
+	result := make([]*StoredRequest, 0, len(requests))
+	for _, requestID := range order {
+		if request, exists := requests[requestID]; exists {
+			result = append(result, request)
+			delete(requests, requestID)
+		}
+	}
+	return result, nil
+}
+
+// Close closes the store log
+func (s *FileRequestStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// append writes a record to the end of the store log
+func (s *FileRequestStore) append(record *storeRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return fmt.Errorf("store is closed")
+	}
+	if _, err := s.file.Write(line); err != nil {
+		return fmt.Errorf("failed to write store file %s: %w", s.path, err)
+	}
+	return s.file.Sync()
+}
+
+// compactLocked rewrites the open store log so it only contains the live requests. It must be
+// called with s.mu held.
+func (s *FileRequestStore) compactLocked() error {
+	requests, err := s.loadLocked()
+	if err != nil {
+		return err
+	}
+	if err := s.compact(requests); err != nil {
+		return err
+	}
+
+	// The old file was replaced, append to the compacted one from now on
+	file, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open store file %s: %w", s.path, err)
+	}
+	s.file.Close()
+	s.file = file
+	s.deleted = 0
+	log.Printf("Compacted store file %s to %d requests", s.path, len(requests))
+	return nil
+}
+
+// compact rewrites the store log so it only contains the given requests
+func (s *FileRequestStore) compact(requests []*StoredRequest) error {
+	tmpPath := s.path + ".tmp"
+	tmp, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create store file %s: %w", tmpPath, err)
+	}
+
+	writer := bufio.NewWriter(tmp)
+	encoder := json.NewEncoder(writer)
+	for _, request := range requests {
+		message, err := proto.Marshal(request.Message)
+		if err != nil {
+			tmp.Close()
+			return fmt.Errorf("failed to marshal request %s: %w", request.RequestID, err)
+		}
+		records := []*storeRecord{{
+			Op:        storeOpSave,
+			RequestID: request.RequestID,
+			UserToken: request.UserToken,
+			Message:   message,
+			CreatedAt: storeTime(request.CreatedAt),
+			Deadline:  request.Deadline.UnixMilli(),
+		}}
+		if request.Response != nil {
+			stored, err := encodeStoreResponse(request.Response)
+			if err != nil {
+				tmp.Close()
+				return fmt.Errorf("failed to marshal response for request %s: %w", request.RequestID, err)
+			}
+			records = append(records, &storeRecord{
+				Op:        storeOpResponse,
+				RequestID: request.RequestID,
+				Response:  stored,
+			})
+		} else {
+			// Only pending requests need their approvals and claim
+			if len(request.Approvals) > 0 {
+				approvals, err := encodeStoreApprovals(request.Approvals)
+				if err != nil {
+					tmp.Close()
+					return fmt.Errorf("failed to marshal approvals for request %s: %w", request.RequestID, err)
+				}
+				records = append(records, &storeRecord{
+					Op:        storeOpApprovals,
+					RequestID: request.RequestID,
+					Approvals: approvals,
+				})
+			}
+			if request.ClaimedBy != "" {
+				records = append(records, &storeRecord{
+					Op:        storeOpClaim,
+					RequestID: request.RequestID,
+					ClaimedBy: request.ClaimedBy,
+				})
+			}
+		}
+		for _, record := range records {
+			if err := encoder.Encode(record); err != nil {
+				tmp.Close()
+				return err
+			}
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, s.path)
+}
+
+// encodeStoreResponse converts a WebResponse to its persisted form
+func encodeStoreResponse(response *WebResponse) (*storeResponse, error) {
+	stored := &storeResponse{
+		IsError: response.IsError,
+		Meta:    response.Meta,
+	}
+	for _, content := range response.Contents {
+		data, err := proto.Marshal(content)
+		if err != nil {
+			return nil, err
+		}
+		stored.Contents = append(stored.Contents, data)
+	}
+	if response.Decision != nil {
+		data, err := proto.Marshal(response.Decision)
+		if err != nil {
+			return nil, err
+		}
+		stored.Decision = data
+	}
+	return stored, nil
+}
+
+// decodeStoreResponse converts a persisted response back to a WebResponse
+func decodeStoreResponse(stored *storeResponse) (*WebResponse, error) {
+	response := &WebResponse{
+		IsError: stored.IsError,
+		Meta:    stored.Meta,
+	}
+	for _, data := range stored.Contents {
+		content := &agentassistproto.McpResultContent{}
+		if err := proto.Unmarshal(data, content); err != nil {
+			return nil, err
+		}
+		response.Contents = append(response.Contents, content)
+	}
+	if stored.Decision != nil {
+		decision := &agentassistproto.WorkReportDecision{}
+		if err := proto.Unmarshal(stored.Decision, decision); err != nil {
+			return nil, err
+		}
+		response.Decision = decision
+	}
+	return response, nil
+}
+
+// encodeStoreApprovals converts approvals to their persisted form
+func encodeStoreApprovals(approvals []StoredApproval) ([]storeApproval, error) {
+	var stored []storeApproval
+	for _, a := range approvals {
+		response, err := encodeStoreResponse(a.Response)
+		if err != nil {
+			return nil, err
+		}
+		stored = append(stored, storeApproval{Nickname: a.Nickname, Response: response})
+	}
+	return stored, nil
+}
+
+// decodeStoreApprovals converts persisted approvals back to StoredApprovals
+func decodeStoreApprovals(stored []storeApproval) ([]StoredApproval, error) {
+	var approvals []StoredApproval
+	for _, a := range stored {
+		if a.Response == nil {
+			return nil, fmt.Errorf("approval of %s has no response", a.Nickname)
+		}
+		response, err := decodeStoreResponse(a.Response)
+		if err != nil {
+			return nil, err
+		}
+		approvals = append(approvals, StoredApproval{Nickname: a.Nickname, Response: response})
+	}
+	return approvals, nil
+}
@@ -0,0 +1,327 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	agentassistproto "github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+func newTestAskQuestionMessage(requestID string) *agentassistproto.WebsocketMessage {
+	return &agentassistproto.WebsocketMessage{
+		Cmd: "AskQuestion",
+		AskQuestionRequest: &agentassistproto.AskQuestionRequest{
+			ID:        requestID,
+			UserToken: "test-token",
+			Request: &agentassistproto.McpAskQuestionRequest{
+				ProjectDirectory: "/test/project",
+				Question:         "What should I do next?",
+				Timeout:          60,
+			},
+		},
+	}
+}
+
+func TestFileRequestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "requests.log")
+
+	store, err := OpenFileRequestStore(path)
+	if err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+
+	createdAt := time.Now().Truncate(time.Millisecond)
+	deadline := createdAt.Add(time.Minute)
+	for _, requestID := range []string{"req-1", "req-2", "req-3"} {
+		err := store.SaveRequest(&StoredRequest{
+			RequestID: requestID,
+			UserToken: "test-token",
+			Message:   newTestAskQuestionMessage(requestID),
+			CreatedAt: createdAt,
+			Deadline:  deadline,
+		})
+		if err != nil {
+			t.Fatalf("Failed to save request %s: %v", requestID, err)
+		}
+	}
+	if err := store.SaveResponse("req-2", &WebResponse{
+		Meta:     map[string]string{"source": "test"},
+		Contents: []*agentassistproto.McpResultContent{CreateTextContent("Go ahead")},
+		Decision: &agentassistproto.WorkReportDecision{Decision: DecisionChangesRequested, Comments: []*agentassistproto.WorkReportComment{{Item: "api.go", Comment: "Rename it"}}},
+	}); err != nil {
+		t.Fatalf("Failed to save response: %v", err)
+	}
+	extended := deadline.Add(10 * time.Minute)
+	if err := store.SaveDeadline("req-1", extended); err != nil {
+		t.Fatalf("Failed to save deadline: %v", err)
+	}
+	approvals := []StoredApproval{{Nickname: "alice", Response: &WebResponse{
+		Contents: []*agentassistproto.McpResultContent{CreateTextContent("LGTM")},
+		Decision: &agentassistproto.WorkReportDecision{Decision: DecisionApproved},
+	}}}
+	if err := store.SaveApprovals("req-1", approvals); err != nil {
+		t.Fatalf("Failed to save approvals: %v", err)
+	}
+	if err := store.SaveClaim("req-1", "bob"); err != nil {
+		t.Fatalf("Failed to save claim: %v", err)
+	}
+	if err := store.DeleteRequest("req-3"); err != nil {
+		t.Fatalf("Failed to delete request: %v", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Failed to close store: %v", err)
+	}
+
+	// Reopening compacts the log and keeps the live requests
+	store, err = OpenFileRequestStore(path)
+	if err != nil {
+		t.Fatalf("Failed to reopen store: %v", err)
+	}
+	defer store.Close()
+
+	requests, err := store.LoadRequests()
+	if err != nil {
+		t.Fatalf("Failed to load requests: %v", err)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(requests))
+	}
+
+	if requests[0].RequestID != "req-1" || requests[0].Response != nil {
+		t.Errorf("Expected pending req-1, got %+v", requests[0])
+	}
+	if !requests[0].Deadline.Equal(extended) {
+		t.Errorf("Expected the extended deadline %v, got %v", extended, requests[0].Deadline)
+	}
+	if !requests[0].CreatedAt.Equal(createdAt) {
+		t.Errorf("Expected creation time %v, got %v", createdAt, requests[0].CreatedAt)
+	}
+	if requests[0].Message.AskQuestionRequest.Request.Question != "What should I do next?" {
+		t.Errorf("Request message was not restored: %+v", requests[0].Message)
+	}
+	if approvals := requests[0].Approvals; len(approvals) != 1 || approvals[0].Nickname != "alice" ||
+		approvals[0].Response.Decision.GetDecision() != DecisionApproved || approvals[0].Response.Contents[0].Text.Text != "LGTM" {
+		t.Errorf("Approvals were not restored: %+v", approvals)
+	}
+	if requests[0].ClaimedBy != "bob" {
+		t.Errorf("Expected the claim of bob to be restored, got %q", requests[0].ClaimedBy)
+	}
+
+	answered := requests[1]
+	if answered.RequestID != "req-2" || answered.Response == nil {
+		t.Fatalf("Expected answered req-2, got %+v", answered)
+	}
+	if answered.Response.Meta["source"] != "test" {
+		t.Errorf("Response meta was not restored: %v", answered.Response.Meta)
+	}
+	if len(answered.Response.Contents) != 1 || answered.Response.Contents[0].Text.Text != "Go ahead" {
+		t.Errorf("Response contents were not restored: %v", answered.Response.Contents)
+	}
+	if decision := answered.Response.Decision; decision.GetDecision() != DecisionChangesRequested ||
+		len(decision.GetComments()) != 1 || decision.GetComments()[0].GetComment() != "Rename it" {
+		t.Errorf("Response decision was not restored: %v", decision)
+	}
+}
+
+func TestBroadcasterRestoresPersistedRequests(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "requests.log")
+
+	store, err := OpenFileRequestStore(path)
+	if err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+
+	broadcaster := NewBroadcasterWithStore(store)
+	client := NewWebClient("client1")
+	client.SetToken("test-token")
+	broadcaster.RegisterClient(client)
+	time.Sleep(100 * time.Millisecond)
+
+	responseChan := make(chan *WebResponse, 1)
+	broadcaster.BroadcastToToken(newTestAskQuestionMessage("req-1"), "test-token", time.Now().Add(time.Minute), responseChan)
+	time.Sleep(100 * time.Millisecond)
+
+	// Simulate a server restart before the user answers
+	store.Close()
+	store, err = OpenFileRequestStore(path)
+	if err != nil {
+		t.Fatalf("Failed to reopen store: %v", err)
+	}
+	defer store.Close()
+	restarted := NewBroadcasterWithStore(store)
+
+	if validity := restarted.CheckMessageValidity([]string{"req-1"}); !validity["req-1"] {
+		t.Fatal("Expected req-1 to be pending after restart")
+	}
+
+	// The user answers before the caller reconnects
+	restarted.HandleResponse("req-1", &WebResponse{
+		Contents: []*agentassistproto.McpResultContent{CreateTextContent("Go ahead")},
+	})
+	time.Sleep(100 * time.Millisecond)
+
+	// The reconnecting caller resumes the request and gets the stored answer
+	resumedChan := make(chan *WebResponse, 1)
+	if _, ok, _ := restarted.ResumeRequest("req-1", "test-token", resumedChan); !ok {
+		t.Fatal("Expected req-1 to be resumable")
+	}
+	select {
+	case response := <-resumedChan:
+		if len(response.Contents) != 1 || response.Contents[0].Text.Text != "Go ahead" {
+			t.Errorf("Unexpected response: %+v", response)
+		}
+	default:
+		t.Error("Expected the stored response to be delivered on resume")
+	}
+}
+
+func TestBroadcasterRestoresApprovalsAndClaims(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "requests.log")
+
+	store, err := OpenFileRequestStore(path)
+	if err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+
+	broadcaster := NewBroadcasterWithStore(store)
+	alice := NewWebClient("alice-client")
+	alice.SetToken("test-token")
+	alice.SetNickname("alice")
+	bob := NewWebClient("bob-client")
+	bob.SetToken("test-token")
+	bob.SetNickname("bob")
+	broadcaster.RegisterClient(alice)
+	broadcaster.RegisterClient(bob)
+	time.Sleep(100 * time.Millisecond)
+
+	responseChan := make(chan *WebResponse, 1)
+	broadcaster.BroadcastToToken(newTestQuorumMessage("req-1", 2), "test-token", time.Now().Add(time.Minute), responseChan)
+	time.Sleep(100 * time.Millisecond)
+	if broadcaster.AcceptWorkReportReply(alice, "req-1", newTestDecision("alice", DecisionApproved, "LGTM")) {
+		t.Fatal("Expected the first approval to be recorded only")
+	}
+	if claim := broadcaster.ClaimRequest(bob, "req-1"); !claim.Success {
+		t.Fatalf("Expected bob to claim the request, got %v", claim)
+	}
+
+	// Simulate a server restart while bob is answering
+	store.Close()
+	store, err = OpenFileRequestStore(path)
+	if err != nil {
+		t.Fatalf("Failed to reopen store: %v", err)
+	}
+	defer store.Close()
+	restarted := NewBroadcasterWithStore(store)
+
+	// Bob reconnects with a new client and keeps his claim, carol cannot answer meanwhile
+	bob = NewWebClient("bob-client-2")
+	bob.SetToken("test-token")
+	bob.SetNickname("bob")
+	carol := NewWebClient("carol-client")
+	carol.SetToken("test-token")
+	carol.SetNickname("carol")
+	if claim := restarted.ClaimRequest(carol, "req-1"); claim.Success || claim.Nickname != "bob" {
+		t.Errorf("Expected the restored claim of bob to refuse carol, got %v", claim)
+	}
+	if claim := restarted.ClaimRequest(bob, "req-1"); !claim.Success {
+		t.Errorf("Expected bob to keep his claim, got %v", claim)
+	}
+
+	// The approval of alice still counts
+	if !restarted.AcceptWorkReportReply(bob, "req-1", newTestDecision("bob", DecisionApproved, "Ship it")) {
+		t.Fatal("Expected the second approval to meet the quorum")
+	}
+}
+
+// slowSaveStore delays saving new requests, to let whatever completes them run meanwhile
+type slowSaveStore struct {
+	*FileRequestStore
+}
+
+func (s slowSaveStore) SaveRequest(request *StoredRequest) error {
+	time.Sleep(100 * time.Millisecond)
+	return s.FileRequestStore.SaveRequest(request)
+}
+
+func TestBroadcasterPersistsRequestsBeforeTheirResponses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "requests.log")
+
+	store, err := OpenFileRequestStore(path)
+	if err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+	broadcaster := NewBroadcasterWithStore(slowSaveStore{store})
+
+	// The request times out while it is being saved
+	responseChan := make(chan *WebResponse, 1)
+	broadcaster.BroadcastToToken(newTestAskQuestionMessage("req-1"), "test-token", time.Now().Add(10*time.Millisecond), responseChan)
+	select {
+	case <-responseChan:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the request to time out")
+	}
+	store.Close()
+
+	// Replaying the log gives the request with its response rather than a pending one
+	store, err = OpenFileRequestStore(path)
+	if err != nil {
+		t.Fatalf("Failed to reopen store: %v", err)
+	}
+	defer store.Close()
+	requests, err := store.LoadRequests()
+	if err != nil {
+		t.Fatalf("Failed to load requests: %v", err)
+	}
+	if len(requests) != 1 || requests[0].Response == nil {
+		t.Fatalf("Expected req-1 to be stored with its response, got %+v", requests)
+	}
+}
+
+func TestFileRequestStore_CompactsDeletedRequests(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "requests.log")
+	store, err := OpenFileRequestStore(path)
+	if err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+	defer store.Close()
+
+	save := func(requestID string) {
+		if err := store.SaveRequest(&StoredRequest{
+			RequestID: requestID,
+			UserToken: "test-token",
+			Message:   newTestAskQuestionMessage(requestID),
+			Deadline:  time.Now().Add(time.Minute),
+		}); err != nil {
+			t.Fatalf("Failed to save %s: %v", requestID, err)
+		}
+	}
+	save("live")
+	for i := range storeCompactInterval {
+		requestID := fmt.Sprintf("req-%d", i)
+		save(requestID)
+		if err := store.DeleteRequest(requestID); err != nil {
+			t.Fatalf("Failed to delete %s: %v", requestID, err)
+		}
+	}
+
+	// The deleted requests are gone from the log, which is still appended to
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read store file: %v", err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 1 {
+		t.Errorf("Expected the compacted log to hold 1 record, got %d", lines)
+	}
+	save("after")
+	requests, err := store.LoadRequests()
+	if err != nil {
+		t.Fatalf("Failed to load requests: %v", err)
+	}
+	if len(requests) != 2 || requests[0].RequestID != "live" || requests[1].RequestID != "after" {
+		t.Errorf("Expected the live requests to survive compaction, got %d requests", len(requests))
+	}
+}
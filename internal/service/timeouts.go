@@ -0,0 +1,311 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"path"
+	"time"
+
+	agentassistproto "github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+// Actions of a timeout policy
+const (
+	// TimeoutActionError times the request out with an error, like without a policy
+	TimeoutActionError = "error"
+	// TimeoutActionReply answers the request with the reply of the policy
+	TimeoutActionReply = "reply"
+	// TimeoutActionApprove approves a work report
+	TimeoutActionApprove = "approve"
+	// TimeoutActionReject rejects a work report
+	TimeoutActionReject = "reject"
+	// TimeoutActionEscalate gives the request more time and delivers it to more web users
+	TimeoutActionEscalate = "escalate"
+)
+
+// Request kinds a timeout policy can be limited to
+const (
+	timeoutKindAskQuestion = "ask_question"
+	timeoutKindWorkReport  = "work_report"
+)
+
+// TimeoutPolicyConfig is a timeout policy of the server configuration. It decides what the
+// agent gets when nobody answers a request in time instead of a timeout error. The first
+// policy that matches a request applies; empty patterns match anything.
+type TimeoutPolicyConfig struct {
+	// Token is a glob on the token of the request, which is the token group when token
+	// authentication is enabled
+	Token string `toml:"token"`
+	// Project is a glob on the project directory, like in routing rules
+	Project string `toml:"project"`
+	// Kind limits the policy to "ask_question" or "work_report" requests
+	Kind string `toml:"kind"`
+	// Action is "error", "reply", "approve", "reject" or "escalate". Approve and reject
+	// only apply to work reports.
+	Action string `toml:"action"`
+	// Reply is the text the agent gets with the reply, approve and reject actions
+	Reply string `toml:"reply"`
+	// EscalateTo lists the nicknames an escalated request is delivered to, "@name" refers
+	// to a client group. Empty delivers it to every web user of its token.
+	EscalateTo []string `toml:"escalate_to"`
+	// ExtendBy is how much more time an escalated request gets, e.g. "10m"
+	ExtendBy string `toml:"extend_by"`
+}
+
+// TimeoutPolicy is a timeout policy that applies to a request
+type TimeoutPolicy struct {
+	// Name describes the policy in logs and in the response Meta
+	Name string
+	// Action is one of the TimeoutAction constants
+	Action string
+	// Reply is the text of the automatic answer
+	Reply string
+	// EscalateTo are the web users an escalated request is delivered to, empty for everyone
+	EscalateTo map[string]bool
+	// ExtendBy is how much more time an escalated request gets
+	ExtendBy time.Duration
+
+	token, project, kind string
+}
+
+// TimeoutPolicies picks the policy that applies to a request nobody answered in time
+type TimeoutPolicies struct {
+	policies []*TimeoutPolicy
+}
+
+// NewTimeoutPolicies creates timeout policies from the configured ones and client groups.
+// Groups map a group name to the nicknames of its members.
+func NewTimeoutPolicies(configs []TimeoutPolicyConfig, groups map[string][]string) (*TimeoutPolicies, error) {
+	p := &TimeoutPolicies{}
+
+	for i, config := range configs {
+		policy := &TimeoutPolicy{
+			Name:       fmt.Sprintf("timeout policy %d", i+1),
+			Action:     config.Action,
+			Reply:      config.Reply,
+			EscalateTo: make(map[string]bool),
+			token:      config.Token,
+			project:    config.Project,
+			kind:       config.Kind,
+		}
+
+		for _, pattern := range []string{config.Token, config.Project} {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("%s: invalid pattern %q: %w", policy.Name, pattern, err)
+			}
+		}
+
+		switch config.Kind {
+		case "", timeoutKindAskQuestion, timeoutKindWorkReport:
+		default:
+			return nil, fmt.Errorf("%s: unknown kind %q, must be %s or %s",
+				policy.Name, config.Kind, timeoutKindAskQuestion, timeoutKindWorkReport)
+		}
+
+		switch config.Action {
+		case TimeoutActionError:
+		case TimeoutActionReply:
+			if config.Reply == "" {
+				return nil, fmt.Errorf("%s: the reply action requires a reply", policy.Name)
+			}
+		case TimeoutActionApprove, TimeoutActionReject:
+			if config.Kind == timeoutKindAskQuestion {
+				return nil, fmt.Errorf("%s: %s only applies to work reports", policy.Name, config.Action)
+			}
+		case TimeoutActionEscalate:
+			if err := addTargets(policy.EscalateTo, config.EscalateTo, groups); err != nil {
+				return nil, fmt.Errorf("%s: %w", policy.Name, err)
+			}
+			extendBy, err := time.ParseDuration(config.ExtendBy)
+			if err != nil || extendBy <= 0 {
+				return nil, fmt.Errorf("%s: extend_by must be a positive duration such as \"10m\"", policy.Name)
+			}
+			policy.ExtendBy = extendBy
+		default:
+			return nil, fmt.Errorf("%s: unknown action %q, must be %s, %s, %s, %s or %s",
+				policy.Name, config.Action, TimeoutActionError, TimeoutActionReply,
+				TimeoutActionApprove, TimeoutActionReject, TimeoutActionEscalate)
+		}
+
+		p.policies = append(p.policies, policy)
+	}
+
+	return p, nil
+}
+
+// Policy returns the first policy that applies to a request that timed out, nil if none
+// does. Escalation policies are skipped unless escalate is set, so that a request is
+// escalated only once and gets one of the following policies when it times out again.
+func (p *TimeoutPolicies) Policy(request *WebsocketRequest, escalate bool) *TimeoutPolicy {
+	if p == nil {
+		return nil
+	}
+
+	kind, projectDirectory := timeoutKindAskQuestion, request.Message.GetAskQuestionRequest().GetRequest().GetProjectDirectory()
+	if request.Message.WorkReportRequest != nil {
+		kind, projectDirectory = timeoutKindWorkReport, request.Message.GetWorkReportRequest().GetRequest().GetProjectDirectory()
+	}
+
+	for _, policy := range p.policies {
+		if policy.Action == TimeoutActionEscalate && !escalate {
+			continue
+		}
+		if (policy.Action == TimeoutActionApprove || policy.Action == TimeoutActionReject) && kind != timeoutKindWorkReport {
+			continue
+		}
+		if (policy.kind == "" || policy.kind == kind) &&
+			matchPattern(policy.token, request.UserToken) &&
+			matchProject(policy.project, projectDirectory) {
+			return policy
+		}
+	}
+	return nil
+}
+
+// response returns the automatic answer of the policy to a request nobody answered in time.
+// Meta["timeout_policy"] records the action so that the agent and the history can tell the
+// answer from one of a web user.
+func (p *TimeoutPolicy) response(request *WebsocketRequest) *WebResponse {
+	if p == nil {
+		return timeoutResponse(request)
+	}
+
+	if p.Action == TimeoutActionError {
+		response := timeoutResponse(request)
+		response.Meta["timeout_policy"] = p.Action
+		return response
+	}
+
+	response := &WebResponse{
+		Meta: map[string]string{
+			"timeout_policy": p.Action,
+			"message": fmt.Sprintf("Nobody answered within %d seconds, this answer was given automatically by the %s (%s)",
+				request.timeoutSeconds(), p.Name, p.Action),
+		},
+	}
+	if p.Reply != "" {
+		response.Contents = []*agentassistproto.McpResultContent{CreateTextContent(p.Reply)}
+	}
+	switch p.Action {
+	case TimeoutActionApprove:
+		response.Decision = &agentassistproto.WorkReportDecision{Decision: DecisionApproved}
+	case TimeoutActionReject:
+		response.Decision = &agentassistproto.WorkReportDecision{Decision: DecisionRejected}
+	}
+	return response
+}
+
+// expireRequest applies the timeout policy of a request that is still pending at its
+// deadline, timing it out with an error if no policy applies. The deadline is checked and
+// the request completed under one lock, so a deadline extended meanwhile is respected.
+func (b *Broadcaster) expireRequest(requestID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	request, exists := b.pendingRequests[requestID]
+	if !exists || time.Now().Before(request.Deadline) {
+		// Answered, or the deadline moved while the timer fired
+		return
+	}
+
+	policy := b.timeoutPolicies.Policy(request, !request.escalated)
+	if policy != nil && policy.Action == TimeoutActionEscalate {
+		b.escalateLocked(requestID, request, policy)
+		return
+	}
+
+	response := policy.response(request)
+	_, messageType := requestIDAndType(request.Message)
+	if policy == nil || policy.Action == TimeoutActionError {
+		if policy != nil {
+			log.Printf("%s request %s timed out, applying %s (%s)", messageType, requestID, policy.Name, policy.Action)
+		} else {
+			log.Printf("%s request %s timed out", messageType, requestID)
+		}
+		b.cancelRequestLocked(requestID, messageType, response.Meta["message"], response)
+		return
+	}
+
+	// The policy answered the request, the web clients see it answered rather than cancelled
+	log.Printf("%s request %s timed out, applying %s (%s)", messageType, requestID, policy.Name, policy.Action)
+	b.completeRequestLocked(requestID, response)
+	b.broadcastToRequestLocked(request, autoAnswerNotification(request, policy, response), "")
+}
+
+// autoAnswerNotification tells the web clients that a timeout policy answered a request, like
+// the notification of the reply of a web user
+func autoAnswerNotification(request *WebsocketRequest, policy *TimeoutPolicy, response *WebResponse) *agentassistproto.WebsocketMessage {
+	notification := &agentassistproto.WebsocketMessage{
+		StrParam: fmt.Sprintf("Answered automatically by the %s", policy.Name),
+		Nickname: policy.Name,
+	}
+	if question := request.Message.GetAskQuestionRequest(); question != nil {
+		notification.Cmd = "AskQuestionReplyNotification"
+		notification.AskQuestionRequest = &agentassistproto.AskQuestionRequest{
+			ID:        question.ID,
+			UserToken: question.UserToken,
+			Request:   question.Request,
+		}
+		notification.AskQuestionResponse = &agentassistproto.AskQuestionResponse{
+			ID:       question.ID,
+			Meta:     response.Meta,
+			Contents: response.Contents,
+		}
+		return notification
+	}
+
+	report := request.Message.GetWorkReportRequest()
+	notification.Cmd = "WorkReportReplyNotification"
+	notification.WorkReportRequest = &agentassistproto.WorkReportRequest{
+		ID:        report.GetID(),
+		UserToken: report.GetUserToken(),
+		Request:   report.GetRequest(),
+	}
+	notification.WorkReportResponse = &agentassistproto.WorkReportResponse{
+		ID:       report.GetID(),
+		Meta:     response.Meta,
+		Contents: response.Contents,
+		Decision: response.Decision,
+	}
+	return notification
+}
+
+// escalateLocked gives a request nobody answered in time more time and delivers it to the
+// escalation targets of policy that did not get it yet. It must be called with b.mu held.
+func (b *Broadcaster) escalateLocked(requestID string, request *WebsocketRequest, policy *TimeoutPolicy) {
+	request.escalated = true
+	b.setDeadlineLocked(requestID, request, time.Now().Add(policy.ExtendBy))
+	if len(policy.EscalateTo) == 0 {
+		request.fallenBack = true
+	} else {
+		b.escalateToLocked(request, policy.EscalateTo)
+	}
+
+	var clients []*WebClient
+	for _, client := range b.clients {
+		if client.IsActive() && request.forClient(client) && request.routedTo(client) && !request.deliveredTo[client.ID] {
+			clients = append(clients, client)
+		}
+	}
+	log.Printf("Request %s was not answered in time, escalating it by %s for %s to %d more web clients",
+		requestID, policy.Name, policy.ExtendBy, len(clients))
+
+	b.publishEventLocked(requestID, request, &agentassistproto.RequestEvent{
+		Type:    RequestEventEscalated,
+		Message: fmt.Sprintf("not answered in time, escalated for %s", policy.ExtendBy),
+	})
+	b.notifyDeadlineLocked(requestID, request, "", "")
+	if len(clients) == 0 {
+		return
+	}
+	request.held = false
+	b.recordDeliveryLocked(requestID, request, clients)
+	for _, client := range clients {
+		go func(c *WebClient) {
+			if !c.Send(request.Message) {
+				// Client failed to receive, unregister it
+				b.unregister <- c
+			}
+		}(client)
+	}
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTimeoutPolicies_Policy(t *testing.T) {
+	policies, err := NewTimeoutPolicies([]TimeoutPolicyConfig{
+		{Project: "/work/acme", Action: TimeoutActionEscalate, EscalateTo: []string{"@ops"}, ExtendBy: "10m"},
+		{Project: "/work/acme", Action: TimeoutActionApprove},
+		{Token: "team-*", Action: TimeoutActionReply, Reply: "Use your best judgment"},
+	}, map[string][]string{"ops": {"bob"}})
+	if err != nil {
+		t.Fatalf("Failed to create timeout policies: %v", err)
+	}
+
+	question := &WebsocketRequest{Message: newTestRoutedMessage("req-1", "/work/acme/app", ""), UserToken: "team-a"}
+	if policy := policies.Policy(question, true); policy == nil || policy.Name != "timeout policy 1" || !policy.EscalateTo["bob"] {
+		t.Errorf("Expected the question to be escalated to bob, got %+v", policy)
+	}
+	// Approving only applies to work reports, the question falls through to the reply
+	if policy := policies.Policy(question, false); policy == nil || policy.Name != "timeout policy 3" {
+		t.Errorf("Expected the reply policy once escalated, got %+v", policy)
+	}
+
+	report := &WebsocketRequest{Message: newTestQuorumMessage("req-2", 1), UserToken: "team-a"}
+	report.Message.WorkReportRequest.Request.ProjectDirectory = "/work/acme"
+	if policy := policies.Policy(report, false); policy == nil || policy.Action != TimeoutActionApprove {
+		t.Errorf("Expected the work report to be approved, got %+v", policy)
+	}
+
+	other := &WebsocketRequest{Message: newTestRoutedMessage("req-3", "/work/other", ""), UserToken: "solo"}
+	if policy := policies.Policy(other, true); policy != nil {
+		t.Errorf("Expected no policy to apply, got %+v", policy)
+	}
+}
+
+func TestNewTimeoutPolicies_Invalid(t *testing.T) {
+	for _, configs := range [][]TimeoutPolicyConfig{
+		{{Action: "ignore"}},
+		{{Action: TimeoutActionReply}},
+		{{Kind: "chat", Action: TimeoutActionError}},
+		{{Kind: "ask_question", Action: TimeoutActionApprove}},
+		{{Project: "/work/[", Action: TimeoutActionError}},
+		{{Action: TimeoutActionEscalate}},
+		{{Action: TimeoutActionEscalate, ExtendBy: "10m", EscalateTo: []string{"@unknown"}}},
+	} {
+		if _, err := NewTimeoutPolicies(configs, nil); err == nil {
+			t.Errorf("Expected timeout policies %+v to be rejected", configs)
+		}
+	}
+}
+
+func TestBroadcaster_TimeoutPolicies(t *testing.T) {
+	router, err := NewRouter([]RouteConfig{{Project: "/work/acme", To: []string{"alice"}}}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create router: %v", err)
+	}
+	policies, err := NewTimeoutPolicies([]TimeoutPolicyConfig{
+		{Project: "/work/acme", Kind: "ask_question", Action: TimeoutActionEscalate, EscalateTo: []string{"bob"}, ExtendBy: "200ms"},
+		{Kind: "ask_question", Action: TimeoutActionReply, Reply: "Use your best judgment"},
+		{Action: TimeoutActionReject, Reply: "Nobody reviewed this"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create timeout policies: %v", err)
+	}
+	b := NewBroadcasterWithOptions(BroadcasterOptions{Router: router, TimeoutPolicies: policies})
+
+	alice := NewWebClient("alice-client")
+	alice.SetToken("test-token")
+	alice.SetNickname("alice")
+	bob := NewWebClient("bob-client")
+	bob.SetToken("test-token")
+	bob.SetNickname("bob")
+	b.RegisterClient(alice)
+	b.RegisterClient(bob)
+	time.Sleep(100 * time.Millisecond)
+
+	// The routed question is escalated to bob, then answered by the reply policy
+	responseChan := make(chan *WebResponse, 1)
+	b.BroadcastToToken(newTestRoutedMessage("req-1", "/work/acme/app", ""), "test-token", time.Now().Add(200*time.Millisecond), responseChan)
+	time.Sleep(50 * time.Millisecond)
+	events, stop, watched := b.WatchRequest("req-1")
+	if !watched {
+		t.Fatal("Expected the request to be watchable")
+	}
+	defer stop()
+	time.Sleep(250 * time.Millisecond)
+	nextMessage(t, bob, "AskQuestion")
+	if extension := nextMessage(t, alice, "DeadlineExtended").DeadlineExtension; extension.Nickname != "" {
+		t.Errorf("Expected alice to be told the server extended the deadline, got %v", extension)
+	}
+
+	response := <-responseChan
+	if response.IsError || response.Meta["timeout_policy"] != TimeoutActionReply || len(response.Contents) != 1 ||
+		response.Contents[0].Text.Text != "Use your best judgment" {
+		t.Errorf("Expected the fallback reply, got %v", response)
+	}
+	var types []string
+	for event := range events {
+		types = append(types, event.Type)
+	}
+	if !slices.Contains(types, RequestEventEscalated) || types[len(types)-1] != RequestEventTimedOut {
+		t.Errorf("Expected the request to be escalated then time out, got %v", types)
+	}
+	// The web users see the automatic answer instead of a cancellation
+	if notification := nextMessage(t, bob, "AskQuestionReplyNotification"); notification.Nickname != "timeout policy 2" ||
+		notification.AskQuestionResponse.GetContents()[0].GetText().GetText() != "Use your best judgment" {
+		t.Errorf("Expected bob to be told the reply policy answered, got %v", notification)
+	}
+
+	// Work reports are rejected
+	b.BroadcastToToken(newTestQuorumMessage("req-2", 1), "test-token", time.Now().Add(100*time.Millisecond), responseChan)
+	response = <-responseChan
+	if response.IsError || response.Meta["timeout_policy"] != TimeoutActionReject || response.Decision.GetDecision() != DecisionRejected {
+		t.Errorf("Expected the work report to be rejected, got %v", response)
+	}
+	if notification := nextMessage(t, alice, "WorkReportReplyNotification"); notification.WorkReportResponse.GetDecision().GetDecision() != DecisionRejected {
+		t.Errorf("Expected alice to be told the reject policy answered, got %v", notification)
+	}
+}
@@ -0,0 +1,399 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+	agentassistproto "github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+// Events a webhook can be notified of. They follow the request events, see RequestEvent in
+// agentassist.proto, with created for new requests.
+const (
+	WebhookEventCreated   = "created"
+	WebhookEventReminded  = RequestEventReminded
+	WebhookEventEscalated = RequestEventEscalated
+	WebhookEventAnswered  = RequestEventAnswered
+	WebhookEventCancelled = RequestEventCancelled
+	WebhookEventTimedOut  = RequestEventTimedOut
+	WebhookEventNoClients = RequestEventNoClients
+)
+
+// defaultWebhookEvents are the events of webhooks that do not list any
+var defaultWebhookEvents = []string{
+	WebhookEventCreated, WebhookEventAnswered, WebhookEventCancelled, WebhookEventTimedOut, WebhookEventNoClients,
+}
+
+const (
+	// defaultWebhookAttempts is how often a delivery is tried unless the webhook says
+	defaultWebhookAttempts = 5
+	// webhookRetryBackoff is the delay before the first retry, doubled for every further one
+	webhookRetryBackoff = time.Second
+	// maxWebhookRetryBackoff caps the delay between retries
+	maxWebhookRetryBackoff = time.Minute
+	// webhookRequestTimeout bounds a single delivery attempt
+	webhookRequestTimeout = 10 * time.Second
+	// webhookExcerptLength is how many characters of the question or summary are sent
+	webhookExcerptLength = 280
+	// webhookDeliveryHistory is how many delivery attempts Deliveries keeps
+	webhookDeliveryHistory = 100
+)
+
+// WebhookConfig is a webhook of the server configuration. The server POSTs a JSON payload
+// describing a request to URL when one of Events happens to a request matching the
+// patterns; empty patterns match anything.
+type WebhookConfig struct {
+	// URL receives the POST requests
+	URL string `toml:"url"`
+	// Secret signs the payloads, see WebhookSignatureHeader. Empty sends them unsigned.
+	Secret string `toml:"secret"`
+	// Events lists the events the webhook is notified of: created, reminded, escalated,
+	// answered, cancelled, timed_out and no_clients. Empty notifies all but reminded and
+	// escalated.
+	Events []string `toml:"events"`
+	// Token is a glob on the token of the request, which is the token group when token
+	// authentication is enabled
+	Token string `toml:"token"`
+	// Project is a glob on the project directory, like in routing rules
+	Project string `toml:"project"`
+	// Link is the deep link into the web UI sent with the payload, "{request_id}" is
+	// replaced by the ID of the request, e.g. "https://assist.example.com/#/chat?request={request_id}"
+	Link string `toml:"link"`
+	// Template is a text/template of the body that is sent instead of the JSON payload,
+	// e.g. for chat tools that expect their own format. It is executed with the
+	// WebhookPayload, the json function quotes a value as JSON.
+	Template string `toml:"template"`
+	// ContentType is the Content-Type of the body. Empty sends the JSON payload as
+	// application/json, and a rendered template as application/json if it is valid JSON and
+	// as text/plain otherwise.
+	ContentType string `toml:"content_type"`
+	// MaxAttempts is how often a delivery is tried before it is given up, default 5
+	MaxAttempts int `toml:"max_attempts"`
+}
+
+// Headers of webhook deliveries
+const (
+	// WebhookSignatureHeader is "sha256=" followed by the hex HMAC-SHA256 of the timestamp
+	// header, a dot and the body, keyed with the secret of the webhook
+	WebhookSignatureHeader = "X-AgentAssistant-Signature"
+	// WebhookTimestampHeader is the unix time the delivery attempt was signed at
+	WebhookTimestampHeader = "X-AgentAssistant-Timestamp"
+	// WebhookEventHeader is the event of the delivery
+	WebhookEventHeader = "X-AgentAssistant-Event"
+	// WebhookDeliveryHeader identifies the delivery, it is the same for all its attempts
+	WebhookDeliveryHeader = "X-AgentAssistant-Delivery"
+)
+
+// WebhookPayload is the JSON body of a webhook delivery
+type WebhookPayload struct {
+	Event     string `json:"event"`
+	RequestID string `json:"request_id"`
+	// Kind is ask_question or work_report
+	Kind    string `json:"kind"`
+	Project string `json:"project"`
+	Agent   string `json:"agent,omitempty"`
+	Model   string `json:"model,omitempty"`
+	// McpClient is the name of the MCP client (IDE) of the agent
+	McpClient string `json:"mcp_client,omitempty"`
+	// Excerpt is the beginning of the question or the work report summary
+	Excerpt string `json:"excerpt"`
+	// Link opens the request in the web UI, empty unless the webhook has a link
+	Link string `json:"link,omitempty"`
+	// Nickname is the web user the event is about, e.g. who answered
+	Nickname string `json:"nickname,omitempty"`
+	// Message describes the event, e.g. "answered by alice"
+	Message string `json:"message,omitempty"`
+	// Timestamp is the time of the event, unix milliseconds
+	Timestamp int64 `json:"timestamp"`
+}
+
+// WebhookDelivery is an attempt to deliver a webhook payload, as kept in the delivery log
+type WebhookDelivery struct {
+	ID         string    `json:"id"`
+	URL        string    `json:"url"`
+	Event      string    `json:"event"`
+	RequestID  string    `json:"request_id"`
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Delivered  bool      `json:"delivered"`
+	Time       time.Time `json:"time"`
+}
+
+// webhook is a configured webhook
+type webhook struct {
+	config   WebhookConfig
+	events   map[string]bool
+	template *template.Template
+}
+
+// Webhooks notifies external services, e.g. chat or paging tools, of the lifecycle of
+// requests. Deliveries run in the background and are retried with backoff.
+type Webhooks struct {
+	webhooks []*webhook
+	client   *http.Client
+	backoff  time.Duration // Delay before the first retry
+
+	mu          sync.Mutex
+	deliveries  []WebhookDelivery // The latest delivery attempts, the oldest first
+	deliveryLog io.Writer         // Gets every delivery attempt as a JSON line, nil for none
+}
+
+// NewWebhooks creates webhooks from the configured ones. Every delivery attempt is written
+// to deliveryLog as a line of JSON, unless it is nil.
+func NewWebhooks(configs []WebhookConfig, deliveryLog io.Writer) (*Webhooks, error) {
+	w := &Webhooks{
+		client:      &http.Client{Timeout: webhookRequestTimeout},
+		backoff:     webhookRetryBackoff,
+		deliveryLog: deliveryLog,
+	}
+
+	for i, config := range configs {
+		name := fmt.Sprintf("webhook %d", i+1)
+		if u, err := url.Parse(config.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("%s: url must be an http or https URL, got %q", name, config.URL)
+		}
+		for _, pattern := range []string{config.Token, config.Project} {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("%s: invalid pattern %q: %w", name, pattern, err)
+			}
+		}
+		if config.ContentType != "" {
+			if _, _, err := mime.ParseMediaType(config.ContentType); err != nil {
+				return nil, fmt.Errorf("%s: invalid content_type %q: %w", name, config.ContentType, err)
+			}
+		}
+		if config.MaxAttempts < 0 {
+			return nil, fmt.Errorf("%s: max_attempts must not be negative", name)
+		}
+		if config.MaxAttempts == 0 {
+			config.MaxAttempts = defaultWebhookAttempts
+		}
+
+		hook := &webhook{config: config, events: make(map[string]bool)}
+		events := config.Events
+		if len(events) == 0 {
+			events = defaultWebhookEvents
+		}
+		for _, event := range events {
+			switch event {
+			case WebhookEventCreated, WebhookEventReminded, WebhookEventEscalated, WebhookEventAnswered,
+				WebhookEventCancelled, WebhookEventTimedOut, WebhookEventNoClients:
+				hook.events[event] = true
+			default:
+				return nil, fmt.Errorf("%s: unknown event %q", name, event)
+			}
+		}
+
+		if config.Template != "" {
+			tmpl, err := template.New(name).Funcs(template.FuncMap{"json": webhookJSON}).Parse(config.Template)
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid template: %w", name, err)
+			}
+			hook.template = tmpl
+		}
+
+		w.webhooks = append(w.webhooks, hook)
+	}
+
+	return w, nil
+}
+
+// webhookJSON quotes a value as JSON for templates
+func webhookJSON(value any) (string, error) {
+	data, err := json.Marshal(value)
+	return string(data), err
+}
+
+// Deliveries returns the latest delivery attempts, the oldest first
+func (w *Webhooks) Deliveries() []WebhookDelivery {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return slices.Clone(w.deliveries)
+}
+
+// notify sends the webhooks interested in a lifecycle event of a request. It only reads the
+// request, the deliveries run in the background.
+func (w *Webhooks) notify(requestID string, request *WebsocketRequest, event *agentassistproto.RequestEvent) {
+	if w == nil {
+		return
+	}
+	eventType := event.Type
+	if eventType == RequestEventQueued {
+		eventType = WebhookEventCreated
+	}
+
+	payload := WebhookPayload{
+		Event:     eventType,
+		RequestID: requestID,
+		Nickname:  event.Nickname,
+		Message:   event.Message,
+		Timestamp: event.Timestamp,
+	}
+	if question := request.Message.GetAskQuestionRequest().GetRequest(); question != nil {
+		payload.Kind, payload.Project, payload.Excerpt = timeoutKindAskQuestion, question.ProjectDirectory, question.Question
+		payload.Agent, payload.Model, payload.McpClient = question.AgentName, question.ReasoningModelName, question.McpClientName
+	} else if report := request.Message.GetWorkReportRequest().GetRequest(); report != nil {
+		payload.Kind, payload.Project, payload.Excerpt = timeoutKindWorkReport, report.ProjectDirectory, report.Summary
+		payload.Agent, payload.Model, payload.McpClient = report.AgentName, report.ReasoningModelName, report.McpClientName
+	}
+	if excerpt := []rune(payload.Excerpt); len(excerpt) > webhookExcerptLength {
+		payload.Excerpt = string(excerpt[:webhookExcerptLength]) + "…"
+	}
+
+	for _, hook := range w.webhooks {
+		if !hook.events[eventType] || !matchPattern(hook.config.Token, request.UserToken) ||
+			!matchProject(hook.config.Project, payload.Project) {
+			continue
+		}
+		payload := payload
+		if hook.config.Link != "" {
+			payload.Link = strings.ReplaceAll(hook.config.Link, "{request_id}", url.QueryEscape(requestID))
+		}
+		go w.deliver(hook, payload)
+	}
+}
+
+// deliver POSTs a payload to a webhook, retrying with backoff until it is accepted or the
+// attempts of the webhook are used up
+func (w *Webhooks) deliver(hook *webhook, payload WebhookPayload) {
+	var body bytes.Buffer
+	var err error
+	if hook.template != nil {
+		err = hook.template.Execute(&body, payload)
+	} else {
+		err = json.NewEncoder(&body).Encode(payload)
+	}
+	if err != nil {
+		log.Printf("Failed to build the %s webhook payload for request %s: %v", payload.Event, payload.RequestID, err)
+		return
+	}
+	contentType := hook.contentType(body.Bytes())
+
+	id := uuid.NewString()
+	backoff := w.backoff
+	for attempt := 1; ; attempt++ {
+		delivery := WebhookDelivery{
+			ID:        id,
+			URL:       hook.config.URL,
+			Event:     payload.Event,
+			RequestID: payload.RequestID,
+			Attempt:   attempt,
+		}
+		retry := w.post(hook, id, payload.Event, contentType, body.Bytes(), &delivery)
+		w.record(delivery)
+		if delivery.Delivered {
+			return
+		}
+		if !retry || attempt >= hook.config.MaxAttempts {
+			log.Printf("Giving up the %s webhook of request %s to %s after %d attempts: %s",
+				payload.Event, payload.RequestID, hook.config.URL, attempt, delivery.Error)
+			return
+		}
+
+		time.Sleep(backoff)
+		backoff = min(backoff*2, maxWebhookRetryBackoff)
+	}
+}
+
+// contentType returns the Content-Type of a body built for the webhook
+func (hook *webhook) contentType(body []byte) string {
+	switch {
+	case hook.config.ContentType != "":
+		return hook.config.ContentType
+	case hook.template == nil || json.Valid(body):
+		return "application/json"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
+
+// post makes one delivery attempt and records its outcome in delivery. It returns whether
+// a failed attempt is worth retrying.
+func (w *Webhooks) post(hook *webhook, id, event, contentType string, body []byte, delivery *WebhookDelivery) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.config.URL, bytes.NewReader(body))
+	if err != nil {
+		delivery.Error = err.Error()
+		return false
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set(WebhookEventHeader, event)
+	req.Header.Set(WebhookDeliveryHeader, id)
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	if hook.config.Secret != "" {
+		req.Header.Set(WebhookSignatureHeader, SignWebhook(hook.config.Secret, timestamp, body))
+	}
+
+	delivery.Time = time.Now()
+	resp, err := w.client.Do(req)
+	if err != nil {
+		delivery.Error = err.Error()
+		return true
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	delivery.StatusCode = resp.StatusCode
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		delivery.Delivered = true
+		return false
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		delivery.Error = resp.Status
+		return true
+	default:
+		// The receiver refuses the payload, sending it again does not help
+		delivery.Error = resp.Status
+		return false
+	}
+}
+
+// record adds a delivery attempt to the delivery log
+func (w *Webhooks) record(delivery WebhookDelivery) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.deliveries = append(w.deliveries, delivery)
+	if len(w.deliveries) > webhookDeliveryHistory {
+		w.deliveries = slices.Delete(w.deliveries, 0, len(w.deliveries)-webhookDeliveryHistory)
+	}
+
+	if w.deliveryLog != nil {
+		line, _ := json.Marshal(delivery)
+		if _, err := w.deliveryLog.Write(append(line, '\n')); err != nil {
+			log.Printf("Failed to write the webhook delivery log: %v", err)
+		}
+	}
+}
+
+// SignWebhook returns the WebhookSignatureHeader of a payload, receivers compute it the same
+// way to check that the payload comes from the server
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
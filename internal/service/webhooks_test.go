@@ -0,0 +1,195 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	agentassistproto "github.com/yangjuncode/agentassistant/agentassistproto"
+)
+
+// webhookRequest is a request received by a webhook stand-in
+type webhookRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// newWebhookServer starts a webhook stand-in that answers with the given status codes in
+// turn, then with 200, and passes the requests it gets on
+func newWebhookServer(t *testing.T, statuses ...int) (*httptest.Server, <-chan webhookRequest) {
+	t.Helper()
+	requests := make(chan webhookRequest, 10)
+	var count atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- webhookRequest{header: r.Header, body: body}
+		if i := int(count.Add(1)) - 1; i < len(statuses) {
+			w.WriteHeader(statuses[i])
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+// nextWebhook returns the next request received by a webhook stand-in
+func nextWebhook(t *testing.T, requests <-chan webhookRequest) webhookRequest {
+	t.Helper()
+	select {
+	case request := <-requests:
+		return request
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected a webhook request")
+		return webhookRequest{}
+	}
+}
+
+func TestWebhooks_Deliver(t *testing.T) {
+	server, requests := newWebhookServer(t, http.StatusServiceUnavailable)
+	webhooks, err := NewWebhooks([]WebhookConfig{{
+		URL:    server.URL,
+		Secret: "s3cret",
+		Link:   "https://assist.example.com/#/chat?request={request_id}",
+	}}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create webhooks: %v", err)
+	}
+	webhooks.backoff = 10 * time.Millisecond
+
+	b := NewBroadcasterWithOptions(BroadcasterOptions{Webhooks: webhooks})
+	alice := NewWebClient("alice-client")
+	alice.SetToken("test-token")
+	alice.SetNickname("alice")
+	b.RegisterClient(alice)
+	time.Sleep(100 * time.Millisecond)
+
+	responseChan := make(chan *WebResponse, 1)
+	b.BroadcastToToken(newTestAskQuestionMessage("req-1"), "test-token", time.Now().Add(time.Minute), responseChan)
+
+	// The first attempt fails and is retried with the same signed payload
+	first, retry := nextWebhook(t, requests), nextWebhook(t, requests)
+	if !bytes.Equal(first.body, retry.body) || first.header.Get(WebhookDeliveryHeader) != retry.header.Get(WebhookDeliveryHeader) {
+		t.Errorf("Expected the retry to send the same delivery, got %s and %s", first.body, retry.body)
+	}
+	if signature := retry.header.Get(WebhookSignatureHeader); signature != SignWebhook("s3cret", retry.header.Get(WebhookTimestampHeader), retry.body) {
+		t.Errorf("Expected a valid signature, got %q", signature)
+	}
+	var payload WebhookPayload
+	if err := json.Unmarshal(retry.body, &payload); err != nil {
+		t.Fatalf("Failed to parse payload %s: %v", retry.body, err)
+	}
+	if payload.Event != WebhookEventCreated || payload.RequestID != "req-1" || payload.Kind != "ask_question" ||
+		payload.Project != "/test/project" || payload.Link != "https://assist.example.com/#/chat?request=req-1" {
+		t.Errorf("Unexpected payload of the new request: %+v", payload)
+	}
+
+	b.HandleClientResponse(alice, "req-1", &WebResponse{Contents: []*agentassistproto.McpResultContent{CreateTextContent("Yes")}, RepliedBy: "alice"})
+	<-responseChan
+	answered := nextWebhook(t, requests)
+	if err := json.Unmarshal(answered.body, &payload); err != nil || payload.Event != WebhookEventAnswered || payload.Nickname != "alice" {
+		t.Errorf("Expected the answered event of alice, got %s", answered.body)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	deliveries := webhooks.Deliveries()
+	if len(deliveries) != 3 || deliveries[0].Delivered || deliveries[0].StatusCode != http.StatusServiceUnavailable ||
+		!deliveries[1].Delivered || deliveries[1].Attempt != 2 || !deliveries[2].Delivered {
+		t.Errorf("Expected the delivery log to show the retry, got %+v", deliveries)
+	}
+}
+
+func TestWebhooks_TemplateAndRefusal(t *testing.T) {
+	server, requests := newWebhookServer(t, http.StatusBadRequest)
+	var deliveryLog bytes.Buffer
+	webhooks, err := NewWebhooks([]WebhookConfig{{
+		URL:      server.URL,
+		Events:   []string{WebhookEventCreated},
+		Project:  "/test/*",
+		Template: `{"text": {{json (printf "%s asks: %s" .Agent .Excerpt)}}}`,
+	}, {
+		URL:     server.URL,
+		Project: "/other/*",
+	}}, &deliveryLog)
+	if err != nil {
+		t.Fatalf("Failed to create webhooks: %v", err)
+	}
+
+	message := newTestAskQuestionMessage("req-1")
+	message.AskQuestionRequest.Request.AgentName = "Cascade"
+	message.AskQuestionRequest.Request.Question = strings.Repeat("x", 300)
+	webhooks.notify("req-1", &WebsocketRequest{Message: message}, &agentassistproto.RequestEvent{Type: RequestEventQueued})
+
+	request := nextWebhook(t, requests)
+	var body struct{ Text string }
+	if err := json.Unmarshal(request.body, &body); err != nil || body.Text != "Cascade asks: "+strings.Repeat("x", webhookExcerptLength)+"…" {
+		t.Errorf("Expected the templated body with an excerpt, got %s", request.body)
+	}
+	if request.header.Get(WebhookSignatureHeader) != "" {
+		t.Error("Expected no signature without a secret")
+	}
+	if contentType := request.header.Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("Expected a template rendering JSON to be sent as JSON, got %q", contentType)
+	}
+
+	// A refused payload is not retried
+	time.Sleep(100 * time.Millisecond)
+	select {
+	case request := <-requests:
+		t.Errorf("Expected no retry of a refused payload, got %s", request.body)
+	default:
+	}
+	if deliveries := webhooks.Deliveries(); len(deliveries) != 1 || deliveries[0].Delivered || deliveries[0].StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected one refused delivery, got %+v", deliveries)
+	}
+	if !strings.Contains(deliveryLog.String(), `"request_id":"req-1"`) {
+		t.Errorf("Expected the delivery log to record the attempt, got %q", deliveryLog.String())
+	}
+}
+
+func TestWebhooks_ContentType(t *testing.T) {
+	server, requests := newWebhookServer(t)
+	webhooks, err := NewWebhooks([]WebhookConfig{{
+		URL:      server.URL,
+		Events:   []string{WebhookEventCreated},
+		Template: `{{.Agent}} asks: {{.Excerpt}}`,
+	}, {
+		URL:         server.URL,
+		Events:      []string{WebhookEventAnswered},
+		Template:    `text={{.Message}}`,
+		ContentType: "application/x-www-form-urlencoded",
+	}}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create webhooks: %v", err)
+	}
+
+	message := newTestAskQuestionMessage("req-1")
+	webhooks.notify("req-1", &WebsocketRequest{Message: message}, &agentassistproto.RequestEvent{Type: RequestEventQueued})
+	if contentType := nextWebhook(t, requests).header.Get("Content-Type"); contentType != "text/plain; charset=utf-8" {
+		t.Errorf("Expected a plain text template to be sent as text, got %q", contentType)
+	}
+	webhooks.notify("req-1", &WebsocketRequest{Message: message}, &agentassistproto.RequestEvent{Type: RequestEventAnswered})
+	if contentType := nextWebhook(t, requests).header.Get("Content-Type"); contentType != "application/x-www-form-urlencoded" {
+		t.Errorf("Expected the configured content type, got %q", contentType)
+	}
+}
+
+func TestNewWebhooks_Invalid(t *testing.T) {
+	for _, configs := range [][]WebhookConfig{
+		{{}},
+		{{URL: "ftp://example.com/hook"}},
+		{{URL: "https://example.com/hook", Events: []string{"viewed"}}},
+		{{URL: "https://example.com/hook", Project: "/work/["}},
+		{{URL: "https://example.com/hook", Template: "{{.Missing"}},
+		{{URL: "https://example.com/hook", MaxAttempts: -1}},
+		{{URL: "https://example.com/hook", ContentType: "text/"}},
+	} {
+		if _, err := NewWebhooks(configs, nil); err == nil {
+			t.Errorf("Expected webhooks %+v to be rejected", configs)
+		}
+	}
+}
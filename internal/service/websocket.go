@@ -15,18 +15,36 @@ import (
 // WebSocketHandler handles WebSocket connections for the web interface
 type WebSocketHandler struct {
 	broadcaster *Broadcaster
+	tokens      *TokenRegistry // nil accepts any token
 	upgrader    websocket.Upgrader
 }
 
-// NewWebSocketHandler creates a new WebSocket handler
+// WebSocketHandlerOptions configures a WebSocket handler
+type WebSocketHandlerOptions struct {
+	// Tokens only accepts logins with a human token of the registry, nil accepts any token
+	Tokens *TokenRegistry
+	// Origins restricts which browser origins may connect, nil only allows same-origin connections
+	Origins *OriginPolicy
+}
+
+// NewWebSocketHandler creates a new WebSocket handler that accepts any token
 func NewWebSocketHandler(broadcaster *Broadcaster) *WebSocketHandler {
+	return NewWebSocketHandlerWithOptions(broadcaster, WebSocketHandlerOptions{})
+}
+
+// NewWebSocketHandlerWithOptions creates a new WebSocket handler with token authentication
+// and origin checks
+func NewWebSocketHandlerWithOptions(broadcaster *Broadcaster, options WebSocketHandlerOptions) *WebSocketHandler {
+	origins := options.Origins
+	if origins == nil {
+		origins, _ = NewOriginPolicy(nil, false)
+	}
+
 	return &WebSocketHandler{
 		broadcaster: broadcaster,
+		tokens:      options.Tokens,
 		upgrader: websocket.Upgrader{
-			CheckOrigin: func(r *http.Request) bool {
-				// Allow all origins for development - in production, you should restrict this
-				return true
-			},
+			CheckOrigin:     origins.CheckOrigin,
 			ReadBufferSize:  1024,
 			WriteBufferSize: 1024,
 		},
@@ -137,7 +155,18 @@ func (h *WebSocketHandler) handleIncomingMessages(conn *websocket.Conn, client *
 		switch message.Cmd {
 		case "UserLogin":
 			// Handle user login - store the token and nickname
-			if message.StrParam != "" {
+			var identity *Identity
+			if h.tokens != nil {
+				var err error
+				identity, err = h.tokens.Authenticate(message.StrParam, ScopeHuman)
+				if err != nil {
+					h.rejectLogin(client, err)
+					continue
+				}
+				// Clients are routed by the group of their user, never by the secret itself
+				client.SetToken(identity.Group)
+				log.Printf("Client %s authenticated as user %s", client.ID, identity.User)
+			} else if message.StrParam != "" {
 				client.SetToken(message.StrParam)
 				log.Printf("Client %s authenticated with token", client.ID)
 			} else {
@@ -148,6 +177,9 @@ func (h *WebSocketHandler) handleIncomingMessages(conn *websocket.Conn, client *
 			if message.Nickname != "" {
 				client.SetNickname(message.Nickname)
 				log.Printf("Client %s set nickname to: %s", client.ID, message.Nickname)
+			} else if identity != nil {
+				client.SetNickname(identity.User)
+				log.Printf("Client %s assigned user name as nickname: %s", client.ID, identity.User)
 			} else {
 				// Generate default nickname if not provided
 				defaultNickname := fmt.Sprintf("User_%s", client.ID[:8])
@@ -172,11 +204,18 @@ func (h *WebSocketHandler) handleIncomingMessages(conn *websocket.Conn, client *
 
 			// Broadcast user connection status to other clients with the same token
 			h.broadcaster.BroadcastUserConnectionStatus(client, "connected")
+
+			// Deliver the requests that arrived while no web client was online
+			h.broadcaster.DeliverHeldRequests(client)
 		case "AskQuestionReply":
-			h.handleAskQuestionReply(client, &message)
+			if !h.authorizeReply(client, message.AskQuestionRequest.GetID()) || !h.handleAskQuestionReply(client, &message) {
+				continue
+			}
 			h.broadcastAskQuestionReply(client, &message)
 		case "WorkReportReply":
-			h.handleWorkReportReply(client, &message)
+			if !h.authorizeReply(client, message.WorkReportRequest.GetID()) || !h.handleWorkReportReply(client, &message) {
+				continue
+			}
 			h.broadcastWorkReportReply(client, &message)
 		case "CheckMessageValidity":
 			h.handleCheckMessageValidity(client, &message)
@@ -186,6 +225,18 @@ func (h *WebSocketHandler) handleIncomingMessages(conn *websocket.Conn, client *
 			h.handleGetOnlineUsers(client, &message)
 		case "SendChatMessage":
 			h.handleSendChatMessage(client, &message)
+		case "RequestViewed":
+			h.broadcaster.RequestViewed(client, message.StrParam)
+		case "ReplyDraft":
+			h.broadcaster.ReplyDraft(client, message.StrParam)
+		case "ClaimRequest":
+			h.handleClaimRequest(client, message.StrParam)
+		case "ReleaseClaim":
+			h.broadcaster.ReleaseClaim(client, message.StrParam)
+		case "ExtendDeadline":
+			h.handleExtendDeadline(client, message.DeadlineExtension)
+		case "InterimReply":
+			h.handleInterimReply(client, message.InterimReply)
 
 		case "RequestCancelled":
 			// This is a notification message, clients don't send this to server
@@ -196,16 +247,113 @@ func (h *WebSocketHandler) handleIncomingMessages(conn *websocket.Conn, client *
 	}
 }
 
-// handleAskQuestionReply processes an AskQuestionReply from the web client
-func (h *WebSocketHandler) handleAskQuestionReply(client *WebClient, message *agentassistproto.WebsocketMessage) {
+// rejectLogin answers a UserLogin with an unknown or non-human token
+func (h *WebSocketHandler) rejectLogin(client *WebClient, err error) {
+	log.Printf("Client %s login rejected: %v", client.ID, err)
+
+	client.SetToken("")
+	loginResponse := &agentassistproto.WebsocketMessage{
+		Cmd: "UserLogin",
+		UserLoginResponse: &agentassistproto.UserLoginResponse{
+			ClientId:     client.ID,
+			Success:      false,
+			ErrorMessage: fmt.Sprintf("Login rejected: %v", err),
+		},
+	}
+	if !client.Send(loginResponse) {
+		log.Printf("Failed to send login response to client %s", client.ID)
+	}
+}
+
+// authorizeReply reports whether the client may answer a request. Only users the request is
+// meant for and routed to may answer it, and with token authentication enabled only logged in
+// users of the request's group.
+func (h *WebSocketHandler) authorizeReply(client *WebClient, requestID string) bool {
+	if h.tokens != nil && client.GetToken() == "" {
+		log.Printf("Ignoring reply to request %s from client %s: not logged in", requestID, client.ID)
+		return false
+	}
+
+	if !h.broadcaster.mayAnswer(client, requestID, h.tokens != nil) {
+		log.Printf("Ignoring reply to request %s from client %s: request is not meant for the client", requestID, client.ID)
+		return false
+	}
+	return true
+}
+
+// handleClaimRequest claims a request for the client and sends it the outcome
+func (h *WebSocketHandler) handleClaimRequest(client *WebClient, requestID string) {
+	var claim *agentassistproto.RequestClaim
+	if h.authorizeReply(client, requestID) {
+		claim = h.broadcaster.ClaimRequest(client, requestID)
+	} else {
+		claim = &agentassistproto.RequestClaim{RequestId: requestID, ErrorMessage: "not allowed to answer this request"}
+	}
+
+	response := &agentassistproto.WebsocketMessage{
+		Cmd:          "ClaimRequest",
+		RequestClaim: claim,
+	}
+	if !client.Send(response) {
+		log.Printf("Failed to send ClaimRequest response to client %s", client.ID)
+	}
+}
+
+// handleExtendDeadline gives a request more time for the client and sends it the outcome
+func (h *WebSocketHandler) handleExtendDeadline(client *WebClient, request *agentassistproto.DeadlineExtension) {
+	requestID := request.GetRequestId()
+	var extension *agentassistproto.DeadlineExtension
+	if h.authorizeReply(client, requestID) {
+		extendBy := time.Duration(request.GetExtendSeconds()) * time.Second
+		extension = h.broadcaster.ExtendDeadline(client, requestID, extendBy)
+	} else {
+		extension = &agentassistproto.DeadlineExtension{RequestId: requestID, ErrorMessage: "not allowed to answer this request"}
+	}
+
+	response := &agentassistproto.WebsocketMessage{
+		Cmd:               "ExtendDeadline",
+		DeadlineExtension: extension,
+	}
+	if !client.Send(response) {
+		log.Printf("Failed to send ExtendDeadline response to client %s", client.ID)
+	}
+}
+
+// handleInterimReply forwards an interim reply of the client to the waiting agent and sends
+// the client the outcome
+func (h *WebSocketHandler) handleInterimReply(client *WebClient, reply *agentassistproto.InterimReply) {
+	requestID := reply.GetRequestId()
+	var result *agentassistproto.InterimReply
+	if h.authorizeReply(client, requestID) {
+		result = h.broadcaster.InterimReply(client, reply)
+	} else {
+		result = &agentassistproto.InterimReply{RequestId: requestID, ErrorMessage: "not allowed to answer this request"}
+	}
+
+	response := &agentassistproto.WebsocketMessage{
+		Cmd:          "InterimReply",
+		InterimReply: result,
+	}
+	if !client.Send(response) {
+		log.Printf("Failed to send InterimReply response to client %s", client.ID)
+	}
+}
+
+// handleAskQuestionReply processes an AskQuestionReply from the web client. It returns whether
+// the reply was queued for delivery to the agent.
+func (h *WebSocketHandler) handleAskQuestionReply(client *WebClient, message *agentassistproto.WebsocketMessage) bool {
 	// For now, we expect the response data to be in the AskQuestionRequest field
 	// This is a workaround until the protobuf generation includes response fields
 	if message.AskQuestionRequest == nil {
 		log.Printf("Received AskQuestionReply from client %s with no request data", client.ID)
-		return
+		return false
 	}
 
 	request := message.AskQuestionRequest
+	if message.AskQuestionResponse == nil {
+		rejectEmptyReply(client, "AskQuestionReply", request.ID)
+		return false
+	}
 
 	// Create a simple success response for now
 	// In a full implementation, the web client would send actual response data
@@ -213,24 +361,35 @@ func (h *WebSocketHandler) handleAskQuestionReply(client *WebClient, message *ag
 		IsError:  message.AskQuestionResponse.IsError,
 		Meta:     message.AskQuestionResponse.Meta,
 		Contents: message.AskQuestionResponse.Contents,
+
+		RepliedBy: client.GetNickname(),
 	}
 
 	log.Printf("Received AskQuestionReply from client %s for request %s", client.ID, request.ID)
+	if !h.broadcaster.AcceptReply(client, request.ID) {
+		return false
+	}
 
 	// Send the response to the broadcaster for proper request matching
-	h.broadcaster.HandleResponse(request.ID, webResponse)
+	return h.broadcaster.HandleClientResponse(client, request.ID, webResponse)
 }
 
-// handleWorkReportReply processes a WorkReportReply from the web client
-func (h *WebSocketHandler) handleWorkReportReply(client *WebClient, message *agentassistproto.WebsocketMessage) {
+// handleWorkReportReply processes a WorkReportReply from the web client. It returns whether the
+// reply was accepted as the answer of the work report, rather than refused or recorded as one
+// of the approvals the work report needs.
+func (h *WebSocketHandler) handleWorkReportReply(client *WebClient, message *agentassistproto.WebsocketMessage) bool {
 	// For now, we expect the response data to be in the WorkReportRequest field
 	// This is a workaround until the protobuf generation includes response fields
 	if message.WorkReportRequest == nil {
 		log.Printf("Received WorkReportReply from client %s with no request data", client.ID)
-		return
+		return false
 	}
 
 	request := message.WorkReportRequest
+	if message.WorkReportResponse == nil {
+		rejectEmptyReply(client, "WorkReportReply", request.ID)
+		return false
+	}
 
 	// Create a simple success response for now
 	// In a full implementation, the web client would send actual response data
@@ -238,12 +397,37 @@ func (h *WebSocketHandler) handleWorkReportReply(client *WebClient, message *age
 		IsError:  message.WorkReportResponse.IsError,
 		Meta:     message.WorkReportResponse.Meta,
 		Contents: message.WorkReportResponse.Contents,
+		Decision: message.WorkReportResponse.Decision,
+
+		RepliedBy: client.GetNickname(),
 	}
 
 	log.Printf("Received WorkReportReply from client %s for request %s", client.ID, request.ID)
+	if err := ValidateDecision(webResponse.Decision); err != nil {
+		log.Printf("Ignoring WorkReportReply from client %s for request %s: %v", client.ID, request.ID, err)
+		sendReplyAck(client, &agentassistproto.ReplyAck{
+			RequestId: request.ID,
+			Status:    ReplyStatusInvalid,
+			Message:   err.Error(),
+		})
+		return false
+	}
+	if !h.broadcaster.AcceptWorkReportReply(client, request.ID, webResponse) {
+		return false
+	}
 
 	// Send the response to the broadcaster for proper request matching
-	h.broadcaster.HandleResponse(request.ID, webResponse)
+	return h.broadcaster.HandleClientResponse(client, request.ID, webResponse)
+}
+
+// rejectEmptyReply tells client that its reply to a request carries no response
+func rejectEmptyReply(client *WebClient, cmd string, requestID string) {
+	log.Printf("Ignoring %s from client %s for request %s: no response data", cmd, client.ID, requestID)
+	sendReplyAck(client, &agentassistproto.ReplyAck{
+		RequestId: requestID,
+		Status:    ReplyStatusInvalid,
+		Message:   "reply has no response",
+	})
 }
 
 // broadcastAskQuestionReply broadcasts an AskQuestionReply to all connected clients except the sender
@@ -270,8 +454,8 @@ func (h *WebSocketHandler) broadcastAskQuestionReply(client *WebClient, message
 	log.Printf("Broadcasting AskQuestionReply notification for request %s from client %s",
 		message.AskQuestionRequest.ID, client.ID)
 
-	// Broadcast to all clients except the sender
-	h.broadcaster.BroadcastToAllExcept(notificationMessage, client.ID)
+	// Broadcast to the clients that see the request except the sender
+	h.broadcaster.BroadcastToRequestClients(message.AskQuestionRequest.ID, notificationMessage, client.ID)
 }
 
 // broadcastWorkReportReply broadcasts a WorkReportReply to all connected clients except the sender
@@ -298,8 +482,8 @@ func (h *WebSocketHandler) broadcastWorkReportReply(client *WebClient, message *
 	log.Printf("Broadcasting WorkReportReply notification for request %s from client %s",
 		message.WorkReportRequest.ID, client.ID)
 
-	// Broadcast to all clients except the sender
-	h.broadcaster.BroadcastToAllExcept(notificationMessage, client.ID)
+	// Broadcast to the clients that see the request except the sender
+	h.broadcaster.BroadcastToRequestClients(message.WorkReportRequest.ID, notificationMessage, client.ID)
 }
 
 // handleCheckMessageValidity handles message validity check requests
@@ -351,7 +535,7 @@ func (h *WebSocketHandler) handleGetPendingMessages(client *WebClient, message *
 	}
 
 	// Get pending messages from broadcaster
-	pendingMessages := h.broadcaster.GetPendingMessages(userToken)
+	pendingMessages := h.broadcaster.GetPendingMessagesForClient(client)
 
 	log.Printf("Found %d pending messages for client %s", len(pendingMessages), client.ID)
 
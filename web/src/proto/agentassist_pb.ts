@@ -10,7 +10,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file agentassist.proto.
  */
 export const file_agentassist: GenFile = /*@__PURE__*/
-  fileDesc("ChFhZ2VudGFzc2lzdC5wcm90bxIQYWdlbnRhc3Npc3Rwcm90byIpCgtUZXh0Q29udGVudBIMCgR0eXBlGAEgASgJEgwKBHRleHQYAiABKAkiPQoMSW1hZ2VDb250ZW50EgwKBHR5cGUYASABKAkSDAoEZGF0YRgCIAEoCRIRCgltaW1lX3R5cGUYAyABKAkiPQoMQXVkaW9Db250ZW50EgwKBHR5cGUYASABKAkSDAoEZGF0YRgCIAEoCRIRCgltaW1lX3R5cGUYAyABKAkiTgoQRW1iZWRkZWRSZXNvdXJjZRIMCgR0eXBlGAEgASgJEgsKA3VyaRgCIAEoCRIRCgltaW1lX3R5cGUYAyABKAkSDAoEZGF0YRgEIAEoDCLqAQoQTWNwUmVzdWx0Q29udGVudBIMCgR0eXBlGAEgASgFEisKBHRleHQYAiABKAsyHS5hZ2VudGFzc2lzdHByb3RvLlRleHRDb250ZW50Ei0KBWltYWdlGAMgASgLMh4uYWdlbnRhc3Npc3Rwcm90by5JbWFnZUNvbnRlbnQSLQoFYXVkaW8YBCABKAsyHi5hZ2VudGFzc2lzdHByb3RvLkF1ZGlvQ29udGVudBI9ChFlbWJlZGRlZF9yZXNvdXJjZRgFIAEoCzIiLmFnZW50YXNzaXN0cHJvdG8uRW1iZWRkZWRSZXNvdXJjZSIKCghNc2dFbXB0eSKaAQoVTWNwQXNrUXVlc3Rpb25SZXF1ZXN0EhgKEFByb2plY3REaXJlY3RvcnkYASABKAkSEAoIUXVlc3Rpb24YAiABKAkSDwoHVGltZW91dBgDIAEoBRIRCglBZ2VudE5hbWUYBCABKAkSGgoSUmVhc29uaW5nTW9kZWxOYW1lGAUgASgJEhUKDU1jcENsaWVudE5hbWUYBiABKAkigAEKEkFza1F1ZXN0aW9uUmVxdWVzdBIKCgJJRBgBIAEoCRIRCglVc2VyVG9rZW4YAiABKAkSOAoHUmVxdWVzdBgDIAEoCzInLmFnZW50YXNzaXN0cHJvdG8uTWNwQXNrUXVlc3Rpb25SZXF1ZXN0EhEKCVRpbWVzdGFtcBgEIAEoAyLUAQoTQXNrUXVlc3Rpb25SZXNwb25zZRIKCgJJRBgBIAEoCRIPCgdJc0Vycm9yGAIgASgIEj0KBE1ldGEYAyADKAsyLy5hZ2VudGFzc2lzdHByb3RvLkFza1F1ZXN0aW9uUmVzcG9uc2UuTWV0YUVudHJ5EjQKCGNvbnRlbnRzGAQgAygLMiIuYWdlbnRhc3Npc3Rwcm90by5NY3BSZXN1bHRDb250ZW50GisKCU1ldGFFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIpgBChRNY3BXb3JrUmVwb3J0UmVxdWVzdBIYChBQcm9qZWN0RGlyZWN0b3J5GAEgASgJEg8KB1N1bW1hcnkYAiABKAkSDwoHVGltZW91dBgDIAEoBRIRCglBZ2VudE5hbWUYBCABKAkSGgoSUmVhc29uaW5nTW9kZWxOYW1lGAUgASgJEhUKDU1jcENsaWVudE5hbWUYBiABKAkifgoRV29ya1JlcG9ydFJlcXVlc3QSCgoCSUQYASABKAkSEQoJVXNlclRva2VuGAIgASgJEjcKB1JlcXVlc3QYAyABKAsyJi5hZ2VudGFzc2lzdHByb3RvLk1jcFdvcmtSZXBvcnRSZXF1ZXN0EhEKCVRpbWVzdGFtcBgEIAEoAyLSAQoSV29ya1JlcG9ydFJlc3BvbnNlEgoKAklEGAEgASgJEg8KB0lzRXJyb3IYAiABKAgSPAoETWV0YRgDIAMoCzIuLmFnZW50YXNzaXN0cHJvdG8uV29ya1JlcG9ydFJlc3BvbnNlLk1ldGFFbnRyeRI0Cghjb250ZW50cxgEIAMoCzIiLmFnZW50YXNzaXN0cHJvdG8uTWNwUmVzdWx0Q29udGVudBorCglNZXRhRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASJxChFNY3BDbGllbnRJbmZvRGF0YRIXCg9Qcm90b2NvbFZlcnNpb24YASABKAkSGAoQQ2FwYWJpbGl0aWVzSnNvbhgCIAEoCRISCgpDbGllbnROYW1lGAMgASgJEhUKDUNsaWVudFZlcnNpb24YBCABKAkifgoUTWNwQ2xpZW50SW5mb1JlcXVlc3QSCgoCSUQYASABKAkSEQoJVXNlclRva2VuGAIgASgJEjQKB1JlcXVlc3QYAyABKAsyIy5hZ2VudGFzc2lzdHByb3RvLk1jcENsaWVudEluZm9EYXRhEhEKCVRpbWVzdGFtcBgEIAEoAyIoChVNY3BDbGllbnRJbmZvUmVzcG9uc2USDwoHU3VjY2VzcxgBIAEoCCIyChtDaGVja01lc3NhZ2VWYWxpZGl0eVJlcXVlc3QSEwoLcmVxdWVzdF9pZHMYASADKAkinwEKHENoZWNrTWVzc2FnZVZhbGlkaXR5UmVzcG9uc2USTgoIdmFsaWRpdHkYASADKAsyPC5hZ2VudGFzc2lzdHByb3RvLkNoZWNrTWVzc2FnZVZhbGlkaXR5UmVzcG9uc2UuVmFsaWRpdHlFbnRyeRovCg1WYWxpZGl0eUVudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCDoCOAEiLwoZR2V0UGVuZGluZ01lc3NhZ2VzUmVxdWVzdBISCgp1c2VyX3Rva2VuGAEgASgJItEBCg5QZW5kaW5nTWVzc2FnZRIUCgxtZXNzYWdlX3R5cGUYASABKAkSQgoUYXNrX3F1ZXN0aW9uX3JlcXVlc3QYAiABKAsyJC5hZ2VudGFzc2lzdHByb3RvLkFza1F1ZXN0aW9uUmVxdWVzdBJAChN3b3JrX3JlcG9ydF9yZXF1ZXN0GAMgASgLMiMuYWdlbnRhc3Npc3Rwcm90by5Xb3JrUmVwb3J0UmVxdWVzdBISCgpjcmVhdGVkX2F0GAQgASgDEg8KB3RpbWVvdXQYBSABKAUibQoaR2V0UGVuZGluZ01lc3NhZ2VzUmVzcG9uc2USOgoQcGVuZGluZ19tZXNzYWdlcxgBIAMoCzIgLmFnZW50YXNzaXN0cHJvdG8uUGVuZGluZ01lc3NhZ2USEwoLdG90YWxfY291bnQYAiABKAUiWAocUmVxdWVzdENhbmNlbGxlZE5vdGlmaWNhdGlvbhISCgpyZXF1ZXN0X2lkGAEgASgJEg4KBnJlYXNvbhgCIAEoCRIUCgxtZXNzYWdlX3R5cGUYAyABKAkiRwoKT25saW5lVXNlchIRCgljbGllbnRfaWQYASABKAkSEAoIbmlja25hbWUYAiABKAkSFAoMY29ubmVjdGVkX2F0GAMgASgDIisKFUdldE9ubGluZVVzZXJzUmVxdWVzdBISCgp1c2VyX3Rva2VuGAEgASgJImEKFkdldE9ubGluZVVzZXJzUmVzcG9uc2USMgoMb25saW5lX3VzZXJzGAEgAygLMhwuYWdlbnRhc3Npc3Rwcm90by5PbmxpbmVVc2VyEhMKC3RvdGFsX2NvdW50GAIgASgFIq0BCgtDaGF0TWVzc2FnZRISCgptZXNzYWdlX2lkGAEgASgJEhgKEHNlbmRlcl9jbGllbnRfaWQYAiABKAkSFwoPc2VuZGVyX25pY2tuYW1lGAMgASgJEhoKEnJlY2VpdmVyX2NsaWVudF9pZBgEIAEoCRIZChFyZWNlaXZlcl9uaWNrbmFtZRgFIAEoCRIPCgdjb250ZW50GAYgASgJEg8KB3NlbnRfYXQYByABKAMiRQoWU2VuZENoYXRNZXNzYWdlUmVxdWVzdBIaChJyZWNlaXZlcl9jbGllbnRfaWQYASABKAkSDwoHY29udGVudBgCIAEoCSJOChdDaGF0TWVzc2FnZU5vdGlmaWNhdGlvbhIzCgxjaGF0X21lc3NhZ2UYASABKAsyHS5hZ2VudGFzc2lzdHByb3RvLkNoYXRNZXNzYWdlIk4KEVVzZXJMb2dpblJlc3BvbnNlEhEKCWNsaWVudF9pZBgBIAEoCRIPCgdzdWNjZXNzGAIgASgIEhUKDWVycm9yX21lc3NhZ2UYAyABKAkicQogVXNlckNvbm5lY3Rpb25TdGF0dXNOb3RpZmljYXRpb24SKgoEdXNlchgBIAEoCzIcLmFnZW50YXNzaXN0cHJvdG8uT25saW5lVXNlchIOCgZzdGF0dXMYAiABKAkSEQoJdGltZXN0YW1wGAMgASgDIrMJChBXZWJzb2NrZXRNZXNzYWdlEgsKA0NtZBgBIAEoCRJAChJBc2tRdWVzdGlvblJlcXVlc3QYAiABKAsyJC5hZ2VudGFzc2lzdHByb3RvLkFza1F1ZXN0aW9uUmVxdWVzdBI+ChFXb3JrUmVwb3J0UmVxdWVzdBgDIAEoCzIjLmFnZW50YXNzaXN0cHJvdG8uV29ya1JlcG9ydFJlcXVlc3QSQgoTQXNrUXVlc3Rpb25SZXNwb25zZRgEIAEoCzIlLmFnZW50YXNzaXN0cHJvdG8uQXNrUXVlc3Rpb25SZXNwb25zZRJAChJXb3JrUmVwb3J0UmVzcG9uc2UYBSABKAsyJC5hZ2VudGFzc2lzdHByb3RvLldvcmtSZXBvcnRSZXNwb25zZRJSChtDaGVja01lc3NhZ2VWYWxpZGl0eVJlcXVlc3QYDSABKAsyLS5hZ2VudGFzc2lzdHByb3RvLkNoZWNrTWVzc2FnZVZhbGlkaXR5UmVxdWVzdBJUChxDaGVja01lc3NhZ2VWYWxpZGl0eVJlc3BvbnNlGA4gASgLMi4uYWdlbnRhc3Npc3Rwcm90by5DaGVja01lc3NhZ2VWYWxpZGl0eVJlc3BvbnNlEk4KGUdldFBlbmRpbmdNZXNzYWdlc1JlcXVlc3QYDyABKAsyKy5hZ2VudGFzc2lzdHByb3RvLkdldFBlbmRpbmdNZXNzYWdlc1JlcXVlc3QSUAoaR2V0UGVuZGluZ01lc3NhZ2VzUmVzcG9uc2UYECABKAsyLC5hZ2VudGFzc2lzdHByb3RvLkdldFBlbmRpbmdNZXNzYWdlc1Jlc3BvbnNlElQKHFJlcXVlc3RDYW5jZWxsZWROb3RpZmljYXRpb24YESABKAsyLi5hZ2VudGFzc2lzdHByb3RvLlJlcXVlc3RDYW5jZWxsZWROb3RpZmljYXRpb24SRgoVR2V0T25saW5lVXNlcnNSZXF1ZXN0GBMgASgLMicuYWdlbnRhc3Npc3Rwcm90by5HZXRPbmxpbmVVc2Vyc1JlcXVlc3QSSAoWR2V0T25saW5lVXNlcnNSZXNwb25zZRgUIAEoCzIoLmFnZW50YXNzaXN0cHJvdG8uR2V0T25saW5lVXNlcnNSZXNwb25zZRJIChZTZW5kQ2hhdE1lc3NhZ2VSZXF1ZXN0GBUgASgLMiguYWdlbnRhc3Npc3Rwcm90by5TZW5kQ2hhdE1lc3NhZ2VSZXF1ZXN0EkoKF0NoYXRNZXNzYWdlTm90aWZpY2F0aW9uGBYgASgLMikuYWdlbnRhc3Npc3Rwcm90by5DaGF0TWVzc2FnZU5vdGlmaWNhdGlvbhI+ChFVc2VyTG9naW5SZXNwb25zZRgXIAEoCzIjLmFnZW50YXNzaXN0cHJvdG8uVXNlckxvZ2luUmVzcG9uc2USXAogVXNlckNvbm5lY3Rpb25TdGF0dXNOb3RpZmljYXRpb24YGCABKAsyMi5hZ2VudGFzc2lzdHByb3RvLlVzZXJDb25uZWN0aW9uU3RhdHVzTm90aWZpY2F0aW9uEhAKCFN0clBhcmFtGAwgASgJEhAKCE5pY2tuYW1lGBIgASgJMqsCCg5TcnZBZ2VudEFzc2lzdBJaCgtBc2tRdWVzdGlvbhIkLmFnZW50YXNzaXN0cHJvdG8uQXNrUXVlc3Rpb25SZXF1ZXN0GiUuYWdlbnRhc3Npc3Rwcm90by5Bc2tRdWVzdGlvblJlc3BvbnNlElcKCldvcmtSZXBvcnQSIy5hZ2VudGFzc2lzdHByb3RvLldvcmtSZXBvcnRSZXF1ZXN0GiQuYWdlbnRhc3Npc3Rwcm90by5Xb3JrUmVwb3J0UmVzcG9uc2USZAoRU2VuZE1jcENsaWVudEluZm8SJi5hZ2VudGFzc2lzdHByb3RvLk1jcENsaWVudEluZm9SZXF1ZXN0GicuYWdlbnRhc3Npc3Rwcm90by5NY3BDbGllbnRJbmZvUmVzcG9uc2VCOFo2Z2l0aHViLmNvbS95YW5nanVuY29kZS9hZ2VudGFzc2lzdGFudC9hZ2VudGFzc2lzdHByb3RvYgZwcm90bzM");
+  fileDesc("ChFhZ2VudGFzc2lzdC5wcm90bxIQYWdlbnRhc3Npc3Rwcm90byIpCgtUZXh0Q29udGVudBIMCgR0eXBlGAEgASgJEgwKBHRleHQYAiABKAkiPQoMSW1hZ2VDb250ZW50EgwKBHR5cGUYASABKAkSDAoEZGF0YRgCIAEoCRIRCgltaW1lX3R5cGUYAyABKAkiPQoMQXVkaW9Db250ZW50EgwKBHR5cGUYASABKAkSDAoEZGF0YRgCIAEoCRIRCgltaW1lX3R5cGUYAyABKAkiTgoQRW1iZWRkZWRSZXNvdXJjZRIMCgR0eXBlGAEgASgJEgsKA3VyaRgCIAEoCRIRCgltaW1lX3R5cGUYAyABKAkSDAoEZGF0YRgEIAEoDCLqAQoQTWNwUmVzdWx0Q29udGVudBIMCgR0eXBlGAEgASgFEisKBHRleHQYAiABKAsyHS5hZ2VudGFzc2lzdHByb3RvLlRleHRDb250ZW50Ei0KBWltYWdlGAMgASgLMh4uYWdlbnRhc3Npc3Rwcm90by5JbWFnZUNvbnRlbnQSLQoFYXVkaW8YBCABKAsyHi5hZ2VudGFzc2lzdHByb3RvLkF1ZGlvQ29udGVudBI9ChFlbWJlZGRlZF9yZXNvdXJjZRgFIAEoCzIiLmFnZW50YXNzaXN0cHJvdG8uRW1iZWRkZWRSZXNvdXJjZSIKCghNc2dFbXB0eSLsAQoVTWNwQXNrUXVlc3Rpb25SZXF1ZXN0EhgKEFByb2plY3REaXJlY3RvcnkYASABKAkSEAoIUXVlc3Rpb24YAiABKAkSDwoHVGltZW91dBgDIAEoBRIRCglBZ2VudE5hbWUYBCABKAkSGgoSUmVhc29uaW5nTW9kZWxOYW1lGAUgASgJEhUKDU1jcENsaWVudE5hbWUYBiABKAkSFAoMTWNwU2Vzc2lvbklEGAcgASgJEjoKDU1jcENsaWVudEluZm8YCCABKAsyIy5hZ2VudGFzc2lzdHByb3RvLk1jcENsaWVudEluZm9EYXRhIoABChJBc2tRdWVzdGlvblJlcXVlc3QSCgoCSUQYASABKAkSEQoJVXNlclRva2VuGAIgASgJEjgKB1JlcXVlc3QYAyABKAsyJy5hZ2VudGFzc2lzdHByb3RvLk1jcEFza1F1ZXN0aW9uUmVxdWVzdBIRCglUaW1lc3RhbXAYBCABKAMi1AEKE0Fza1F1ZXN0aW9uUmVzcG9uc2USCgoCSUQYASABKAkSDwoHSXNFcnJvchgCIAEoCBI9CgRNZXRhGAMgAygLMi8uYWdlbnRhc3Npc3Rwcm90by5Bc2tRdWVzdGlvblJlc3BvbnNlLk1ldGFFbnRyeRI0Cghjb250ZW50cxgEIAMoCzIiLmFnZW50YXNzaXN0cHJvdG8uTWNwUmVzdWx0Q29udGVudBorCglNZXRhRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASKYAgoUTWNwV29ya1JlcG9ydFJlcXVlc3QSGAoQUHJvamVjdERpcmVjdG9yeRgBIAEoCRIPCgdTdW1tYXJ5GAIgASgJEg8KB1RpbWVvdXQYAyABKAUSEQoJQWdlbnROYW1lGAQgASgJEhoKElJlYXNvbmluZ01vZGVsTmFtZRgFIAEoCRIVCg1NY3BDbGllbnROYW1lGAYgASgJEhQKDE1jcFNlc3Npb25JRBgHIAEoCRI6Cg1NY3BDbGllbnRJbmZvGAggASgLMiMuYWdlbnRhc3Npc3Rwcm90by5NY3BDbGllbnRJbmZvRGF0YRIZChFSZXF1aXJlZEFwcHJvdmFscxgJIAEoBRIRCglBcHByb3ZlcnMYCiADKAkifgoRV29ya1JlcG9ydFJlcXVlc3QSCgoCSUQYASABKAkSEQoJVXNlclRva2VuGAIgASgJEjcKB1JlcXVlc3QYAyABKAsyJi5hZ2VudGFzc2lzdHByb3RvLk1jcFdvcmtSZXBvcnRSZXF1ZXN0EhEKCVRpbWVzdGFtcBgEIAEoAyKKAgoSV29ya1JlcG9ydFJlc3BvbnNlEgoKAklEGAEgASgJEg8KB0lzRXJyb3IYAiABKAgSPAoETWV0YRgDIAMoCzIuLmFnZW50YXNzaXN0cHJvdG8uV29ya1JlcG9ydFJlc3BvbnNlLk1ldGFFbnRyeRI0Cghjb250ZW50cxgEIAMoCzIiLmFnZW50YXNzaXN0cHJvdG8uTWNwUmVzdWx0Q29udGVudBI2CghEZWNpc2lvbhgFIAEoCzIkLmFnZW50YXNzaXN0cHJvdG8uV29ya1JlcG9ydERlY2lzaW9uGisKCU1ldGFFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIjIKEVdvcmtSZXBvcnRDb21tZW50EgwKBEl0ZW0YASABKAkSDwoHQ29tbWVudBgCIAEoCSJdChJXb3JrUmVwb3J0RGVjaXNpb24SEAoIRGVjaXNpb24YASABKAkSNQoIQ29tbWVudHMYAiADKAsyIy5hZ2VudGFzc2lzdHByb3RvLldvcmtSZXBvcnRDb21tZW50InEKEU1jcENsaWVudEluZm9EYXRhEhcKD1Byb3RvY29sVmVyc2lvbhgBIAEoCRIYChBDYXBhYmlsaXRpZXNKc29uGAIgASgJEhIKCkNsaWVudE5hbWUYAyABKAkSFQoNQ2xpZW50VmVyc2lvbhgEIAEoCSKUAQoUTWNwQ2xpZW50SW5mb1JlcXVlc3QSCgoCSUQYASABKAkSEQoJVXNlclRva2VuGAIgASgJEjQKB1JlcXVlc3QYAyABKAsyIy5hZ2VudGFzc2lzdHByb3RvLk1jcENsaWVudEluZm9EYXRhEhEKCVRpbWVzdGFtcBgEIAEoAxIUCgxNY3BTZXNzaW9uSUQYBSABKAkiKAoVTWNwQ2xpZW50SW5mb1Jlc3BvbnNlEg8KB1N1Y2Nlc3MYASABKAgimAEKFFN1Ym1pdFJlcXVlc3RSZXF1ZXN0EkAKEkFza1F1ZXN0aW9uUmVxdWVzdBgBIAEoCzIkLmFnZW50YXNzaXN0cHJvdG8uQXNrUXVlc3Rpb25SZXF1ZXN0Ej4KEVdvcmtSZXBvcnRSZXF1ZXN0GAIgASgLMiMuYWdlbnRhc3Npc3Rwcm90by5Xb3JrUmVwb3J0UmVxdWVzdCLFAQoVU3VibWl0UmVxdWVzdFJlc3BvbnNlEgoKAklEGAEgASgJEg8KB1N1Y2Nlc3MYAiABKAgSDwoHUmVzdW1lZBgDIAEoCBIQCghEZWFkbGluZRgEIAEoAxI/CgRNZXRhGAUgAygLMjEuYWdlbnRhc3Npc3Rwcm90by5TdWJtaXRSZXF1ZXN0UmVzcG9uc2UuTWV0YUVudHJ5GisKCU1ldGFFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIkgKEkF3YWl0UmVzdWx0UmVxdWVzdBIKCgJJRBgBIAEoCRIRCglVc2VyVG9rZW4YAiABKAkSEwoLV2FpdFNlY29uZHMYAyABKAUi2QEKE0F3YWl0UmVzdWx0UmVzcG9uc2USCgoCSUQYASABKAkSDAoERG9uZRgCIAEoCBIQCghOb3RGb3VuZBgDIAEoCBJCChNBc2tRdWVzdGlvblJlc3BvbnNlGAQgASgLMiUuYWdlbnRhc3Npc3Rwcm90by5Bc2tRdWVzdGlvblJlc3BvbnNlEkAKEldvcmtSZXBvcnRSZXNwb25zZRgFIAEoCzIkLmFnZW50YXNzaXN0cHJvdG8uV29ya1JlcG9ydFJlc3BvbnNlEhAKCERlYWRsaW5lGAYgASgDIkUKFENhbmNlbFJlcXVlc3RSZXF1ZXN0EgoKAklEGAEgASgJEhEKCVVzZXJUb2tlbhgCIAEoCRIOCgZSZWFzb24YAyABKAkiKAoVQ2FuY2VsUmVxdWVzdFJlc3BvbnNlEg8KB1N1Y2Nlc3MYASABKAgiNAoTV2F0Y2hSZXF1ZXN0UmVxdWVzdBIKCgJJRBgBIAEoCRIRCglVc2VyVG9rZW4YAiABKAkiggEKDFJlcXVlc3RFdmVudBIKCgJJRBgBIAEoCRIMCgRUeXBlGAIgASgJEhMKC0NsaWVudENvdW50GAMgASgFEhAKCE5pY2tuYW1lGAQgASgJEg8KB01lc3NhZ2UYBSABKAkSEQoJVGltZXN0YW1wGAYgASgDEg0KBUZpbmFsGAcgASgIIjIKG0NoZWNrTWVzc2FnZVZhbGlkaXR5UmVxdWVzdBITCgtyZXF1ZXN0X2lkcxgBIAMoCSKfAQocQ2hlY2tNZXNzYWdlVmFsaWRpdHlSZXNwb25zZRJOCgh2YWxpZGl0eRgBIAMoCzI8LmFnZW50YXNzaXN0cHJvdG8uQ2hlY2tNZXNzYWdlVmFsaWRpdHlSZXNwb25zZS5WYWxpZGl0eUVudHJ5Gi8KDVZhbGlkaXR5RW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgIOgI4ASIvChlHZXRQZW5kaW5nTWVzc2FnZXNSZXF1ZXN0EhIKCnVzZXJfdG9rZW4YASABKAki0wIKDlBlbmRpbmdNZXNzYWdlEhQKDG1lc3NhZ2VfdHlwZRgBIAEoCRJCChRhc2tfcXVlc3Rpb25fcmVxdWVzdBgCIAEoCzIkLmFnZW50YXNzaXN0cHJvdG8uQXNrUXVlc3Rpb25SZXF1ZXN0EkAKE3dvcmtfcmVwb3J0X3JlcXVlc3QYAyABKAsyIy5hZ2VudGFzc2lzdHByb3RvLldvcmtSZXBvcnRSZXF1ZXN0EhIKCmNyZWF0ZWRfYXQYBCABKAMSDwoHdGltZW91dBgFIAEoBRIQCghkZWFkbGluZRgGIAEoAxIWCg5kZWxpdmVyeV9jb3VudBgHIAEoBRIXCg9maXJzdF92aWV3ZWRfYXQYCCABKAMSPQoRYXBwcm92YWxfcHJvZ3Jlc3MYCSABKAsyIi5hZ2VudGFzc2lzdHByb3RvLkFwcHJvdmFsUHJvZ3Jlc3MibQoaR2V0UGVuZGluZ01lc3NhZ2VzUmVzcG9uc2USOgoQcGVuZGluZ19tZXNzYWdlcxgBIAMoCzIgLmFnZW50YXNzaXN0cHJvdG8uUGVuZGluZ01lc3NhZ2USEwoLdG90YWxfY291bnQYAiABKAUiWAocUmVxdWVzdENhbmNlbGxlZE5vdGlmaWNhdGlvbhISCgpyZXF1ZXN0X2lkGAEgASgJEg4KBnJlYXNvbhgCIAEoCRIUCgxtZXNzYWdlX3R5cGUYAyABKAkiRwoKT25saW5lVXNlchIRCgljbGllbnRfaWQYASABKAkSEAoIbmlja25hbWUYAiABKAkSFAoMY29ubmVjdGVkX2F0GAMgASgDIisKFUdldE9ubGluZVVzZXJzUmVxdWVzdBISCgp1c2VyX3Rva2VuGAEgASgJImEKFkdldE9ubGluZVVzZXJzUmVzcG9uc2USMgoMb25saW5lX3VzZXJzGAEgAygLMhwuYWdlbnRhc3Npc3Rwcm90by5PbmxpbmVVc2VyEhMKC3RvdGFsX2NvdW50GAIgASgFIq0BCgtDaGF0TWVzc2FnZRISCgptZXNzYWdlX2lkGAEgASgJEhgKEHNlbmRlcl9jbGllbnRfaWQYAiABKAkSFwoPc2VuZGVyX25pY2tuYW1lGAMgASgJEhoKEnJlY2VpdmVyX2NsaWVudF9pZBgEIAEoCRIZChFyZWNlaXZlcl9uaWNrbmFtZRgFIAEoCRIPCgdjb250ZW50GAYgASgJEg8KB3NlbnRfYXQYByABKAMiRQoWU2VuZENoYXRNZXNzYWdlUmVxdWVzdBIaChJyZWNlaXZlcl9jbGllbnRfaWQYASABKAkSDwoHY29udGVudBgCIAEoCSJOChdDaGF0TWVzc2FnZU5vdGlmaWNhdGlvbhIzCgxjaGF0X21lc3NhZ2UYASABKAsyHS5hZ2VudGFzc2lzdHByb3RvLkNoYXRNZXNzYWdlIk4KEVVzZXJMb2dpblJlc3BvbnNlEhEKCWNsaWVudF9pZBgBIAEoCRIPCgdzdWNjZXNzGAIgASgIEhUKDWVycm9yX21lc3NhZ2UYAyABKAkicQogVXNlckNvbm5lY3Rpb25TdGF0dXNOb3RpZmljYXRpb24SKgoEdXNlchgBIAEoCzIcLmFnZW50YXNzaXN0cHJvdG8uT25saW5lVXNlchIOCgZzdGF0dXMYAiABKAkSEQoJdGltZXN0YW1wGAMgASgDIoMBCgxSZXF1ZXN0Q2xhaW0SEgoKcmVxdWVzdF9pZBgBIAEoCRIRCgljbGllbnRfaWQYAiABKAkSEAoIbmlja25hbWUYAyABKAkSEgoKZXhwaXJlc19hdBgEIAEoAxIPCgdzdWNjZXNzGAUgASgIEhUKDWVycm9yX21lc3NhZ2UYBiABKAkiaQoIUmVwbHlBY2sSEgoKcmVxdWVzdF9pZBgBIAEoCRIOCgZzdGF0dXMYAiABKAkSFAoMZGVsaXZlcmVkX2F0GAMgASgDEhIKCnJlcGxpZWRfYnkYBCABKAkSDwoHbWVzc2FnZRgFIAEoCSKFAQoQQXBwcm92YWxQcm9ncmVzcxISCgpyZXF1ZXN0X2lkGAEgASgJEhoKEnJlcXVpcmVkX2FwcHJvdmFscxgCIAEoBRIRCglhcHByb3ZlcnMYAyADKAkSEwoLYXBwcm92ZWRfYnkYBCADKAkSGQoRcGVuZGluZ19hcHByb3ZlcnMYBSADKAkiiwEKEURlYWRsaW5lRXh0ZW5zaW9uEhIKCnJlcXVlc3RfaWQYASABKAkSFgoOZXh0ZW5kX3NlY29uZHMYAiABKAUSEAoIZGVhZGxpbmUYAyABKAMSEAoIbmlja25hbWUYBCABKAkSDwoHc3VjY2VzcxgFIAEoCBIVCg1lcnJvcl9tZXNzYWdlGAYgASgJIpQBCgxJbnRlcmltUmVwbHkSEgoKcmVxdWVzdF9pZBgBIAEoCRIMCgR0ZXh0GAIgASgJEhYKDmV4dGVuZF9zZWNvbmRzGAMgASgFEhAKCG5pY2tuYW1lGAQgASgJEhAKCGRlYWRsaW5lGAUgASgDEg8KB3N1Y2Nlc3MYBiABKAgSFQoNZXJyb3JfbWVzc2FnZRgHIAEoCSJxCghSZW1pbmRlchISCgpyZXF1ZXN0X2lkGAEgASgJEg4KBm51bWJlchgCIAEoBRIaChJ1bmFuc3dlcmVkX3NlY29uZHMYAyABKAMSFAoMZXNjYWxhdGVkX3RvGAQgAygJEg8KB21lc3NhZ2UYBSABKAki+QsKEFdlYnNvY2tldE1lc3NhZ2USCwoDQ21kGAEgASgJEkAKEkFza1F1ZXN0aW9uUmVxdWVzdBgCIAEoCzIkLmFnZW50YXNzaXN0cHJvdG8uQXNrUXVlc3Rpb25SZXF1ZXN0Ej4KEVdvcmtSZXBvcnRSZXF1ZXN0GAMgASgLMiMuYWdlbnRhc3Npc3Rwcm90by5Xb3JrUmVwb3J0UmVxdWVzdBJCChNBc2tRdWVzdGlvblJlc3BvbnNlGAQgASgLMiUuYWdlbnRhc3Npc3Rwcm90by5Bc2tRdWVzdGlvblJlc3BvbnNlEkAKEldvcmtSZXBvcnRSZXNwb25zZRgFIAEoCzIkLmFnZW50YXNzaXN0cHJvdG8uV29ya1JlcG9ydFJlc3BvbnNlElIKG0NoZWNrTWVzc2FnZVZhbGlkaXR5UmVxdWVzdBgNIAEoCzItLmFnZW50YXNzaXN0cHJvdG8uQ2hlY2tNZXNzYWdlVmFsaWRpdHlSZXF1ZXN0ElQKHENoZWNrTWVzc2FnZVZhbGlkaXR5UmVzcG9uc2UYDiABKAsyLi5hZ2VudGFzc2lzdHByb3RvLkNoZWNrTWVzc2FnZVZhbGlkaXR5UmVzcG9uc2USTgoZR2V0UGVuZGluZ01lc3NhZ2VzUmVxdWVzdBgPIAEoCzIrLmFnZW50YXNzaXN0cHJvdG8uR2V0UGVuZGluZ01lc3NhZ2VzUmVxdWVzdBJQChpHZXRQZW5kaW5nTWVzc2FnZXNSZXNwb25zZRgQIAEoCzIsLmFnZW50YXNzaXN0cHJvdG8uR2V0UGVuZGluZ01lc3NhZ2VzUmVzcG9uc2USVAocUmVxdWVzdENhbmNlbGxlZE5vdGlmaWNhdGlvbhgRIAEoCzIuLmFnZW50YXNzaXN0cHJvdG8uUmVxdWVzdENhbmNlbGxlZE5vdGlmaWNhdGlvbhJGChVHZXRPbmxpbmVVc2Vyc1JlcXVlc3QYEyABKAsyJy5hZ2VudGFzc2lzdHByb3RvLkdldE9ubGluZVVzZXJzUmVxdWVzdBJIChZHZXRPbmxpbmVVc2Vyc1Jlc3BvbnNlGBQgASgLMiguYWdlbnRhc3Npc3Rwcm90by5HZXRPbmxpbmVVc2Vyc1Jlc3BvbnNlEkgKFlNlbmRDaGF0TWVzc2FnZVJlcXVlc3QYFSABKAsyKC5hZ2VudGFzc2lzdHByb3RvLlNlbmRDaGF0TWVzc2FnZVJlcXVlc3QSSgoXQ2hhdE1lc3NhZ2VOb3RpZmljYXRpb24YFiABKAsyKS5hZ2VudGFzc2lzdHByb3RvLkNoYXRNZXNzYWdlTm90aWZpY2F0aW9uEj4KEVVzZXJMb2dpblJlc3BvbnNlGBcgASgLMiMuYWdlbnRhc3Npc3Rwcm90by5Vc2VyTG9naW5SZXNwb25zZRJcCiBVc2VyQ29ubmVjdGlvblN0YXR1c05vdGlmaWNhdGlvbhgYIAEoCzIyLmFnZW50YXNzaXN0cHJvdG8uVXNlckNvbm5lY3Rpb25TdGF0dXNOb3RpZmljYXRpb24SNAoMUmVxdWVzdENsYWltGBkgASgLMh4uYWdlbnRhc3Npc3Rwcm90by5SZXF1ZXN0Q2xhaW0SLAoIUmVwbHlBY2sYGiABKAsyGi5hZ2VudGFzc2lzdHByb3RvLlJlcGx5QWNrEjwKEEFwcHJvdmFsUHJvZ3Jlc3MYGyABKAsyIi5hZ2VudGFzc2lzdHByb3RvLkFwcHJvdmFsUHJvZ3Jlc3MSPgoRRGVhZGxpbmVFeHRlbnNpb24YHCABKAsyIy5hZ2VudGFzc2lzdHByb3RvLkRlYWRsaW5lRXh0ZW5zaW9uEjQKDEludGVyaW1SZXBseRgdIAEoCzIeLmFnZW50YXNzaXN0cHJvdG8uSW50ZXJpbVJlcGx5EiwKCFJlbWluZGVyGB4gASgLMhouYWdlbnRhc3Npc3Rwcm90by5SZW1pbmRlchIQCghTdHJQYXJhbRgMIAEoCRIQCghOaWNrbmFtZRgSIAEoCTKkBQoOU3J2QWdlbnRBc3Npc3QSWgoLQXNrUXVlc3Rpb24SJC5hZ2VudGFzc2lzdHByb3RvLkFza1F1ZXN0aW9uUmVxdWVzdBolLmFnZW50YXNzaXN0cHJvdG8uQXNrUXVlc3Rpb25SZXNwb25zZRJXCgpXb3JrUmVwb3J0EiMuYWdlbnRhc3Npc3Rwcm90by5Xb3JrUmVwb3J0UmVxdWVzdBokLmFnZW50YXNzaXN0cHJvdG8uV29ya1JlcG9ydFJlc3BvbnNlEmQKEVNlbmRNY3BDbGllbnRJbmZvEiYuYWdlbnRhc3Npc3Rwcm90by5NY3BDbGllbnRJbmZvUmVxdWVzdBonLmFnZW50YXNzaXN0cHJvdG8uTWNwQ2xpZW50SW5mb1Jlc3BvbnNlEmAKDVN1Ym1pdFJlcXVlc3QSJi5hZ2VudGFzc2lzdHByb3RvLlN1Ym1pdFJlcXVlc3RSZXF1ZXN0GicuYWdlbnRhc3Npc3Rwcm90by5TdWJtaXRSZXF1ZXN0UmVzcG9uc2USWgoLQXdhaXRSZXN1bHQSJC5hZ2VudGFzc2lzdHByb3RvLkF3YWl0UmVzdWx0UmVxdWVzdBolLmFnZW50YXNzaXN0cHJvdG8uQXdhaXRSZXN1bHRSZXNwb25zZRJgCg1DYW5jZWxSZXF1ZXN0EiYuYWdlbnRhc3Npc3Rwcm90by5DYW5jZWxSZXF1ZXN0UmVxdWVzdBonLmFnZW50YXNzaXN0cHJvdG8uQ2FuY2VsUmVxdWVzdFJlc3BvbnNlElcKDFdhdGNoUmVxdWVzdBIlLmFnZW50YXNzaXN0cHJvdG8uV2F0Y2hSZXF1ZXN0UmVxdWVzdBoeLmFnZW50YXNzaXN0cHJvdG8uUmVxdWVzdEV2ZW50MAFCOFo2Z2l0aHViLmNvbS95YW5nanVuY29kZS9hZ2VudGFzc2lzdGFudC9hZ2VudGFzc2lzdHByb3RvYgZwcm90bzM");
 
 /**
  * TextContent represents text provided to or from an LLM.
@@ -265,6 +265,20 @@ export type McpAskQuestionRequest = Message<"agentassistproto.McpAskQuestionRequ
    * @generated from field: string McpClientName = 6;
    */
   McpClientName: string;
+
+  /**
+   * MCP session the request was sent from, see McpClientInfoRequest
+   *
+   * @generated from field: string McpSessionID = 7;
+   */
+  McpSessionID: string;
+
+  /**
+   * client info of the MCP session, attached by the server
+   *
+   * @generated from field: agentassistproto.McpClientInfoData McpClientInfo = 8;
+   */
+  McpClientInfo?: McpClientInfoData;
 };
 
 /**
@@ -393,6 +407,35 @@ export type McpWorkReportRequest = Message<"agentassistproto.McpWorkReportReques
    * @generated from field: string McpClientName = 6;
    */
   McpClientName: string;
+
+  /**
+   * MCP session the request was sent from, see McpClientInfoRequest
+   *
+   * @generated from field: string McpSessionID = 7;
+   */
+  McpSessionID: string;
+
+  /**
+   * client info of the MCP session, attached by the server
+   *
+   * @generated from field: agentassistproto.McpClientInfoData McpClientInfo = 8;
+   */
+  McpClientInfo?: McpClientInfoData;
+
+  /**
+   * number of web users who must approve the work report before the agent gets the answer,
+   * 0 or 1 lets the first reply decide unless Approvers are named
+   *
+   * @generated from field: int32 RequiredApprovals = 9;
+   */
+  RequiredApprovals: number;
+
+  /**
+   * nicknames of the web users who must all approve the work report
+   *
+   * @generated from field: repeated string Approvers = 10;
+   */
+  Approvers: string[];
 };
 
 /**
@@ -467,6 +510,13 @@ export type WorkReportResponse = Message<"agentassistproto.WorkReportResponse">
    * @generated from field: repeated agentassistproto.McpResultContent contents = 4;
    */
   contents: McpResultContent[];
+
+  /**
+   * decision of the web user, unset for plain confirmations which count as approvals
+   *
+   * @generated from field: agentassistproto.WorkReportDecision Decision = 5;
+   */
+  Decision?: WorkReportDecision;
 };
 
 /**
@@ -476,6 +526,62 @@ export type WorkReportResponse = Message<"agentassistproto.WorkReportResponse">
 export const WorkReportResponseSchema: GenMessage<WorkReportResponse> = /*@__PURE__*/
   messageDesc(file_agentassist, 11);
 
+/**
+ * WorkReportComment is a comment of a web user on one item of a work report
+ *
+ * @generated from message agentassistproto.WorkReportComment
+ */
+export type WorkReportComment = Message<"agentassistproto.WorkReportComment"> & {
+  /**
+   * what the comment is about, e.g. a file, a step or a line of the summary
+   *
+   * @generated from field: string Item = 1;
+   */
+  Item: string;
+
+  /**
+   * the comment
+   *
+   * @generated from field: string Comment = 2;
+   */
+  Comment: string;
+};
+
+/**
+ * Describes the message agentassistproto.WorkReportComment.
+ * Use `create(WorkReportCommentSchema)` to create a new message.
+ */
+export const WorkReportCommentSchema: GenMessage<WorkReportComment> = /*@__PURE__*/
+  messageDesc(file_agentassist, 12);
+
+/**
+ * WorkReportDecision is the typed decision of a web user on a work report
+ *
+ * @generated from message agentassistproto.WorkReportDecision
+ */
+export type WorkReportDecision = Message<"agentassistproto.WorkReportDecision"> & {
+  /**
+   * approved, rejected or changes_requested
+   *
+   * @generated from field: string Decision = 1;
+   */
+  Decision: string;
+
+  /**
+   * optional comments on items of the work report
+   *
+   * @generated from field: repeated agentassistproto.WorkReportComment Comments = 2;
+   */
+  Comments: WorkReportComment[];
+};
+
+/**
+ * Describes the message agentassistproto.WorkReportDecision.
+ * Use `create(WorkReportDecisionSchema)` to create a new message.
+ */
+export const WorkReportDecisionSchema: GenMessage<WorkReportDecision> = /*@__PURE__*/
+  messageDesc(file_agentassist, 13);
+
 /**
  * @generated from message agentassistproto.McpClientInfoData
  */
@@ -514,7 +620,7 @@ export type McpClientInfoData = Message<"agentassistproto.McpClientInfoData"> &
  * Use `create(McpClientInfoDataSchema)` to create a new message.
  */
 export const McpClientInfoDataSchema: GenMessage<McpClientInfoData> = /*@__PURE__*/
-  messageDesc(file_agentassist, 12);
+  messageDesc(file_agentassist, 14);
 
 /**
  * @generated from message agentassistproto.McpClientInfoRequest
@@ -547,6 +653,13 @@ export type McpClientInfoRequest = Message<"agentassistproto.McpClientInfoReques
    * @generated from field: int64 Timestamp = 4;
    */
   Timestamp: bigint;
+
+  /**
+   * MCP session the client info belongs to, requests of the session refer to it with McpSessionID
+   *
+   * @generated from field: string McpSessionID = 5;
+   */
+  McpSessionID: string;
 };
 
 /**
@@ -554,7 +667,7 @@ export type McpClientInfoRequest = Message<"agentassistproto.McpClientInfoReques
  * Use `create(McpClientInfoRequestSchema)` to create a new message.
  */
 export const McpClientInfoRequestSchema: GenMessage<McpClientInfoRequest> = /*@__PURE__*/
-  messageDesc(file_agentassist, 13);
+  messageDesc(file_agentassist, 15);
 
 /**
  * @generated from message agentassistproto.McpClientInfoResponse
@@ -571,271 +684,627 @@ export type McpClientInfoResponse = Message<"agentassistproto.McpClientInfoRespo
  * Use `create(McpClientInfoResponseSchema)` to create a new message.
  */
 export const McpClientInfoResponseSchema: GenMessage<McpClientInfoResponse> = /*@__PURE__*/
-  messageDesc(file_agentassist, 14);
-
-/**
- * @generated from message agentassistproto.CheckMessageValidityRequest
- */
-export type CheckMessageValidityRequest = Message<"agentassistproto.CheckMessageValidityRequest"> & {
-  /**
-   * list of request IDs to check
-   *
-   * @generated from field: repeated string request_ids = 1;
-   */
-  requestIds: string[];
-};
-
-/**
- * Describes the message agentassistproto.CheckMessageValidityRequest.
- * Use `create(CheckMessageValidityRequestSchema)` to create a new message.
- */
-export const CheckMessageValidityRequestSchema: GenMessage<CheckMessageValidityRequest> = /*@__PURE__*/
-  messageDesc(file_agentassist, 15);
+  messageDesc(file_agentassist, 16);
 
 /**
- * @generated from message agentassistproto.CheckMessageValidityResponse
+ * SubmitRequestRequest submits an ask_question or work_report request without waiting for its result.
+ * Submitting a request ID that the server already knows re-attaches to the existing request.
+ *
+ * @generated from message agentassistproto.SubmitRequestRequest
  */
-export type CheckMessageValidityResponse = Message<"agentassistproto.CheckMessageValidityResponse"> & {
+export type SubmitRequestRequest = Message<"agentassistproto.SubmitRequestRequest"> & {
   /**
-   * map of request ID to validity status
+   * ask question request (exactly one of the requests must be set)
    *
-   * @generated from field: map<string, bool> validity = 1;
+   * @generated from field: agentassistproto.AskQuestionRequest AskQuestionRequest = 1;
    */
-  validity: { [key: string]: boolean };
-};
-
-/**
- * Describes the message agentassistproto.CheckMessageValidityResponse.
- * Use `create(CheckMessageValidityResponseSchema)` to create a new message.
- */
-export const CheckMessageValidityResponseSchema: GenMessage<CheckMessageValidityResponse> = /*@__PURE__*/
-  messageDesc(file_agentassist, 16);
+  AskQuestionRequest?: AskQuestionRequest;
 
-/**
- * GetPendingMessagesRequest represents a request to get all pending messages for a user
- *
- * @generated from message agentassistproto.GetPendingMessagesRequest
- */
-export type GetPendingMessagesRequest = Message<"agentassistproto.GetPendingMessagesRequest"> & {
   /**
-   * user token to filter messages
+   * work report request
    *
-   * @generated from field: string user_token = 1;
+   * @generated from field: agentassistproto.WorkReportRequest WorkReportRequest = 2;
    */
-  userToken: string;
+  WorkReportRequest?: WorkReportRequest;
 };
 
 /**
- * Describes the message agentassistproto.GetPendingMessagesRequest.
- * Use `create(GetPendingMessagesRequestSchema)` to create a new message.
+ * Describes the message agentassistproto.SubmitRequestRequest.
+ * Use `create(SubmitRequestRequestSchema)` to create a new message.
  */
-export const GetPendingMessagesRequestSchema: GenMessage<GetPendingMessagesRequest> = /*@__PURE__*/
+export const SubmitRequestRequestSchema: GenMessage<SubmitRequestRequest> = /*@__PURE__*/
   messageDesc(file_agentassist, 17);
 
 /**
- * PendingMessage represents a single pending message
- *
- * @generated from message agentassistproto.PendingMessage
+ * @generated from message agentassistproto.SubmitRequestResponse
  */
-export type PendingMessage = Message<"agentassistproto.PendingMessage"> & {
+export type SubmitRequestResponse = Message<"agentassistproto.SubmitRequestResponse"> & {
   /**
-   * message type: "AskQuestion" or "WorkReport"
+   * request id
    *
-   * @generated from field: string message_type = 1;
+   * @generated from field: string ID = 1;
    */
-  messageType: string;
+  ID: string;
 
   /**
-   * ask question request (if message_type is "AskQuestion")
+   * false if the request was rejected, see Meta for details
    *
-   * @generated from field: agentassistproto.AskQuestionRequest ask_question_request = 2;
+   * @generated from field: bool Success = 2;
    */
-  askQuestionRequest?: AskQuestionRequest;
+  Success: boolean;
 
   /**
-   * work report request (if message_type is "WorkReport")
+   * true if the request was already known to the server
    *
-   * @generated from field: agentassistproto.WorkReportRequest work_report_request = 3;
+   * @generated from field: bool Resumed = 3;
    */
-  workReportRequest?: WorkReportRequest;
+  Resumed: boolean;
 
   /**
-   * timestamp when the message was created
+   * time after which the request times out (unix milliseconds)
    *
-   * @generated from field: int64 created_at = 4;
+   * @generated from field: int64 Deadline = 4;
    */
-  createdAt: bigint;
+  Deadline: bigint;
 
   /**
-   * timeout in seconds
-   *
-   * @generated from field: int32 timeout = 5;
+   * @generated from field: map<string, string> Meta = 5;
    */
-  timeout: number;
+  Meta: { [key: string]: string };
 };
 
 /**
- * Describes the message agentassistproto.PendingMessage.
- * Use `create(PendingMessageSchema)` to create a new message.
+ * Describes the message agentassistproto.SubmitRequestResponse.
+ * Use `create(SubmitRequestResponseSchema)` to create a new message.
  */
-export const PendingMessageSchema: GenMessage<PendingMessage> = /*@__PURE__*/
+export const SubmitRequestResponseSchema: GenMessage<SubmitRequestResponse> = /*@__PURE__*/
   messageDesc(file_agentassist, 18);
 
 /**
- * GetPendingMessagesResponse represents the response containing all pending messages
+ * AwaitResultRequest waits for the result of a submitted request
  *
- * @generated from message agentassistproto.GetPendingMessagesResponse
+ * @generated from message agentassistproto.AwaitResultRequest
  */
-export type GetPendingMessagesResponse = Message<"agentassistproto.GetPendingMessagesResponse"> & {
+export type AwaitResultRequest = Message<"agentassistproto.AwaitResultRequest"> & {
   /**
-   * list of pending messages
+   * request id
    *
-   * @generated from field: repeated agentassistproto.PendingMessage pending_messages = 1;
+   * @generated from field: string ID = 1;
    */
-  pendingMessages: PendingMessage[];
+  ID: string;
 
   /**
-   * total count of pending messages
+   * user token
    *
-   * @generated from field: int32 total_count = 2;
+   * @generated from field: string UserToken = 2;
    */
-  totalCount: number;
+  UserToken: string;
+
+  /**
+   * how long to wait for the result in seconds before returning Done=false, default is 30s
+   *
+   * @generated from field: int32 WaitSeconds = 3;
+   */
+  WaitSeconds: number;
 };
 
 /**
- * Describes the message agentassistproto.GetPendingMessagesResponse.
- * Use `create(GetPendingMessagesResponseSchema)` to create a new message.
+ * Describes the message agentassistproto.AwaitResultRequest.
+ * Use `create(AwaitResultRequestSchema)` to create a new message.
  */
-export const GetPendingMessagesResponseSchema: GenMessage<GetPendingMessagesResponse> = /*@__PURE__*/
+export const AwaitResultRequestSchema: GenMessage<AwaitResultRequest> = /*@__PURE__*/
   messageDesc(file_agentassist, 19);
 
 /**
- * RequestCancelledNotification represents a notification that a request has been cancelled
- *
- * @generated from message agentassistproto.RequestCancelledNotification
+ * @generated from message agentassistproto.AwaitResultResponse
  */
-export type RequestCancelledNotification = Message<"agentassistproto.RequestCancelledNotification"> & {
+export type AwaitResultResponse = Message<"agentassistproto.AwaitResultResponse"> & {
   /**
-   * request id that was cancelled
+   * request id
    *
-   * @generated from field: string request_id = 1;
+   * @generated from field: string ID = 1;
    */
-  requestId: string;
+  ID: string;
 
   /**
-   * reason for cancellation
+   * true if the request has completed, the result is in AskQuestionResponse or WorkReportResponse
    *
-   * @generated from field: string reason = 2;
+   * @generated from field: bool Done = 2;
    */
-  reason: string;
+  Done: boolean;
 
   /**
-   * message type: "AskQuestion" or "WorkReport"
+   * true if the server does not know the request (e.g. it restarted without a request store),
+   * the caller should submit it again
    *
-   * @generated from field: string message_type = 3;
+   * @generated from field: bool NotFound = 3;
    */
-  messageType: string;
+  NotFound: boolean;
+
+  /**
+   * result of an ask question request
+   *
+   * @generated from field: agentassistproto.AskQuestionResponse AskQuestionResponse = 4;
+   */
+  AskQuestionResponse?: AskQuestionResponse;
+
+  /**
+   * result of a work report request
+   *
+   * @generated from field: agentassistproto.WorkReportResponse WorkReportResponse = 5;
+   */
+  WorkReportResponse?: WorkReportResponse;
+
+  /**
+   * time after which the pending request times out (unix milliseconds), it moves when the
+   * server gives the request more time
+   *
+   * @generated from field: int64 Deadline = 6;
+   */
+  Deadline: bigint;
 };
 
 /**
- * Describes the message agentassistproto.RequestCancelledNotification.
- * Use `create(RequestCancelledNotificationSchema)` to create a new message.
+ * Describes the message agentassistproto.AwaitResultResponse.
+ * Use `create(AwaitResultResponseSchema)` to create a new message.
  */
-export const RequestCancelledNotificationSchema: GenMessage<RequestCancelledNotification> = /*@__PURE__*/
+export const AwaitResultResponseSchema: GenMessage<AwaitResultResponse> = /*@__PURE__*/
   messageDesc(file_agentassist, 20);
 
 /**
- * OnlineUser represents an online user with the same token
+ * CancelRequestRequest withdraws a submitted request the agent no longer waits for
  *
- * @generated from message agentassistproto.OnlineUser
+ * @generated from message agentassistproto.CancelRequestRequest
  */
-export type OnlineUser = Message<"agentassistproto.OnlineUser"> & {
+export type CancelRequestRequest = Message<"agentassistproto.CancelRequestRequest"> & {
   /**
-   * client id
+   * request id
    *
-   * @generated from field: string client_id = 1;
+   * @generated from field: string ID = 1;
    */
-  clientId: string;
+  ID: string;
 
   /**
-   * user nickname
+   * user token
    *
-   * @generated from field: string nickname = 2;
+   * @generated from field: string UserToken = 2;
    */
-  nickname: string;
+  UserToken: string;
 
   /**
-   * connection timestamp
+   * reason shown to the web users, default is "agent cancelled"
    *
-   * @generated from field: int64 connected_at = 3;
+   * @generated from field: string Reason = 3;
    */
-  connectedAt: bigint;
+  Reason: string;
 };
 
 /**
- * Describes the message agentassistproto.OnlineUser.
- * Use `create(OnlineUserSchema)` to create a new message.
+ * Describes the message agentassistproto.CancelRequestRequest.
+ * Use `create(CancelRequestRequestSchema)` to create a new message.
  */
-export const OnlineUserSchema: GenMessage<OnlineUser> = /*@__PURE__*/
+export const CancelRequestRequestSchema: GenMessage<CancelRequestRequest> = /*@__PURE__*/
   messageDesc(file_agentassist, 21);
 
 /**
- * GetOnlineUsersRequest represents a request to get online users with the same token
- *
- * @generated from message agentassistproto.GetOnlineUsersRequest
+ * @generated from message agentassistproto.CancelRequestResponse
  */
-export type GetOnlineUsersRequest = Message<"agentassistproto.GetOnlineUsersRequest"> & {
+export type CancelRequestResponse = Message<"agentassistproto.CancelRequestResponse"> & {
   /**
-   * user token to filter users
+   * false if the server does not know the request or it has already completed
    *
-   * @generated from field: string user_token = 1;
+   * @generated from field: bool Success = 1;
    */
-  userToken: string;
+  Success: boolean;
 };
 
 /**
- * Describes the message agentassistproto.GetOnlineUsersRequest.
- * Use `create(GetOnlineUsersRequestSchema)` to create a new message.
+ * Describes the message agentassistproto.CancelRequestResponse.
+ * Use `create(CancelRequestResponseSchema)` to create a new message.
  */
-export const GetOnlineUsersRequestSchema: GenMessage<GetOnlineUsersRequest> = /*@__PURE__*/
+export const CancelRequestResponseSchema: GenMessage<CancelRequestResponse> = /*@__PURE__*/
   messageDesc(file_agentassist, 22);
 
 /**
- * GetOnlineUsersResponse represents the response containing online users
+ * WatchRequestRequest subscribes to the lifecycle events of a submitted request
  *
- * @generated from message agentassistproto.GetOnlineUsersResponse
+ * @generated from message agentassistproto.WatchRequestRequest
  */
-export type GetOnlineUsersResponse = Message<"agentassistproto.GetOnlineUsersResponse"> & {
+export type WatchRequestRequest = Message<"agentassistproto.WatchRequestRequest"> & {
   /**
-   * list of online users
+   * request id
    *
-   * @generated from field: repeated agentassistproto.OnlineUser online_users = 1;
+   * @generated from field: string ID = 1;
    */
-  onlineUsers: OnlineUser[];
+  ID: string;
 
   /**
-   * total count of online users
+   * user token
    *
-   * @generated from field: int32 total_count = 2;
+   * @generated from field: string UserToken = 2;
    */
-  totalCount: number;
+  UserToken: string;
 };
 
 /**
- * Describes the message agentassistproto.GetOnlineUsersResponse.
- * Use `create(GetOnlineUsersResponseSchema)` to create a new message.
+ * Describes the message agentassistproto.WatchRequestRequest.
+ * Use `create(WatchRequestRequestSchema)` to create a new message.
  */
-export const GetOnlineUsersResponseSchema: GenMessage<GetOnlineUsersResponse> = /*@__PURE__*/
+export const WatchRequestRequestSchema: GenMessage<WatchRequestRequest> = /*@__PURE__*/
   messageDesc(file_agentassist, 23);
 
 /**
- * ChatMessage represents a chat message between users
+ * RequestEvent reports a step in the lifecycle of a request
  *
- * @generated from message agentassistproto.ChatMessage
+ * @generated from message agentassistproto.RequestEvent
  */
-export type ChatMessage = Message<"agentassistproto.ChatMessage"> & {
+export type RequestEvent = Message<"agentassistproto.RequestEvent"> & {
   /**
-   * message id
+   * request id
+   *
+   * @generated from field: string ID = 1;
+   */
+  ID: string;
+
+  /**
+   * event type:
+   * queued: the server accepted the request, it stays queued while no web client is online
+   * broadcast: the request was delivered to ClientCount web clients
+   * viewed: the web user Nickname viewed the request
+   * reply_draft: the web user Nickname is typing a reply
+   * claimed: the web user Nickname claimed the request to answer it
+   * approved: the web user Nickname approved a work report that needs more approvals
+   * escalated: nobody replied in time, a timeout policy of the server gave the request more
+   * time and delivered it to more web users
+   * extended: the web user Nickname gave the request more time, Message tells the new deadline
+   * reminded: nobody answered for a while, a reminder was sent to the web users and the
+   * external notifier of the reminder, if any
+   * interim_reply: the web user Nickname sent an interim reply, e.g. "looking into it", the
+   * request stays pending; Message is "<Nickname>: <text>"
+   * no_clients: no web client was online and the server fails such requests (final)
+   * answered: the web user Nickname replied (final)
+   * cancelled: the request was cancelled, Message is the reason (final)
+   * timed_out: nobody replied before the timeout of the request, Message tells whether a
+   * timeout policy of the server answered it (final)
+   *
+   * @generated from field: string Type = 2;
+   */
+  Type: string;
+
+  /**
+   * number of web clients the request was delivered to
+   *
+   * @generated from field: int32 ClientCount = 3;
+   */
+  ClientCount: number;
+
+  /**
+   * nickname of the web user the event is about
+   *
+   * @generated from field: string Nickname = 4;
+   */
+  Nickname: string;
+
+  /**
+   * human readable description of the event, e.g. "viewed by alice"
+   *
+   * @generated from field: string Message = 5;
+   */
+  Message: string;
+
+  /**
+   * timestamp of the event (UTC)
+   *
+   * @generated from field: int64 Timestamp = 6;
+   */
+  Timestamp: bigint;
+
+  /**
+   * true for the event that completed the request, the last one of the stream
+   *
+   * @generated from field: bool Final = 7;
+   */
+  Final: boolean;
+};
+
+/**
+ * Describes the message agentassistproto.RequestEvent.
+ * Use `create(RequestEventSchema)` to create a new message.
+ */
+export const RequestEventSchema: GenMessage<RequestEvent> = /*@__PURE__*/
+  messageDesc(file_agentassist, 24);
+
+/**
+ * @generated from message agentassistproto.CheckMessageValidityRequest
+ */
+export type CheckMessageValidityRequest = Message<"agentassistproto.CheckMessageValidityRequest"> & {
+  /**
+   * list of request IDs to check
+   *
+   * @generated from field: repeated string request_ids = 1;
+   */
+  requestIds: string[];
+};
+
+/**
+ * Describes the message agentassistproto.CheckMessageValidityRequest.
+ * Use `create(CheckMessageValidityRequestSchema)` to create a new message.
+ */
+export const CheckMessageValidityRequestSchema: GenMessage<CheckMessageValidityRequest> = /*@__PURE__*/
+  messageDesc(file_agentassist, 25);
+
+/**
+ * @generated from message agentassistproto.CheckMessageValidityResponse
+ */
+export type CheckMessageValidityResponse = Message<"agentassistproto.CheckMessageValidityResponse"> & {
+  /**
+   * map of request ID to validity status
+   *
+   * @generated from field: map<string, bool> validity = 1;
+   */
+  validity: { [key: string]: boolean };
+};
+
+/**
+ * Describes the message agentassistproto.CheckMessageValidityResponse.
+ * Use `create(CheckMessageValidityResponseSchema)` to create a new message.
+ */
+export const CheckMessageValidityResponseSchema: GenMessage<CheckMessageValidityResponse> = /*@__PURE__*/
+  messageDesc(file_agentassist, 26);
+
+/**
+ * GetPendingMessagesRequest represents a request to get all pending messages for a user
+ *
+ * @generated from message agentassistproto.GetPendingMessagesRequest
+ */
+export type GetPendingMessagesRequest = Message<"agentassistproto.GetPendingMessagesRequest"> & {
+  /**
+   * user token to filter messages
+   *
+   * @generated from field: string user_token = 1;
+   */
+  userToken: string;
+};
+
+/**
+ * Describes the message agentassistproto.GetPendingMessagesRequest.
+ * Use `create(GetPendingMessagesRequestSchema)` to create a new message.
+ */
+export const GetPendingMessagesRequestSchema: GenMessage<GetPendingMessagesRequest> = /*@__PURE__*/
+  messageDesc(file_agentassist, 27);
+
+/**
+ * PendingMessage represents a single pending message
+ *
+ * @generated from message agentassistproto.PendingMessage
+ */
+export type PendingMessage = Message<"agentassistproto.PendingMessage"> & {
+  /**
+   * message type: "AskQuestion" or "WorkReport"
+   *
+   * @generated from field: string message_type = 1;
+   */
+  messageType: string;
+
+  /**
+   * ask question request (if message_type is "AskQuestion")
+   *
+   * @generated from field: agentassistproto.AskQuestionRequest ask_question_request = 2;
+   */
+  askQuestionRequest?: AskQuestionRequest;
+
+  /**
+   * work report request (if message_type is "WorkReport")
+   *
+   * @generated from field: agentassistproto.WorkReportRequest work_report_request = 3;
+   */
+  workReportRequest?: WorkReportRequest;
+
+  /**
+   * time the server accepted the request, unix milliseconds
+   *
+   * @generated from field: int64 created_at = 4;
+   */
+  createdAt: bigint;
+
+  /**
+   * timeout in seconds
+   *
+   * @generated from field: int32 timeout = 5;
+   */
+  timeout: number;
+
+  /**
+   * time the request times out, unix milliseconds
+   *
+   * @generated from field: int64 deadline = 6;
+   */
+  deadline: bigint;
+
+  /**
+   * number of web clients the request was delivered to
+   *
+   * @generated from field: int32 delivery_count = 7;
+   */
+  deliveryCount: number;
+
+  /**
+   * time a web user first viewed the request, unix milliseconds, 0 if nobody has
+   *
+   * @generated from field: int64 first_viewed_at = 8;
+   */
+  firstViewedAt: bigint;
+
+  /**
+   * approvals so far of a work report that needs several of them
+   *
+   * @generated from field: agentassistproto.ApprovalProgress approval_progress = 9;
+   */
+  approvalProgress?: ApprovalProgress;
+};
+
+/**
+ * Describes the message agentassistproto.PendingMessage.
+ * Use `create(PendingMessageSchema)` to create a new message.
+ */
+export const PendingMessageSchema: GenMessage<PendingMessage> = /*@__PURE__*/
+  messageDesc(file_agentassist, 28);
+
+/**
+ * GetPendingMessagesResponse represents the response containing all pending messages
+ *
+ * @generated from message agentassistproto.GetPendingMessagesResponse
+ */
+export type GetPendingMessagesResponse = Message<"agentassistproto.GetPendingMessagesResponse"> & {
+  /**
+   * list of pending messages
+   *
+   * @generated from field: repeated agentassistproto.PendingMessage pending_messages = 1;
+   */
+  pendingMessages: PendingMessage[];
+
+  /**
+   * total count of pending messages
+   *
+   * @generated from field: int32 total_count = 2;
+   */
+  totalCount: number;
+};
+
+/**
+ * Describes the message agentassistproto.GetPendingMessagesResponse.
+ * Use `create(GetPendingMessagesResponseSchema)` to create a new message.
+ */
+export const GetPendingMessagesResponseSchema: GenMessage<GetPendingMessagesResponse> = /*@__PURE__*/
+  messageDesc(file_agentassist, 29);
+
+/**
+ * RequestCancelledNotification represents a notification that a request has been cancelled
+ *
+ * @generated from message agentassistproto.RequestCancelledNotification
+ */
+export type RequestCancelledNotification = Message<"agentassistproto.RequestCancelledNotification"> & {
+  /**
+   * request id that was cancelled
+   *
+   * @generated from field: string request_id = 1;
+   */
+  requestId: string;
+
+  /**
+   * reason for cancellation
+   *
+   * @generated from field: string reason = 2;
+   */
+  reason: string;
+
+  /**
+   * message type: "AskQuestion" or "WorkReport"
+   *
+   * @generated from field: string message_type = 3;
+   */
+  messageType: string;
+};
+
+/**
+ * Describes the message agentassistproto.RequestCancelledNotification.
+ * Use `create(RequestCancelledNotificationSchema)` to create a new message.
+ */
+export const RequestCancelledNotificationSchema: GenMessage<RequestCancelledNotification> = /*@__PURE__*/
+  messageDesc(file_agentassist, 30);
+
+/**
+ * OnlineUser represents an online user with the same token
+ *
+ * @generated from message agentassistproto.OnlineUser
+ */
+export type OnlineUser = Message<"agentassistproto.OnlineUser"> & {
+  /**
+   * client id
+   *
+   * @generated from field: string client_id = 1;
+   */
+  clientId: string;
+
+  /**
+   * user nickname
+   *
+   * @generated from field: string nickname = 2;
+   */
+  nickname: string;
+
+  /**
+   * connection timestamp
+   *
+   * @generated from field: int64 connected_at = 3;
+   */
+  connectedAt: bigint;
+};
+
+/**
+ * Describes the message agentassistproto.OnlineUser.
+ * Use `create(OnlineUserSchema)` to create a new message.
+ */
+export const OnlineUserSchema: GenMessage<OnlineUser> = /*@__PURE__*/
+  messageDesc(file_agentassist, 31);
+
+/**
+ * GetOnlineUsersRequest represents a request to get online users with the same token
+ *
+ * @generated from message agentassistproto.GetOnlineUsersRequest
+ */
+export type GetOnlineUsersRequest = Message<"agentassistproto.GetOnlineUsersRequest"> & {
+  /**
+   * user token to filter users
+   *
+   * @generated from field: string user_token = 1;
+   */
+  userToken: string;
+};
+
+/**
+ * Describes the message agentassistproto.GetOnlineUsersRequest.
+ * Use `create(GetOnlineUsersRequestSchema)` to create a new message.
+ */
+export const GetOnlineUsersRequestSchema: GenMessage<GetOnlineUsersRequest> = /*@__PURE__*/
+  messageDesc(file_agentassist, 32);
+
+/**
+ * GetOnlineUsersResponse represents the response containing online users
+ *
+ * @generated from message agentassistproto.GetOnlineUsersResponse
+ */
+export type GetOnlineUsersResponse = Message<"agentassistproto.GetOnlineUsersResponse"> & {
+  /**
+   * list of online users
+   *
+   * @generated from field: repeated agentassistproto.OnlineUser online_users = 1;
+   */
+  onlineUsers: OnlineUser[];
+
+  /**
+   * total count of online users
+   *
+   * @generated from field: int32 total_count = 2;
+   */
+  totalCount: number;
+};
+
+/**
+ * Describes the message agentassistproto.GetOnlineUsersResponse.
+ * Use `create(GetOnlineUsersResponseSchema)` to create a new message.
+ */
+export const GetOnlineUsersResponseSchema: GenMessage<GetOnlineUsersResponse> = /*@__PURE__*/
+  messageDesc(file_agentassist, 33);
+
+/**
+ * ChatMessage represents a chat message between users
+ *
+ * @generated from message agentassistproto.ChatMessage
+ */
+export type ChatMessage = Message<"agentassistproto.ChatMessage"> & {
+  /**
+   * message id
    *
    * @generated from field: string message_id = 1;
    */
@@ -889,7 +1358,7 @@ export type ChatMessage = Message<"agentassistproto.ChatMessage"> & {
  * Use `create(ChatMessageSchema)` to create a new message.
  */
 export const ChatMessageSchema: GenMessage<ChatMessage> = /*@__PURE__*/
-  messageDesc(file_agentassist, 24);
+  messageDesc(file_agentassist, 34);
 
 /**
  * SendChatMessageRequest represents a request to send a chat message
@@ -917,7 +1386,7 @@ export type SendChatMessageRequest = Message<"agentassistproto.SendChatMessageRe
  * Use `create(SendChatMessageRequestSchema)` to create a new message.
  */
 export const SendChatMessageRequestSchema: GenMessage<SendChatMessageRequest> = /*@__PURE__*/
-  messageDesc(file_agentassist, 25);
+  messageDesc(file_agentassist, 35);
 
 /**
  * ChatMessageNotification represents a notification of a new chat message
@@ -938,7 +1407,7 @@ export type ChatMessageNotification = Message<"agentassistproto.ChatMessageNotif
  * Use `create(ChatMessageNotificationSchema)` to create a new message.
  */
 export const ChatMessageNotificationSchema: GenMessage<ChatMessageNotification> = /*@__PURE__*/
-  messageDesc(file_agentassist, 26);
+  messageDesc(file_agentassist, 36);
 
 /**
  * UserLoginResponse represents the response to a user login
@@ -973,7 +1442,7 @@ export type UserLoginResponse = Message<"agentassistproto.UserLoginResponse"> &
  * Use `create(UserLoginResponseSchema)` to create a new message.
  */
 export const UserLoginResponseSchema: GenMessage<UserLoginResponse> = /*@__PURE__*/
-  messageDesc(file_agentassist, 27);
+  messageDesc(file_agentassist, 37);
 
 /**
  * UserConnectionStatusNotification represents a notification when a user connects or disconnects
@@ -1008,7 +1477,336 @@ export type UserConnectionStatusNotification = Message<"agentassistproto.UserCon
  * Use `create(UserConnectionStatusNotificationSchema)` to create a new message.
  */
 export const UserConnectionStatusNotificationSchema: GenMessage<UserConnectionStatusNotification> = /*@__PURE__*/
-  messageDesc(file_agentassist, 28);
+  messageDesc(file_agentassist, 38);
+
+/**
+ * RequestClaim is the claim of a web user on a request they are answering. Only the user
+ * holding the claim can reply until it is released, on disconnect or after it was idle.
+ *
+ * @generated from message agentassistproto.RequestClaim
+ */
+export type RequestClaim = Message<"agentassistproto.RequestClaim"> & {
+  /**
+   * request id
+   *
+   * @generated from field: string request_id = 1;
+   */
+  requestId: string;
+
+  /**
+   * client id of the user holding the claim, empty if the request is not claimed
+   *
+   * @generated from field: string client_id = 2;
+   */
+  clientId: string;
+
+  /**
+   * nickname of the user holding the claim or who answered the request
+   *
+   * @generated from field: string nickname = 3;
+   */
+  nickname: string;
+
+  /**
+   * time the claim is released unless the user keeps typing, unix milliseconds
+   *
+   * @generated from field: int64 expires_at = 4;
+   */
+  expiresAt: bigint;
+
+  /**
+   * true if the claim was granted (ClaimRequest only)
+   *
+   * @generated from field: bool success = 5;
+   */
+  success: boolean;
+
+  /**
+   * why a claim or reply was refused, e.g. "already answered by alice"
+   *
+   * @generated from field: string error_message = 6;
+   */
+  errorMessage: string;
+};
+
+/**
+ * Describes the message agentassistproto.RequestClaim.
+ * Use `create(RequestClaimSchema)` to create a new message.
+ */
+export const RequestClaimSchema: GenMessage<RequestClaim> = /*@__PURE__*/
+  messageDesc(file_agentassist, 39);
+
+/**
+ * ReplyAck tells the user who sent a reply whether it reached the waiting agent
+ *
+ * @generated from message agentassistproto.ReplyAck
+ */
+export type ReplyAck = Message<"agentassistproto.ReplyAck"> & {
+  /**
+   * request id
+   *
+   * @generated from field: string request_id = 1;
+   */
+  requestId: string;
+
+  /**
+   * delivered, recorded (an approval that does not complete the quorum yet), unknown,
+   * expired, already_answered, invalid (e.g. an unknown decision, see message) or failed
+   * (the server was too busy to take the reply, it can be sent again)
+   *
+   * @generated from field: string status = 2;
+   */
+  status: string;
+
+  /**
+   * time the reply was delivered, unix milliseconds; for already_answered the time the
+   * earlier reply was, 0 if it is still being delivered
+   *
+   * @generated from field: int64 delivered_at = 3;
+   */
+  deliveredAt: bigint;
+
+  /**
+   * nickname of the user who answered, for already_answered
+   *
+   * @generated from field: string replied_by = 4;
+   */
+  repliedBy: string;
+
+  /**
+   * how an expired request ended, e.g. "Request timed out"
+   *
+   * @generated from field: string message = 5;
+   */
+  message: string;
+};
+
+/**
+ * Describes the message agentassistproto.ReplyAck.
+ * Use `create(ReplyAckSchema)` to create a new message.
+ */
+export const ReplyAckSchema: GenMessage<ReplyAck> = /*@__PURE__*/
+  messageDesc(file_agentassist, 40);
+
+/**
+ * ApprovalProgress is the state of a work report that needs several approvals. A reply with
+ * a decision other than approved resolves the work report right away.
+ *
+ * @generated from message agentassistproto.ApprovalProgress
+ */
+export type ApprovalProgress = Message<"agentassistproto.ApprovalProgress"> & {
+  /**
+   * request id
+   *
+   * @generated from field: string request_id = 1;
+   */
+  requestId: string;
+
+  /**
+   * number of approvals required
+   *
+   * @generated from field: int32 required_approvals = 2;
+   */
+  requiredApprovals: number;
+
+  /**
+   * nicknames of the users who must all approve
+   *
+   * @generated from field: repeated string approvers = 3;
+   */
+  approvers: string[];
+
+  /**
+   * nicknames of the users who approved so far, in order
+   *
+   * @generated from field: repeated string approved_by = 4;
+   */
+  approvedBy: string[];
+
+  /**
+   * named approvers who did not approve yet
+   *
+   * @generated from field: repeated string pending_approvers = 5;
+   */
+  pendingApprovers: string[];
+};
+
+/**
+ * Describes the message agentassistproto.ApprovalProgress.
+ * Use `create(ApprovalProgressSchema)` to create a new message.
+ */
+export const ApprovalProgressSchema: GenMessage<ApprovalProgress> = /*@__PURE__*/
+  messageDesc(file_agentassist, 41);
+
+/**
+ * DeadlineExtension asks for more time to answer a pending request, or tells its new deadline
+ *
+ * @generated from message agentassistproto.DeadlineExtension
+ */
+export type DeadlineExtension = Message<"agentassistproto.DeadlineExtension"> & {
+  /**
+   * request id
+   *
+   * @generated from field: string request_id = 1;
+   */
+  requestId: string;
+
+  /**
+   * how many seconds to push the deadline out by (ExtendDeadline requests), 0 for the server
+   * default
+   *
+   * @generated from field: int32 extend_seconds = 2;
+   */
+  extendSeconds: number;
+
+  /**
+   * deadline of the request, unix milliseconds
+   *
+   * @generated from field: int64 deadline = 3;
+   */
+  deadline: bigint;
+
+  /**
+   * nickname of the user who extended the deadline, empty if the server did
+   *
+   * @generated from field: string nickname = 4;
+   */
+  nickname: string;
+
+  /**
+   * true if the deadline was extended (ExtendDeadline responses)
+   *
+   * @generated from field: bool success = 5;
+   */
+  success: boolean;
+
+  /**
+   * why the deadline could not be extended, e.g. "already answered by alice"
+   *
+   * @generated from field: string error_message = 6;
+   */
+  errorMessage: string;
+};
+
+/**
+ * Describes the message agentassistproto.DeadlineExtension.
+ * Use `create(DeadlineExtensionSchema)` to create a new message.
+ */
+export const DeadlineExtensionSchema: GenMessage<DeadlineExtension> = /*@__PURE__*/
+  messageDesc(file_agentassist, 42);
+
+/**
+ * InterimReply tells the waiting agent something without answering the request, e.g. that
+ * the user is looking into it. The request stays pending until the final reply.
+ *
+ * @generated from message agentassistproto.InterimReply
+ */
+export type InterimReply = Message<"agentassistproto.InterimReply"> & {
+  /**
+   * request id
+   *
+   * @generated from field: string request_id = 1;
+   */
+  requestId: string;
+
+  /**
+   * text forwarded to the agent
+   *
+   * @generated from field: string text = 2;
+   */
+  text: string;
+
+  /**
+   * how many seconds to push the deadline out by, 0 to keep it
+   *
+   * @generated from field: int32 extend_seconds = 3;
+   */
+  extendSeconds: number;
+
+  /**
+   * nickname of the user who sent the interim reply
+   *
+   * @generated from field: string nickname = 4;
+   */
+  nickname: string;
+
+  /**
+   * deadline of the request after the interim reply, unix milliseconds
+   *
+   * @generated from field: int64 deadline = 5;
+   */
+  deadline: bigint;
+
+  /**
+   * true if the interim reply was forwarded (InterimReply responses)
+   *
+   * @generated from field: bool success = 6;
+   */
+  success: boolean;
+
+  /**
+   * why the interim reply was refused, e.g. "already answered by alice"
+   *
+   * @generated from field: string error_message = 7;
+   */
+  errorMessage: string;
+};
+
+/**
+ * Describes the message agentassistproto.InterimReply.
+ * Use `create(InterimReplySchema)` to create a new message.
+ */
+export const InterimReplySchema: GenMessage<InterimReply> = /*@__PURE__*/
+  messageDesc(file_agentassist, 43);
+
+/**
+ * Reminder reminds the web users of a request that nobody answered for a while
+ *
+ * @generated from message agentassistproto.Reminder
+ */
+export type Reminder = Message<"agentassistproto.Reminder"> & {
+  /**
+   * request id
+   *
+   * @generated from field: string request_id = 1;
+   */
+  requestId: string;
+
+  /**
+   * number of the reminder, 1 for the first
+   *
+   * @generated from field: int32 number = 2;
+   */
+  number: number;
+
+  /**
+   * how long the request has been waiting for an answer, in seconds
+   *
+   * @generated from field: int64 unanswered_seconds = 3;
+   */
+  unansweredSeconds: bigint;
+
+  /**
+   * nicknames the request was escalated to by this reminder
+   *
+   * @generated from field: repeated string escalated_to = 4;
+   */
+  escalatedTo: string[];
+
+  /**
+   * human readable description, e.g. "not answered for 5m0s"
+   *
+   * @generated from field: string message = 5;
+   */
+  message: string;
+};
+
+/**
+ * Describes the message agentassistproto.Reminder.
+ * Use `create(ReminderSchema)` to create a new message.
+ */
+export const ReminderSchema: GenMessage<Reminder> = /*@__PURE__*/
+  messageDesc(file_agentassist, 44);
 
 /**
  * @generated from message agentassistproto.WebsocketMessage
@@ -1029,6 +1827,24 @@ export type WebsocketMessage = Message<"agentassistproto.WebsocketMessage"> & {
    * GetOnlineUsers: get online users with the same token
    * SendChatMessage: send a chat message to another user
    * ChatMessageNotification: notification of a new chat message
+   * RequestViewed: user viewed a request, str param is the request id
+   * ReplyDraft: user is typing a reply to a request, str param is the request id
+   * ClaimRequest: user claims a request to answer it, str param is the request id;
+   *   the server answers with the outcome in RequestClaim
+   * ReleaseClaim: user gives up the claim on a request, str param is the request id
+   * RequestClaimed: notification that a request was claimed or released, in RequestClaim
+   * ReplyRejected: the reply of the user was refused because another user claimed the
+   *   request, RequestClaim tells who
+   * ReplyAck: outcome of an AskQuestionReply or WorkReportReply, in ReplyAck
+   * ApprovalProgress: an approval of a work report needing several approvals was recorded,
+   *   in ApprovalProgress
+   * ExtendDeadline: user asks for more time to answer a request, in DeadlineExtension; the
+   *   server answers with the outcome in DeadlineExtension
+   * DeadlineExtended: notification of the new deadline of a request, in DeadlineExtension
+   * InterimReply: user tells the agent something without answering a request, in
+   *   InterimReply; the server answers with the outcome, and notifies the other users of
+   *   the request with the same cmd
+   * Reminder: a request nobody answered for a while, in Reminder
    *
    * @generated from field: string Cmd = 1;
    */
@@ -1139,6 +1955,48 @@ export type WebsocketMessage = Message<"agentassistproto.WebsocketMessage"> & {
    */
   UserConnectionStatusNotification?: UserConnectionStatusNotification;
 
+  /**
+   * claim of a request, for ClaimRequest, RequestClaimed and ReplyRejected
+   *
+   * @generated from field: agentassistproto.RequestClaim RequestClaim = 25;
+   */
+  RequestClaim?: RequestClaim;
+
+  /**
+   * reply acknowledgement
+   *
+   * @generated from field: agentassistproto.ReplyAck ReplyAck = 26;
+   */
+  ReplyAck?: ReplyAck;
+
+  /**
+   * approval progress of a work report
+   *
+   * @generated from field: agentassistproto.ApprovalProgress ApprovalProgress = 27;
+   */
+  ApprovalProgress?: ApprovalProgress;
+
+  /**
+   * deadline extension, for ExtendDeadline and DeadlineExtended
+   *
+   * @generated from field: agentassistproto.DeadlineExtension DeadlineExtension = 28;
+   */
+  DeadlineExtension?: DeadlineExtension;
+
+  /**
+   * interim reply, for InterimReply
+   *
+   * @generated from field: agentassistproto.InterimReply InterimReply = 29;
+   */
+  InterimReply?: InterimReply;
+
+  /**
+   * reminder of an unanswered request
+   *
+   * @generated from field: agentassistproto.Reminder Reminder = 30;
+   */
+  Reminder?: Reminder;
+
   /**
    * str param
    *
@@ -1159,7 +2017,7 @@ export type WebsocketMessage = Message<"agentassistproto.WebsocketMessage"> & {
  * Use `create(WebsocketMessageSchema)` to create a new message.
  */
 export const WebsocketMessageSchema: GenMessage<WebsocketMessage> = /*@__PURE__*/
-  messageDesc(file_agentassist, 29);
+  messageDesc(file_agentassist, 45);
 
 /**
  * @generated from service agentassistproto.SrvAgentAssist
@@ -1189,6 +2047,38 @@ export const SrvAgentAssist: GenService<{
     input: typeof McpClientInfoRequestSchema;
     output: typeof McpClientInfoResponseSchema;
   },
+  /**
+   * @generated from rpc agentassistproto.SrvAgentAssist.SubmitRequest
+   */
+  submitRequest: {
+    methodKind: "unary";
+    input: typeof SubmitRequestRequestSchema;
+    output: typeof SubmitRequestResponseSchema;
+  },
+  /**
+   * @generated from rpc agentassistproto.SrvAgentAssist.AwaitResult
+   */
+  awaitResult: {
+    methodKind: "unary";
+    input: typeof AwaitResultRequestSchema;
+    output: typeof AwaitResultResponseSchema;
+  },
+  /**
+   * @generated from rpc agentassistproto.SrvAgentAssist.CancelRequest
+   */
+  cancelRequest: {
+    methodKind: "unary";
+    input: typeof CancelRequestRequestSchema;
+    output: typeof CancelRequestResponseSchema;
+  },
+  /**
+   * @generated from rpc agentassistproto.SrvAgentAssist.WatchRequest
+   */
+  watchRequest: {
+    methodKind: "server_streaming";
+    input: typeof WatchRequestRequestSchema;
+    output: typeof RequestEventSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_agentassist, 0);
 
@@ -1,5 +1,5 @@
 import type { WebsocketMessage, AskQuestionRequest, WorkReportRequest, AskQuestionResponse, WorkReportResponse } from '../proto/agentassist_pb';
-import { WebsocketMessageSchema } from '../proto/agentassist_pb';
+import { WebsocketMessageSchema, DeadlineExtensionSchema, InterimReplySchema } from '../proto/agentassist_pb';
 import { create,fromBinary, toBinary } from '@bufbuild/protobuf';
 import { WebSocketCommands } from '../types/websocket';
 import { APP_CONFIG } from '../config/app';
@@ -189,6 +189,46 @@ export class WebSocketService {
     this.sendMessage(message);
   }
 
+  sendRequestViewed(requestId: string): void {
+    const message = create(WebsocketMessageSchema, {
+      Cmd: WebSocketCommands.REQUEST_VIEWED,
+      StrParam: requestId
+    });
+    this.sendMessage(message);
+  }
+
+  sendReplyDraft(requestId: string): void {
+    const message = create(WebsocketMessageSchema, {
+      Cmd: WebSocketCommands.REPLY_DRAFT,
+      StrParam: requestId
+    });
+    this.sendMessage(message);
+  }
+
+  sendExtendDeadline(requestId: string, extendSeconds: number): void {
+    const message = create(WebsocketMessageSchema, {
+      Cmd: WebSocketCommands.EXTEND_DEADLINE,
+      DeadlineExtension: create(DeadlineExtensionSchema, { requestId, extendSeconds })
+    });
+    this.sendMessage(message);
+  }
+
+  sendInterimReply(requestId: string, text: string, extendSeconds: number): void {
+    const message = create(WebsocketMessageSchema, {
+      Cmd: WebSocketCommands.INTERIM_REPLY,
+      InterimReply: create(InterimReplySchema, { requestId, text, extendSeconds })
+    });
+    this.sendMessage(message);
+  }
+
+  sendClaimRequest(requestId: string): void {
+    const message = create(WebsocketMessageSchema, {
+      Cmd: WebSocketCommands.CLAIM_REQUEST,
+      StrParam: requestId
+    });
+    this.sendMessage(message);
+  }
+
   getOnlineUsers(): void {
     const message = create(WebsocketMessageSchema, {
       Cmd: WebSocketCommands.GET_ONLINE_USERS,
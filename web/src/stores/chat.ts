@@ -12,6 +12,7 @@ import type {
 import {
   AskQuestionResponseSchema,
   WorkReportResponseSchema,
+  WorkReportDecisionSchema,
   McpResultContentSchema,
   TextContentSchema,
   ChatMessageSchema
@@ -34,15 +35,42 @@ export interface ChatMessage {
   originalRequest?: AskQuestionRequest | WorkReportRequest;
   response?: AskQuestionResponse | WorkReportResponse;
   timeout: number | undefined;
+  deadline?: Date;
+  deadlineExtendedBy?: string;
+  interimReplies?: InterimReplyEntry[];
+  reminderCount?: number;
   replyText?: string;
   repliedAt?: Date;
   repliedByCurrentUser?: boolean;
   repliedByNickname?: string;
+  claimedByNickname?: string;
+  claimedByCurrentUser?: boolean;
+  replyError?: string;
+  replyDeliveredAt?: Date;
+  approval?: ApprovalState;
   mcpClientName?: string;
+  mcpClientVersion?: string;
   agentName?: string;
   reasoningModelName?: string;
 }
 
+// ApprovalState tracks a work report that needs the approval of several users
+export interface ApprovalState {
+  required: number;
+  approvers: string[];
+  approvedBy: string[];
+  pendingApprovers: string[];
+}
+
+// InterimReplyEntry is an interim reply sent to the agent while the request stays pending
+export interface InterimReplyEntry {
+  nickname: string;
+  text: string;
+}
+
+// Decisions a user can take on a work report
+export type WorkReportDecision = 'approved' | 'rejected' | 'changes_requested';
+
 export const useChatStore = defineStore('chat', () => {
   // State
   const messages = ref<ChatMessage[]>([]);
@@ -163,12 +191,41 @@ export const useChatStore = defineStore('chat', () => {
       case WebSocketCommands.USER_CONNECTION_STATUS_NOTIFICATION:
         handleUserConnectionStatusNotification(message);
         break;
+      case WebSocketCommands.CLAIM_REQUEST:
+      case WebSocketCommands.REQUEST_CLAIMED:
+        handleRequestClaim(message);
+        break;
+      case WebSocketCommands.REPLY_REJECTED:
+        handleReplyRejected(message);
+        break;
+      case WebSocketCommands.REPLY_ACK:
+        handleReplyAck(message);
+        break;
+      case WebSocketCommands.APPROVAL_PROGRESS:
+        handleApprovalProgress(message);
+        break;
+      case WebSocketCommands.EXTEND_DEADLINE:
+      case WebSocketCommands.DEADLINE_EXTENDED:
+        handleDeadlineExtension(message);
+        break;
+      case WebSocketCommands.INTERIM_REPLY:
+        handleInterimReply(message);
+        break;
+      case WebSocketCommands.REMINDER:
+        handleReminder(message);
+        break;
       default:
         console.log('Unknown message command:', message.Cmd);
     }
   }
 
   function handleAskQuestion(request: AskQuestionRequest) {
+    // Requests held while no client was online may arrive again after a reconnect
+    if (messages.value.some(msg => msg.id === request.ID)) {
+      console.log(`Ignoring duplicate question ${request.ID}`);
+      return;
+    }
+
     const chatMessage: ChatMessage = {
       id: request.ID,
       type: 'question',
@@ -179,7 +236,9 @@ export const useChatStore = defineStore('chat', () => {
       isAnswered: false,
       originalRequest: request,
       timeout: request.Request?.Timeout,
+      ...(request.Timestamp > 0n && request.Request?.Timeout ? { deadline: new Date(Number(request.Timestamp) + request.Request.Timeout * 1000) } : {}),
       ...(request.Request?.McpClientName ? { mcpClientName: request.Request.McpClientName } : {}),
+      ...(request.Request?.McpClientInfo?.ClientVersion ? { mcpClientVersion: request.Request.McpClientInfo.ClientVersion } : {}),
       ...(request.Request?.AgentName ? { agentName: request.Request.AgentName } : {}),
       ...(request.Request?.ReasoningModelName ? { reasoningModelName: request.Request.ReasoningModelName } : {})
     };
@@ -189,6 +248,12 @@ export const useChatStore = defineStore('chat', () => {
   }
 
   function handleWorkReport(request: WorkReportRequest) {
+    // Requests held while no client was online may arrive again after a reconnect
+    if (messages.value.some(msg => msg.id === request.ID)) {
+      console.log(`Ignoring duplicate work report ${request.ID}`);
+      return;
+    }
+
     const chatMessage: ChatMessage = {
       id: request.ID,
       type: 'task',
@@ -200,9 +265,15 @@ export const useChatStore = defineStore('chat', () => {
       originalRequest: request,
       timeout: request.Request?.Timeout,
       ...(request.Request?.McpClientName ? { mcpClientName: request.Request.McpClientName } : {}),
+      ...(request.Request?.McpClientInfo?.ClientVersion ? { mcpClientVersion: request.Request.McpClientInfo.ClientVersion } : {}),
       ...(request.Request?.AgentName ? { agentName: request.Request.AgentName } : {}),
       ...(request.Request?.ReasoningModelName ? { reasoningModelName: request.Request.ReasoningModelName } : {})
     };
+    const requiredApprovals = request.Request?.RequiredApprovals ?? 0;
+    const approvers = request.Request?.Approvers ?? [];
+    if (requiredApprovals > 1 || approvers.length > 0) {
+      chatMessage.approval = { required: requiredApprovals, approvers, approvedBy: [], pendingApprovers: approvers };
+    }
 
     messages.value.push(chatMessage);
     NotificationService.taskReceived();
@@ -276,6 +347,145 @@ export const useChatStore = defineStore('chat', () => {
     }
   }
 
+  // handleRequestClaim applies the outcome of our own claim or the claim of another user
+  function handleRequestClaim(message: WebsocketMessage) {
+    const claim = message.RequestClaim;
+    const existingMessage = claim && messages.value.find(msg => msg.id === claim.requestId);
+    if (!claim || !existingMessage) {
+      return;
+    }
+
+    const ownClaim = message.Cmd === WebSocketCommands.CLAIM_REQUEST && claim.success;
+    existingMessage.claimedByCurrentUser = ownClaim;
+    existingMessage.claimedByNickname = ownClaim || !claim.nickname ? undefined : claim.nickname;
+    existingMessage.replyError = claim.errorMessage || undefined;
+    if (!ownClaim) {
+      claimedRequests.delete(claim.requestId);
+    }
+  }
+
+  // handleReplyRejected undoes a reply the server did not accept because another user claimed the request
+  function handleReplyRejected(message: WebsocketMessage) {
+    const rejection = message.RequestClaim;
+    const existingMessage = rejection && messages.value.find(msg => msg.id === rejection.requestId);
+    if (!rejection || !existingMessage) {
+      return;
+    }
+
+    // The reply can be sent again once the claim is released
+    console.warn(`Reply to ${rejection.requestId} was rejected: ${rejection.errorMessage}`);
+    existingMessage.replyError = rejection.errorMessage;
+    existingMessage.repliedByCurrentUser = false;
+    existingMessage.isAnswered = false;
+    existingMessage.claimedByNickname = rejection.nickname;
+  }
+
+  // handleReplyAck tells whether a reply of this user reached the agent
+  function handleReplyAck(message: WebsocketMessage) {
+    const ack = message.ReplyAck;
+    const existingMessage = ack && messages.value.find(msg => msg.id === ack.requestId);
+    if (!ack || !existingMessage) {
+      return;
+    }
+
+    console.log(`Reply to ${ack.requestId}: ${ack.status}`);
+    switch (ack.status) {
+      case 'delivered':
+        existingMessage.replyError = undefined;
+        existingMessage.replyDeliveredAt = new Date(Number(ack.deliveredAt));
+        break;
+      case 'recorded':
+        // Our approval counts, the agent waits for the other approvers
+        existingMessage.replyError = undefined;
+        break;
+      case 'already_answered':
+        existingMessage.repliedByCurrentUser = false;
+        existingMessage.repliedByNickname = ack.repliedBy;
+        existingMessage.replyError = `回复未送达：已由 ${ack.repliedBy || '其他用户'} 回复`;
+        break;
+      case 'invalid':
+        existingMessage.isAnswered = false;
+        existingMessage.repliedByCurrentUser = false;
+        existingMessage.replyError = `回复无效：${ack.message}`;
+        break;
+      case 'expired':
+        existingMessage.replyError = `回复未送达：请求已结束${ack.message ? `（${ack.message}）` : ''}`;
+        break;
+      case 'failed':
+        // The server was busy, the request can be answered again
+        existingMessage.isAnswered = false;
+        existingMessage.repliedByCurrentUser = false;
+        existingMessage.replyError = '回复未送达：服务器繁忙，请重新发送';
+        break;
+      default:
+        existingMessage.replyError = '回复未送达：服务器不认识该请求';
+    }
+  }
+
+  // handleApprovalProgress shows who approved a work report that needs several approvals
+  function handleApprovalProgress(message: WebsocketMessage) {
+    const progress = message.ApprovalProgress;
+    const existingMessage = progress && messages.value.find(msg => msg.id === progress.requestId);
+    if (!progress || !existingMessage) {
+      return;
+    }
+
+    existingMessage.approval = {
+      required: progress.requiredApprovals,
+      approvers: progress.approvers,
+      approvedBy: progress.approvedBy,
+      pendingApprovers: progress.pendingApprovers
+    };
+  }
+
+  // handleDeadlineExtension applies the outcome of our own extension or the new deadline set by someone else
+  function handleDeadlineExtension(message: WebsocketMessage) {
+    const extension = message.DeadlineExtension;
+    const existingMessage = extension && messages.value.find(msg => msg.id === extension.requestId);
+    if (!extension || !existingMessage) {
+      return;
+    }
+
+    if (message.Cmd === WebSocketCommands.EXTEND_DEADLINE && !extension.success) {
+      existingMessage.replyError = `无法延长截止时间：${extension.errorMessage}`;
+      return;
+    }
+    if (extension.deadline > 0n) {
+      existingMessage.deadline = new Date(Number(extension.deadline));
+    }
+    existingMessage.deadlineExtendedBy = message.Cmd === WebSocketCommands.EXTEND_DEADLINE ? '您' : (extension.nickname || '服务器');
+  }
+
+  // handleInterimReply applies the outcome of our own interim reply or shows the one of someone else
+  function handleInterimReply(message: WebsocketMessage) {
+    const interim = message.InterimReply;
+    const existingMessage = interim && messages.value.find(msg => msg.id === interim.requestId);
+    if (!interim || !existingMessage) {
+      return;
+    }
+
+    if (!interim.success) {
+      existingMessage.replyError = `无法发送临时回复：${interim.errorMessage}`;
+      return;
+    }
+    existingMessage.replyError = undefined;
+    existingMessage.interimReplies = [...(existingMessage.interimReplies || []), { nickname: interim.nickname, text: interim.text }];
+    if (interim.extendSeconds > 0 && interim.deadline > 0n) {
+      existingMessage.deadline = new Date(Number(interim.deadline));
+      existingMessage.deadlineExtendedBy = interim.nickname === userNickname.value ? '您' : interim.nickname;
+    }
+  }
+
+  // handleReminder marks a request nobody answered for a while
+  function handleReminder(message: WebsocketMessage) {
+    const reminder = message.Reminder;
+    const existingMessage = reminder && messages.value.find(msg => msg.id === reminder.requestId);
+    if (!reminder || !existingMessage || existingMessage.isAnswered) {
+      return;
+    }
+    existingMessage.reminderCount = reminder.number;
+  }
+
   function handleRequestCancelled(message: WebsocketMessage) {
     const cancelNotification = message.RequestCancelledNotification;
     if (!cancelNotification) {
@@ -352,7 +562,7 @@ export const useChatStore = defineStore('chat', () => {
     NotificationService.replySent();
   }
 
-  function confirmTask(taskId: string, confirmationText: string = 'Task confirmed') {
+  function confirmTask(taskId: string, confirmationText: string = 'Task confirmed', decision: WorkReportDecision = 'approved') {
     const taskMessage = messages.value.find(msg => msg.id === taskId);
     if (!taskMessage || !taskMessage.originalRequest) {
       console.error('Task not found or missing original request');
@@ -376,7 +586,8 @@ export const useChatStore = defineStore('chat', () => {
       ID: taskId,
       IsError: false,
       Meta: {},
-      contents: [mcpContent]
+      contents: [mcpContent],
+      Decision: create(WorkReportDecisionSchema, { Decision: decision })
     });
 
     // Send reply via WebSocket
@@ -393,6 +604,50 @@ export const useChatStore = defineStore('chat', () => {
     NotificationService.confirmationSent();
   }
 
+  // Requests the agent was told about, so that each is reported as viewed only once
+  const viewedRequests = new Set<string>();
+  // Time of the last draft notification per request, typing is reported at most every 5 seconds
+  const lastReplyDraft = new Map<string, number>();
+  // Requests we asked to claim, the first keystroke claims a request so nobody else answers it
+  const claimedRequests = new Set<string>();
+
+  // extendDeadline asks the server for more time to answer a request
+  function extendDeadline(requestId: string, extendSeconds: number = 600) {
+    if (wsService.value) {
+      wsService.value.sendExtendDeadline(requestId, extendSeconds);
+    }
+  }
+
+  // sendInterimReply tells the waiting agent to hold on without answering the request
+  function sendInterimReply(requestId: string, text: string, extendSeconds: number = 600) {
+    if (wsService.value) {
+      wsService.value.sendInterimReply(requestId, text, extendSeconds);
+    }
+  }
+
+  function markRequestViewed(requestId: string) {
+    if (!wsService.value || viewedRequests.has(requestId)) {
+      return;
+    }
+    viewedRequests.add(requestId);
+    wsService.value.sendRequestViewed(requestId);
+  }
+
+  function notifyReplyDraft(requestId: string) {
+    const message = messages.value.find(msg => msg.id === requestId);
+    if (wsService.value && !claimedRequests.has(requestId) && !message?.claimedByNickname) {
+      claimedRequests.add(requestId);
+      wsService.value.sendClaimRequest(requestId);
+    }
+
+    const now = Date.now();
+    if (!wsService.value || now - (lastReplyDraft.get(requestId) ?? 0) < 5000) {
+      return;
+    }
+    lastReplyDraft.set(requestId, now);
+    wsService.value.sendReplyDraft(requestId);
+  }
+
   function clearMessages() {
     messages.value = [];
   }
@@ -574,6 +829,10 @@ export const useChatStore = defineStore('chat', () => {
     disconnect,
     replyToQuestion,
     confirmTask,
+    markRequestViewed,
+    notifyReplyDraft,
+    extendDeadline,
+    sendInterimReply,
     clearMessages,
     setConnectionError,
     setNickname,
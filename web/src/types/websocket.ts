@@ -12,7 +12,19 @@ export const WebSocketCommands = {
   GET_ONLINE_USERS: 'GetOnlineUsers',
   SEND_CHAT_MESSAGE: 'SendChatMessage',
   CHAT_MESSAGE_NOTIFICATION: 'ChatMessageNotification',
-  USER_CONNECTION_STATUS_NOTIFICATION: 'UserConnectionStatusNotification'
+  USER_CONNECTION_STATUS_NOTIFICATION: 'UserConnectionStatusNotification',
+  REQUEST_VIEWED: 'RequestViewed',
+  REPLY_DRAFT: 'ReplyDraft',
+  CLAIM_REQUEST: 'ClaimRequest',
+  RELEASE_CLAIM: 'ReleaseClaim',
+  REQUEST_CLAIMED: 'RequestClaimed',
+  REPLY_REJECTED: 'ReplyRejected',
+  REPLY_ACK: 'ReplyAck',
+  APPROVAL_PROGRESS: 'ApprovalProgress',
+  EXTEND_DEADLINE: 'ExtendDeadline',
+  DEADLINE_EXTENDED: 'DeadlineExtended',
+  INTERIM_REPLY: 'InterimReply',
+  REMINDER: 'Reminder'
 } as const;
 
 export type WebSocketCommand = typeof WebSocketCommands[keyof typeof WebSocketCommands];